@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"net/url"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
@@ -50,6 +52,32 @@ func (c *Client) GetAlertsByFilter(filter dto.AlertsFilter) ([]model.Alert, erro
 	return alerts, nil
 }
 
+// GetAlerts return all the alerts with the status
+func (c *Client) GetAlerts(status string) ([]model.Alert, error) {
+	var items []struct {
+		model.Alert
+		MongoID primitive.ObjectID `json:"_id"`
+	}
+
+	params := url.Values{}
+	params.Add("status", status)
+
+	err := c.getParsedResponseWithParams(context.TODO(), "/alerts", nil, &items, params)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]model.Alert, 0, len(items))
+
+	for _, item := range items {
+		alert := item.Alert
+		alert.ID = item.MongoID
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
 func (c *Client) AckAlerts(filter dto.AlertsFilter) error {
 	b := struct {
 		Filter dto.AlertsFilter `json:"filter"`
@@ -34,11 +34,16 @@ import (
 
 type ApiSvcClientInterface interface {
 	GetAlertsByFilter(filter dto.AlertsFilter) ([]model.Alert, error)
+	GetAlerts(status string) ([]model.Alert, error)
 	AckAlerts(filter dto.AlertsFilter) error
 	GetOracleDatabaseLicenseTypes() ([]model.OracleDatabaseLicenseType, error)
 	GetSQLServerDatabaseLicenseTypes() ([]model.SqlServerDatabaseLicenseType, error)
 	GetMySqlDatabaseLicenseTypes() ([]model.MySqlLicenseType, error)
 	GetOracleDatabases() ([]model.OracleDatabase, error)
+	GetLicenseIgnoreRules() ([]model.LicenseIgnoreRule, error)
+	GetHostnames() ([]string, error)
+	GetMongoHostData(hostname string) (*model.HostDataBE, error)
+	GetOracleDatabaseContracts() ([]model.OracleDatabaseContract, error)
 }
 
 type Client struct {
@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (c *Client) GetHostnames() ([]string, error) {
+	var hostnames []string
+
+	params := url.Values{}
+	params.Add("mode", "hostnames")
+
+	err := c.getParsedResponseWithParams(context.TODO(), "/hosts", nil, &hostnames, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return hostnames, nil
+}
+
+// GetMongoHostData return the current hostdata of the host as it's stored in the database
+func (c *Client) GetMongoHostData(hostname string) (*model.HostDataBE, error) {
+	u := utils.NewAPIUrlNoParams(
+		c.remoteEndpoint,
+		c.config.AuthenticationProvider.Username,
+		c.config.AuthenticationProvider.Password,
+		"/hosts/"+url.PathEscape(hostname))
+
+	req, err := http.NewRequestWithContext(context.TODO(), "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.ercole.mongohostdata+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("api error (code: %d): %s", resp.StatusCode, string(raw))
+	}
+
+	var hostdata model.HostDataBE
+	if err := bson.UnmarshalExtJSON(raw, true, &hostdata); err != nil {
+		return nil, utils.NewError(err, "Can't unmarshal")
+	}
+
+	return &hostdata, nil
+}
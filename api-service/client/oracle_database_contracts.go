@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// GetOracleDatabaseContracts return the Oracle/Database contracts as they are stored in the database
+func (c *Client) GetOracleDatabaseContracts() ([]model.OracleDatabaseContract, error) {
+	var response struct {
+		Contracts []dto.OracleDatabaseContractFE `json:"contracts"`
+	}
+
+	err := c.getParsedResponse(context.TODO(), "/contracts/oracle/database", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make([]model.OracleDatabaseContract, 0, len(response.Contracts))
+
+	for _, fe := range response.Contracts {
+		hosts := make([]string, 0, len(fe.Hosts))
+		for _, h := range fe.Hosts {
+			hosts = append(hosts, h.Hostname)
+		}
+
+		contracts = append(contracts, model.OracleDatabaseContract{
+			ID:                fe.ID,
+			ContractID:        fe.ContractID,
+			CSI:               fe.CSI,
+			LicenseTypeID:     fe.LicenseTypeID,
+			ReferenceNumber:   fe.ReferenceNumber,
+			Unlimited:         fe.Unlimited,
+			Count:             int(fe.LicensesPerCore + fe.LicensesPerUser),
+			Basket:            fe.Basket,
+			Restricted:        fe.Restricted,
+			SupportExpiration: fe.SupportExpiration,
+			Hosts:             hosts,
+		})
+	}
+
+	return contracts, nil
+}
@@ -98,7 +98,7 @@ func (ctrl *APIController) SearchClustersJSON(w http.ResponseWriter, r *http.Req
 
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
@@ -154,7 +154,7 @@ func (ctrl *APIController) SearchClustersXLSX(w http.ResponseWriter, r *http.Req
 func (ctrl *APIController) GetCluster(w http.ResponseWriter, r *http.Request) {
 	clusterName := mux.Vars(r)["name"]
 
-	olderThan, err := utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME)
+	olderThan, err := utils.GetOlderThanParam(r)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
@@ -136,6 +136,8 @@ type APIControllerInterface interface {
 	AddOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
 	UpdateOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
 	GetOracleDatabaseContracts(w http.ResponseWriter, r *http.Request)
+	// CompareOracleDatabaseContractsAssignments return the result of the greedy and of the optimized assignment of the contracts
+	CompareOracleDatabaseContractsAssignments(w http.ResponseWriter, r *http.Request)
 	DeleteOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
 
 	AddHostToOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
@@ -155,12 +157,39 @@ type APIControllerInterface interface {
 	// UpdateOracleDatabaseLicenseType update a licence type - Oracle/Database contract part
 	UpdateOracleDatabaseLicenseType(w http.ResponseWriter, r *http.Request)
 
+	// LICENSE IGNORE RULES
+
+	// ListLicenseIgnoreRules return the list of the license ignore rules
+	ListLicenseIgnoreRules(w http.ResponseWriter, r *http.Request)
+	// AddLicenseIgnoreRule add a license ignore rule
+	AddLicenseIgnoreRule(w http.ResponseWriter, r *http.Request)
+	// UpdateLicenseIgnoreRule update a license ignore rule
+	UpdateLicenseIgnoreRule(w http.ResponseWriter, r *http.Request)
+	// DeleteLicenseIgnoreRule remove a license ignore rule
+	DeleteLicenseIgnoreRule(w http.ResponseWriter, r *http.Request)
+	// ImportOracleLMSReview import an LMS workbook reviewed by the licensing team as pending changes
+	ImportOracleLMSReview(w http.ResponseWriter, r *http.Request)
+	// ListLMSPendingChanges return the pending changes imported from the LMS workbooks
+	ListLMSPendingChanges(w http.ResponseWriter, r *http.Request)
+	// ApproveLMSPendingChange apply an LMS pending change
+	ApproveLMSPendingChange(w http.ResponseWriter, r *http.Request)
+	// RejectLMSPendingChange discard an LMS pending change
+	RejectLMSPendingChange(w http.ResponseWriter, r *http.Request)
+	// GetHostDataChanges return the fields changed by the uploads of an host
+	GetHostDataChanges(w http.ResponseWriter, r *http.Request)
+	// SearchHostDataChanges return the feed of the fields changed by the uploads of all the hosts
+	SearchHostDataChanges(w http.ResponseWriter, r *http.Request)
+	// ListHostDataSnapshots return the snapshots of the hostdata that can be queried with the at param
+	ListHostDataSnapshots(w http.ResponseWriter, r *http.Request)
+
 	ListOracleGrantDbaByHostname(w http.ResponseWriter, r *http.Request)
 	GetOracleGrantDbaJSON(hostname string, filters *dto.GlobalFilter) ([]dto.OracleGrantDbaDto, error)
 	GetOracleGrantDbaXLSX(hostname string, filters *dto.GlobalFilter) (*excelize.File, error)
 
 	GetOraclePatchList(w http.ResponseWriter, r *http.Request)
 	GetOracleOptionList(w http.ResponseWriter, r *http.Request)
+	// GetOracleFeatureUsageLedger return the Oracle feature usage ledger, as JSON or as an XLSX evidence report
+	GetOracleFeatureUsageLedger(w http.ResponseWriter, r *http.Request)
 	GetOracleChanges(w http.ResponseWriter, r *http.Request)
 	GetOraclePDBChanges(w http.ResponseWriter, r *http.Request)
 	GetOracleBackupList(w http.ResponseWriter, r *http.Request)
@@ -190,6 +219,29 @@ type APIControllerInterface interface {
 	GetMySQLContracts(w http.ResponseWriter, r *http.Request)
 	DeleteMySQLContract(w http.ResponseWriter, r *http.Request)
 
+	// POSTGRESQL CONTRACTS
+	AddPostgreSQLContract(w http.ResponseWriter, r *http.Request)
+	UpdatePostgreSQLContract(w http.ResponseWriter, r *http.Request)
+	GetPostgreSQLContracts(w http.ResponseWriter, r *http.Request)
+	DeletePostgreSQLContract(w http.ResponseWriter, r *http.Request)
+	GetPostgreSQLLicenseTypes(w http.ResponseWriter, r *http.Request)
+
+	// MONGODB CONTRACTS
+	AddMongoDBContract(w http.ResponseWriter, r *http.Request)
+	UpdateMongoDBContract(w http.ResponseWriter, r *http.Request)
+	GetMongoDBContracts(w http.ResponseWriter, r *http.Request)
+	DeleteMongoDBContract(w http.ResponseWriter, r *http.Request)
+	GetMongoDBLicenseTypes(w http.ResponseWriter, r *http.Request)
+
+	// HOST LICENSES
+	GetHostLicenseTypes(w http.ResponseWriter, r *http.Request)
+	GetHostsUsedLicenses(w http.ResponseWriter, r *http.Request)
+	GetHostLicensesCompliance(w http.ResponseWriter, r *http.Request)
+	AddHostContract(w http.ResponseWriter, r *http.Request)
+	UpdateHostContract(w http.ResponseWriter, r *http.Request)
+	GetHostContracts(w http.ResponseWriter, r *http.Request)
+	DeleteHostContract(w http.ResponseWriter, r *http.Request)
+
 	// ROLES
 	GetRole(w http.ResponseWriter, r *http.Request)
 	GetRoles(w http.ResponseWriter, r *http.Request)
@@ -16,8 +16,10 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang/gddo/httputil"
 	"github.com/gorilla/context"
@@ -187,6 +189,30 @@ func (ctrl *APIController) GetUsedLicensesPerDatabasesJSON(w http.ResponseWriter
 	utils.WriteJSONResponse(w, http.StatusOK, response)
 }
 
+func (ctrl *APIController) ExplainUsedLicensesPerDatabasesByHost(w http.ResponseWriter, r *http.Request) {
+	filter, err := dto.GetGlobalFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	hostname := mux.Vars(r)["hostname"]
+
+	explanations, err := ctrl.Service.ExplainUsedLicensesPerDatabases(hostname, *filter)
+	if errors.Is(err, utils.ErrHostNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"explanations": explanations,
+	}
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
 func (ctrl *APIController) GetUsedLicensesPerDatabasesAsXLSX(w http.ResponseWriter, r *http.Request, filter dto.GlobalFilter) {
 	xlsx, err := ctrl.Service.GetUsedLicensesPerDatabasesAsXLSX(filter)
 
@@ -199,18 +225,24 @@ func (ctrl *APIController) GetUsedLicensesPerDatabasesAsXLSX(w http.ResponseWrit
 }
 
 func (ctrl *APIController) GetDatabaseLicensesCompliance(w http.ResponseWriter, r *http.Request) {
+	olderThan, err := utils.GetOlderThanParam(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
 	choice := httputil.NegotiateContentType(r, []string{"application/json", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}, "application/json")
 
 	switch choice {
 	case "application/json":
-		ctrl.GetDatabaseLicensesComplianceJSON(w, r)
+		ctrl.GetDatabaseLicensesComplianceJSON(w, r, olderThan)
 	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
-		ctrl.GetDatabaseLicensesComplianceXLSX(w, r)
+		ctrl.GetDatabaseLicensesComplianceXLSX(w, r, olderThan)
 	}
 }
 
-func (ctrl *APIController) GetDatabaseLicensesComplianceJSON(w http.ResponseWriter, r *http.Request) {
-	licenses, err := ctrl.Service.GetDatabaseLicensesCompliance()
+func (ctrl *APIController) GetDatabaseLicensesComplianceJSON(w http.ResponseWriter, r *http.Request, olderThan time.Time) {
+	licenses, err := ctrl.Service.GetDatabaseLicensesCompliance(olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -223,8 +255,8 @@ func (ctrl *APIController) GetDatabaseLicensesComplianceJSON(w http.ResponseWrit
 	utils.WriteJSONResponse(w, http.StatusOK, response)
 }
 
-func (ctrl *APIController) GetDatabaseLicensesComplianceXLSX(w http.ResponseWriter, r *http.Request) {
-	xlsx, err := ctrl.Service.GetDatabaseLicensesComplianceAsXLSX()
+func (ctrl *APIController) GetDatabaseLicensesComplianceXLSX(w http.ResponseWriter, r *http.Request, olderThan time.Time) {
+	xlsx, err := ctrl.Service.GetDatabaseLicensesComplianceAsXLSX(olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
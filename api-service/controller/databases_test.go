@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -365,7 +366,7 @@ func TestGetDatabaseLicensesComplianceXLSX_Success(t *testing.T) {
 	xlsx := excelize.File{}
 
 	as.EXPECT().
-		GetDatabaseLicensesComplianceAsXLSX().
+		GetDatabaseLicensesComplianceAsXLSX(utils.MAX_TIME).
 		Return(&xlsx, nil)
 
 	rr := httptest.NewRecorder()
@@ -381,6 +382,55 @@ func TestGetDatabaseLicensesComplianceXLSX_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetDatabaseLicensesCompliance_At(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	licenses := []dto.LicenseCompliance{
+		{LicenseTypeID: "A90611", Consumed: 10, Covered: 8, Purchased: 12, Compliance: 0.8, Available: 4},
+	}
+
+	as.EXPECT().
+		GetDatabaseLicensesCompliance(utils.P("2020-03-01T23:59:59.999999999Z")).
+		Return(licenses, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetDatabaseLicensesCompliance)
+	req, err := http.NewRequest("GET", "/?at=2020-03-01", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"licensesCompliance": licenses}), rr.Body.String())
+}
+
+func TestGetDatabaseLicensesCompliance_InvalidAt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetDatabaseLicensesCompliance)
+	req, err := http.NewRequest("GET", "/?at=2020-03-01&older-than=2020-03-01T00%3A00%3A00Z", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
 func TestGetDatabaseLicensesComplianceXLSX_InternalServerError1(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -394,7 +444,7 @@ func TestGetDatabaseLicensesComplianceXLSX_InternalServerError1(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetDatabaseLicensesComplianceAsXLSX().
+		GetDatabaseLicensesComplianceAsXLSX(utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -621,3 +671,75 @@ func TestGetUsedLicensesPerClusterAsXLSX_InternalServerError1(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestExplainUsedLicensesPerDatabasesByHost_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	explanations := []dto.DatabaseUsedLicenseExplanation{
+		{
+			DatabaseUsedLicense: dto.DatabaseUsedLicense{
+				Hostname:      "foobar",
+				DbName:        "ERCOLE",
+				LicenseTypeID: "A90611",
+				UsedLicenses:  2,
+			},
+			Steps: []dto.DatabaseUsedLicenseExplanationStep{
+				{Name: "agent", Description: "The agent reported 2 licenses", Value: 2},
+			},
+		},
+	}
+	as.EXPECT().ExplainUsedLicensesPerDatabases("foobar", dto.GlobalFilter{OlderThan: utils.MAX_TIME}).
+		Return(explanations, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ExplainUsedLicensesPerDatabasesByHost)
+	req, err := http.NewRequest("GET", "", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{
+		"hostname": "foobar",
+	})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := map[string]interface{}{
+		"explanations": explanations,
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestExplainUsedLicensesPerDatabasesByHost_NotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().ExplainUsedLicensesPerDatabases("foobar", dto.GlobalFilter{OlderThan: utils.MAX_TIME}).
+		Return(nil, utils.ErrHostNotFound)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ExplainUsedLicensesPerDatabasesByHost)
+	req, err := http.NewRequest("GET", "", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{
+		"hostname": "foobar",
+	})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -33,13 +33,15 @@ func (ctrl *APIController) GetInfoForFrontendDashboard(w http.ResponseWriter, r
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	info, err := ctrl.Service.GetInfoForFrontendDashboard(location, environment, olderThan)
+	info, err := ctrl.Service.GetInfoForFrontendDashboard(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -96,7 +96,7 @@ func TestGetInfoForFrontendDashboard_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetInfoForFrontendDashboard("Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+		GetInfoForFrontendDashboard("Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(res, nil)
 
 	rr := httptest.NewRecorder()
@@ -143,7 +143,7 @@ func TestGetInfoForFrontendDashboard_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetInfoForFrontendDashboard("", "", utils.MAX_TIME).
+		GetInfoForFrontendDashboard("", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -0,0 +1,145 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ListHostIdentities return the identities of the hosts with their aliases
+func (ctrl *APIController) ListHostIdentities(w http.ResponseWriter, r *http.Request) {
+	identities, err := ctrl.Service.ListHostIdentities()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"identities": identities,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// GetHostIdentity return the identity of a host
+func (ctrl *APIController) GetHostIdentity(w http.ResponseWriter, r *http.Request) {
+	identity, err := ctrl.Service.GetHostIdentity(mux.Vars(r)["id"])
+	ctrl.writeHostIdentityResponse(w, identity, err)
+}
+
+// AddHostAlias add an alias to the identity of a host
+func (ctrl *APIController) AddHostAlias(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var body struct {
+		Alias string `json:"alias"`
+	}
+
+	if !ctrl.decodeHostIdentityRequest(w, r, &body) {
+		return
+	}
+
+	identity, err := ctrl.Service.AddHostAlias(mux.Vars(r)["id"], body.Alias)
+	ctrl.writeHostIdentityResponse(w, identity, err)
+}
+
+// RemoveHostAlias remove an alias from the identity of a host
+func (ctrl *APIController) RemoveHostAlias(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	identity, err := ctrl.Service.RemoveHostAlias(mux.Vars(r)["id"], mux.Vars(r)["alias"])
+	ctrl.writeHostIdentityResponse(w, identity, err)
+}
+
+// MergeHostIdentities merge the identity in the body into the identity of the host
+func (ctrl *APIController) MergeHostIdentities(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var body struct {
+		ID string `json:"id"`
+	}
+
+	if !ctrl.decodeHostIdentityRequest(w, r, &body) {
+		return
+	}
+
+	identity, err := ctrl.Service.MergeHostIdentities(mux.Vars(r)["id"], body.ID)
+	ctrl.writeHostIdentityResponse(w, identity, err)
+}
+
+// SplitHostIdentity move an alias of the identity of the host to a new identity
+func (ctrl *APIController) SplitHostIdentity(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var body struct {
+		Alias string `json:"alias"`
+	}
+
+	if !ctrl.decodeHostIdentityRequest(w, r, &body) {
+		return
+	}
+
+	identity, err := ctrl.Service.SplitHostIdentity(mux.Vars(r)["id"], body.Alias)
+	ctrl.writeHostIdentityResponse(w, identity, err)
+}
+
+// decodeHostIdentityRequest decode the body of the request, it write the error response if it isn't valid
+func (ctrl *APIController) decodeHostIdentityRequest(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(body); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return false
+	}
+
+	return true
+}
+
+func (ctrl *APIController) writeHostIdentityResponse(w http.ResponseWriter, identity *model.HostIdentity, err error) {
+	switch {
+	case errors.Is(err, utils.ErrHostIdentityNotFound):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+	case errors.Is(err, utils.ErrHostAliasConflict):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+	case errors.Is(err, utils.ErrInvalidHostAlias), errors.Is(err, utils.ErrHostIdentityMergedWithItself):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+	case err != nil:
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+	default:
+		utils.WriteJSONResponse(w, http.StatusOK, identity)
+	}
+}
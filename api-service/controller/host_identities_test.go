@@ -0,0 +1,236 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestListHostIdentities(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		identities := []model.HostIdentity{
+			model.NewHostIdentity("foobar", []string{"foobar", "foobar.example.com"}, utils.P("2019-11-05T14:02:03Z")),
+		}
+		as.EXPECT().ListHostIdentities().Return(identities, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.ListHostIdentities)
+		req, err := http.NewRequest("GET", "/hosts/identities", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"identities": identities}), rr.Body.String())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		as.EXPECT().ListHostIdentities().Return(nil, aerrMock)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.ListHostIdentities)
+		req, err := http.NewRequest("GET", "/hosts/identities", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestAddHostAlias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	identity := model.NewHostIdentity("foobar", []string{"foobar", "pippo"}, utils.P("2019-11-05T14:02:03Z"))
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "success", expected: http.StatusOK},
+		{name: "not found", err: utils.ErrHostIdentityNotFound, expected: http.StatusNotFound},
+		{name: "conflict", err: utils.ErrHostAliasConflict, expected: http.StatusConflict},
+		{name: "invalid alias", err: utils.ErrInvalidHostAlias, expected: http.StatusUnprocessableEntity},
+		{name: "internal error", err: aerrMock, expected: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.err != nil {
+				as.EXPECT().AddHostAlias(identity.ID, "pippo").Return(nil, tc.err)
+			} else {
+				as.EXPECT().AddHostAlias(identity.ID, "pippo").Return(&identity, nil)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(ac.AddHostAlias)
+			req, err := http.NewRequest("POST", "/hosts/identities/"+identity.ID+"/aliases", bytes.NewReader([]byte(`{"alias": "pippo"}`)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"id": identity.ID})
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+			if tc.err == nil {
+				assert.JSONEq(t, utils.ToJSON(identity), rr.Body.String())
+			}
+		})
+	}
+
+	t.Run("bad request", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.AddHostAlias)
+		req, err := http.NewRequest("POST", "/hosts/identities/"+identity.ID+"/aliases", bytes.NewReader([]byte(`{"name": "pippo"}`)))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": identity.ID})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		ac := ac
+		ac.Config.APIService.ReadOnly = true
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.AddHostAlias)
+		req, err := http.NewRequest("POST", "/hosts/identities/"+identity.ID+"/aliases", bytes.NewReader([]byte(`{"alias": "pippo"}`)))
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestMergeHostIdentities(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	identity := model.NewHostIdentity("foobar", []string{"foobar", "foobar-new"}, utils.P("2019-11-05T14:02:03Z"))
+
+	t.Run("success", func(t *testing.T) {
+		as.EXPECT().MergeHostIdentities(identity.ID, "other").Return(&identity, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.MergeHostIdentities)
+		req, err := http.NewRequest("POST", "/hosts/identities/"+identity.ID+"/merge", bytes.NewReader([]byte(`{"id": "other"}`)))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": identity.ID})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(identity), rr.Body.String())
+	})
+
+	t.Run("with itself", func(t *testing.T) {
+		as.EXPECT().MergeHostIdentities(identity.ID, identity.ID).Return(nil, utils.ErrHostIdentityMergedWithItself)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.MergeHostIdentities)
+		req, err := http.NewRequest("POST", "/hosts/identities/"+identity.ID+"/merge", bytes.NewReader([]byte(`{"id": "`+identity.ID+`"}`)))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": identity.ID})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestSplitHostIdentity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	split := model.NewHostIdentity("pippo", []string{"pippo"}, utils.P("2019-11-05T14:02:03Z"))
+
+	t.Run("success", func(t *testing.T) {
+		as.EXPECT().SplitHostIdentity("id", "pippo").Return(&split, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.SplitHostIdentity)
+		req, err := http.NewRequest("POST", "/hosts/identities/id/split", bytes.NewReader([]byte(`{"alias": "pippo"}`)))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "id"})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(split), rr.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().SplitHostIdentity("id", "pippo").Return(nil, utils.ErrHostIdentityNotFound)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.SplitHostIdentity)
+		req, err := http.NewRequest("POST", "/hosts/identities/id/split", bytes.NewReader([]byte(`{"alias": "pippo"}`)))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "id"})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) AddHostContract(w http.ResponseWriter, r *http.Request) {
+	var contract model.HostContract
+
+	if err := utils.Decode(r.Body, &contract); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if contract.ID != primitive.NilObjectID {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("ID must be empty"))
+		return
+	}
+
+	if !contract.IsValid() {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Contract isn't valid"))
+		return
+	}
+
+	contractAdded, err := ctrl.Service.AddHostContract(contract)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, contractAdded)
+}
+
+func (ctrl *APIController) UpdateHostContract(w http.ResponseWriter, r *http.Request) {
+	var contract model.HostContract
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := utils.Decode(r.Body, &contract); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if contract.ID != id {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Object ID does not correspond"))
+		return
+	}
+
+	if !contract.IsValid() {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Contract isn't valid"))
+		return
+	}
+
+	contractUpdated, err := ctrl.Service.UpdateHostContract(contract)
+	if errors.Is(err, utils.ErrNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	}
+
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, contractUpdated)
+}
+
+func (ctrl *APIController) GetHostContracts(w http.ResponseWriter, r *http.Request) {
+	contracts, err := ctrl.Service.GetHostContracts()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"contracts": contracts,
+	}
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (ctrl *APIController) DeleteHostContract(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, fmt.Errorf("Can't decode id: %w", err))
+		return
+	}
+
+	err = ctrl.Service.DeleteHostContract(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	}
+
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetHostLicenseTypes return the list of the operating systems and virtualization license types
+func (ctrl *APIController) GetHostLicenseTypes(w http.ResponseWriter, r *http.Request) {
+	data, err := ctrl.Service.GetHostLicenseTypes()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"license-types": data,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// GetHostsUsedLicenses return the operating systems and virtualization licenses used by hosts and clusters
+func (ctrl *APIController) GetHostsUsedLicenses(w http.ResponseWriter, r *http.Request) {
+	filter, err := dto.GetGlobalFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	usedLicenses, err := ctrl.Service.GetHostsUsedLicenses(*filter)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"usedLicenses": usedLicenses,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// GetHostLicensesCompliance return the compliance of the operating systems and virtualization licenses
+func (ctrl *APIController) GetHostLicensesCompliance(w http.ResponseWriter, r *http.Request) {
+	olderThan, err := utils.GetOlderThanParam(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	licenses, err := ctrl.Service.GetHostLicensesCompliance(olderThan)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licensesCompliance": licenses,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/context"
+	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetHostDataChanges return the fields changed by the uploads of an host
+func (ctrl *APIController) GetHostDataChanges(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseHostDataChangesFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	filter.Hostname = mux.Vars(r)["hostname"]
+
+	ctrl.writeHostDataChanges(w, *filter)
+}
+
+// SearchHostDataChanges return the feed of the fields changed by the uploads of all the hosts
+func (ctrl *APIController) SearchHostDataChanges(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseHostDataChangesFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	filter.Hostname = r.URL.Query().Get("hostname")
+	filter.Environment = r.URL.Query().Get("environment")
+
+	filter.Location = r.URL.Query().Get("location")
+	if filter.Location == "" {
+		user := context.Get(r, "user")
+
+		locations, err := ctrl.Service.ListLocations(user)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		filter.Location = strings.Join(locations, ",")
+	}
+
+	ctrl.writeHostDataChanges(w, *filter)
+}
+
+func parseHostDataChangesFilter(r *http.Request) (*dto.HostDataChangesFilter, error) {
+	var err error
+
+	filter := dto.HostDataChangesFilter{
+		Field: r.URL.Query().Get("field"),
+	}
+
+	if filter.From, err = utils.Str2time(r.URL.Query().Get("from"), utils.MIN_TIME); err != nil {
+		return nil, err
+	}
+
+	if filter.To, err = utils.Str2time(r.URL.Query().Get("to"), utils.MAX_TIME); err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+func (ctrl *APIController) writeHostDataChanges(w http.ResponseWriter, filter dto.HostDataChangesFilter) {
+	changes, err := ctrl.Service.GetHostDataChanges(filter)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"changes": changes,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
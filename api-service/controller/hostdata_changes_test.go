@@ -0,0 +1,156 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetHostDataChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		changes := []model.HostDataChange{
+			{
+				ID:       utils.Str2oid("000000000000000000000001"),
+				Hostname: "foobar",
+				Date:     utils.P("2019-11-05T14:02:03Z"),
+				Changes: []model.HostDataFieldChange{
+					{Field: model.HostDataChangeFieldCPUCores, OldValue: "2", NewValue: "4"},
+				},
+			},
+		}
+
+		filter := dto.HostDataChangesFilter{
+			Hostname: "foobar",
+			From:     utils.P("2019-11-01T00:00:00Z"),
+			To:       utils.MAX_TIME,
+		}
+		as.EXPECT().GetHostDataChanges(filter).Return(changes, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.GetHostDataChanges)
+		req, err := http.NewRequest("GET", "/hosts/foobar/changes?from=2019-11-01T00%3A00%3A00Z", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{
+			"hostname": "foobar",
+		})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"changes": changes}), rr.Body.String())
+	})
+
+	t.Run("invalid from", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.GetHostDataChanges)
+		req, err := http.NewRequest("GET", "/hosts/foobar/changes?from=yesterday", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{
+			"hostname": "foobar",
+		})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		as.EXPECT().GetHostDataChanges(gomock.Any()).Return(nil, aerrMock)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.GetHostDataChanges)
+		req, err := http.NewRequest("GET", "/hosts/foobar/changes", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{
+			"hostname": "foobar",
+		})
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestSearchHostDataChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var user interface{}
+
+		as.EXPECT().ListLocations(user).Return([]string{"Italy", "Germany"}, nil)
+
+		filter := dto.HostDataChangesFilter{
+			Location:    "Italy,Germany",
+			Environment: "PRD",
+			Field:       model.HostDataChangeFieldOracleDatabaseVersion,
+			From:        utils.MIN_TIME,
+			To:          utils.P("2019-11-30T00:00:00Z"),
+		}
+		as.EXPECT().GetHostDataChanges(filter).Return([]model.HostDataChange{}, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.SearchHostDataChanges)
+		req, err := http.NewRequest("GET", "/hosts/changes?environment=PRD&field=oracle.database.version&to=2019-11-30T00%3A00%3A00Z", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"changes":[]}`, rr.Body.String())
+	})
+
+	t.Run("invalid to", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.SearchHostDataChanges)
+		req, err := http.NewRequest("GET", "/hosts/changes?to=tomorrow", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ListQuarantinedHostData return the hostdata rejected by the validation
+func (ctrl *APIController) ListQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	hostname := r.URL.Query().Get("hostname")
+
+	from, err := utils.Str2time(r.URL.Query().Get("from"), utils.MIN_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	to, err := utils.Str2time(r.URL.Query().Get("to"), utils.MAX_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	quarantined, err := ctrl.Service.ListQuarantinedHostData(hostname, from, to)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, map[string]interface{}{"quarantined": quarantined})
+}
+
+// GetQuarantinedHostData return a quarantined hostdata with its validation errors
+func (ctrl *APIController) GetQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	quarantined, err := ctrl.Service.GetQuarantinedHostData(id)
+	if errors.Is(err, utils.ErrQuarantinedHostDataNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, quarantined)
+}
+
+// DownloadQuarantinedHostData return the (sanitized) payload of a quarantined hostdata as a json file
+func (ctrl *APIController) DownloadQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	quarantined, err := ctrl.Service.GetQuarantinedHostData(id)
+	if errors.Is(err, utils.ErrQuarantinedHostDataNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=hostdata_%s_%s.json", quarantined.Hostname, quarantined.ID.Hex()))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(quarantined.Payload); err != nil {
+		ctrl.Log.Error(err)
+	}
+}
+
+// ReplayQuarantinedHostData validate again a quarantined hostdata, e.g. after an upgrade of ercole,
+// and insert it if it's valid and the host hasn't sent a more recent hostdata
+func (ctrl *APIController) ReplayQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	err = ctrl.Service.ReplayQuarantinedHostData(id)
+	switch {
+	case errors.Is(err, utils.ErrQuarantinedHostDataNotFound):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	case errors.Is(err, utils.ErrQuarantinedHostDataOutdated):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+		return
+	case errors.Is(err, utils.ErrInvalidHostdata):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	case err != nil:
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteQuarantinedHostData discard a quarantined hostdata
+func (ctrl *APIController) DeleteQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	err = ctrl.Service.DeleteQuarantinedHostData(id)
+	if errors.Is(err, utils.ErrQuarantinedHostDataNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
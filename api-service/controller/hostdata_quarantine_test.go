@@ -0,0 +1,234 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newQuarantineRequest(t *testing.T, method, id string) *http.Request {
+	req, err := http.NewRequest(method, "/hosts/quarantine/"+id, nil)
+	require.NoError(t, err)
+
+	return mux.SetURLVars(req, map[string]string{"id": id})
+}
+
+func TestListQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		quarantined := []model.QuarantinedHostData{
+			{
+				ID:         utils.Str2oid("000000000000000000000001"),
+				Hostname:   "foobar",
+				Errors:     "invalid hostdata",
+				ReceivedAt: utils.P("2019-11-04T14:02:03Z"),
+			},
+		}
+		as.EXPECT().ListQuarantinedHostData("foobar", utils.P("2019-11-01T00:00:00Z"), utils.MAX_TIME).
+			Return(quarantined, nil)
+
+		req, err := http.NewRequest("GET", "/hosts/quarantine?hostname=foobar&from=2019-11-01T00:00:00Z", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ListQuarantinedHostData).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"quarantined": quarantined}), rr.Body.String())
+	})
+
+	t.Run("wrong from", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/hosts/quarantine?from=yesterday", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ListQuarantinedHostData).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestGetQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000001")
+	quarantined := &model.QuarantinedHostData{
+		ID:         id,
+		Hostname:   "foobar",
+		Errors:     "invalid hostdata",
+		Payload:    []byte(`{"hostname":"foobar"}`),
+		ReceivedAt: utils.P("2019-11-04T14:02:03Z"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		as.EXPECT().GetQuarantinedHostData(id).Return(quarantined, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "GET", id.Hex()))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(quarantined), rr.Body.String())
+	})
+
+	t.Run("download", func(t *testing.T) {
+		as.EXPECT().GetQuarantinedHostData(id).Return(quarantined, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DownloadQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "GET", id.Hex()))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "attachment; filename=hostdata_foobar_000000000000000000000001.json", rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, `{"hostname":"foobar"}`, rr.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().GetQuarantinedHostData(id).Return(nil, utils.ErrQuarantinedHostDataNotFound)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "GET", id.Hex()))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DownloadQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "GET", "foobar"))
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestReplayQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000001")
+
+	t.Run("success", func(t *testing.T) {
+		as.EXPECT().ReplayQuarantinedHostData(id).Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("still invalid", func(t *testing.T) {
+		as.EXPECT().ReplayQuarantinedHostData(id).Return(fmt.Errorf("%w: hostname is required", utils.ErrInvalidHostdata))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		as.EXPECT().ReplayQuarantinedHostData(id).Return(utils.ErrQuarantinedHostDataOutdated)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().ReplayQuarantinedHostData(id).Return(utils.ErrQuarantinedHostDataNotFound)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		ac := ac
+		ac.Config.APIService.ReadOnly = true
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestDeleteQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000001")
+
+	t.Run("success", func(t *testing.T) {
+		as.EXPECT().DeleteQuarantinedHostData(id).Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "DELETE", id.Hex()))
+
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().DeleteQuarantinedHostData(id).Return(utils.ErrQuarantinedHostDataNotFound)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "DELETE", id.Hex()))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
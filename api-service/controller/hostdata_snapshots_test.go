@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestListHostDataSnapshots(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		snapshots := []model.HostDataSnapshot{
+			{
+				ID:            utils.Str2oid("000000000000000000000001"),
+				Kind:          model.HostDataSnapshotKindQuarterly,
+				Date:          utils.P("2019-10-01T00:00:00Z"),
+				Hosts:         42,
+				RetainedUntil: utils.P("2022-10-01T00:00:00Z"),
+			},
+		}
+		as.EXPECT().ListHostDataSnapshots().Return(snapshots, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.ListHostDataSnapshots)
+		req, err := http.NewRequest("GET", "/hosts/snapshots", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"snapshots": snapshots}), rr.Body.String())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		as.EXPECT().ListHostDataSnapshots().Return(nil, aerrMock)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.ListHostDataSnapshots)
+		req, err := http.NewRequest("GET", "/hosts/snapshots", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -221,7 +221,7 @@ func (ctrl *APIController) GetHostJSON(w http.ResponseWriter, r *http.Request) {
 
 	hostname := mux.Vars(r)["hostname"]
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
@@ -268,7 +268,7 @@ func (ctrl *APIController) GetHostMongoJSON(w http.ResponseWriter, r *http.Reque
 
 	hostname := mux.Vars(r)["hostname"]
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
@@ -309,7 +309,7 @@ func (ctrl *APIController) ListEnvironments(w http.ResponseWriter, r *http.Reque
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
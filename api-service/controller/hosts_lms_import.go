@@ -0,0 +1,124 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/context"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ImportOracleLMSReview import an LMS workbook reviewed by the licensing team as pending changes
+func (ctrl *APIController) ImportOracleLMSReview(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	defer file.Close()
+
+	report, err := ctrl.Service.ImportOracleLMSReview(file, requestUsername(r))
+	if errors.Is(err, utils.ErrInvalidLMSWorkbook) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, report)
+}
+
+// ListLMSPendingChanges return the pending changes imported from the LMS workbooks
+func (ctrl *APIController) ListLMSPendingChanges(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" && !model.IsValidLMSPendingChangeStatus(status) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("invalid status in param"))
+		return
+	}
+
+	changes, err := ctrl.Service.ListLMSPendingChanges(status)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"changes": changes,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// ApproveLMSPendingChange apply an LMS pending change
+func (ctrl *APIController) ApproveLMSPendingChange(w http.ResponseWriter, r *http.Request) {
+	ctrl.reviewLMSPendingChange(w, r, ctrl.Service.ApproveLMSPendingChange)
+}
+
+// RejectLMSPendingChange discard an LMS pending change
+func (ctrl *APIController) RejectLMSPendingChange(w http.ResponseWriter, r *http.Request) {
+	ctrl.reviewLMSPendingChange(w, r, ctrl.Service.RejectLMSPendingChange)
+}
+
+func (ctrl *APIController) reviewLMSPendingChange(w http.ResponseWriter, r *http.Request,
+	review func(id primitive.ObjectID, user string) (*model.LMSPendingChange, error)) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	change, err := review(id, requestUsername(r))
+
+	switch {
+	case errors.Is(err, utils.ErrLMSPendingChangeNotFound), errors.Is(err, utils.ErrLicenseNotFound):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	case errors.Is(err, utils.ErrLMSPendingChangeAlreadyReviewed):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	case err != nil:
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, change)
+}
+
+func requestUsername(r *http.Request) string {
+	if user, ok := context.Get(r, "user").(model.User); ok {
+		return user.Username
+	}
+
+	return ""
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestImportOracleLMSReview_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	report := &dto.OracleLMSImportReport{
+		ImportID:      utils.Str2oid("000000000000000000000001"),
+		Changes:       []model.LMSPendingChange{},
+		UnmatchedRows: []dto.OracleLMSUnmatchedRow{},
+	}
+	as.EXPECT().ImportOracleLMSReview(gomock.Any(), "").Return(report, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ImportOracleLMSReview)
+	req := newContractsUploadRequest(t, "/hosts/lms/import", "lms.xlsm", "content")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(report), rr.Body.String())
+}
+
+func TestImportOracleLMSReview_InvalidWorkbook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().ImportOracleLMSReview(gomock.Any(), "").
+		Return(nil, fmt.Errorf("%w: missing sheet", utils.ErrInvalidLMSWorkbook))
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ImportOracleLMSReview)
+	req := newContractsUploadRequest(t, "/hosts/lms/import", "lms.xlsm", "content")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestImportOracleLMSReview_ReadOnly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config: config.Configuration{
+			APIService: config.APIService{
+				ReadOnly: true,
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ImportOracleLMSReview)
+	req := newContractsUploadRequest(t, "/hosts/lms/import", "lms.xlsm", "content")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestListLMSPendingChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		changes := []model.LMSPendingChange{
+			{
+				ID:            utils.Str2oid("000000000000000000000002"),
+				Hostname:      "vm1",
+				DatabaseName:  "DB1",
+				Field:         model.LMSPendingChangeFieldPhysicalServerName,
+				ProposedValue: "esx01",
+				Status:        model.LMSPendingChangeStatusPending,
+			},
+		}
+		as.EXPECT().ListLMSPendingChanges(model.LMSPendingChangeStatusPending).Return(changes, nil)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.ListLMSPendingChanges)
+		req, err := http.NewRequest("GET", "/hosts/lms/pending-changes?status=Pending", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"changes": changes}), rr.Body.String())
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.ListLMSPendingChanges)
+		req, err := http.NewRequest("GET", "/hosts/lms/pending-changes?status=foobar", nil)
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestApproveLMSPendingChange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000002")
+
+	newRequest := func(t *testing.T) *http.Request {
+		req, err := http.NewRequest("POST", "/hosts/lms/pending-changes/000000000000000000000002/approve", nil)
+		require.NoError(t, err)
+
+		return mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		change := &model.LMSPendingChange{ID: id, Status: model.LMSPendingChangeStatusApproved}
+		as.EXPECT().ApproveLMSPendingChange(id, "").Return(change, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ApproveLMSPendingChange).ServeHTTP(rr, newRequest(t))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(change), rr.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().ApproveLMSPendingChange(id, "").Return(nil, utils.ErrLMSPendingChangeNotFound)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ApproveLMSPendingChange).ServeHTTP(rr, newRequest(t))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		as.EXPECT().ApproveLMSPendingChange(id, "").
+			Return(nil, fmt.Errorf("%w: %s", utils.ErrLMSPendingChangeAlreadyReviewed, model.LMSPendingChangeStatusRejected))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ApproveLMSPendingChange).ServeHTTP(rr, newRequest(t))
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestRejectLMSPendingChange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000002")
+	change := &model.LMSPendingChange{ID: id, Status: model.LMSPendingChangeStatusRejected}
+	as.EXPECT().RejectLMSPendingChange(id, "").Return(change, nil)
+
+	req, err := http.NewRequest("POST", "/hosts/lms/pending-changes/000000000000000000000002/reject", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.RejectLMSPendingChange).ServeHTTP(rr, mux.SetURLVars(req, map[string]string{"id": id.Hex()}))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(change), rr.Body.String())
+}
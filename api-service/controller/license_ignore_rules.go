@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/context"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ListLicenseIgnoreRules return the list of the license ignore rules
+func (ctrl *APIController) ListLicenseIgnoreRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := ctrl.Service.ListLicenseIgnoreRules()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"rules": rules,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// AddLicenseIgnoreRule add a license ignore rule
+func (ctrl *APIController) AddLicenseIgnoreRule(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	rule, ok := ctrl.decodeLicenseIgnoreRule(w, r)
+	if !ok {
+		return
+	}
+
+	if rule.ID != primitive.NilObjectID {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(errors.New("ID must be empty to add a new license ignore rule"), http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	if user, ok := context.Get(r, "user").(model.User); ok {
+		rule.CreatedBy = user.Username
+	}
+
+	res, err := ctrl.Service.AddLicenseIgnoreRule(*rule)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, res)
+}
+
+// UpdateLicenseIgnoreRule update a license ignore rule
+func (ctrl *APIController) UpdateLicenseIgnoreRule(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	rule, ok := ctrl.decodeLicenseIgnoreRule(w, r)
+	if !ok {
+		return
+	}
+
+	rule.ID = id
+
+	res, err := ctrl.Service.UpdateLicenseIgnoreRule(*rule)
+	if errors.Is(err, utils.ErrLicenseIgnoreRuleNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, res)
+}
+
+// DeleteLicenseIgnoreRule remove a license ignore rule
+func (ctrl *APIController) DeleteLicenseIgnoreRule(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	if err := ctrl.Service.DeleteLicenseIgnoreRule(id); errors.Is(err, utils.ErrLicenseIgnoreRuleNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, nil)
+}
+
+// decodeLicenseIgnoreRule decode and check the rule in the body of the request, it write the error response if it isn't valid
+func (ctrl *APIController) decodeLicenseIgnoreRule(w http.ResponseWriter, r *http.Request) (*model.LicenseIgnoreRule, bool) {
+	var rule model.LicenseIgnoreRule
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rule); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return nil, false
+	}
+
+	if err := rule.Check(); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return nil, false
+	}
+
+	return &rule, true
+}
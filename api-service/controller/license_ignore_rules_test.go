@@ -0,0 +1,204 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var licenseIgnoreRuleSample = model.LicenseIgnoreRule{
+	HostnameRegex: "^test-",
+	LicenseTypeID: "A90611",
+	Justification: "Test hosts covered by the development agreement",
+	ExpiresAt:     utils.P("2020-01-01T00:00:00Z"),
+}
+
+func TestListLicenseIgnoreRules_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rules := []model.LicenseIgnoreRule{licenseIgnoreRuleSample}
+	as.EXPECT().ListLicenseIgnoreRules().Return(rules, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ListLicenseIgnoreRules)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(map[string]interface{}{"rules": rules}), rr.Body.String())
+}
+
+func TestAddLicenseIgnoreRule_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	expected := licenseIgnoreRuleSample
+	expected.ID = utils.Str2oid("6512f1a5ba2e2a1f4b6b8f01")
+
+	as.EXPECT().AddLicenseIgnoreRule(licenseIgnoreRuleSample).Return(&expected, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.AddLicenseIgnoreRule)
+	req, err := http.NewRequest("POST", "/", bytes.NewReader([]byte(`{
+		"hostnameRegex": "^test-",
+		"environment": "",
+		"tag": "",
+		"licenseTypeID": "A90611",
+		"justification": "Test hosts covered by the development agreement",
+		"expiresAt": "2020-01-01T00:00:00Z"
+	}`)))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestAddLicenseIgnoreRule_BadRequests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	bodies := []string{
+		`{"hostnameRegex": "^test-", "expiresAt": "2020-01-01T00:00:00Z"}`,
+		`{"hostnameRegex": "^test-", "justification": "test"}`,
+		`{"justification": "test", "expiresAt": "2020-01-01T00:00:00Z"}`,
+		`{"hostnameRegex": "^test-", "justification": "test", "expiresAt": "2020-01-01T00:00:00Z", "foo": "bar"}`,
+	}
+
+	for _, body := range bodies {
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ac.AddLicenseIgnoreRule)
+		req, err := http.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
+
+func TestUpdateLicenseIgnoreRule_NotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rule := licenseIgnoreRuleSample
+	rule.ID = utils.Str2oid("6512f1a5ba2e2a1f4b6b8f01")
+
+	as.EXPECT().UpdateLicenseIgnoreRule(rule).Return(nil, utils.ErrLicenseIgnoreRuleNotFound)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.UpdateLicenseIgnoreRule)
+	req, err := http.NewRequest("PUT", "/", bytes.NewReader([]byte(utils.ToJSON(licenseIgnoreRuleSample))))
+	require.NoError(t, err)
+
+	req = mux.SetURLVars(req, map[string]string{"id": "6512f1a5ba2e2a1f4b6b8f01"})
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestDeleteLicenseIgnoreRule_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().DeleteLicenseIgnoreRule(utils.Str2oid("6512f1a5ba2e2a1f4b6b8f01")).Return(nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.DeleteLicenseIgnoreRule)
+	req, err := http.NewRequest("DELETE", "/", nil)
+	require.NoError(t, err)
+
+	req = mux.SetURLVars(req, map[string]string{"id": "6512f1a5ba2e2a1f4b6b8f01"})
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDeleteLicenseIgnoreRule_ReadOnly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config: config.Configuration{
+			APIService: config.APIService{ReadOnly: true},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.DeleteLicenseIgnoreRule)
+	req, err := http.NewRequest("DELETE", "/", nil)
+	require.NoError(t, err)
+
+	req = mux.SetURLVars(req, map[string]string{"id": "6512f1a5ba2e2a1f4b6b8f01"})
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
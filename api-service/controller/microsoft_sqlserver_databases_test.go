@@ -69,7 +69,7 @@ func TestSearchSqlServerInstances_JSONPaged(t *testing.T) {
 		SearchSqlServerInstances(
 			dto.SearchSqlServerInstancesFilter{
 				dto.GlobalFilter{
-					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"),
+					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"), "",
 				},
 				"foobar", "Hostname", true, 2, 3,
 			}).
@@ -132,7 +132,7 @@ func TestSearchSqlServerInstances_JSONUnpaged(t *testing.T) {
 		SearchSqlServerInstances(
 			dto.SearchSqlServerInstancesFilter{
 				dto.GlobalFilter{
-					"", "", utils.MAX_TIME,
+					"", "", utils.MAX_TIME, "",
 				},
 				"", "", false, -1, -1,
 			},
@@ -256,7 +256,7 @@ func TestSearchSqlServerInstances_JSONInternalServerError1(t *testing.T) {
 		SearchSqlServerInstances(
 			dto.SearchSqlServerInstancesFilter{
 				dto.GlobalFilter{
-					"", "", utils.MAX_TIME,
+					"", "", utils.MAX_TIME, "",
 				},
 				"", "", false, -1, -1,
 			},
@@ -292,7 +292,7 @@ func TestSearchSqlServerInstances_XLSXSuccess(t *testing.T) {
 		SearchSqlServerInstancesAsXLSX(
 			dto.SearchSqlServerInstancesFilter{
 				dto.GlobalFilter{
-					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"),
+					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"), "",
 				},
 				"foobar", "Hostname", true, -1, -1,
 			},
@@ -384,7 +384,7 @@ func TestSearchSqlServerInstances_XLSXInternalServerError1(t *testing.T) {
 		SearchSqlServerInstancesAsXLSX(
 			dto.SearchSqlServerInstancesFilter{
 				dto.GlobalFilter{
-					"", "", utils.MAX_TIME,
+					"", "", utils.MAX_TIME, "",
 				},
 				"", "", false, -1, -1,
 			},
@@ -156,6 +156,17 @@ func (ctrl *APIController) GetOracleDatabaseContractsXLSX(w http.ResponseWriter,
 	utils.WriteXLSXResponse(w, xlsx)
 }
 
+// CompareOracleDatabaseContractsAssignments return the result of the greedy and of the optimized assignment of the contracts
+func (ctrl *APIController) CompareOracleDatabaseContractsAssignments(w http.ResponseWriter, r *http.Request) {
+	comparison, err := ctrl.Service.CompareOracleDatabaseContractsAssignments()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, comparison)
+}
+
 func parseGetOracleDatabaseContractsFilters(urlValues url.Values) (dto.GetOracleDatabaseContractsFilter,
 	error) {
 	var err error
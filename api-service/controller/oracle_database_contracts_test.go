@@ -476,6 +476,59 @@ func TestGetOracleDatabaseContracts_FailedInternalServerError(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
 
+func TestCompareOracleDatabaseContractsAssignments_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	comparison := dto.OracleDatabaseContractsAssignmentComparison{
+		Mode:      "Greedy",
+		Greedy:    dto.OracleDatabaseContractsAssignmentResult{CoveredLicenses: 12, UncoveredLicenses: 10},
+		Optimized: dto.OracleDatabaseContractsAssignmentResult{CoveredLicenses: 22},
+	}
+
+	as.EXPECT().CompareOracleDatabaseContractsAssignments().Return(&comparison, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.CompareOracleDatabaseContractsAssignments)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(comparison), rr.Body.String())
+}
+
+func TestCompareOracleDatabaseContractsAssignments_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().CompareOracleDatabaseContractsAssignments().Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.CompareOracleDatabaseContractsAssignments)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
 func TestParseGetOracleDatabaseContractsFilters_SuccessEmpty(t *testing.T) {
 	r, _ := http.NewRequest("GET", "/", nil)
 	filters, err := parseGetOracleDatabaseContractsFilters(r.URL.Query())
@@ -87,13 +87,15 @@ func (ctrl *APIController) SearchOracleDatabaseAddmsJSON(w http.ResponseWriter,
 
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	addms, err := ctrl.Service.SearchOracleDatabaseAddms(search, sortBy, sortDesc, pageNumber, pageSize, location, environment, olderThan)
+	addms, err := ctrl.Service.SearchOracleDatabaseAddms(search, sortBy, sortDesc, pageNumber, pageSize, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -133,13 +135,15 @@ func (ctrl *APIController) SearchOracleDatabaseAddmsXLSX(w http.ResponseWriter,
 
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	addms, err := ctrl.Service.SearchOracleDatabaseAddms(search, "benefit", true, -1, -1, location, environment, olderThan)
+	addms, err := ctrl.Service.SearchOracleDatabaseAddms(search, "benefit", true, -1, -1, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -221,12 +225,14 @@ func (ctrl *APIController) SearchOracleDatabaseSegmentAdvisorsJSON(w http.Respon
 
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
-	segmentAdvisors, err := ctrl.Service.SearchOracleDatabaseSegmentAdvisors(search, sortBy, sortDesc, location, environment, olderThan)
+	site := r.URL.Query().Get("site")
+
+	segmentAdvisors, err := ctrl.Service.SearchOracleDatabaseSegmentAdvisors(search, sortBy, sortDesc, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -335,13 +341,15 @@ func (ctrl *APIController) SearchOracleDatabasePatchAdvisorsJSON(w http.Response
 
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	patchAdvisorResponse, err := ctrl.Service.SearchOracleDatabasePatchAdvisors(search, sortBy, sortDesc, pageNumber, pageSize, ctrl.TimeNow().AddDate(0, -windowTime, 0), location, environment, olderThan, status)
+	patchAdvisorResponse, err := ctrl.Service.SearchOracleDatabasePatchAdvisors(search, sortBy, sortDesc, pageNumber, pageSize, ctrl.TimeNow().AddDate(0, -windowTime, 0), location, environment, site, olderThan, status)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -476,12 +484,14 @@ func (ctrl *APIController) SearchOracleDatabaseUsedLicenses(w http.ResponseWrite
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
-	response, err := ctrl.Service.SearchOracleDatabaseUsedLicenses("", sortBy, sortDesc, pageNumber, pageSize, location, environment, olderThan)
+	site := r.URL.Query().Get("site")
+
+	response, err := ctrl.Service.SearchOracleDatabaseUsedLicenses("", sortBy, sortDesc, pageNumber, pageSize, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -86,7 +86,7 @@ func TestSearchOracleDatabaseAddms_JSONPaged(t *testing.T) {
 	}
 
 	as.EXPECT().
-		SearchOracleDatabaseAddms("foobar", "benefit", true, 2, 3, "Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+		SearchOracleDatabaseAddms("foobar", "benefit", true, 2, 3, "Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(resFromService, nil)
 
 	rr := httptest.NewRecorder()
@@ -146,7 +146,7 @@ func TestSearchOracleDatabaseAddms_JSONUnpaged(t *testing.T) {
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabaseAddms("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseAddms("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -270,7 +270,7 @@ func TestSearchOracleDatabaseAddms_JSONInternalServerError(t *testing.T) {
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabaseAddms("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseAddms("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -324,7 +324,7 @@ func TestSearchOracleDatabaseAddms_XLSXSuccess(t *testing.T) {
 	}
 
 	as.EXPECT().
-		SearchOracleDatabaseAddms("foobar", "benefit", true, -1, -1, "Germany", "TST", utils.P("2020-06-10T11:54:59Z")).
+		SearchOracleDatabaseAddms("foobar", "benefit", true, -1, -1, "Germany", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -408,7 +408,7 @@ func TestSearchOracleDatabaseAddms_XLSXInternalServerError1(t *testing.T) {
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabaseAddms("", "benefit", true, -1, -1, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseAddms("", "benefit", true, -1, -1, "", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -447,7 +447,7 @@ func TestSearchOracleDatabaseAddms_XLSXInternalServerError2(t *testing.T) {
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabaseAddms("", "benefit", true, -1, -1, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseAddms("", "benefit", true, -1, -1, "", "", "", utils.MAX_TIME).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -511,7 +511,7 @@ func TestSearchOracleDatabaseSegmentAdvisors_JSON(t *testing.T) {
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabaseSegmentAdvisors("", "", false, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseSegmentAdvisors("", "", false, "", "", "", utils.MAX_TIME).
 		Return(segmentAdvisors, nil)
 
 	rr := httptest.NewRecorder()
@@ -597,7 +597,7 @@ func TestSearchOracleDatabaseSegmentAdvisors_JSONInternalServerError(t *testing.
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabaseSegmentAdvisors("", "", false, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseSegmentAdvisors("", "", false, "", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -787,7 +787,7 @@ func TestSearchOracleDatabasePatchAdvisors_JSONPaged(t *testing.T) {
 	}
 
 	as.EXPECT().
-		SearchOracleDatabasePatchAdvisors("foobar", "Hostname", true, 2, 3, utils.P("2019-03-05T14:02:03Z"), "Italy", "TST", utils.P("2020-06-10T11:54:59Z"), "KO").
+		SearchOracleDatabasePatchAdvisors("foobar", "Hostname", true, 2, 3, utils.P("2019-03-05T14:02:03Z"), "Italy", "TST", "", utils.P("2020-06-10T11:54:59Z"), "KO").
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -850,7 +850,7 @@ func TestSearchOracleDatabasePatchAdvisors_JSONUnpaged(t *testing.T) {
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabasePatchAdvisors("", "", false, -1, -1, utils.P("2019-05-05T14:02:03Z"), "", "", utils.MAX_TIME, "").
+		SearchOracleDatabasePatchAdvisors("", "", false, -1, -1, utils.P("2019-05-05T14:02:03Z"), "", "", "", utils.MAX_TIME, "").
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -1016,7 +1016,7 @@ func TestSearchOracleDatabasePatchAdvisors_JSONInternalServerError(t *testing.T)
 		Return(locations, nil)
 
 	as.EXPECT().
-		SearchOracleDatabasePatchAdvisors("", "", false, -1, -1, utils.P("2019-05-05T14:02:03Z"), "", "", utils.MAX_TIME, "").
+		SearchOracleDatabasePatchAdvisors("", "", false, -1, -1, utils.P("2019-05-05T14:02:03Z"), "", "", "", utils.MAX_TIME, "").
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -1186,7 +1186,7 @@ func TestSearchOracleDatabases_JSONPaged(t *testing.T) {
 		SearchOracleDatabases(
 			dto.SearchOracleDatabasesFilter{
 				dto.GlobalFilter{
-					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"),
+					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"), "",
 				},
 				"foobar", "Hostname", true, 2, 3,
 			}).
@@ -1274,7 +1274,7 @@ func TestSearchOracleDatabases_JSONUnpaged(t *testing.T) {
 		SearchOracleDatabases(
 			dto.SearchOracleDatabasesFilter{
 				dto.GlobalFilter{
-					"", "", utils.MAX_TIME,
+					"", "", utils.MAX_TIME, "",
 				},
 				"", "", false, -1, -1,
 			},
@@ -1398,7 +1398,7 @@ func TestSearchOracleDatabases_JSONInternalServerError1(t *testing.T) {
 		SearchOracleDatabases(
 			dto.SearchOracleDatabasesFilter{
 				dto.GlobalFilter{
-					"", "", utils.MAX_TIME,
+					"", "", utils.MAX_TIME, "",
 				},
 				"", "", false, -1, -1,
 			},
@@ -1434,7 +1434,7 @@ func TestSearchOracleDatabases_XLSXSuccess(t *testing.T) {
 		SearchOracleDatabasesAsXLSX(
 			dto.SearchOracleDatabasesFilter{
 				dto.GlobalFilter{
-					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"),
+					"Italy", "TST", utils.P("2020-06-10T11:54:59Z"), "",
 				},
 				"foobar", "Hostname", true, -1, -1,
 			},
@@ -1526,7 +1526,7 @@ func TestSearchOracleDatabases_XLSXInternalServerError1(t *testing.T) {
 		SearchOracleDatabasesAsXLSX(
 			dto.SearchOracleDatabasesFilter{
 				dto.GlobalFilter{
-					"", "", utils.MAX_TIME,
+					"", "", utils.MAX_TIME, "",
 				},
 				"", "", false, -1, -1,
 			},
@@ -1577,7 +1577,7 @@ func TestSearchOracleDatabaseUsedLicenses_JSONPaged(t *testing.T) {
 
 	t.Run("JSON paged", func(t *testing.T) {
 		as.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "benefit", true, 2, 3, "Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+			SearchOracleDatabaseUsedLicenses("", "benefit", true, 2, 3, "Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 			Return(&resFromService, nil)
 
 		rr := httptest.NewRecorder()
@@ -1594,7 +1594,7 @@ func TestSearchOracleDatabaseUsedLicenses_JSONPaged(t *testing.T) {
 	t.Run("JSON unpaged", func(t *testing.T) {
 
 		as.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&resFromService, nil)
 
 		rr := httptest.NewRecorder()
@@ -1705,7 +1705,7 @@ func TestSearchOracleDatabaseUsedLicenses_JSONInternalServerError(t *testing.T)
 	}
 
 	as.EXPECT().
-		SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
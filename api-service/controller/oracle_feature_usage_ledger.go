@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang/gddo/httputil"
+	"github.com/gorilla/context"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetOracleFeatureUsageLedger return the Oracle feature usage ledger, as JSON or as an XLSX evidence report
+func (ctrl *APIController) GetOracleFeatureUsageLedger(w http.ResponseWriter, r *http.Request) {
+	choice := httputil.NegotiateContentType(r, []string{"application/json", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}, "application/json")
+
+	filter := dto.OracleFeatureUsageLedgerFilter{
+		Hostname:    r.URL.Query().Get("hostname"),
+		DbName:      r.URL.Query().Get("dbname"),
+		Product:     r.URL.Query().Get("product"),
+		Location:    r.URL.Query().Get("location"),
+		Environment: r.URL.Query().Get("environment"),
+		Site:        r.URL.Query().Get("site"),
+	}
+
+	if filter.Location == "" {
+		user := context.Get(r, "user")
+
+		locations, err := ctrl.Service.ListLocations(user)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		filter.Location = strings.Join(locations, ",")
+	}
+
+	switch choice {
+	case "application/json":
+		entries, err := ctrl.Service.GetOracleFeatureUsageLedger(filter)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteJSONResponse(w, http.StatusOK, entries)
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		file, err := ctrl.Service.GetOracleFeatureUsageLedgerAsXLSX(filter)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteXLSXResponse(w, file)
+	}
+}
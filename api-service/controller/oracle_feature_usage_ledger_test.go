@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetOracleFeatureUsageLedger_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	entries := []dto.OracleFeatureUsageLedgerEntry{
+		{
+			OracleFeatureUsageLedgerEntry: model.OracleFeatureUsageLedgerEntry{
+				Hostname:       "foobar",
+				DbName:         "ERCOLE",
+				Product:        "Diagnostics Pack",
+				Feature:        "AWR Report",
+				FirstDetected:  utils.P("2019-10-05T14:02:03Z"),
+				FirstUploadID:  utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+				LastDetected:   utils.P("2019-11-05T14:02:03Z"),
+				LastUploadID:   utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+				DetectedUsages: 3,
+			},
+			Location:    "Italy",
+			Environment: "PRD",
+		},
+	}
+
+	filter := dto.OracleFeatureUsageLedgerFilter{Hostname: "foobar", Location: "Italy"}
+	as.EXPECT().GetOracleFeatureUsageLedger(filter).Return(entries, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetOracleFeatureUsageLedger)
+	req, err := http.NewRequest("GET", "/hosts/technologies/oracle/databases/feature-usage-ledger?hostname=foobar&location=Italy", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(entries), rr.Body.String())
+}
+
+func TestGetOracleFeatureUsageLedger_XLSX(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	var user interface{}
+
+	gomock.InOrder(
+		as.EXPECT().ListLocations(user).Return([]string{"Italy", "Germany"}, nil),
+		as.EXPECT().GetOracleFeatureUsageLedgerAsXLSX(dto.OracleFeatureUsageLedgerFilter{Location: "Italy,Germany"}).
+			Return(excelize.NewFile(), nil),
+	)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetOracleFeatureUsageLedger)
+	req, err := http.NewRequest("GET", "/hosts/technologies/oracle/databases/feature-usage-ledger", nil)
+	require.NoError(t, err)
+	req.Header.Add("Accept", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	_, err = excelize.OpenReader(rr.Body)
+	require.NoError(t, err)
+}
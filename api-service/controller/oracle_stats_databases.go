@@ -36,13 +36,15 @@ func (ctrl *APIController) GetOracleDatabaseEnvironmentStats(w http.ResponseWrit
 	//parse the query params
 	location = r.URL.Query().Get("location")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabaseEnvironmentStats(location, olderThan)
+	stats, err := ctrl.Service.GetOracleDatabaseEnvironmentStats(location, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -64,13 +66,15 @@ func (ctrl *APIController) GetOracleDatabaseHighReliabilityStats(w http.Response
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabaseHighReliabilityStats(location, environment, olderThan)
+	stats, err := ctrl.Service.GetOracleDatabaseHighReliabilityStats(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -91,13 +95,15 @@ func (ctrl *APIController) GetOracleDatabaseVersionStats(w http.ResponseWriter,
 	//parse the query params
 	location = r.URL.Query().Get("location")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabaseVersionStats(location, olderThan)
+	stats, err := ctrl.Service.GetOracleDatabaseVersionStats(location, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -125,13 +131,15 @@ func (ctrl *APIController) GetTopReclaimableOracleDatabaseStats(w http.ResponseW
 		return
 	}
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetTopReclaimableOracleDatabaseStats(location, limit, olderThan)
+	stats, err := ctrl.Service.GetTopReclaimableOracleDatabaseStats(location, site, limit, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -159,13 +167,15 @@ func (ctrl *APIController) GetOracleDatabasePatchStatusStats(w http.ResponseWrit
 		return
 	}
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabasePatchStatusStats(location, ctrl.TimeNow().AddDate(0, -windowTime, 0), olderThan)
+	stats, err := ctrl.Service.GetOracleDatabasePatchStatusStats(location, site, ctrl.TimeNow().AddDate(0, -windowTime, 0), olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -204,13 +214,15 @@ func (ctrl *APIController) GetTopWorkloadOracleDatabaseStats(w http.ResponseWrit
 		return
 	}
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetTopWorkloadOracleDatabaseStats(location, limit, olderThan)
+	stats, err := ctrl.Service.GetTopWorkloadOracleDatabaseStats(location, site, limit, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -232,13 +244,15 @@ func (ctrl *APIController) GetOracleDatabaseDataguardStatusStats(w http.Response
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabaseDataguardStatusStats(location, environment, olderThan)
+	stats, err := ctrl.Service.GetOracleDatabaseDataguardStatusStats(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -260,13 +274,15 @@ func (ctrl *APIController) GetOracleDatabaseRACStatusStats(w http.ResponseWriter
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabaseRACStatusStats(location, environment, olderThan)
+	stats, err := ctrl.Service.GetOracleDatabaseRACStatusStats(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -288,13 +304,15 @@ func (ctrl *APIController) GetOracleDatabaseArchivelogStatusStats(w http.Respons
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOracleDatabaseArchivelogStatusStats(location, environment, olderThan)
+	stats, err := ctrl.Service.GetOracleDatabaseArchivelogStatusStats(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -364,13 +382,15 @@ func (ctrl *APIController) GetTopUnusedOracleDatabaseInstanceResourceStats(w htt
 		return
 	}
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetTopUnusedOracleDatabaseInstanceResourceStats(location, environment, limit, olderThan)
+	stats, err := ctrl.Service.GetTopUnusedOracleDatabaseInstanceResourceStats(location, environment, site, limit, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -53,7 +53,7 @@ func TestGetOracleDatabaseEnvironmentStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseEnvironmentStats("Italy", utils.P("2020-06-10T11:54:59Z")).
+		GetOracleDatabaseEnvironmentStats("Italy", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -100,7 +100,7 @@ func TestGetOracleDatabaseEnvironmentStats_FailInternalServerError(t *testing.T)
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseEnvironmentStats("", utils.MAX_TIME).
+		GetOracleDatabaseEnvironmentStats("", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -136,7 +136,7 @@ func TestGetOracleDatabaseVersionStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseVersionStats("Italy", utils.P("2020-06-10T11:54:59Z")).
+		GetOracleDatabaseVersionStats("Italy", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -183,7 +183,7 @@ func TestGetOracleDatabaseVersionStats_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseVersionStats("", utils.MAX_TIME).
+		GetOracleDatabaseVersionStats("", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -223,7 +223,7 @@ func TestGetTopReclaimableOracleDatabaseStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetTopReclaimableOracleDatabaseStats("Italy", 10, utils.P("2020-06-10T11:54:59Z")).
+		GetTopReclaimableOracleDatabaseStats("Italy", "", 10, utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -291,7 +291,7 @@ func TestGetTopReclaimableOracleDatabaseStats_FailInternalServerError(t *testing
 	}
 
 	as.EXPECT().
-		GetTopReclaimableOracleDatabaseStats("", 15, utils.MAX_TIME).
+		GetTopReclaimableOracleDatabaseStats("", "", 15, utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -327,7 +327,7 @@ func TestGetOracleDatabasePatchStatusStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabasePatchStatusStats("Italy", utils.P("2019-03-05T14:02:03Z"), utils.P("2020-06-10T11:54:59Z")).
+		GetOracleDatabasePatchStatusStats("Italy", "", utils.P("2019-03-05T14:02:03Z"), utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -395,7 +395,7 @@ func TestGetOracleDatabasePatchStatusStats_FailInternalServerError(t *testing.T)
 	}
 
 	as.EXPECT().
-		GetOracleDatabasePatchStatusStats("", utils.P("2019-05-05T14:02:03Z"), utils.MAX_TIME).
+		GetOracleDatabasePatchStatusStats("", "", utils.P("2019-05-05T14:02:03Z"), utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -435,7 +435,7 @@ func TestGetTopWorkloadOracleDatabaseStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetTopWorkloadOracleDatabaseStats("Italy", 9, utils.P("2020-06-10T11:54:59Z")).
+		GetTopWorkloadOracleDatabaseStats("Italy", "", 9, utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -524,7 +524,7 @@ func TestGetTopWorkloadOracleDatabaseStats_FailInternalServerError(t *testing.T)
 		Return(locations, nil)
 
 	as.EXPECT().
-		GetTopWorkloadOracleDatabaseStats("", 10, utils.MAX_TIME).
+		GetTopWorkloadOracleDatabaseStats("", "", 10, utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -560,7 +560,7 @@ func TestGetOracleDatabaseDataguardStatusStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseDataguardStatusStats("Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+		GetOracleDatabaseDataguardStatusStats("Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -607,7 +607,7 @@ func TestGetOracleDatabaseDataguardStatusStats_FailInternalServerError(t *testin
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseDataguardStatusStats("", "", utils.MAX_TIME).
+		GetOracleDatabaseDataguardStatusStats("", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -643,7 +643,7 @@ func TestGetOracleDatabaseRACStatusStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseRACStatusStats("Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+		GetOracleDatabaseRACStatusStats("Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -690,7 +690,7 @@ func TestGetOracleDatabaseRACStatusStats_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseRACStatusStats("", "", utils.MAX_TIME).
+		GetOracleDatabaseRACStatusStats("", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -726,7 +726,7 @@ func TestGetOracleDatabaseArchivelogStatusStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseArchivelogStatusStats("Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+		GetOracleDatabaseArchivelogStatusStats("Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -773,7 +773,7 @@ func TestGetOracleDatabaseArchivelogStatusStats_FailInternalServerError(t *testi
 	}
 
 	as.EXPECT().
-		GetOracleDatabaseArchivelogStatusStats("", "", utils.MAX_TIME).
+		GetOracleDatabaseArchivelogStatusStats("", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -0,0 +1,53 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) AddPostgreSQLContract(w http.ResponseWriter, r *http.Request) {
+	addSubscriptionContract(ctrl, w, r, ctrl.Service.AddPostgreSQLContract)
+}
+
+func (ctrl *APIController) UpdatePostgreSQLContract(w http.ResponseWriter, r *http.Request) {
+	updateSubscriptionContract(ctrl, w, r, ctrl.Service.UpdatePostgreSQLContract)
+}
+
+func (ctrl *APIController) GetPostgreSQLContracts(w http.ResponseWriter, r *http.Request) {
+	getSubscriptionContracts(ctrl, w, r, ctrl.Service.GetPostgreSQLContracts, ctrl.Service.GetPostgreSQLContractsAsXLSX)
+}
+
+func (ctrl *APIController) DeletePostgreSQLContract(w http.ResponseWriter, r *http.Request) {
+	deleteSubscriptionContract(ctrl, w, r, ctrl.Service.DeletePostgreSQLContract)
+}
+
+// GetPostgreSQLLicenseTypes return the list of PostgreSQLLicenseTypes
+func (ctrl *APIController) GetPostgreSQLLicenseTypes(w http.ResponseWriter, r *http.Request) {
+	data, err := ctrl.Service.GetPostgreSQLLicenseTypes()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"license-types": data,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
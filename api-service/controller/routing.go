@@ -92,12 +92,32 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts/environments", ctrl.ListEnvironments).Methods("GET")
 	router.HandleFunc("/hosts/clusters", ctrl.SearchClusters).Methods("GET")
 	router.HandleFunc("/hosts/clusters/{name}", ctrl.GetCluster).Methods("GET")
+	router.HandleFunc("/hosts/licenses-used", ctrl.GetHostsUsedLicenses).Methods("GET")
+	router.HandleFunc("/hosts/licenses-compliance", ctrl.GetHostLicensesCompliance).Methods("GET")
+	router.HandleFunc("/hosts/lms/import", ctrl.ImportOracleLMSReview).Methods("POST")
+	router.HandleFunc("/hosts/lms/pending-changes", ctrl.ListLMSPendingChanges).Methods("GET")
+	router.HandleFunc("/hosts/lms/pending-changes/{id}/approve", ctrl.ApproveLMSPendingChange).Methods("POST")
+	router.HandleFunc("/hosts/lms/pending-changes/{id}/reject", ctrl.RejectLMSPendingChange).Methods("POST")
+	router.HandleFunc("/hosts/changes", ctrl.SearchHostDataChanges).Methods("GET")
+	router.HandleFunc("/hosts/snapshots", ctrl.ListHostDataSnapshots).Methods("GET")
+	router.HandleFunc("/hosts/quarantine", ctrl.ListQuarantinedHostData).Methods("GET")
+	router.HandleFunc("/hosts/quarantine/{id}", ctrl.GetQuarantinedHostData).Methods("GET")
+	router.HandleFunc("/hosts/quarantine/{id}", ctrl.DeleteQuarantinedHostData).Methods("DELETE")
+	router.HandleFunc("/hosts/quarantine/{id}/payload", ctrl.DownloadQuarantinedHostData).Methods("GET")
+	router.HandleFunc("/hosts/quarantine/{id}/replay", ctrl.ReplayQuarantinedHostData).Methods("POST")
+	router.HandleFunc("/hosts/identities", ctrl.ListHostIdentities).Methods("GET")
+	router.HandleFunc("/hosts/identities/{id}", ctrl.GetHostIdentity).Methods("GET")
+	router.HandleFunc("/hosts/identities/{id}/aliases", ctrl.AddHostAlias).Methods("POST")
+	router.HandleFunc("/hosts/identities/{id}/aliases/{alias}", ctrl.RemoveHostAlias).Methods("DELETE")
+	router.HandleFunc("/hosts/identities/{id}/merge", ctrl.MergeHostIdentities).Methods("POST")
+	router.HandleFunc("/hosts/identities/{id}/split", ctrl.SplitHostIdentity).Methods("POST")
 
 	router.HandleFunc("/hosts/{hostname}", ctrl.GetHost).Methods("GET")
 	router.HandleFunc("/hosts/{hostname}", ctrl.DismissHost).Methods("DELETE")
 	router.HandleFunc("/hosts/{hostname}/technologies/oracle/databases/{dbname}/licenses/{licenseTypeID}/ignored/{ignored}", ctrl.UpdateLicenseIgnoredField).Methods("PUT")
 
 	router.HandleFunc("/hosts/{hostname}/is-missing-db", ctrl.GetMissingDbHost).Methods("GET")
+	router.HandleFunc("/hosts/{hostname}/changes", ctrl.GetHostDataChanges).Methods("GET")
 
 	router.HandleFunc("/hosts/technologies", ctrl.ListTechnologies).Methods("GET")
 
@@ -106,6 +126,7 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts/technologies/all/databases/statistics", ctrl.GetDatabasesStatistics).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-used", ctrl.GetUsedLicensesPerDatabases).Methods("GET")
 	router.HandleFunc("/hosts/{hostname}/technologies/all/databases/licenses-used", ctrl.GetUsedLicensesPerDatabasesByHost).Methods("GET")
+	router.HandleFunc("/hosts/{hostname}/technologies/all/databases/licenses-used/explain", ctrl.ExplainUsedLicensesPerDatabasesByHost).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-used-per-host", ctrl.GetUsedLicensesPerHost).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-used-per-cluster", ctrl.GetUsedLicensesPerCluster).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-compliance", ctrl.GetDatabaseLicensesCompliance).Methods("GET")
@@ -133,6 +154,7 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts/technologies/oracle/databases/patch-advisors", ctrl.SearchOracleDatabasePatchAdvisors).Methods("GET")
 	router.HandleFunc("/hosts/technologies/oracle/databases/patch-list", ctrl.GetOraclePatchList).Methods("GET")
 	router.HandleFunc("/hosts/technologies/oracle/databases/option-list", ctrl.GetOracleOptionList).Methods("GET")
+	router.HandleFunc("/hosts/technologies/oracle/databases/feature-usage-ledger", ctrl.GetOracleFeatureUsageLedger).Methods("GET")
 	router.HandleFunc("/hosts/technologies/oracle/databases/tablespaces", ctrl.ListOracleDatabaseTablespaces).Methods("GET")
 	router.HandleFunc("/hosts/technologies/oracle/databases/change-list/{hostname}", ctrl.GetOracleChanges).Methods("GET")
 	router.HandleFunc("/hosts/technologies/oracle/databases/change-list/{hostname}/pdbs", ctrl.GetOraclePDBChanges).Methods("GET")
@@ -149,6 +171,7 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/contracts/oracle/database", ctrl.AddOracleDatabaseContract).Methods("POST")
 	router.HandleFunc("/contracts/oracle/database", ctrl.UpdateOracleDatabaseContract).Methods("PUT")
 	router.HandleFunc("/contracts/oracle/database", ctrl.GetOracleDatabaseContracts).Methods("GET")
+	router.HandleFunc("/contracts/oracle/database/assignment-comparison", ctrl.CompareOracleDatabaseContractsAssignments).Methods("GET")
 	router.HandleFunc("/contracts/oracle/database/{id}", ctrl.DeleteOracleDatabaseContract).Methods("DELETE")
 
 	router.HandleFunc("/contracts/oracle/database/{id}/hosts", ctrl.AddHostToOracleDatabaseContract).Methods("POST")
@@ -180,9 +203,27 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	// POSTGRESQL
 	router.HandleFunc("/hosts/technologies/postgresql/databases", ctrl.SearchPostgreSqlInstances).Methods("GET")
 
+	// POSTGRESQL CONTRACTS
+	router.HandleFunc("/contracts/postgresql/database", ctrl.AddPostgreSQLContract).Methods("POST")
+	router.HandleFunc("/contracts/postgresql/database/{id}", ctrl.UpdatePostgreSQLContract).Methods("PUT")
+	router.HandleFunc("/contracts/postgresql/database", ctrl.GetPostgreSQLContracts).Methods("GET")
+	router.HandleFunc("/contracts/postgresql/database/{id}", ctrl.DeletePostgreSQLContract).Methods("DELETE")
+
 	// MONGODB
 	router.HandleFunc("/hosts/technologies/mongodb/databases", ctrl.SearchMongoDBInstances).Methods("GET")
 
+	// MONGODB CONTRACTS
+	router.HandleFunc("/contracts/mongodb/database", ctrl.AddMongoDBContract).Methods("POST")
+	router.HandleFunc("/contracts/mongodb/database/{id}", ctrl.UpdateMongoDBContract).Methods("PUT")
+	router.HandleFunc("/contracts/mongodb/database", ctrl.GetMongoDBContracts).Methods("GET")
+	router.HandleFunc("/contracts/mongodb/database/{id}", ctrl.DeleteMongoDBContract).Methods("DELETE")
+
+	// HOST CONTRACTS
+	router.HandleFunc("/contracts/hosts", ctrl.AddHostContract).Methods("POST")
+	router.HandleFunc("/contracts/hosts/{id}", ctrl.UpdateHostContract).Methods("PUT")
+	router.HandleFunc("/contracts/hosts", ctrl.GetHostContracts).Methods("GET")
+	router.HandleFunc("/contracts/hosts/{id}", ctrl.DeleteHostContract).Methods("DELETE")
+
 	// ALERTS
 	router.HandleFunc("/alerts", ctrl.SearchAlerts).Methods("GET")
 	router.HandleFunc("/alerts/ack", ctrl.AckAlerts).Methods("POST")
@@ -216,6 +257,14 @@ func (ctrl *APIController) setupSettingsRoutes(router *mux.Router) {
 	router.HandleFunc("/oracle/database/license-types/{id}", ctrl.UpdateOracleDatabaseLicenseType).Methods("PUT")
 	router.HandleFunc("/microsoft/database/license-types", ctrl.GetSqlServerDatabaseLicenseTypes).Methods("GET")
 	router.HandleFunc("/mysql/database/license-types", ctrl.GetMySqlLicenseTypes).Methods("GET")
+	router.HandleFunc("/postgresql/database/license-types", ctrl.GetPostgreSQLLicenseTypes).Methods("GET")
+	router.HandleFunc("/mongodb/database/license-types", ctrl.GetMongoDBLicenseTypes).Methods("GET")
+	router.HandleFunc("/hosts/license-types", ctrl.GetHostLicenseTypes).Methods("GET")
+
+	router.HandleFunc("/license-ignore-rules", ctrl.ListLicenseIgnoreRules).Methods("GET")
+	router.HandleFunc("/license-ignore-rules", ctrl.AddLicenseIgnoreRule).Methods("POST")
+	router.HandleFunc("/license-ignore-rules/{id}", ctrl.UpdateLicenseIgnoreRule).Methods("PUT")
+	router.HandleFunc("/license-ignore-rules/{id}", ctrl.DeleteLicenseIgnoreRule).Methods("DELETE")
 }
 
 func (ctrl *APIController) setupFrontendAPIRoutes(router *mux.Router) {
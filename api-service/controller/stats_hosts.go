@@ -34,13 +34,15 @@ func (ctrl *APIController) GetHostsCountStats(w http.ResponseWriter, r *http.Req
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetHostsCountStats(location, environment, olderThan)
+	stats, err := ctrl.Service.GetHostsCountStats(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -61,13 +63,15 @@ func (ctrl *APIController) GetEnvironmentStats(w http.ResponseWriter, r *http.Re
 	//parse the query params
 	location = r.URL.Query().Get("location")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetEnvironmentStats(location, olderThan)
+	stats, err := ctrl.Service.GetEnvironmentStats(location, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -88,13 +92,15 @@ func (ctrl *APIController) GetTypeStats(w http.ResponseWriter, r *http.Request)
 	//parse the query params
 	location = r.URL.Query().Get("location")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetTypeStats(location, olderThan)
+	stats, err := ctrl.Service.GetTypeStats(location, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -115,13 +121,15 @@ func (ctrl *APIController) GetOperatingSystemStats(w http.ResponseWriter, r *htt
 	//parse the query params
 	location = r.URL.Query().Get("location")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
+	site := r.URL.Query().Get("site")
+
 	//get the data
-	stats, err := ctrl.Service.GetOperatingSystemStats(location, olderThan)
+	stats, err := ctrl.Service.GetOperatingSystemStats(location, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -52,7 +52,7 @@ func TestGetEnvironmentStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetEnvironmentStats("Italy", utils.P("2020-06-10T11:54:59Z")).
+		GetEnvironmentStats("Italy", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -99,7 +99,7 @@ func TestGetEnvironmentStats_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetEnvironmentStats("", utils.MAX_TIME).
+		GetEnvironmentStats("", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -135,7 +135,7 @@ func TestGetTypeStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetTypeStats("Italy", utils.P("2020-06-10T11:54:59Z")).
+		GetTypeStats("Italy", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -182,7 +182,7 @@ func TestGetTypeStats_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetTypeStats("", utils.MAX_TIME).
+		GetTypeStats("", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -218,7 +218,7 @@ func TestGetOperatingSystemStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOperatingSystemStats("Italy", utils.P("2020-06-10T11:54:59Z")).
+		GetOperatingSystemStats("Italy", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -265,7 +265,7 @@ func TestGetOperatingSystemStats_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetOperatingSystemStats("", utils.MAX_TIME).
+		GetOperatingSystemStats("", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -303,7 +303,7 @@ func TestGetTopUnusedOracleDatabaseInstanceResourceStats_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		GetTopUnusedOracleDatabaseInstanceResourceStats("Italy", "TST", 10, utils.P("2020-06-10T11:54:59Z")).
+		GetTopUnusedOracleDatabaseInstanceResourceStats("Italy", "TST", "", 10, utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -392,7 +392,7 @@ func TestGetTopUnusedOracleDatabaseInstanceResourceStats_FailInternalServerError
 		Return(locations, nil)
 
 	as.EXPECT().
-		GetTopUnusedOracleDatabaseInstanceResourceStats("", "", 15, utils.MAX_TIME).
+		GetTopUnusedOracleDatabaseInstanceResourceStats("", "", "", 15, utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -0,0 +1,155 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/golang/gddo/httputil"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// subscriptionContract is a contract of a technology licensed by subscription, like MongoDB and PostgreSQL
+type subscriptionContract interface {
+	GetID() primitive.ObjectID
+	IsValid() bool
+}
+
+// addSubscriptionContract decode a new contract from the request and add it with the add function
+func addSubscriptionContract[T subscriptionContract](ctrl *APIController, w http.ResponseWriter, r *http.Request,
+	add func(T) (*T, error)) {
+	var contract T
+
+	if err := utils.Decode(r.Body, &contract); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if contract.GetID() != primitive.NilObjectID {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("ID must be empty"))
+		return
+	}
+
+	if !contract.IsValid() {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Contract isn't valid"))
+		return
+	}
+
+	contractAdded, err := add(contract)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, contractAdded)
+}
+
+// updateSubscriptionContract decode the contract with the id of the request and update it with the update function
+func updateSubscriptionContract[T subscriptionContract](ctrl *APIController, w http.ResponseWriter, r *http.Request,
+	update func(T) (*T, error)) {
+	var contract T
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := utils.Decode(r.Body, &contract); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if contract.GetID() != id {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Object ID does not correspond"))
+		return
+	}
+
+	if !contract.IsValid() {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Contract isn't valid"))
+		return
+	}
+
+	contractUpdated, err := update(contract)
+	if errors.Is(err, utils.ErrNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	}
+
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, contractUpdated)
+}
+
+// getSubscriptionContracts write the contracts as JSON or as XLSX, according to the requested content type
+func getSubscriptionContracts[T subscriptionContract](ctrl *APIController, w http.ResponseWriter, r *http.Request,
+	get func() ([]T, error), getAsXLSX func() (*excelize.File, error)) {
+	choice := httputil.NegotiateContentType(r, []string{"application/json", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}, "application/json")
+
+	switch choice {
+	case "application/json":
+		contracts, err := get()
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		response := map[string]interface{}{
+			"contracts": contracts,
+		}
+		utils.WriteJSONResponse(w, http.StatusOK, response)
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		xlsx, err := getAsXLSX()
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteXLSXResponse(w, xlsx)
+	}
+}
+
+// deleteSubscriptionContract delete the contract with the id of the request with the delete function
+func deleteSubscriptionContract(ctrl *APIController, w http.ResponseWriter, r *http.Request,
+	delete func(primitive.ObjectID) error) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, fmt.Errorf("Can't decode id: %w", err))
+		return
+	}
+
+	err = delete(id)
+	if errors.Is(err, utils.ErrNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	}
+
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddMongoDBContract_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contract := model.MongoDBContract{
+		Type:             model.MongoDBContractTypeCore,
+		ContractID:       "agr01",
+		LicenseTypeID:    model.MongoDBEnterprisePartNumber,
+		NumberOfLicenses: 42,
+		Hosts:            []string{"topolino"},
+	}
+
+	returnAgr := contract
+	returnAgr.ID = utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+
+	as.EXPECT().AddMongoDBContract(contract).Return(&returnAgr, nil)
+
+	agrBytes, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "", bytes.NewReader(agrBytes))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddMongoDBContract).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(returnAgr), rr.Body.String())
+}
+
+func TestAddPostgreSQLContract_NotEmptyID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contract := model.PostgreSQLContract{
+		ID:               utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		Type:             model.PostgreSQLContractTypeCore,
+		ContractID:       "agr01",
+		NumberOfLicenses: 42,
+	}
+
+	agrBytes, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "", bytes.NewReader(agrBytes))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddPostgreSQLContract).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestUpdatePostgreSQLContract_IDNotCorresponding(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contract := model.PostgreSQLContract{
+		ID:               utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+		Type:             model.PostgreSQLContractTypeCore,
+		ContractID:       "agr01",
+		NumberOfLicenses: 42,
+	}
+
+	agrBytes, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("PUT", "", bytes.NewReader(agrBytes))
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"id": "aaaaaaaaaaaaaaaaaaaaaaaa"})
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.UpdatePostgreSQLContract).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDeleteMongoDBContract_NotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+	as.EXPECT().DeleteMongoDBContract(id).Return(utils.ErrNotFound)
+
+	req, err := http.NewRequest("DELETE", "", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.DeleteMongoDBContract).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -42,12 +42,14 @@ func (ctrl *APIController) ListTechnologies(w http.ResponseWriter, r *http.Reque
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
-	data, err := ctrl.Service.ListManagedTechnologies(sortBy, sortDesc, location, environment, olderThan)
+	site := r.URL.Query().Get("site")
+
+	data, err := ctrl.Service.ListManagedTechnologies(sortBy, sortDesc, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -63,7 +63,7 @@ func TestListTechnologies_Success(t *testing.T) {
 	}
 
 	as.EXPECT().
-		ListManagedTechnologies("Count", true, "Italy", "TST", utils.P("2020-06-10T11:54:59Z")).
+		ListManagedTechnologies("Count", true, "Italy", "TST", "", utils.P("2020-06-10T11:54:59Z")).
 		Return(expectedRes, nil)
 
 	rr := httptest.NewRecorder()
@@ -131,7 +131,7 @@ func TestListTechnologies_FailInternalServerError(t *testing.T) {
 	}
 
 	as.EXPECT().
-		ListManagedTechnologies("", false, "", "", utils.MAX_TIME).
+		ListManagedTechnologies("", false, "", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
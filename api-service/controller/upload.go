@@ -20,9 +20,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
-	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
 )
 
 const (
@@ -32,7 +36,7 @@ const (
 )
 
 func (ctrl *APIController) ImportContractFromCSV(w http.ResponseWriter, r *http.Request) {
-	file, _, err := r.FormFile("file")
+	file, header, err := r.FormFile("file")
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
 		return
@@ -46,26 +50,57 @@ func (ctrl *APIController) ImportContractFromCSV(w http.ResponseWriter, r *http.
 		return
 	}
 
-	c := make(chan error)
-
-	reader := csv.NewReader(file)
-	go func(reader *csv.Reader) {
-		switch databaseType {
-		case "oracle":
-			c <- ctrl.Service.ImportOracleDatabaseContracts(reader)
-		case "sqlserver":
-			c <- ctrl.Service.ImportSQLServerDatabaseContracts(reader)
-		case "mysql":
-			c <- ctrl.Service.ImportMySQLDatabaseContracts(reader)
+	var options dto.ContractsImportOptions
+
+	if options.DryRun, err = utils.Str2bool(r.URL.Query().Get("dry-run"), false); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	options.Mode = r.URL.Query().Get("mode")
+	if options.Mode == "" {
+		options.Mode = dto.ContractsImportModeInsert
+	}
+
+	if options.Mode != dto.ContractsImportModeInsert && options.Mode != dto.ContractsImportModeUpsert {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("invalid import mode in param"))
+		return
+	}
+
+	var reader *csv.Reader
+
+	if strings.HasSuffix(strings.ToLower(header.Filename), ".xlsx") {
+		reader, err = exutils.NewCSVReaderFromXLSX(file)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+			return
 		}
-	}(reader)
+	} else {
+		reader = csv.NewReader(file)
+	}
+
+	var report *dto.ContractsImportReport
+
+	switch databaseType {
+	case ORACLE:
+		report, err = ctrl.Service.ImportOracleDatabaseContracts(reader, options)
+	case SQLSERVER:
+		report, err = ctrl.Service.ImportSQLServerDatabaseContracts(reader, options)
+	case MYSQL:
+		report, err = ctrl.Service.ImportMySQLDatabaseContracts(reader, options)
+	}
 
-	if err := <-c; err != nil {
+	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	if !report.Valid {
+		utils.WriteJSONResponse(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, report)
 }
 
 func (ctrl *APIController) GetContractSampleCSV(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newContractsUploadRequest(t *testing.T, url, filename, content string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", url, body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return mux.SetURLVars(req, map[string]string{"databaseType": "oracle"})
+}
+
+func TestImportContractFromCSV_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	report := &dto.ContractsImportReport{
+		Mode:     dto.ContractsImportModeUpsert,
+		Valid:    true,
+		Inserted: 1,
+		Rows:     []dto.ContractsImportRow{},
+	}
+	options := dto.ContractsImportOptions{Mode: dto.ContractsImportModeUpsert}
+	as.EXPECT().ImportOracleDatabaseContracts(gomock.Any(), options).Return(report, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ImportContractFromCSV)
+	req := newContractsUploadRequest(t, "/contracts/oracle/upload?mode=upsert", "contracts.csv", "Contract Number\nAID001\n")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(report), rr.Body.String())
+}
+
+func TestImportContractFromCSV_DryRunInvalid(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	report := &dto.ContractsImportReport{
+		DryRun: true,
+		Mode:   dto.ContractsImportModeInsert,
+		Valid:  false,
+		Rows: []dto.ContractsImportRow{
+			{Row: 2, ContractID: "AID001", Action: dto.ContractsImportActionInsert, Errors: []string{`Unknown license type: ""`}},
+		},
+	}
+	options := dto.ContractsImportOptions{DryRun: true, Mode: dto.ContractsImportModeInsert}
+	as.EXPECT().ImportOracleDatabaseContracts(gomock.Any(), options).Return(report, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ImportContractFromCSV)
+	req := newContractsUploadRequest(t, "/contracts/oracle/upload?dry-run=true", "contracts.csv", "Contract Number\nAID001\n")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(report), rr.Body.String())
+}
+
+func TestImportContractFromCSV_InvalidMode(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ImportContractFromCSV)
+	req := newContractsUploadRequest(t, "/contracts/oracle/upload?mode=replace", "contracts.csv", "Contract Number\nAID001\n")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -114,6 +114,7 @@ func (md *MongoDatabase) GetClusters(filter dto.GlobalFilter) ([]dto.Cluster, er
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$clusters"),
 			mu.APProject(bson.M{
 				"hostname":    1,
@@ -39,6 +39,27 @@ func FilterByLocationAndEnvironmentSteps(location string, environment string) in
 	}
 }
 
+// FilterBySiteSteps return the steps required to filter the data by the comma separated names of the sites.
+// The data without site belong to the localSite
+func FilterBySiteSteps(site string, localSite string) interface{} {
+	sites := bson.A{}
+
+	if site != "" {
+		for _, s := range strings.Split(site, ",") {
+			sites = append(sites, s)
+			if s == localSite {
+				sites = append(sites, nil)
+			}
+		}
+	}
+
+	return bson.A{
+		mu.APOptionalStage(site != "", mu.APMatch(bson.M{
+			"site": bson.M{"$in": sites},
+		})),
+	}
+}
+
 func FilterByOldnessSteps(olderThan time.Time) bson.A {
 	return mu.MAPipeline(
 		mu.APOptionalStage(olderThan == utils.MAX_TIME, mu.APMatch(bson.M{
@@ -53,9 +74,10 @@ func FilterByOldnessSteps(olderThan time.Time) bson.A {
 			bson.M{
 				"$sort": bson.M{"createdAt": 1},
 			},
+			// the same hostname can be used by a local host and by the hosts of other sites
 			bson.M{
 				"$group": bson.M{
-					"_id": "$hostname",
+					"_id": bson.M{"hostname": "$hostname", "site": "$site"},
 					"hostdata": bson.M{
 						"$max": bson.M{
 							"$mergeObjects": bson.A{
@@ -73,6 +95,13 @@ func FilterByOldnessSteps(olderThan time.Time) bson.A {
 					"newRoot": "$hostdata",
 				},
 			},
+			// the hosts dismissed before olderThan weren't there anymore
+			mu.APMatch(bson.M{
+				"$or": bson.A{
+					bson.M{"dismissedAt": nil},
+					bson.M{"dismissedAt": bson.M{"$gt": olderThan}},
+				},
+			}),
 		}),
 	)
 }
@@ -85,8 +114,9 @@ func AddHardwareAbstraction(field string) bson.A {
 
 func AddAssociatedClusterNameAndVirtualizationNode(olderThan time.Time) bson.A {
 	return mu.MAPipeline(
-		mu.APLookupPipeline("hosts", bson.M{"hn": "$hostname"}, "vm", mu.MAPipeline(
+		mu.APLookupPipeline("hosts", bson.M{"hn": "$hostname", "site": mu.APOIfNull("$site", nil)}, "vm", mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
+			mu.APMatch(mu.QOExpr(mu.APOEqual(mu.APOIfNull("$site", nil), "$$site"))),
 			mu.APUnwind("$clusters"),
 			mu.APReplaceWith("$clusters"),
 			mu.APUnwind("$vms"),
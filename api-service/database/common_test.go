@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/ercole-io/ercole/v2/utils/mongoutils"
 )
@@ -119,6 +120,72 @@ func (m *MongodbSuite) TestFilterByLocationAndEnvironmentSteps() {
 	)
 }
 
+func (m *MongodbSuite) TestFilterBySiteSteps() {
+	defer m.db.Client.Database(m.dbname).Collection("hosts").DeleteMany(context.TODO(), bson.M{})
+	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_01.json"))
+	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_04.json"))
+	federated := mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_05.json")
+	federated["site"] = "milan"
+	m.InsertHostData(federated)
+
+	m.RunTestQuery(
+		"no_site",
+		mu.MAPipeline(
+			FilterBySiteSteps("", "rome"),
+			mu.APProject(bson.M{
+				"hostname": 1,
+				"_id":      0,
+			}),
+		),
+		func(out []map[string]interface{}) {
+			var expectedOut string = `[
+				{ "hostname": "test-small" },
+				{ "hostname": "test-small2" },
+				{ "hostname": "test-small3" }
+			]`
+
+			assert.JSONEq(m.T(), expectedOut, utils.ToJSON(out))
+		},
+	)
+
+	m.RunTestQuery(
+		"federated_site",
+		mu.MAPipeline(
+			FilterBySiteSteps("milan", "rome"),
+			mu.APProject(bson.M{
+				"hostname": 1,
+				"_id":      0,
+			}),
+		),
+		func(out []map[string]interface{}) {
+			var expectedOut string = `[
+				{ "hostname": "test-small3" }
+			]`
+
+			assert.JSONEq(m.T(), expectedOut, utils.ToJSON(out))
+		},
+	)
+
+	m.RunTestQuery(
+		"local_site",
+		mu.MAPipeline(
+			FilterBySiteSteps("rome", "rome"),
+			mu.APProject(bson.M{
+				"hostname": 1,
+				"_id":      0,
+			}),
+		),
+		func(out []map[string]interface{}) {
+			var expectedOut string = `[
+				{ "hostname": "test-small" },
+				{ "hostname": "test-small2" }
+			]`
+
+			assert.JSONEq(m.T(), expectedOut, utils.ToJSON(out))
+		},
+	)
+}
+
 func (m *MongodbSuite) TestFilterByOldnessSteps() {
 	defer m.db.Client.Database(m.dbname).Collection("hosts").DeleteMany(context.TODO(), bson.M{})
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_01.json"))
@@ -226,4 +293,79 @@ func (m *MongodbSuite) TestFilterByOldnessSteps() {
 			assert.ElementsMatch(m.T(), expectedOut, out)
 		},
 	)
+
+	m.InsertHostData(model.RawObject{
+		"_id":         utils.Str2oid("5ea2d3c920d55cbdc35022c1"),
+		"hostname":    "test-dismissed",
+		"archived":    true,
+		"createdAt":   utils.P("2020-04-24T13:00:00+02:00"),
+		"dismissedAt": utils.P("2020-04-24T13:55:00+02:00"),
+	})
+
+	m.RunTestQuery(
+		"dismissed_mongohostdata",
+		mu.MAPipeline(
+			FilterByOldnessSteps(utils.P("2020-04-24T13:50:36+02:00")),
+			mu.APMatch(bson.M{"hostname": "test-dismissed"}),
+			mu.APProject(bson.M{
+				"_id": 1,
+			}),
+		),
+		func(out []map[string]interface{}) {
+			var expectedOut interface{} = []interface{}{
+				map[string]interface{}{"_id": utils.Str2oid("5ea2d3c920d55cbdc35022c1")},
+			}
+
+			assert.ElementsMatch(m.T(), expectedOut, out)
+		},
+	)
+
+	m.RunTestQuery(
+		"no_mongohostdata_dismissed_before",
+		mu.MAPipeline(
+			FilterByOldnessSteps(utils.P("2020-04-24T13:58:36+02:00")),
+			mu.APMatch(bson.M{"hostname": "test-dismissed"}),
+			mu.APProject(bson.M{
+				"_id": 1,
+			}),
+		),
+		func(out []map[string]interface{}) {
+			var expectedOut interface{} = []interface{}{}
+
+			assert.ElementsMatch(m.T(), expectedOut, out)
+		},
+	)
+
+	m.InsertHostData(model.RawObject{
+		"_id":       utils.Str2oid("5ea2d3c920d55cbdc35022c2"),
+		"hostname":  "test-two-sites",
+		"archived":  false,
+		"createdAt": utils.P("2020-04-24T13:00:00+02:00"),
+	})
+	m.InsertHostData(model.RawObject{
+		"_id":       utils.Str2oid("5ea2d3c920d55cbdc35022c3"),
+		"hostname":  "test-two-sites",
+		"site":      "milan",
+		"archived":  false,
+		"createdAt": utils.P("2020-04-24T13:10:00+02:00"),
+	})
+
+	m.RunTestQuery(
+		"same_hostname_on_two_sites",
+		mu.MAPipeline(
+			FilterByOldnessSteps(utils.P("2020-04-24T13:50:36+02:00")),
+			mu.APMatch(bson.M{"hostname": "test-two-sites"}),
+			mu.APProject(bson.M{
+				"_id": 1,
+			}),
+		),
+		func(out []map[string]interface{}) {
+			var expectedOut interface{} = []interface{}{
+				map[string]interface{}{"_id": utils.Str2oid("5ea2d3c920d55cbdc35022c2")},
+				map[string]interface{}{"_id": utils.Str2oid("5ea2d3c920d55cbdc35022c3")},
+			}
+
+			assert.ElementsMatch(m.T(), expectedOut, out)
+		},
+	)
 }
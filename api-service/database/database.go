@@ -49,6 +49,8 @@ type MongoDatabaseInterface interface {
 	GetHost(hostname string, olderThan time.Time, raw bool) (*dto.HostData, error)
 	GetHostData(hostname string, olderThan time.Time) (*model.HostDataBE, error)
 	GetHostDatas(olderThan time.Time) ([]model.HostDataBE, error)
+	// GetHostsLicensingInfo return the informations needed to compute the operating system licenses of the hosts
+	GetHostsLicensingInfo(filter dto.GlobalFilter) ([]dto.HostLicensingInfo, error)
 	// SearchAlerts search alerts
 	SearchAlerts(alertFilter alert_filter.Alert) (*dto.Pagination, error)
 	// GetAlerts get alerts
@@ -59,16 +61,16 @@ type MongoDatabaseInterface interface {
 	// GetCluster fetch all information about a cluster in the database
 	GetCluster(clusterName string, olderThan time.Time) (*dto.Cluster, error)
 	// SearchOracleDatabaseAddms search addms
-	SearchOracleDatabaseAddms(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) ([]map[string]interface{}, error)
+	SearchOracleDatabaseAddms(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) ([]map[string]interface{}, error)
 	// SearchOracleDatabaseSegmentAdvisors search segment advisors
-	SearchOracleDatabaseSegmentAdvisors(keywords []string, sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error)
-	SearchOraclePdbSegmentAdvisors(sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error)
+	SearchOracleDatabaseSegmentAdvisors(keywords []string, sortBy string, sortDesc bool, location string, environment string, site string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error)
+	SearchOraclePdbSegmentAdvisors(sortBy string, sortDesc bool, location string, environment string, site string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error)
 	// SearchOracleDatabasePatchAdvisors search patch advisors
-	SearchOracleDatabasePatchAdvisors(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, windowTime time.Time, location string, environment string, olderThan time.Time, status string) (*dto.PatchAdvisorResponse, error)
+	SearchOracleDatabasePatchAdvisors(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, windowTime time.Time, location string, environment string, site string, olderThan time.Time, status string) (*dto.PatchAdvisorResponse, error)
 	// SearchOracleDatabases search databases
-	SearchOracleDatabases(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.OracleDatabaseResponse, error)
+	SearchOracleDatabases(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.OracleDatabaseResponse, error)
 	// SearchOracleDatabaseUsedLicenses search consumed licenses
-	SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.OracleDatabaseUsedLicenseSearchResponse, error)
+	SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.OracleDatabaseUsedLicenseSearchResponse, error)
 
 	FindPsqlMigrabilities(hostname, dbname string) ([]model.PgsqlMigrability, error)
 
@@ -77,41 +79,41 @@ type MongoDatabaseInterface interface {
 	// ListEnvironments list environments
 	ListEnvironments(location string, environment string, olderThan time.Time) ([]string, error)
 	// GetHostsCountStats return the number of the non-archived hosts
-	GetHostsCountStats(location string, environment string, olderThan time.Time) (int, error)
+	GetHostsCountStats(location string, environment string, site string, olderThan time.Time) (int, error)
 	// GetEnvironmentStats return a array containing the number of hosts per environment
-	GetEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTypeStats return a array containing the number of hosts per operating system
-	GetOperatingSystemStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetOperatingSystemStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTypeStats return a array containing the number of hosts per type
-	GetTypeStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetTypeStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTopUnusedOracleDatabaseInstanceResourceStats return a array containing top unused instance resource by workload
-	GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, limit int, olderThan time.Time) ([]interface{}, error)
+	GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, site string, limit int, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseEnvironmentStats return a array containing the number of databases per environment
-	GetOracleDatabaseEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseHighReliabilityStats return a array containing the number of databases per high-reliability status
-	GetOracleDatabaseHighReliabilityStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseHighReliabilityStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseVersionStats return a array containing the number of databases per version
-	GetOracleDatabaseVersionStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseVersionStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTopReclaimableOracleDatabaseStats return a array containing the total sum of reclaimable of segments advisors of the top reclaimable databases
-	GetTopReclaimableOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error)
+	GetTopReclaimableOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabasePatchStatusStats return a array containing the number of databases per patch status
-	GetOracleDatabasePatchStatusStats(location string, windowTime time.Time, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabasePatchStatusStats(location string, site string, windowTime time.Time, olderThan time.Time) ([]interface{}, error)
 	// GetTopWorkloadOracleDatabaseStats return a array containing top databases by workload
-	GetTopWorkloadOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error)
+	GetTopWorkloadOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseDataguardStatusStats return a array containing the number of databases per dataguard status
-	GetOracleDatabaseDataguardStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseDataguardStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseRACStatusStats return a array containing the number of databases per RAC status
-	GetOracleDatabaseRACStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseRACStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseArchivelogStatusStats return a array containing the number of databases per archivelog status
-	GetOracleDatabaseArchivelogStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseArchivelogStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTotalOracleDatabaseWorkStats return the total work of databases
-	GetTotalOracleDatabaseWorkStats(location string, environment string, olderThan time.Time) (float64, error)
+	GetTotalOracleDatabaseWorkStats(location string, environment string, site string, olderThan time.Time) (float64, error)
 	// GetTotalOracleDatabaseMemorySizeStats return the total of memory size of databases
-	GetTotalOracleDatabaseMemorySizeStats(location string, environment string, olderThan time.Time) (float64, error)
+	GetTotalOracleDatabaseMemorySizeStats(location string, environment string, site string, olderThan time.Time) (float64, error)
 	// GetTotalOracleDatabaseDatafileSizeStats return the total size of datafiles of databases
-	GetTotalOracleDatabaseDatafileSizeStats(location string, environment string, olderThan time.Time) (float64, error)
+	GetTotalOracleDatabaseDatafileSizeStats(location string, environment string, site string, olderThan time.Time) (float64, error)
 	// GetTotalOracleDatabaseSegmentSizeStats return the total size of segments of databases
-	GetTotalOracleDatabaseSegmentSizeStats(location string, environment string, olderThan time.Time) (float64, error)
+	GetTotalOracleDatabaseSegmentSizeStats(location string, environment string, site string, olderThan time.Time) (float64, error)
 	//GetOracleDatabaseLicenseTypes return an array of OracleDatabaseLicenseType
 	GetOracleDatabaseLicenseTypes() ([]model.OracleDatabaseLicenseType, error)
 	//GetOracleDatabaseLicenseType return a OracleDatabaseLicenseType
@@ -142,6 +144,8 @@ type MongoDatabaseInterface interface {
 	GetOraclePatchList(filter dto.GlobalFilter) ([]dto.OracleDatabasePatchDto, error)
 	GetOracleOptionList(filter dto.GlobalFilter) ([]dto.OracleDatabaseFeatureUsageStatDto, error)
 	FindOracleOptionsByDbname(hostname string, dbname string) ([]model.OracleDatabaseFeatureUsageStat, error)
+	// GetOracleFeatureUsageLedger return the entries of the Oracle feature usage ledger
+	GetOracleFeatureUsageLedger(filter dto.OracleFeatureUsageLedgerFilter) ([]dto.OracleFeatureUsageLedgerEntry, error)
 	FindOracleChangesByHostname(filter dto.GlobalFilter, hostname string) ([]dto.OracleChangesDto, error)
 	GetOracleBackupList(filter dto.GlobalFilter) ([]dto.OracleDatabaseBackupDto, error)
 	GetOracleServiceList(filter dto.GlobalFilter) ([]dto.OracleDatabaseServiceDto, error)
@@ -175,7 +179,7 @@ type MongoDatabaseInterface interface {
 	// ExistHostdata return true if the host specified by hostname exist, otherwise false
 	ExistHostdata(hostname string) (bool, error)
 	// GetHostsCountUsingTechnologies return a map that contains the number of usages for every features
-	GetHostsCountUsingTechnologies(location string, environment string, olderThan time.Time) (map[string]float64, error)
+	GetHostsCountUsingTechnologies(location string, environment string, site string, olderThan time.Time) (map[string]float64, error)
 	// ExistNotInClusterHost return true if the host specified by hostname exist and it is not in cluster, otherwise false
 	ExistNotInClusterHost(hostname string) (bool, error)
 	// Check if there are any db instances not running on host
@@ -203,8 +207,8 @@ type MongoDatabaseInterface interface {
 
 	GetSqlServerDatabaseLicenseTypes() ([]model.SqlServerDatabaseLicenseType, error)
 	InsertSqlServerDatabaseLicenseType(licenseType model.SqlServerDatabaseLicenseType) error
-	SearchSqlServerInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.SqlServerInstanceResponse, error)
-	SearchSqlServerDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.SqlServerDatabaseUsedLicenseSearchResponse, error)
+	SearchSqlServerInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.SqlServerInstanceResponse, error)
+	SearchSqlServerDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.SqlServerDatabaseUsedLicenseSearchResponse, error)
 	UpdateSqlServerLicenseIgnoredField(hostname string, instancename string, ignored bool, ignoredComment string) error
 
 	InsertSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) error
@@ -215,9 +219,27 @@ type MongoDatabaseInterface interface {
 
 	// POSTGRESQL
 	SearchPostgreSqlInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.PostgreSqlInstanceResponse, error)
+	// GetPostgreSQLUsedLicenses return the PostgreSQL instances with the cores of their host
+	GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.PostgreSQLUsedLicense, error)
+	AddPostgreSQLContract(contract model.PostgreSQLContract) error
+	UpdatePostgreSQLContract(contract model.PostgreSQLContract) error
+	GetPostgreSQLContracts() ([]model.PostgreSQLContract, error)
+	DeletePostgreSQLContract(id primitive.ObjectID) error
 
 	// MONGODB
 	SearchMongoDBInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.MongoDBInstanceResponse, error)
+	// GetMongoDBUsedLicenses return the MongoDB instances with the cores of their host
+	GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MongoDBUsedLicense, error)
+	AddMongoDBContract(contract model.MongoDBContract) error
+	UpdateMongoDBContract(contract model.MongoDBContract) error
+	GetMongoDBContracts() ([]model.MongoDBContract, error)
+	DeleteMongoDBContract(id primitive.ObjectID) error
+
+	// HOST CONTRACTS
+	AddHostContract(contract model.HostContract) error
+	UpdateHostContract(contract model.HostContract) error
+	GetHostContracts() ([]model.HostContract, error)
+	DeleteHostContract(id primitive.ObjectID) error
 
 	// ROLES
 	GetRole(name string) (*model.Role, error)
@@ -260,6 +282,39 @@ type MongoDatabaseInterface interface {
 	InsertExadataVmClustername(rackID, hostID, vmname, clustername string) error
 	FindExadataVmClustername(rackID, hostID, vmname string) (*model.OracleExadataVmClustername, error)
 	UpdateExadataVmClustername(rackID, hostID, vmname, clustername string) error
+
+	ListLicenseIgnoreRules() ([]model.LicenseIgnoreRule, error)
+	InsertLicenseIgnoreRule(rule model.LicenseIgnoreRule) error
+	UpdateLicenseIgnoreRule(rule model.LicenseIgnoreRule) error
+	RemoveLicenseIgnoreRule(id primitive.ObjectID) error
+
+	ListLMSPendingChanges(status string) ([]model.LMSPendingChange, error)
+	InsertLMSPendingChanges(changes []model.LMSPendingChange) error
+	GetLMSPendingChange(id primitive.ObjectID) (*model.LMSPendingChange, error)
+	UpdateLMSPendingChangeStatus(id primitive.ObjectID, status string, reviewedBy string, reviewedAt time.Time) error
+
+	FindHostDataChanges(filter dto.HostDataChangesFilter) ([]model.HostDataChange, error)
+
+	ListHostDataSnapshots() ([]model.HostDataSnapshot, error)
+
+	// ListQuarantinedHostData return the quarantined hostdata, without payload, received between from and to
+	ListQuarantinedHostData(hostname string, from, to time.Time) ([]model.QuarantinedHostData, error)
+	// GetQuarantinedHostData return the quarantined hostdata with the id
+	GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error)
+	// DeleteQuarantinedHostData remove a quarantined hostdata
+	DeleteQuarantinedHostData(id primitive.ObjectID) error
+	// GetLicensesComplianceAt return the licenses compliance of the most recent day of the history not after at
+	GetLicensesComplianceAt(at time.Time) ([]dto.LicenseCompliance, error)
+
+	ListHostIdentities() ([]model.HostIdentity, error)
+	GetHostIdentity(id string) (*model.HostIdentity, error)
+	// FindHostIdentityByAlias return the identity of the host with the alias
+	FindHostIdentityByAlias(alias string) (*model.HostIdentity, error)
+	InsertHostIdentity(identity model.HostIdentity) error
+	// UpdateHostIdentityAliases replace the aliases of the identity of the host
+	UpdateHostIdentityAliases(id string, aliases []string) error
+	// MergeHostIdentities merge the other identity into the identity in a transaction, moving the hostdata and the contracts of the other host
+	MergeHostIdentities(identity model.HostIdentity, other model.HostIdentity) error
 }
 
 // MongoDatabase is a implementation
@@ -0,0 +1,142 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package database
+
+import (
+	"context"
+
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// subscriptionContract is a contract of a technology licensed by subscription, like PostgreSQL and MongoDB
+type subscriptionContract interface {
+	model.PostgreSQLContract | model.MongoDBContract
+}
+
+// subscriptionUsedLicense is the subscription used by an instance of a technology licensed by subscription
+type subscriptionUsedLicense interface {
+	dto.PostgreSQLUsedLicense | dto.MongoDBUsedLicense
+}
+
+func addSubscriptionContract[T subscriptionContract](md *MongoDatabase, collection string, contract T) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		InsertOne(
+			context.TODO(),
+			contract,
+		)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+func updateSubscriptionContract[T subscriptionContract](md *MongoDatabase, collection string, contract T) error {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		ReplaceOne(
+			context.TODO(),
+			bson.M{"_id": model.SubscriptionContract(contract).ID},
+			contract,
+		)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if cur.MatchedCount != 1 {
+		return utils.NewError(utils.ErrNotFound, "DB ERROR")
+	}
+
+	return nil
+}
+
+func getSubscriptionContracts[T subscriptionContract](md *MongoDatabase, collection string) ([]T, error) {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		Find(context.TODO(), bson.D{})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	contracts := make([]T, 0)
+
+	err = cur.All(context.TODO(), &contracts)
+	if err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return contracts, nil
+}
+
+func deleteSubscriptionContract(md *MongoDatabase, collection string, id primitive.ObjectID) error {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		DeleteOne(
+			context.TODO(),
+			bson.M{"_id": id},
+		)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if cur.DeletedCount != 1 {
+		return utils.NewError(utils.ErrNotFound, "DB ERROR")
+	}
+
+	return nil
+}
+
+// getSubscriptionUsedLicenses return the instances of the feature with the cores of their host, used to compute the subscriptions.
+// fields are the other fields of the instances to return, with their path relative to the instance
+func getSubscriptionUsedLicenses[T subscriptionUsedLicense](md *MongoDatabase, feature string, fields map[string]string,
+	hostname string, filter dto.GlobalFilter) ([]T, error) {
+	instances := "$features." + feature + ".instances"
+
+	project := bson.M{
+		"hostname":     1,
+		"instanceName": instances + ".name",
+		"cpuCores":     "$info.cpuCores",
+	}
+	for field, path := range fields {
+		project[field] = instances + "." + path
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
+		context.TODO(),
+		mu.MAPipeline(
+			FindByHostname(hostname),
+			FilterByOldnessSteps(filter.OlderThan),
+			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
+			mu.APUnwind(instances),
+			mu.APProject(project),
+		),
+	)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	out := make([]T, 0)
+
+	err = cur.All(context.TODO(), &out)
+	if err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return out, nil
+}
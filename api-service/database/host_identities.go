@@ -0,0 +1,186 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostIdentitiesCollection = "host_identities"
+
+// ListHostIdentities return all the identities of the hosts, sorted by hostname
+func (md *MongoDatabase) ListHostIdentities() ([]model.HostIdentity, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "hostname", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	identities := make([]model.HostIdentity, 0)
+	if err := cur.All(ctx, &identities); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return identities, nil
+}
+
+func (md *MongoDatabase) GetHostIdentity(id string) (*model.HostIdentity, error) {
+	return md.findHostIdentity(bson.M{"_id": id})
+}
+
+// FindHostIdentityByAlias return the identity of the host with the alias
+func (md *MongoDatabase) FindHostIdentityByAlias(alias string) (*model.HostIdentity, error) {
+	return md.findHostIdentity(bson.M{"aliases": alias})
+}
+
+func (md *MongoDatabase) findHostIdentity(filter bson.M) (*model.HostIdentity, error) {
+	var identity model.HostIdentity
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		FindOne(context.TODO(), filter).Decode(&identity)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, utils.ErrHostIdentityNotFound
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &identity, nil
+}
+
+func (md *MongoDatabase) InsertHostIdentity(identity model.HostIdentity) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		InsertOne(context.TODO(), identity)
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrHostAliasConflict
+	} else if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// UpdateHostIdentityAliases replace the aliases of the identity of the host
+func (md *MongoDatabase) UpdateHostIdentityAliases(id string, aliases []string) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$set": bson.M{
+			"aliases":   aliases,
+			"updatedAt": md.TimeNow(),
+		}})
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrHostAliasConflict
+	} else if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrHostIdentityNotFound
+	}
+
+	return nil
+}
+
+// MergeHostIdentities merge the other identity into the identity in a transaction:
+// the current hostdata of the other host is dismissed, its history and contracts are moved to the hostname of the identity,
+// the other identity is deleted and the identity takes the aliases
+func (md *MongoDatabase) MergeHostIdentities(identity model.HostIdentity, other model.HostIdentity) error {
+	ctx := context.TODO()
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		db := md.Client.Database(md.Config.Mongodb.DBName)
+
+		if _, err := db.Collection("hosts").UpdateMany(sessCtx,
+			bson.M{"hostname": other.Hostname, "dismissedAt": nil},
+			bson.M{"$set": bson.M{"archived": true, "dismissedAt": md.TimeNow()}}); err != nil {
+			return nil, err
+		}
+
+		if err := renameHost(sessCtx, db, other.Hostname, identity.Hostname); err != nil {
+			return nil, err
+		}
+
+		// the aliases are unique, so the other identity is deleted before they are moved
+		result, err := db.Collection(hostIdentitiesCollection).DeleteOne(sessCtx, bson.M{"_id": other.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		if result.DeletedCount == 0 {
+			return nil, utils.ErrHostIdentityNotFound
+		}
+
+		updated, err := db.Collection(hostIdentitiesCollection).UpdateOne(sessCtx, bson.M{"_id": identity.ID}, bson.M{"$set": bson.M{
+			"aliases":   identity.Aliases,
+			"updatedAt": md.TimeNow(),
+		}})
+		if err != nil {
+			return nil, err
+		}
+
+		if updated.MatchedCount != 1 {
+			return nil, utils.ErrHostIdentityNotFound
+		}
+
+		return nil, nil
+	})
+	if errors.Is(err, utils.ErrHostIdentityNotFound) {
+		return err
+	} else if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrHostAliasConflict
+	} else if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// renameHost move the hostdata and the Oracle/Database contracts of the host to the new name
+func renameHost(ctx context.Context, db *mongo.Database, hostname, newHostname string) error {
+	if _, err := db.Collection("hosts").UpdateMany(ctx,
+		bson.M{"hostname": hostname},
+		bson.M{"$set": bson.M{"hostname": newHostname}}); err != nil {
+		return err
+	}
+
+	if _, err := db.Collection(oracleDbContractsCollection).UpdateMany(ctx,
+		bson.M{"hosts": hostname},
+		bson.M{"$addToSet": bson.M{"hosts": newHostname}}); err != nil {
+		return err
+	}
+
+	if _, err := db.Collection(oracleDbContractsCollection).UpdateMany(ctx,
+		bson.M{"hosts": hostname},
+		bson.M{"$pull": bson.M{"hosts": hostname}}); err != nil {
+		return err
+	}
+
+	return nil
+}
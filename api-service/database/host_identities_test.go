@@ -0,0 +1,122 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHostIdentities() {
+	defer m.db.Client.Database(m.dbname).Collection(hostIdentitiesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-06T10:00:00Z") }
+
+	foobar := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2020-12-05T10:00:00Z"))
+	barfoo := model.NewHostIdentity("barfoo", []string{"barfoo"}, utils.P("2020-12-05T10:00:00Z"))
+	require.NoError(m.T(), m.db.InsertHostIdentity(foobar))
+	require.NoError(m.T(), m.db.InsertHostIdentity(barfoo))
+
+	m.T().Run("list", func(t *testing.T) {
+		identities, err := m.db.ListHostIdentities()
+		require.NoError(t, err)
+		assert.Equal(t, []model.HostIdentity{barfoo, foobar}, identities)
+	})
+
+	m.T().Run("update_aliases", func(t *testing.T) {
+		require.NoError(t, m.db.UpdateHostIdentityAliases(foobar.ID, []string{"foobar", "foobar.example.com"}))
+
+		actual, err := m.db.FindHostIdentityByAlias("foobar.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, foobar.ID, actual.ID)
+		assert.Equal(t, utils.P("2020-12-06T10:00:00Z"), actual.UpdatedAt)
+
+		assert.ErrorIs(t, m.db.UpdateHostIdentityAliases(barfoo.ID, []string{"barfoo", "foobar"}), utils.ErrHostAliasConflict)
+		assert.ErrorIs(t, m.db.UpdateHostIdentityAliases("missing", []string{"missing"}), utils.ErrHostIdentityNotFound)
+	})
+}
+
+func (m *MongodbSuite) TestMergeHostIdentities() {
+	defer m.db.Client.Database(m.dbname).Collection(hostIdentitiesCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection("hosts").DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(oracleDbContractsCollection).DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-06T10:00:00Z") }
+
+	foobar := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2020-12-05T10:00:00Z"))
+	foobarNew := model.NewHostIdentity("foobar-new", []string{"foobar-new"}, utils.P("2020-12-05T10:00:00Z"))
+	require.NoError(m.T(), m.db.InsertHostIdentity(foobar))
+	require.NoError(m.T(), m.db.InsertHostIdentity(foobarNew))
+
+	_, err := m.db.Client.Database(m.dbname).Collection("hosts").InsertMany(context.TODO(), []interface{}{
+		bson.M{"hostname": "foobar-new", "archived": true, "dismissedAt": nil},
+		bson.M{"hostname": "foobar-new", "archived": false, "dismissedAt": nil},
+		bson.M{"hostname": "pippo", "archived": false, "dismissedAt": nil},
+	})
+	require.NoError(m.T(), err)
+
+	_, err = m.db.Client.Database(m.dbname).Collection(oracleDbContractsCollection).InsertOne(context.TODO(),
+		bson.M{"contractID": "AID001", "hosts": []string{"foobar-new", "pippo"}})
+	require.NoError(m.T(), err)
+
+	merged := foobar
+	merged.Aliases = []string{"foobar", "foobar-new"}
+	require.NoError(m.T(), m.db.MergeHostIdentities(merged, foobarNew))
+
+	count, err := m.db.Client.Database(m.dbname).Collection("hosts").CountDocuments(context.TODO(), bson.M{"hostname": "foobar", "dismissedAt": nil})
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), int64(0), count)
+
+	count, err = m.db.Client.Database(m.dbname).Collection("hosts").CountDocuments(context.TODO(), bson.M{"hostname": "foobar"})
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), int64(2), count)
+
+	var contract struct {
+		Hosts []string `bson:"hosts"`
+	}
+	err = m.db.Client.Database(m.dbname).Collection(oracleDbContractsCollection).FindOne(context.TODO(), bson.M{"contractID": "AID001"}).Decode(&contract)
+	require.NoError(m.T(), err)
+	assert.ElementsMatch(m.T(), []string{"pippo", "foobar"}, contract.Hosts)
+
+	_, err = m.db.GetHostIdentity(foobarNew.ID)
+	assert.ErrorIs(m.T(), err, utils.ErrHostIdentityNotFound)
+
+	actual, err := m.db.FindHostIdentityByAlias("foobar-new")
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), foobar.ID, actual.ID)
+
+	m.T().Run("rollback", func(t *testing.T) {
+		pippo := model.NewHostIdentity("pippo", []string{"pippo"}, utils.P("2020-12-05T10:00:00Z"))
+		require.NoError(t, m.db.InsertHostIdentity(pippo))
+
+		assert.ErrorIs(t, m.db.MergeHostIdentities(model.HostIdentity{ID: "missing", Hostname: "missing"}, pippo), utils.ErrHostIdentityNotFound)
+
+		_, err := m.db.GetHostIdentity(pippo.ID)
+		require.NoError(t, err)
+
+		count, err := m.db.Client.Database(m.dbname).Collection("hosts").CountDocuments(context.TODO(), bson.M{"hostname": "pippo", "dismissedAt": nil})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostDataChangesCollection = "hostdata_changes"
+
+// FindHostDataChanges return the hostdata changes matching the filter, most recent first
+func (md *MongoDatabase) FindHostDataChanges(filter dto.HostDataChangesFilter) ([]model.HostDataChange, error) {
+	ctx := context.TODO()
+
+	query := bson.M{
+		"date": bson.M{
+			"$gte": filter.From,
+			"$lte": filter.To,
+		},
+	}
+
+	if filter.Hostname != "" {
+		query["hostname"] = filter.Hostname
+	}
+
+	if filter.Location != "" {
+		query["location"] = bson.M{"$in": strings.Split(filter.Location, ",")}
+	}
+
+	if filter.Environment != "" {
+		query["environment"] = filter.Environment
+	}
+
+	if filter.Field != "" {
+		query["changes.field"] = filter.Field
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostDataChangesCollection).
+		Find(ctx, query, options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	changes := make([]model.HostDataChange, 0)
+	if err := cur.All(ctx, &changes); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestFindHostDataChanges() {
+	defer m.db.Client.Database(m.dbname).Collection(hostDataChangesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	changes := []model.HostDataChange{
+		{
+			ID:          utils.Str2oid("6512f1a5ba2e2a1f4b6b8f21"),
+			Hostname:    "test-db",
+			Environment: "TST",
+			Location:    "Italy",
+			Date:        utils.P("2019-11-05T14:02:03Z"),
+			Changes: []model.HostDataFieldChange{
+				{Field: model.HostDataChangeFieldCPUCores, OldValue: "2", NewValue: "4"},
+			},
+		},
+		{
+			ID:          utils.Str2oid("6512f1a5ba2e2a1f4b6b8f22"),
+			Hostname:    "test-db",
+			Environment: "TST",
+			Location:    "Italy",
+			Date:        utils.P("2019-11-06T14:02:03Z"),
+			Changes: []model.HostDataFieldChange{
+				{Field: model.HostDataChangeFieldOracleDatabaseVersion, Database: "ERCOLE", OldValue: "12.2.0.1.0", NewValue: "19.0.0.0.0"},
+			},
+		},
+		{
+			ID:          utils.Str2oid("6512f1a5ba2e2a1f4b6b8f23"),
+			Hostname:    "test-db2",
+			Environment: "PRD",
+			Location:    "Germany",
+			Date:        utils.P("2019-11-07T14:02:03Z"),
+			Changes: []model.HostDataFieldChange{
+				{Field: model.HostDataChangeFieldOSVersion, OldValue: "7.6", NewValue: "7.9"},
+			},
+		},
+	}
+
+	for _, change := range changes {
+		_, err := m.db.Client.Database(m.dbname).Collection(hostDataChangesCollection).InsertOne(context.TODO(), change)
+		require.NoError(m.T(), err)
+	}
+
+	filter := dto.HostDataChangesFilter{
+		From: utils.MIN_TIME,
+		To:   utils.MAX_TIME,
+	}
+
+	m.T().Run("all", func(t *testing.T) {
+		actual, err := m.db.FindHostDataChanges(filter)
+		require.NoError(t, err)
+		assert.Equal(t, []model.HostDataChange{changes[2], changes[1], changes[0]}, actual)
+	})
+
+	m.T().Run("hostname_and_range", func(t *testing.T) {
+		f := filter
+		f.Hostname = "test-db"
+		f.From = utils.P("2019-11-06T00:00:00Z")
+
+		actual, err := m.db.FindHostDataChanges(f)
+		require.NoError(t, err)
+		assert.Equal(t, []model.HostDataChange{changes[1]}, actual)
+	})
+
+	m.T().Run("location_environment_field", func(t *testing.T) {
+		f := filter
+		f.Location = "Germany,France"
+		f.Environment = "PRD"
+		f.Field = model.HostDataChangeFieldOSVersion
+
+		actual, err := m.db.FindHostDataChanges(f)
+		require.NoError(t, err)
+		assert.Equal(t, []model.HostDataChange{changes[2]}, actual)
+
+		f.Field = model.HostDataChangeFieldCPUCores
+
+		actual, err = m.db.FindHostDataChanges(f)
+		require.NoError(t, err)
+		assert.Equal(t, []model.HostDataChange{}, actual)
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostdataQuarantineCollection = "hostdata_quarantine"
+
+// ListQuarantinedHostData return the quarantined hostdata, without payload, received between from and to
+// by the host, or by every host if hostname is empty
+func (md *MongoDatabase) ListQuarantinedHostData(hostname string, from, to time.Time) ([]model.QuarantinedHostData, error) {
+	filter := bson.M{
+		"receivedAt": bson.M{
+			"$gte": from,
+			"$lt":  to,
+		},
+	}
+
+	if hostname != "" {
+		filter["hostname"] = hostname
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		Find(context.TODO(),
+			filter,
+			options.Find().
+				SetProjection(bson.M{"payload": 0}).
+				SetSort(bson.D{{Key: "receivedAt", Value: -1}}),
+		)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	quarantined := make([]model.QuarantinedHostData, 0)
+	if err := cur.All(context.TODO(), &quarantined); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return quarantined, nil
+}
+
+// GetQuarantinedHostData return the quarantined hostdata with the id, payload included
+func (md *MongoDatabase) GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error) {
+	var quarantined model.QuarantinedHostData
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		FindOne(context.TODO(), bson.M{"_id": id}).Decode(&quarantined)
+	if err == mongo.ErrNoDocuments {
+		return nil, utils.ErrQuarantinedHostDataNotFound
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &quarantined, nil
+}
+
+// DeleteQuarantinedHostData remove a quarantined hostdata
+func (md *MongoDatabase) DeleteQuarantinedHostData(id primitive.ObjectID) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.DeletedCount != 1 {
+		return utils.ErrQuarantinedHostDataNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHostDataQuarantine() {
+	defer m.db.Client.Database(m.dbname).Collection(hostdataQuarantineCollection).DeleteMany(context.TODO(), bson.M{})
+
+	first := model.QuarantinedHostData{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f31"),
+		Hostname:      "foobar",
+		Location:      "Italy",
+		Environment:   "PRD",
+		SchemaVersion: 1,
+		Errors:        "invalid hostdata: hostname is required",
+		Payload:       []byte(`{"hostname":"foobar"}`),
+		ReceivedAt:    utils.P("2020-12-05T14:00:00Z"),
+	}
+	second := model.QuarantinedHostData{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f32"),
+		Hostname:      "barfoo",
+		SchemaVersion: 1,
+		Errors:        "invalid hostdata: location is required",
+		Payload:       []byte(`{"hostname":"barfoo"}`),
+		ReceivedAt:    utils.P("2020-12-05T15:00:00Z"),
+	}
+
+	for _, quarantined := range []model.QuarantinedHostData{first, second} {
+		_, err := m.db.Client.Database(m.dbname).Collection(hostdataQuarantineCollection).InsertOne(context.TODO(), quarantined)
+		require.NoError(m.T(), err)
+	}
+
+	m.T().Run("list without payload", func(t *testing.T) {
+		actual, err := m.db.ListQuarantinedHostData("", utils.MIN_TIME, utils.MAX_TIME)
+		require.NoError(t, err)
+
+		require.Len(t, actual, 2)
+		assert.Equal(t, second.ID, actual[0].ID)
+		assert.Equal(t, first.ID, actual[1].ID)
+		assert.Nil(t, actual[0].Payload)
+	})
+
+	m.T().Run("list by hostname and dates", func(t *testing.T) {
+		actual, err := m.db.ListQuarantinedHostData("foobar", utils.P("2020-12-05T00:00:00Z"), utils.P("2020-12-06T00:00:00Z"))
+		require.NoError(t, err)
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, first.ID, actual[0].ID)
+
+		actual, err = m.db.ListQuarantinedHostData("barfoo", utils.MIN_TIME, utils.P("2020-12-05T15:00:00Z"))
+		require.NoError(t, err)
+		assert.Len(t, actual, 0)
+	})
+
+	m.T().Run("get", func(t *testing.T) {
+		actual, err := m.db.GetQuarantinedHostData(first.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Hostname, actual.Hostname)
+		assert.Equal(t, first.Payload, actual.Payload)
+	})
+
+	m.T().Run("delete", func(t *testing.T) {
+		require.NoError(t, m.db.DeleteQuarantinedHostData(second.ID))
+
+		_, err := m.db.GetQuarantinedHostData(second.ID)
+		assert.ErrorIs(t, err, utils.ErrQuarantinedHostDataNotFound)
+
+		err = m.db.DeleteQuarantinedHostData(second.ID)
+		assert.ErrorIs(t, err, utils.ErrQuarantinedHostDataNotFound)
+	})
+}
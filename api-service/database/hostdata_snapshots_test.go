@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestListHostDataSnapshots() {
+	defer m.db.Client.Database(m.dbname).Collection(hostDataSnapshotsCollection).DeleteMany(context.TODO(), bson.M{})
+
+	snapshots := []model.HostDataSnapshot{
+		{
+			ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f31"),
+			Kind:          model.HostDataSnapshotKindQuarterly,
+			Date:          utils.P("2020-04-01T00:00:00Z"),
+			Hosts:         2,
+			RetainedUntil: utils.P("2023-04-01T00:00:00Z"),
+		},
+		{
+			ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f32"),
+			Kind:          model.HostDataSnapshotKindMonthly,
+			Date:          utils.P("2020-05-01T00:00:00Z"),
+			Hosts:         3,
+			RetainedUntil: utils.P("2021-05-01T00:00:00Z"),
+		},
+	}
+
+	for _, snapshot := range snapshots {
+		_, err := m.db.Client.Database(m.dbname).Collection(hostDataSnapshotsCollection).InsertOne(context.TODO(), snapshot)
+		require.NoError(m.T(), err)
+	}
+
+	actual, err := m.db.ListHostDataSnapshots()
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), []model.HostDataSnapshot{snapshots[1], snapshots[0]}, actual)
+}
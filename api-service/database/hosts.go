@@ -62,6 +62,7 @@ func (md *MongoDatabase) getHosts(mode string, filters dto.SearchHostsFilters, o
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByLocationAndEnvironmentSteps(filters.Location, filters.Environment),
+			FilterBySiteSteps(filters.Site, md.Config.Federation.LocalSite),
 			FilterByOldnessSteps(filters.OlderThan),
 			mu.MAPipeline(
 				mu.APOptionalStage(filters.Hostname != "", mu.APMatch(bson.M{
@@ -168,6 +169,10 @@ func (md *MongoDatabase) getHosts(mode string, filters dto.SearchHostsFilters, o
 					mu.APUnset("features"),
 					mu.APSet(bson.M{
 						"isVirtualServer": mu.APOEqual("$info.hardwareAbstraction", model.HardwareAbstractionVirtual),
+						"pdbCount": mu.APOSize(mu.APOFilter(mu.APOIfNull("$database.pdbs", bson.A{}), "pdb",
+							mu.APONotEqual("$$pdb.name", model.OracleDatabaseSeedPDB))),
+						"countedLicenses": mu.APOFilter(mu.APOIfNull("$database.licenses", bson.A{}), "lic",
+							mu.APOGreater("$$lic.count", 0)),
 						"database.pdbs": mu.APOCond("$database.isCDB", bson.M{
 							"$concatArrays": bson.A{
 								bson.A{""},
@@ -208,6 +213,7 @@ func (md *MongoDatabase) getHosts(mode string, filters dto.SearchHostsFilters, o
 						},
 						"dbInstanceName":        "$database.name",
 						"pluggableDatabaseName": "$database.pdbs",
+						"pdbCount":              "$pdbCount",
 						"environment":           "$environment",
 						"options": mu.APOJoin(mu.APOMap(
 							mu.APOFilter("$database.licenses", "lic",
@@ -270,6 +276,15 @@ func (md *MongoDatabase) getHosts(mode string, filters dto.SearchHostsFilters, o
 							),
 							0,
 						), 0.0),
+						// the database is ignored when all its counted licenses are ignored
+						"ignored": mu.APOAnd(
+							mu.APOGreater(mu.APOSize("$countedLicenses"), 0),
+							mu.APOEqual(mu.APOSize(mu.APOFilter("$countedLicenses", "lic", mu.APOEqual("$$lic.ignored", false))), 0),
+						),
+						"ignoredComment": mu.APOIfNull(mu.APOArrayElemAt(
+							mu.APOMap(mu.APOFilter("$countedLicenses", "lic", mu.APOEqual("$$lic.ignored", true)), "lic", "$$lic.ignoredComment"),
+							0,
+						), ""),
 						"processorModel":    "$info.cpuModel",
 						"processors":        "$info.cpuSockets",
 						"coresPerProcessor": "$info.coresPerSocket",
@@ -350,12 +365,17 @@ func (md *MongoDatabase) GetHost(hostname string, olderThan time.Time, raw bool)
 					mu.APLookupPipeline(
 						"hosts",
 						bson.M{
-							"hn": "$hostname",
-							"ca": "$createdAt",
+							"hn":   "$hostname",
+							"site": mu.APOIfNull("$site", nil),
+							"ca":   "$createdAt",
 						},
 						"history",
 						mu.MAPipeline(
-							mu.APMatch(mu.QOExpr(mu.APOAnd(mu.APOEqual("$hostname", "$$hn"), mu.APOGreaterOrEqual("$$ca", "$createdAt")))),
+							mu.APMatch(mu.QOExpr(mu.APOAnd(
+								mu.APOEqual("$hostname", "$$hn"),
+								mu.APOEqual(mu.APOIfNull("$site", nil), "$$site"),
+								mu.APOGreaterOrEqual("$$ca", "$createdAt"),
+							))),
 							mu.APProject(bson.M{
 								"createdAt": 1,
 								"features.oracle.database.databases.name":          1,
@@ -419,12 +439,17 @@ func (md *MongoDatabase) GetHost(hostname string, olderThan time.Time, raw bool)
 					mu.APLookupPipeline(
 						"hosts",
 						bson.M{
-							"hn": "$hostname",
-							"ca": "$createdAt",
+							"hn":   "$hostname",
+							"site": mu.APOIfNull("$site", nil),
+							"ca":   "$createdAt",
 						},
 						"history",
 						mu.MAPipeline(
-							mu.APMatch(mu.QOExpr(mu.APOAnd(mu.APOEqual("$hostname", "$$hn"), mu.APOGreaterOrEqual("$$ca", "$createdAt")))),
+							mu.APMatch(mu.QOExpr(mu.APOAnd(
+								mu.APOEqual("$hostname", "$$hn"),
+								mu.APOEqual(mu.APOIfNull("$site", nil), "$$site"),
+								mu.APOGreaterOrEqual("$$ca", "$createdAt"),
+							))),
 							mu.APUnwind("$features.mysql.instances"),
 							mu.APProject(bson.M{
 								"createdAt":                1,
@@ -524,6 +549,8 @@ func getBaseHost(hostname string, technology string, olderThan time.Time, raw bo
 		mu.APMatch(bson.M{
 			"hostname": hostname,
 		}),
+		// the local host comes before the hosts with the same name of other sites
+		mu.APSort(bson.M{"site": 1}),
 		mu.APAddFields(
 			bson.M{
 				"technology": technology,
@@ -585,6 +612,39 @@ func (md *MongoDatabase) GetHostDatas(olderThan time.Time) ([]model.HostDataBE,
 	return hostdatas, nil
 }
 
+// GetHostsLicensingInfo return the informations needed to compute the operating system licenses of the hosts
+func (md *MongoDatabase) GetHostsLicensingInfo(filter dto.GlobalFilter) ([]dto.HostLicensingInfo, error) {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostCollection).
+		Aggregate(
+			context.TODO(),
+			mu.MAPipeline(
+				FilterByOldnessSteps(filter.OlderThan),
+				FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+				FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
+				mu.APProject(bson.M{
+					"_id":                 0,
+					"hostname":            1,
+					"location":            1,
+					"environment":         1,
+					"os":                  "$info.os",
+					"hardwareAbstraction": "$info.hardwareAbstraction",
+					"cpuCores":            "$info.cpuCores",
+					"cpuSockets":          "$info.cpuSockets",
+				}),
+			),
+		)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	out := make([]dto.HostLicensingInfo, 0)
+	if err := cur.All(context.TODO(), &out); err != nil {
+		return nil, utils.NewError(err, "DECODE ERROR")
+	}
+
+	return out, nil
+}
+
 // ListAllLocations list all available locations
 func (md *MongoDatabase) ListAllLocations(location string, environment string, olderThan time.Time) ([]string, error) {
 	var out []string = make([]string, 0)
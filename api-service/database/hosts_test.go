@@ -51,6 +51,7 @@ func (m *MongodbSuite) TestSearchHosts() {
 				"physicalCores":            2,
 				"physicalServerName":       "Puzzait",
 				"pluggableDatabaseName":    "",
+				"pdbCount":                 0,
 				"processorModel":           "Intel(R) Xeon(R) CPU           E5630  @ 2.53GHz",
 				"processorSpeed":           "2.53GHz",
 				"processors":               2,
@@ -0,0 +1,95 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const licenseIgnoreRulesCollection = "license_ignore_rules"
+
+// ListLicenseIgnoreRules return all the license ignore rules, sorted by expiry date
+func (md *MongoDatabase) ListLicenseIgnoreRules() ([]model.LicenseIgnoreRule, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(licenseIgnoreRulesCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "expiresAt", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	rules := make([]model.LicenseIgnoreRule, 0)
+	if err := cur.All(ctx, &rules); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return rules, nil
+}
+
+// InsertLicenseIgnoreRule insert a license ignore rule into the database
+func (md *MongoDatabase) InsertLicenseIgnoreRule(rule model.LicenseIgnoreRule) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(licenseIgnoreRulesCollection).
+		InsertOne(context.TODO(), rule)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// UpdateLicenseIgnoreRule update the criteria, the justification and the expiry date of a license ignore rule
+func (md *MongoDatabase) UpdateLicenseIgnoreRule(rule model.LicenseIgnoreRule) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(licenseIgnoreRulesCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": rule.ID}, bson.M{"$set": bson.M{
+			"hostnameRegex": rule.HostnameRegex,
+			"environment":   rule.Environment,
+			"tag":           rule.Tag,
+			"licenseTypeID": rule.LicenseTypeID,
+			"justification": rule.Justification,
+			"expiresAt":     rule.ExpiresAt,
+		}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrLicenseIgnoreRuleNotFound
+	}
+
+	return nil
+}
+
+// RemoveLicenseIgnoreRule remove a license ignore rule from the database
+func (md *MongoDatabase) RemoveLicenseIgnoreRule(id primitive.ObjectID) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(licenseIgnoreRulesCollection).
+		DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.DeletedCount == 0 {
+		return utils.ErrLicenseIgnoreRuleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var licenseIgnoreRuleSample = model.LicenseIgnoreRule{
+	ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f01"),
+	HostnameRegex: "^test-",
+	Environment:   "TST",
+	LicenseTypeID: "A90611",
+	Justification: "Test hosts covered by the development agreement",
+	ExpiresAt:     utils.P("2020-01-01T00:00:00Z"),
+	CreatedBy:     "admin",
+	CreatedAt:     utils.P("2019-11-05T14:02:03Z"),
+}
+
+func (m *MongodbSuite) TestLicenseIgnoreRules() {
+	defer m.db.Client.Database(m.dbname).Collection(licenseIgnoreRulesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	err := m.db.InsertLicenseIgnoreRule(licenseIgnoreRuleSample)
+	require.NoError(m.T(), err)
+
+	m.T().Run("list", func(t *testing.T) {
+		rules, err := m.db.ListLicenseIgnoreRules()
+		require.NoError(t, err)
+
+		assert.Equal(t, []model.LicenseIgnoreRule{licenseIgnoreRuleSample}, rules)
+	})
+
+	m.T().Run("update", func(t *testing.T) {
+		updated := licenseIgnoreRuleSample
+		updated.Justification = "Extended"
+		updated.ExpiresAt = utils.P("2021-01-01T00:00:00Z")
+		updated.CreatedBy = ""
+
+		err := m.db.UpdateLicenseIgnoreRule(updated)
+		require.NoError(t, err)
+
+		rules, err := m.db.ListLicenseIgnoreRules()
+		require.NoError(t, err)
+
+		expected := licenseIgnoreRuleSample
+		expected.Justification = "Extended"
+		expected.ExpiresAt = utils.P("2021-01-01T00:00:00Z")
+		assert.Equal(t, []model.LicenseIgnoreRule{expected}, rules)
+	})
+
+	m.T().Run("update_not_exist", func(t *testing.T) {
+		err := m.db.UpdateLicenseIgnoreRule(model.LicenseIgnoreRule{ID: utils.Str2oid("6512f1a5ba2e2a1f4b6b8f09")})
+		require.Equal(t, utils.ErrLicenseIgnoreRuleNotFound, err)
+	})
+
+	m.T().Run("remove", func(t *testing.T) {
+		err := m.db.RemoveLicenseIgnoreRule(licenseIgnoreRuleSample.ID)
+		require.NoError(t, err)
+
+		err = m.db.RemoveLicenseIgnoreRule(licenseIgnoreRuleSample.ID)
+		require.Equal(t, utils.ErrLicenseIgnoreRuleNotFound, err)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const licensesComplianceHistoryCollection = "database_licenses_history"
+
+// GetLicensesComplianceAt return the licenses compliance of the most recent day of the history not after at
+func (md *MongoDatabase) GetLicensesComplianceAt(at time.Time) ([]dto.LicenseCompliance, error) {
+	ctx := context.TODO()
+	collection := md.Client.Database(md.Config.Mongodb.DBName).Collection(licensesComplianceHistoryCollection)
+
+	cur, err := collection.Aggregate(ctx, bson.A{
+		bson.M{"$unwind": "$history"},
+		bson.M{"$match": bson.M{"history.date": bson.M{"$lte": at}}},
+		bson.M{"$group": bson.M{"_id": nil, "date": bson.M{"$max": "$history.date"}}},
+	})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	var days []struct {
+		Date time.Time `bson:"date"`
+	}
+	if err := cur.All(ctx, &days); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	licenses := make([]dto.LicenseCompliance, 0)
+	if len(days) == 0 {
+		return licenses, nil
+	}
+
+	cur, err = collection.Aggregate(ctx, bson.A{
+		bson.M{"$unwind": "$history"},
+		bson.M{"$match": bson.M{"history.date": days[0].Date}},
+		bson.M{"$project": bson.M{
+			"_id":             0,
+			"licenseTypeID":   1,
+			"itemDescription": 1,
+			"metric":          1,
+			"consumed":        "$history.consumed",
+			"covered":         "$history.covered",
+			"purchased":       "$history.purchased",
+		}},
+		bson.M{"$sort": bson.D{{Key: "licenseTypeID", Value: 1}, {Key: "itemDescription", Value: 1}}},
+	})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	if err := cur.All(ctx, &licenses); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return licenses, nil
+}
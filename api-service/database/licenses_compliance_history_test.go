@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestGetLicensesComplianceAt() {
+	defer m.db.Client.Database(m.dbname).Collection(licensesComplianceHistoryCollection).DeleteMany(context.TODO(), bson.M{})
+
+	_, err := m.db.Client.Database(m.dbname).Collection(licensesComplianceHistoryCollection).InsertMany(context.TODO(), []interface{}{
+		bson.M{
+			"licenseTypeID":   "A90611",
+			"itemDescription": "Oracle Database Enterprise Edition",
+			"history": bson.A{
+				bson.M{"date": utils.P("2020-02-28T00:00:00Z"), "consumed": 8.0, "covered": 8.0, "purchased": 10.0},
+				bson.M{"date": utils.P("2020-02-29T00:00:00Z"), "consumed": 10.0, "covered": 10.0, "purchased": 10.0},
+				bson.M{"date": utils.P("2020-03-02T00:00:00Z"), "consumed": 12.0, "covered": 10.0, "purchased": 10.0},
+			},
+		},
+		bson.M{
+			"licenseTypeID":   "A90619",
+			"itemDescription": "Real Application Clusters",
+			"history": bson.A{
+				bson.M{"date": utils.P("2020-02-28T00:00:00Z"), "consumed": 2.0, "covered": 0.0, "purchased": 0.0},
+			},
+		},
+	})
+	require.NoError(m.T(), err)
+
+	m.T().Run("most_recent_day", func(t *testing.T) {
+		actual, err := m.db.GetLicensesComplianceAt(utils.P("2020-03-01T23:59:59Z"))
+		require.NoError(t, err)
+
+		expected := []dto.LicenseCompliance{
+			{LicenseTypeID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Consumed: 10, Covered: 10, Purchased: 10},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	m.T().Run("older_day", func(t *testing.T) {
+		actual, err := m.db.GetLicensesComplianceAt(utils.P("2020-02-28T12:00:00Z"))
+		require.NoError(t, err)
+
+		expected := []dto.LicenseCompliance{
+			{LicenseTypeID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Consumed: 8, Covered: 8, Purchased: 10},
+			{LicenseTypeID: "A90619", ItemDescription: "Real Application Clusters", Consumed: 2, Covered: 0, Purchased: 0},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	m.T().Run("before_history", func(t *testing.T) {
+		actual, err := m.db.GetLicensesComplianceAt(utils.P("2020-01-01T00:00:00Z"))
+		require.NoError(t, err)
+		assert.Equal(t, []dto.LicenseCompliance{}, actual)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const lmsPendingChangesCollection = "lms_pending_changes"
+
+// ListLMSPendingChanges return the LMS pending changes with the status, or all of them if status is empty
+func (md *MongoDatabase) ListLMSPendingChanges(status string) ([]model.LMSPendingChange, error) {
+	ctx := context.TODO()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(lmsPendingChangesCollection).
+		Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	changes := make([]model.LMSPendingChange, 0)
+	if err := cur.All(ctx, &changes); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return changes, nil
+}
+
+// InsertLMSPendingChanges insert the LMS pending changes into the database
+func (md *MongoDatabase) InsertLMSPendingChanges(changes []model.LMSPendingChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		docs = append(docs, change)
+	}
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(lmsPendingChangesCollection).
+		InsertMany(context.TODO(), docs)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// GetLMSPendingChange return the LMS pending change with the id
+func (md *MongoDatabase) GetLMSPendingChange(id primitive.ObjectID) (*model.LMSPendingChange, error) {
+	var change model.LMSPendingChange
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(lmsPendingChangesCollection).
+		FindOne(context.TODO(), bson.M{"_id": id}).Decode(&change)
+	if err == mongo.ErrNoDocuments {
+		return nil, utils.ErrLMSPendingChangeNotFound
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &change, nil
+}
+
+// UpdateLMSPendingChangeStatus set the status and the reviewer of an LMS pending change
+func (md *MongoDatabase) UpdateLMSPendingChangeStatus(id primitive.ObjectID, status string, reviewedBy string, reviewedAt time.Time) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(lmsPendingChangesCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$set": bson.M{
+			"status":     status,
+			"reviewedBy": reviewedBy,
+			"reviewedAt": reviewedAt,
+		}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrLMSPendingChangeNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestLMSPendingChanges() {
+	defer m.db.Client.Database(m.dbname).Collection(lmsPendingChangesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	changes := []model.LMSPendingChange{
+		{
+			ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f11"),
+			ImportID:      utils.Str2oid("6512f1a5ba2e2a1f4b6b8f10"),
+			Row:           4,
+			Hostname:      "test-db",
+			DatabaseName:  "ERCOLE",
+			LicenseTypeID: "A90611",
+			Field:         model.LMSPendingChangeFieldIgnored,
+			CurrentValue:  "false",
+			ProposedValue: "true",
+			Comment:       "Test database",
+			Status:        model.LMSPendingChangeStatusPending,
+			CreatedBy:     "admin",
+			CreatedAt:     utils.P("2019-11-05T14:02:03Z"),
+		},
+		{
+			ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f12"),
+			ImportID:      utils.Str2oid("6512f1a5ba2e2a1f4b6b8f10"),
+			Row:           5,
+			Hostname:      "test-vm",
+			DatabaseName:  "ERCOLE2",
+			Field:         model.LMSPendingChangeFieldPhysicalServerName,
+			CurrentValue:  "cluster1",
+			ProposedValue: "esx01",
+			Status:        model.LMSPendingChangeStatusPending,
+			CreatedBy:     "admin",
+			CreatedAt:     utils.P("2019-11-05T14:02:04Z"),
+		},
+	}
+
+	err := m.db.InsertLMSPendingChanges(changes)
+	require.NoError(m.T(), err)
+
+	m.T().Run("list", func(t *testing.T) {
+		actual, err := m.db.ListLMSPendingChanges("")
+		require.NoError(t, err)
+		assert.Equal(t, changes, actual)
+
+		actual, err = m.db.ListLMSPendingChanges(model.LMSPendingChangeStatusApproved)
+		require.NoError(t, err)
+		assert.Equal(t, []model.LMSPendingChange{}, actual)
+	})
+
+	m.T().Run("get_not_exist", func(t *testing.T) {
+		_, err := m.db.GetLMSPendingChange(utils.Str2oid("6512f1a5ba2e2a1f4b6b8f19"))
+		require.Equal(t, utils.ErrLMSPendingChangeNotFound, err)
+	})
+
+	m.T().Run("update_status", func(t *testing.T) {
+		err := m.db.UpdateLMSPendingChangeStatus(changes[1].ID, model.LMSPendingChangeStatusApproved, "reviewer", utils.P("2019-11-06T10:00:00Z"))
+		require.NoError(t, err)
+
+		actual, err := m.db.GetLMSPendingChange(changes[1].ID)
+		require.NoError(t, err)
+
+		expected := changes[1]
+		expected.Status = model.LMSPendingChangeStatusApproved
+		expected.ReviewedBy = "reviewer"
+		reviewedAt := utils.P("2019-11-06T10:00:00Z")
+		expected.ReviewedAt = &reviewedAt
+		assert.Equal(t, &expected, actual)
+	})
+
+	m.T().Run("update_status_not_exist", func(t *testing.T) {
+		err := m.db.UpdateLMSPendingChangeStatus(utils.Str2oid("6512f1a5ba2e2a1f4b6b8f19"), model.LMSPendingChangeStatusApproved, "reviewer", utils.P("2019-11-06T10:00:00Z"))
+		require.Equal(t, utils.ErrLMSPendingChangeNotFound, err)
+	})
+}
@@ -28,7 +28,7 @@ import (
 )
 
 func (md *MongoDatabase) SearchSqlServerDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int,
-	location string, environment string, olderThan time.Time,
+	location string, environment string, site string, olderThan time.Time,
 ) (*dto.SqlServerDatabaseUsedLicenseSearchResponse, error) {
 	cursor, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
@@ -36,6 +36,7 @@ func (md *MongoDatabase) SearchSqlServerDatabaseUsedLicenses(hostname string, so
 			FindByHostname(hostname),
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.microsoft.sqlServer.instances"),
 			mu.APUnwind("$features.microsoft.sqlServer.instances.license"),
 			mu.APMatch(bson.M{"features.microsoft.sqlServer.instances.license.count": bson.M{"$gt": 0}}),
@@ -48,8 +49,10 @@ func (md *MongoDatabase) SearchSqlServerDatabaseUsedLicenses(hostname string, so
 					"dbName":         "$features.microsoft.sqlServer.instances.name",
 					"licenseTypeID":  "$features.microsoft.sqlServer.instances.license.licenseTypeID",
 					"usedLicenses":   "$features.microsoft.sqlServer.instances.license.count",
+					"passive":        "$features.microsoft.sqlServer.instances.license.passive",
 					"ignored":        "$features.microsoft.sqlServer.instances.license.ignored",
 					"ignoredComment": "$features.microsoft.sqlServer.instances.license.ignoredComment",
+					"databases":      "$features.microsoft.sqlServer.instances.databases.name",
 				},
 			),
 
@@ -81,10 +84,16 @@ func (md *MongoDatabase) UpdateSqlServerLicenseIgnoredField(hostname string, ins
 				"archived": false,
 				"features.microsoft.sqlServer.instances.name": instancename,
 			},
-			bson.M{"$set": bson.M{
-				"features.microsoft.sqlServer.instances.$[elemDB].license.ignored":        ignored,
-				"features.microsoft.sqlServer.instances.$[elemDB].license.ignoredComment": ignoredComment,
-			}},
+			bson.M{
+				"$set": bson.M{
+					"features.microsoft.sqlServer.instances.$[elemDB].license.ignored":        ignored,
+					"features.microsoft.sqlServer.instances.$[elemDB].license.ignoredComment": ignoredComment,
+				},
+				// the license is now ignored or counted manually, so it isn't managed by an ignore rule anymore
+				"$unset": bson.M{
+					"features.microsoft.sqlServer.instances.$[elemDB].license.ignoreRuleID": "",
+				},
+			},
 			options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"elemDB.name": instancename}}}),
 		)
 	if err != nil {
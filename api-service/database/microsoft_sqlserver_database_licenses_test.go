@@ -54,28 +54,28 @@ func (m *MongodbSuite) TestSearchSqlServerDatabaseUsedLicenses() {
 	}
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "Italy", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "Italy", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.JSONEq(t, utils.ToJSON(emptyResponse), utils.ToJSON(out))
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "TEST", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "TEST", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.JSONEq(t, utils.ToJSON(emptyResponse), utils.ToJSON(out))
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MIN_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 
 		assert.JSONEq(t, utils.ToJSON(emptyResponse), utils.ToJSON(out))
 	})
 
 	m.T().Run("should_do_pagination", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, 0, 2, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, 0, 2, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.SqlServerDatabaseUsedLicenseSearchResponse{
@@ -97,7 +97,7 @@ func (m *MongodbSuite) TestSearchSqlServerDatabaseUsedLicenses() {
 	})
 
 	m.T().Run("should_be_sorted", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "licenseName", true, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "licenseName", true, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.SqlServerDatabaseUsedLicenseSearchResponse{
@@ -119,7 +119,7 @@ func (m *MongodbSuite) TestSearchSqlServerDatabaseUsedLicenses() {
 	})
 
 	m.T().Run("should_not_filter", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.SqlServerDatabaseUsedLicenseSearchResponse{
@@ -141,7 +141,7 @@ func (m *MongodbSuite) TestSearchSqlServerDatabaseUsedLicenses() {
 	})
 
 	m.T().Run("should_filter_by_hostname", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("test-db3", "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerDatabaseUsedLicenses("test-db3", "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.SqlServerDatabaseUsedLicenseSearchResponse{
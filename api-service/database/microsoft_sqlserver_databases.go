@@ -26,7 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-func (md *MongoDatabase) SearchSqlServerInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.SqlServerInstanceResponse, error) {
+func (md *MongoDatabase) SearchSqlServerInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.SqlServerInstanceResponse, error) {
 	var sqlServerInstanceResponse dto.SqlServerInstanceResponse
 
 	var pagePaging, pagePagingSize int
@@ -46,6 +46,7 @@ func (md *MongoDatabase) SearchSqlServerInstances(keywords []string, sortBy stri
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.microsoft.sqlServer.instances"),
 			mu.APProject(bson.M{
 				"hostname":    1,
@@ -88,6 +89,7 @@ func (md *MongoDatabase) SearchSqlServerInstances(keywords []string, sortBy stri
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.microsoft.sqlServer.instances"),
 			mu.APProject(bson.M{
 				"hostname":    1,
@@ -33,7 +33,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_28.json"))
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, -1, -1, "", "PROD", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, -1, -1, "", "PROD", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expectedOut := dto.SqlServerInstanceResponse{
@@ -53,7 +53,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, -1, -1, "France", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, -1, -1, "France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expectedOut := dto.SqlServerInstanceResponse{
@@ -72,7 +72,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, -1, -1, "", "", utils.P("1999-05-04T16:09:46.608+02:00"))
+		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, -1, -1, "", "", "", utils.P("1999-05-04T16:09:46.608+02:00"))
 		m.Require().NoError(err)
 
 		expectedOut := dto.SqlServerInstanceResponse{
@@ -92,7 +92,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("should_be_paging", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, 0, 1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{""}, "", false, 0, 1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		var expectedContent []dto.SqlServerInstance = []dto.SqlServerInstance{
@@ -125,7 +125,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("should_be_sorting", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{""}, "hostname", true, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{""}, "hostname", true, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.SqlServerInstance = []dto.SqlServerInstance{
 			{
@@ -157,7 +157,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("should_search_return_anything", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{"foobar"}, "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{"foobar"}, "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.SqlServerInstance = []dto.SqlServerInstance{}
 
@@ -178,7 +178,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("should_search_return_found", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{"test-db2"}, "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{"test-db2"}, "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.SqlServerInstance = []dto.SqlServerInstance{
 			{
@@ -210,7 +210,7 @@ func (m *MongodbSuite) TestSearchSqlServerInstances() {
 	})
 
 	m.T().Run("fullmode", func(t *testing.T) {
-		out, err := m.db.SearchSqlServerInstances([]string{""}, "hostname", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchSqlServerInstances([]string{""}, "hostname", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.SqlServerInstance = []dto.SqlServerInstance{
 			{
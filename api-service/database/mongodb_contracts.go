@@ -0,0 +1,40 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+const mongoDBContractCollection = "mongodb_contracts"
+
+func (md *MongoDatabase) AddMongoDBContract(contract model.MongoDBContract) error {
+	return addSubscriptionContract(md, mongoDBContractCollection, contract)
+}
+
+func (md *MongoDatabase) UpdateMongoDBContract(contract model.MongoDBContract) error {
+	return updateSubscriptionContract(md, mongoDBContractCollection, contract)
+}
+
+func (md *MongoDatabase) GetMongoDBContracts() ([]model.MongoDBContract, error) {
+	return getSubscriptionContracts[model.MongoDBContract](md, mongoDBContractCollection)
+}
+
+func (md *MongoDatabase) DeleteMongoDBContract(id primitive.ObjectID) error {
+	return deleteSubscriptionContract(md, mongoDBContractCollection, id)
+}
@@ -136,3 +136,11 @@ func (md *MongoDatabase) SearchMongoDBInstances(keywords []string, sortBy string
 
 	return &mongoDBInstanceResponse, nil
 }
+
+// GetMongoDBUsedLicenses return the MongoDB instances with the cores of their host, used to compute the subscriptions
+func (md *MongoDatabase) GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MongoDBUsedLicense, error) {
+	return getSubscriptionUsedLicenses[dto.MongoDBUsedLicense](md, "mongodb", map[string]string{
+		"version": "version",
+		"edition": "edition",
+	}, hostname, filter)
+}
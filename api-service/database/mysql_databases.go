@@ -33,6 +33,7 @@ func (md *MongoDatabase) SearchMySQLInstances(filter dto.GlobalFilter) ([]dto.My
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.mysql.instances"),
 			mu.APProject(bson.M{
 				"hostname":    1,
@@ -65,6 +66,7 @@ func (md *MongoDatabase) GetMySQLUsedLicenses(hostname string, filter dto.Global
 			FindByHostname(hostname),
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.mysql.instances"),
 			mu.APUnwind("$features.mysql.instances.license"),
 			mu.APMatch(bson.M{
@@ -107,10 +109,16 @@ func (md *MongoDatabase) UpdateMySqlLicenseIgnoredField(hostname string, instanc
 				"archived":                      false,
 				"features.mysql.instances.name": instancename,
 			},
-			bson.M{"$set": bson.M{
-				"features.mysql.instances.$[elemDB].license.ignored":        ignored,
-				"features.mysql.instances.$[elemDB].license.ignoredComment": ignoredComment,
-			}},
+			bson.M{
+				"$set": bson.M{
+					"features.mysql.instances.$[elemDB].license.ignored":        ignored,
+					"features.mysql.instances.$[elemDB].license.ignoredComment": ignoredComment,
+				},
+				// the license is now ignored or counted manually, so it isn't managed by an ignore rule anymore
+				"$unset": bson.M{
+					"features.mysql.instances.$[elemDB].license.ignoreRuleID": "",
+				},
+			},
 			options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"elemDB.name": instancename}}}),
 		)
 	if err != nil {
@@ -26,7 +26,7 @@ import (
 )
 
 // SearchOracleDatabaseAddms search addms
-func (md *MongoDatabase) SearchOracleDatabaseAddms(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) ([]map[string]interface{}, error) {
+func (md *MongoDatabase) SearchOracleDatabaseAddms(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) ([]map[string]interface{}, error) {
 	var out []map[string]interface{} = make([]map[string]interface{}, 0)
 	//Find the matching hostdata
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -34,6 +34,7 @@ func (md *MongoDatabase) SearchOracleDatabaseAddms(keywords []string, sortBy str
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"hostname":    1,
@@ -31,28 +31,28 @@ func (m *MongodbSuite) TestSearchOracleDatabaseAddms() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_07.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, -1, -1, "Italy", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, -1, -1, "Italy", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, -1, -1, "", "PRD", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, -1, -1, "", "PRD", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, -1, -1, "", "", utils.MIN_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, -1, -1, "", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_be_paging", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, 0, 1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "", false, 0, 1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{
 			map[string]interface{}{
@@ -85,7 +85,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseAddms() {
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_be_sorting", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "benefit", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{}, "benefit", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{
 			map[string]interface{}{
@@ -117,14 +117,14 @@ func (m *MongodbSuite) TestSearchOracleDatabaseAddms() {
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_search1", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{"foobar"}, "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{"foobar"}, "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_search2", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseAddms([]string{"test-db", "ERCOLE"}, "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseAddms([]string{"test-db", "ERCOLE"}, "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{
 			map[string]interface{}{
@@ -34,6 +34,7 @@ func (md *MongoDatabase) FindOracleChangesByHostname(filter dto.GlobalFilter, ho
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			mu.APMatch(bson.M{
 				"features.oracle.database.databases": bson.M{
 					"$ne": nil,
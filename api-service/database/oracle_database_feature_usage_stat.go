@@ -35,6 +35,7 @@ func (md *MongoDatabase) GetOracleOptionList(filter dto.GlobalFilter) ([]dto.Ora
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.featureUsageStats"}},
 			bson.M{"$project": bson.M{
@@ -33,6 +33,7 @@ func (md *MongoDatabase) FindGrantDbaByHostname(hostname string, filter dto.Glob
 			FindByHostname(hostname),
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APUnwind("$features.oracle.database.databases.grantDba"),
 			mu.APProject(
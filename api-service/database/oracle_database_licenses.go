@@ -29,7 +29,7 @@ import (
 
 // SearchOracleDatabaseUsedLicenses search used licenses
 func (md *MongoDatabase) SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int,
-	location string, environment string, olderThan time.Time,
+	location string, environment string, site string, olderThan time.Time,
 ) (*dto.OracleDatabaseUsedLicenseSearchResponse, error) {
 	cursor, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
@@ -37,6 +37,7 @@ func (md *MongoDatabase) SearchOracleDatabaseUsedLicenses(hostname string, sortB
 			FindByHostname(hostname),
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APUnwind("$features.oracle.database.databases.licenses"),
 			mu.APMatch(bson.M{"features.oracle.database.databases.licenses.count": bson.M{"$gt": 0}}),
@@ -84,10 +85,16 @@ func (md *MongoDatabase) UpdateLicenseIgnoredField(hostname string, dbname strin
 				"features.oracle.database.databases.name":                   dbname,
 				"features.oracle.database.databases.licenses.licenseTypeID": licenseTypeID,
 			},
-			bson.M{"$set": bson.M{
-				"features.oracle.database.databases.$[elemDB].licenses.$[elemLic].ignored":        ignored,
-				"features.oracle.database.databases.$[elemDB].licenses.$[elemLic].ignoredComment": ignoredComment,
-			}},
+			bson.M{
+				"$set": bson.M{
+					"features.oracle.database.databases.$[elemDB].licenses.$[elemLic].ignored":        ignored,
+					"features.oracle.database.databases.$[elemDB].licenses.$[elemLic].ignoredComment": ignoredComment,
+				},
+				// the license is now ignored or counted manually, so it isn't managed by an ignore rule anymore
+				"$unset": bson.M{
+					"features.oracle.database.databases.$[elemDB].licenses.$[elemLic].ignoreRuleID": "",
+				},
+			},
 			options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"elemDB.name": dbname}, bson.M{"elemLic.licenseTypeID": licenseTypeID}}}),
 		)
 	if err != nil {
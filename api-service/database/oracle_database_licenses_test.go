@@ -57,28 +57,28 @@ func (m *MongodbSuite) TestSearchOracleDatabaseUsedLicenses() {
 	}
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "Italy", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "Italy", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.JSONEq(t, utils.ToJSON(emptyResponse), utils.ToJSON(out))
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "TEST", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "TEST", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.JSONEq(t, utils.ToJSON(emptyResponse), utils.ToJSON(out))
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MIN_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 
 		assert.JSONEq(t, utils.ToJSON(emptyResponse), utils.ToJSON(out))
 	})
 
 	m.T().Run("should_do_pagination", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, 0, 2, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, 0, 2, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.OracleDatabaseUsedLicenseSearchResponse{
@@ -101,7 +101,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseUsedLicenses() {
 	})
 
 	m.T().Run("should_be_sorted", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "licenseName", true, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "licenseName", true, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.OracleDatabaseUsedLicenseSearchResponse{
@@ -128,7 +128,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseUsedLicenses() {
 	})
 
 	m.T().Run("should_not_filter", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.OracleDatabaseUsedLicenseSearchResponse{
@@ -155,7 +155,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseUsedLicenses() {
 	})
 
 	m.T().Run("should_filter_by_hostname", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseUsedLicenses("test-db2", "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseUsedLicenses("test-db2", "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expected := dto.OracleDatabaseUsedLicenseSearchResponse{
@@ -31,6 +31,7 @@ func (md *MongoDatabase) FindAllOracleDatabasePartitionings(filter dto.GlobalFil
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.partitionings"}},
 			bson.M{"$project": bson.M{
@@ -63,6 +64,7 @@ func (md *MongoDatabase) FindAllOraclePDBPartitionings(filter dto.GlobalFilter)
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs.partitionings"}},
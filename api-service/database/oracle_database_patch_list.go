@@ -34,6 +34,7 @@ func (md *MongoDatabase) GetOraclePatchList(filter dto.GlobalFilter) ([]dto.Orac
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.patches"}},
 			bson.M{"$project": bson.M{
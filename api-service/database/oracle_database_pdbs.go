@@ -20,6 +20,7 @@ import (
 
 	"github.com/amreo/mu"
 	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -31,10 +32,20 @@ func (md *MongoDatabase) FindAllOracleDatabasePdbs(filter dto.GlobalFilter) ([]d
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
+			mu.APSet(bson.M{
+				"pdbCount": mu.APOSize(mu.APOFilter(
+					mu.APOIfNull("$features.oracle.database.databases.pdbs", bson.A{}),
+					"pdb",
+					mu.APONotEqual("$$pdb.name", model.OracleDatabaseSeedPDB),
+				)),
+			}),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs"}},
 			bson.M{"$project": bson.M{
 				"hostname": 1,
+				"dbName":   "$features.oracle.database.databases.name",
+				"pdbCount": 1,
 				"pdb":      "$features.oracle.database.databases.pdbs",
 			}},
 		),
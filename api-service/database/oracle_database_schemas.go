@@ -31,6 +31,7 @@ func (md *MongoDatabase) FindAllOracleDatabaseSchemas(filter dto.GlobalFilter) (
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.schemas"}},
 			bson.M{"$project": bson.M{
@@ -65,6 +66,7 @@ func (md *MongoDatabase) FindAllOraclePDBSchemas(filter dto.GlobalFilter) ([]dto
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs.schemas"}},
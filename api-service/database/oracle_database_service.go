@@ -34,6 +34,7 @@ func (md *MongoDatabase) GetOracleServiceList(filter dto.GlobalFilter) ([]dto.Or
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.services"}},
 			bson.M{"$project": bson.M{
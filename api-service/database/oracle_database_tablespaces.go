@@ -31,6 +31,7 @@ func (md *MongoDatabase) FindAllOracleDatabaseTablespaces(filter dto.GlobalFilte
 		mu.MAPipeline(
 			FilterByOldnessSteps(filter.OlderThan),
 			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.tablespaces"}},
 			bson.M{"$project": bson.M{
@@ -28,7 +28,7 @@ import (
 )
 
 // SearchOracleDatabases search databases
-func (md *MongoDatabase) SearchOracleDatabases(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.OracleDatabaseResponse, error) {
+func (md *MongoDatabase) SearchOracleDatabases(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.OracleDatabaseResponse, error) {
 	//Find the matching hostdata
 	var oracleDatabaseResponse dto.OracleDatabaseResponse
 
@@ -49,6 +49,7 @@ func (md *MongoDatabase) SearchOracleDatabases(keywords []string, sortBy string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			AddHardwareAbstraction("features.oracle.database.databases.ha"),
 			mu.APProject(bson.M{
@@ -120,6 +121,7 @@ func (md *MongoDatabase) SearchOracleDatabases(keywords []string, sortBy string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"hostname":    1,
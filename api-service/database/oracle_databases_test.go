@@ -39,7 +39,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_09.json"))
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, -1, -1, "", "PROD", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, -1, -1, "", "PROD", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expectedOut := dto.OracleDatabaseResponse{
@@ -59,7 +59,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, -1, -1, "France", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, -1, -1, "France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		expectedOut := dto.OracleDatabaseResponse{
@@ -78,7 +78,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, -1, -1, "", "", utils.P("1999-05-04T16:09:46.608+02:00"))
+		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, -1, -1, "", "", "", utils.P("1999-05-04T16:09:46.608+02:00"))
 		m.Require().NoError(err)
 
 		expectedOut := dto.OracleDatabaseResponse{
@@ -98,7 +98,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("should_be_paging", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, 0, 1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{""}, "", false, 0, 1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		var expectedContent []dto.OracleDatabase = []dto.OracleDatabase{
@@ -171,7 +171,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("should_be_sorting", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{""}, "memory", true, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{""}, "memory", true, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.OracleDatabase = []dto.OracleDatabase{
 			{
@@ -254,7 +254,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("should_search_return_anything", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{"foobar"}, "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{"foobar"}, "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.OracleDatabase = []dto.OracleDatabase{}
 
@@ -275,7 +275,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("should_search_return_found", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{"pokemon", "test-db2"}, "", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{"pokemon", "test-db2"}, "", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.OracleDatabase = []dto.OracleDatabase{
 			{
@@ -358,7 +358,7 @@ func (m *MongodbSuite) TestSearchOracleDatabases() {
 	})
 
 	m.T().Run("fullmode", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabases([]string{""}, "memory", false, -1, -1, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabases([]string{""}, "memory", false, -1, -1, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedContent []dto.OracleDatabase = []dto.OracleDatabase{
 			{
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const oracleFeatureUsageLedgerCollection = "oracle_feature_usage_ledger"
+
+// GetOracleFeatureUsageLedger return the entries of the Oracle feature usage ledger,
+// with the location and environment of the current hosts
+func (md *MongoDatabase) GetOracleFeatureUsageLedger(filter dto.OracleFeatureUsageLedgerFilter) ([]dto.OracleFeatureUsageLedgerEntry, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleFeatureUsageLedgerCollection).Aggregate(
+		ctx,
+		mu.MAPipeline(
+			mu.APOptionalStage(filter.Hostname != "", mu.APMatch(bson.M{"hostname": filter.Hostname})),
+			mu.APOptionalStage(filter.DbName != "", mu.APMatch(bson.M{"dbName": filter.DbName})),
+			mu.APOptionalStage(filter.Product != "", mu.APMatch(bson.M{"product": filter.Product})),
+			bson.M{"$lookup": bson.M{
+				"from": "hosts",
+				"let":  bson.M{"hostname": "$hostname"},
+				"pipeline": bson.A{
+					mu.APMatch(bson.M{"$expr": bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$hostname", "$$hostname"}},
+						bson.M{"$eq": bson.A{"$archived", false}},
+					}}}),
+					mu.APProject(bson.M{"location": 1, "environment": 1, "site": 1}),
+				},
+				"as": "host",
+			}},
+			bson.M{"$unwind": bson.M{"path": "$host", "preserveNullAndEmptyArrays": true}},
+			bson.M{"$addFields": bson.M{
+				"location":    "$host.location",
+				"environment": "$host.environment",
+				"site":        "$host.site",
+			}},
+			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			FilterBySiteSteps(filter.Site, md.Config.Federation.LocalSite),
+			mu.APProject(bson.M{"_id": 0, "host": 0}),
+			mu.APSort(bson.D{
+				{Key: "hostname", Value: 1},
+				{Key: "dbName", Value: 1},
+				{Key: "product", Value: 1},
+				{Key: "feature", Value: 1},
+			}),
+		),
+	)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]dto.OracleFeatureUsageLedgerEntry, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
@@ -34,7 +34,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "PROD", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "PROD", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{Content: dto.PatchAdvisors{}, Metadata: dto.PagingMetadata{Empty: true, First: true, Last: true}}
 
@@ -42,7 +42,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "France", "", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "France", "", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{Content: dto.PatchAdvisors{}, Metadata: dto.PagingMetadata{Empty: true, First: true, Last: true}}
 
@@ -50,7 +50,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.P("1999-05-04T16:09:46.608+02:00"), "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.P("1999-05-04T16:09:46.608+02:00"), "")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{Content: dto.PatchAdvisors{}, Metadata: dto.PagingMetadata{Empty: true, First: true, Last: true}}
 
@@ -58,7 +58,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_be_paging", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, 0, 1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{""}, "", false, 0, 1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 
 		expectedOut := &dto.PatchAdvisorResponse{
@@ -91,7 +91,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_be_sorting", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{}, "dbname", true, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{}, "dbname", true, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{
 			Content: dto.PatchAdvisors{
@@ -159,7 +159,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_search_return_anything", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{"barfoo"}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{"barfoo"}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{Content: dto.PatchAdvisors{}, Metadata: dto.PagingMetadata{Empty: true, First: true, Last: true}}
 
@@ -167,7 +167,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_search_return_found", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{"test-db2", "foobar1"}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{"test-db2", "foobar1"}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{
 			Content: dto.PatchAdvisors{
@@ -196,7 +196,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_filter_by_status", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.MAX_TIME, "OK")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.MAX_TIME, "OK")
 		m.Require().NoError(err)
 		expectedOut := &dto.PatchAdvisorResponse{
 			Content: dto.PatchAdvisors{
@@ -225,7 +225,7 @@ func (m *MongodbSuite) TestSearchOracleDatabasePatchAdvisors() {
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", utils.MAX_TIME, "")
+		out, err := m.db.SearchOracleDatabasePatchAdvisors([]string{}, "", false, -1, -1, utils.P("2019-10-10T08:46:58.38+02:00"), "", "", "", utils.MAX_TIME, "")
 		m.Require().NoError(err)
 
 		expectedOut := &dto.PatchAdvisorResponse{
@@ -28,13 +28,14 @@ import (
 
 // SearchOracleDatabaseSegmentAdvisors search segment advisors
 func (md *MongoDatabase) SearchOracleDatabaseSegmentAdvisors(keywords []string, sortBy string, sortDesc bool,
-	location string, environment string, olderThan time.Time,
+	location string, environment string, site string, olderThan time.Time,
 ) ([]dto.OracleDatabaseSegmentAdvisor, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"hostname":    1,
@@ -85,12 +86,13 @@ func (md *MongoDatabase) SearchOracleDatabaseSegmentAdvisors(keywords []string,
 }
 
 func (md *MongoDatabase) SearchOraclePdbSegmentAdvisors(sortBy string, sortDesc bool,
-	location string, environment string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error) {
+	location string, environment string, site string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs"}},
 			bson.M{"$unwind": bson.M{"path": "$features.oracle.database.databases.pdbs.segmentAdvisors"}},
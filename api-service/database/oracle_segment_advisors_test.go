@@ -34,7 +34,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "", "PROD", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "", "PROD", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{}
 
@@ -42,7 +42,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	})
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "France", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{}
 
@@ -50,7 +50,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "", "", utils.P("1999-05-04T16:09:46.608+02:00"))
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "", "", "", utils.P("1999-05-04T16:09:46.608+02:00"))
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{}
 
@@ -58,7 +58,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	})
 
 	m.T().Run("should_be_sorting", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "dbname", true, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "dbname", true, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{
 			{
@@ -127,7 +127,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	})
 
 	m.T().Run("should_search_return_anything", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{"barfoo"}, "", false, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{"barfoo"}, "", false, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{}
 
@@ -135,7 +135,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	})
 
 	m.T().Run("should_search_return_found", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{"test-db2", "foobar1"}, "", false, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{"test-db2", "foobar1"}, "", false, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{
 			{
@@ -159,7 +159,7 @@ func (m *MongodbSuite) TestSearchOracleDatabaseSegmentAdvisors() {
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "", "", utils.MAX_TIME)
+		out, err := m.db.SearchOracleDatabaseSegmentAdvisors([]string{""}, "", false, "", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		expectedOut := []dto.OracleDatabaseSegmentAdvisor{
 			{
@@ -26,7 +26,7 @@ import (
 )
 
 // GetOracleDatabaseEnvironmentStats return a array containing the number of databases per environment
-func (md *MongoDatabase) GetOracleDatabaseEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabaseEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 	//Calculate the stats
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -34,6 +34,7 @@ func (md *MongoDatabase) GetOracleDatabaseEnvironmentStats(location string, olde
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APGroup(bson.M{
 				"_id":   "$environment",
 				"count": mu.APOSum(mu.APOCond("$features.oracle.database.databases", mu.APOSize("$features.oracle.database.databases"), 0)),
@@ -66,7 +67,7 @@ func (md *MongoDatabase) GetOracleDatabaseEnvironmentStats(location string, olde
 }
 
 // GetOracleDatabaseHighReliabilityStats return a array containing the number of databases per high-reliability status
-func (md *MongoDatabase) GetOracleDatabaseHighReliabilityStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabaseHighReliabilityStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 	//Calculate the stats
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -74,6 +75,7 @@ func (md *MongoDatabase) GetOracleDatabaseHighReliabilityStats(location string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			AddHardwareAbstraction("ha"),
 			mu.APGroup(bson.M{
 				"_id":   "$ha",
@@ -107,7 +109,7 @@ func (md *MongoDatabase) GetOracleDatabaseHighReliabilityStats(location string,
 }
 
 // GetOracleDatabaseVersionStats return a array containing the number of databases per version
-func (md *MongoDatabase) GetOracleDatabaseVersionStats(location string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabaseVersionStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -116,6 +118,7 @@ func (md *MongoDatabase) GetOracleDatabaseVersionStats(location string, olderTha
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -144,7 +147,7 @@ func (md *MongoDatabase) GetOracleDatabaseVersionStats(location string, olderTha
 }
 
 // GetTopReclaimableOracleDatabaseStats return a array containing the total sum of reclaimable of segments advisors of the top reclaimable databases
-func (md *MongoDatabase) GetTopReclaimableOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetTopReclaimableOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -153,6 +156,7 @@ func (md *MongoDatabase) GetTopReclaimableOracleDatabaseStats(location string, l
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -187,7 +191,7 @@ func (md *MongoDatabase) GetTopReclaimableOracleDatabaseStats(location string, l
 }
 
 // GetTopWorkloadOracleDatabaseStats return a array containing top databases by workload
-func (md *MongoDatabase) GetTopWorkloadOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetTopWorkloadOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -196,6 +200,7 @@ func (md *MongoDatabase) GetTopWorkloadOracleDatabaseStats(location string, limi
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -230,7 +235,7 @@ func (md *MongoDatabase) GetTopWorkloadOracleDatabaseStats(location string, limi
 }
 
 // GetOracleDatabasePatchStatusStats return a array containing the number of databases per patch status
-func (md *MongoDatabase) GetOracleDatabasePatchStatusStats(location string, windowTime time.Time, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabasePatchStatusStats(location string, site string, windowTime time.Time, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -239,6 +244,7 @@ func (md *MongoDatabase) GetOracleDatabasePatchStatusStats(location string, wind
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -285,7 +291,7 @@ func (md *MongoDatabase) GetOracleDatabasePatchStatusStats(location string, wind
 }
 
 // GetOracleDatabaseDataguardStatusStats return a array containing the number of databases per dataguard status
-func (md *MongoDatabase) GetOracleDatabaseDataguardStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabaseDataguardStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -294,6 +300,7 @@ func (md *MongoDatabase) GetOracleDatabaseDataguardStatusStats(location string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -322,7 +329,7 @@ func (md *MongoDatabase) GetOracleDatabaseDataguardStatusStats(location string,
 }
 
 // GetOracleDatabaseRACStatusStats return a array containing the number of databases per RAC status
-func (md *MongoDatabase) GetOracleDatabaseRACStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabaseRACStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -331,6 +338,7 @@ func (md *MongoDatabase) GetOracleDatabaseRACStatusStats(location string, enviro
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -364,7 +372,7 @@ func (md *MongoDatabase) GetOracleDatabaseRACStatusStats(location string, enviro
 }
 
 // GetOracleDatabaseArchivelogStatusStats return a array containing the number of databases per archivelog status
-func (md *MongoDatabase) GetOracleDatabaseArchivelogStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOracleDatabaseArchivelogStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Calculate the stats
@@ -373,6 +381,7 @@ func (md *MongoDatabase) GetOracleDatabaseArchivelogStatusStats(location string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -401,7 +410,7 @@ func (md *MongoDatabase) GetOracleDatabaseArchivelogStatusStats(location string,
 }
 
 // GetTotalOracleDatabaseWorkStats return the total work of databases
-func (md *MongoDatabase) GetTotalOracleDatabaseWorkStats(location string, environment string, olderThan time.Time) (float64, error) {
+func (md *MongoDatabase) GetTotalOracleDatabaseWorkStats(location string, environment string, site string, olderThan time.Time) (float64, error) {
 	var out map[string]float64
 
 	//Calculate the stats
@@ -410,6 +419,7 @@ func (md *MongoDatabase) GetTotalOracleDatabaseWorkStats(location string, enviro
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -439,12 +449,13 @@ func (md *MongoDatabase) GetTotalOracleDatabaseWorkStats(location string, enviro
 }
 
 // GetTotalOracleDatabaseMemorySizeStats return the total of memory size of databases
-func (md *MongoDatabase) GetTotalOracleDatabaseMemorySizeStats(location string, environment string, olderThan time.Time) (float64, error) {
+func (md *MongoDatabase) GetTotalOracleDatabaseMemorySizeStats(location string, environment string, site string, olderThan time.Time) (float64, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -484,12 +495,13 @@ func (md *MongoDatabase) GetTotalOracleDatabaseMemorySizeStats(location string,
 }
 
 // GetTotalOracleDatabaseDatafileSizeStats return the total size of datafiles of databases
-func (md *MongoDatabase) GetTotalOracleDatabaseDatafileSizeStats(location string, environment string, olderThan time.Time) (float64, error) {
+func (md *MongoDatabase) GetTotalOracleDatabaseDatafileSizeStats(location string, environment string, site string, olderThan time.Time) (float64, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -532,12 +544,13 @@ func (md *MongoDatabase) GetTotalOracleDatabaseDatafileSizeStats(location string
 }
 
 // GetTotalOracleDatabaseSegmentSizeStats return the total size of segments of databases
-func (md *MongoDatabase) GetTotalOracleDatabaseSegmentSizeStats(location string, environment string, olderThan time.Time) (float64, error) {
+func (md *MongoDatabase) GetTotalOracleDatabaseSegmentSizeStats(location string, environment string, site string, olderThan time.Time) (float64, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"database": "$features.oracle.database.databases",
@@ -580,7 +593,7 @@ func (md *MongoDatabase) GetTotalOracleDatabaseSegmentSizeStats(location string,
 }
 
 // GetTopUnusedOracleDatabaseInstanceResourceStats return a array containing top unused instance resource by workload
-func (md *MongoDatabase) GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, limit int, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, site string, limit int, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -588,6 +601,7 @@ func (md *MongoDatabase) GetTopUnusedOracleDatabaseInstanceResourceStats(locatio
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APProject(bson.M{
 				"hostname":        1,
 				"info.cpuThreads": 1,
@@ -34,7 +34,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseEnvironmentStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseEnvironmentStats("France", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseEnvironmentStats("France", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -42,7 +42,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseEnvironmentStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseEnvironmentStats("", utils.MIN_TIME)
+		out, err := m.db.GetOracleDatabaseEnvironmentStats("", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -50,7 +50,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseEnvironmentStats() {
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseEnvironmentStats("", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseEnvironmentStats("", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -74,7 +74,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseVersionStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseVersionStats("France", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseVersionStats("France", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -82,7 +82,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseVersionStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseVersionStats("", utils.MIN_TIME)
+		out, err := m.db.GetOracleDatabaseVersionStats("", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -90,7 +90,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseVersionStats() {
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseVersionStats("", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseVersionStats("", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -118,7 +118,7 @@ func (m *MongodbSuite) TestGetTopReclaimableOracleDatabaseStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTopReclaimableOracleDatabaseStats("France", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopReclaimableOracleDatabaseStats("France", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -126,7 +126,7 @@ func (m *MongodbSuite) TestGetTopReclaimableOracleDatabaseStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTopReclaimableOracleDatabaseStats("", 15, utils.MIN_TIME)
+		out, err := m.db.GetTopReclaimableOracleDatabaseStats("", "", 15, utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -134,7 +134,7 @@ func (m *MongodbSuite) TestGetTopReclaimableOracleDatabaseStats() {
 	})
 
 	m.T().Run("should_limit_the_result", func(t *testing.T) {
-		out, err := m.db.GetTopReclaimableOracleDatabaseStats("", 1, utils.MAX_TIME)
+		out, err := m.db.GetTopReclaimableOracleDatabaseStats("", "", 1, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -149,7 +149,7 @@ func (m *MongodbSuite) TestGetTopReclaimableOracleDatabaseStats() {
 	})
 
 	m.T().Run("should_return_all_results", func(t *testing.T) {
-		out, err := m.db.GetTopReclaimableOracleDatabaseStats("", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopReclaimableOracleDatabaseStats("", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -189,7 +189,7 @@ func (m *MongodbSuite) TestGetTopWorkloadOracleDatabaseStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTopWorkloadOracleDatabaseStats("France", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopWorkloadOracleDatabaseStats("France", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -197,7 +197,7 @@ func (m *MongodbSuite) TestGetTopWorkloadOracleDatabaseStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTopWorkloadOracleDatabaseStats("", 15, utils.MIN_TIME)
+		out, err := m.db.GetTopWorkloadOracleDatabaseStats("", "", 15, utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -205,7 +205,7 @@ func (m *MongodbSuite) TestGetTopWorkloadOracleDatabaseStats() {
 	})
 
 	m.T().Run("should_limit_the_result", func(t *testing.T) {
-		out, err := m.db.GetTopWorkloadOracleDatabaseStats("", 1, utils.MAX_TIME)
+		out, err := m.db.GetTopWorkloadOracleDatabaseStats("", "", 1, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -220,7 +220,7 @@ func (m *MongodbSuite) TestGetTopWorkloadOracleDatabaseStats() {
 	})
 
 	m.T().Run("should_return_all_results", func(t *testing.T) {
-		out, err := m.db.GetTopWorkloadOracleDatabaseStats("", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopWorkloadOracleDatabaseStats("", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -260,7 +260,7 @@ func (m *MongodbSuite) TestGetOracleDatabasePatchStatusStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabasePatchStatusStats("France", utils.P("2019-10-10T08:46:58.38+02:00"), utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabasePatchStatusStats("France", "", utils.P("2019-10-10T08:46:58.38+02:00"), utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -268,7 +268,7 @@ func (m *MongodbSuite) TestGetOracleDatabasePatchStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabasePatchStatusStats("", utils.P("2019-10-10T08:46:58.38+02:00"), utils.MIN_TIME)
+		out, err := m.db.GetOracleDatabasePatchStatusStats("", "", utils.P("2019-10-10T08:46:58.38+02:00"), utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -276,7 +276,7 @@ func (m *MongodbSuite) TestGetOracleDatabasePatchStatusStats() {
 	})
 
 	m.T().Run("should_return_correct_result", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabasePatchStatusStats("", utils.P("2019-10-10T08:46:58.38+02:00"), utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabasePatchStatusStats("", "", utils.P("2019-10-10T08:46:58.38+02:00"), utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -300,7 +300,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseDataguardStatusStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseDataguardStatusStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseDataguardStatusStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -308,7 +308,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseDataguardStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseDataguardStatusStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseDataguardStatusStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -316,7 +316,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseDataguardStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseDataguardStatusStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetOracleDatabaseDataguardStatusStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -324,7 +324,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseDataguardStatusStats() {
 	})
 
 	m.T().Run("should_return_correct_result", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseDataguardStatusStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseDataguardStatusStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -348,7 +348,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseRACStatusStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseRACStatusStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseRACStatusStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -356,7 +356,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseRACStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseRACStatusStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseRACStatusStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -364,7 +364,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseRACStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseRACStatusStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetOracleDatabaseRACStatusStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -372,7 +372,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseRACStatusStats() {
 	})
 
 	m.T().Run("should_return_correct_result", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseRACStatusStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseRACStatusStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -396,7 +396,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseArchivelogStatusStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -404,7 +404,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseArchivelogStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -412,7 +412,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseArchivelogStatusStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -420,7 +420,7 @@ func (m *MongodbSuite) TestGetOracleDatabaseArchivelogStatusStats() {
 	})
 
 	m.T().Run("should_return_correct_result", func(t *testing.T) {
-		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetOracleDatabaseArchivelogStatusStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -444,28 +444,28 @@ func (m *MongodbSuite) TestGetTotalOracleDatabaseWorkStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseWorkStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseWorkStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.Equal(t, float64(0.0), out)
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseWorkStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseWorkStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseWorkStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetTotalOracleDatabaseWorkStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseWorkStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseWorkStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-116.4) < 0.00001)
@@ -479,28 +479,28 @@ func (m *MongodbSuite) TestGetTotalOracleDatabaseDatafileSizeStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.Equal(t, float64(0.0), out)
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseDatafileSizeStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-132*1024*1024*1024) < 0.00001)
@@ -514,28 +514,28 @@ func (m *MongodbSuite) TestGetTotalOracleDatabaseMemorySizeStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.Equal(t, float64(0.0), out)
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseMemorySizeStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-34.642*1024*1024*1024) < 0.00001)
@@ -549,28 +549,28 @@ func (m *MongodbSuite) TestGetTotalOracleDatabaseSegmentSizeStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_13.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("France", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("France", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.Equal(t, float64(0.0), out)
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("", "", utils.MIN_TIME)
+		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-0.0) < 0.00001)
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("", "", utils.MAX_TIME)
+		out, err := m.db.GetTotalOracleDatabaseSegmentSizeStats("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 
 		assert.True(t, math.Abs(float64(out)-48*1024*1024*1024) < 0.00001)
@@ -585,7 +585,7 @@ func (m *MongodbSuite) TestGetTopUnusedOracleDatabaseInstanceResourceStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_12.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("France", "", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("France", "", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -593,7 +593,7 @@ func (m *MongodbSuite) TestGetTopUnusedOracleDatabaseInstanceResourceStats() {
 	})
 
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "FOOBAR", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "FOOBAR", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -601,7 +601,7 @@ func (m *MongodbSuite) TestGetTopUnusedOracleDatabaseInstanceResourceStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "", 15, utils.MIN_TIME)
+		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "", "", 15, utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -609,7 +609,7 @@ func (m *MongodbSuite) TestGetTopUnusedOracleDatabaseInstanceResourceStats() {
 	})
 
 	m.T().Run("should_limit_the_result", func(t *testing.T) {
-		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "", 1, utils.MAX_TIME)
+		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "", "", 1, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -623,7 +623,7 @@ func (m *MongodbSuite) TestGetTopUnusedOracleDatabaseInstanceResourceStats() {
 	})
 
 	m.T().Run("should_return_all_results", func(t *testing.T) {
-		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "", 15, utils.MAX_TIME)
+		out, err := m.db.GetTopUnusedOracleDatabaseInstanceResourceStats("", "", "", 15, utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
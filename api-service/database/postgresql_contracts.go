@@ -0,0 +1,40 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+const postgreSQLContractCollection = "postgresql_contracts"
+
+func (md *MongoDatabase) AddPostgreSQLContract(contract model.PostgreSQLContract) error {
+	return addSubscriptionContract(md, postgreSQLContractCollection, contract)
+}
+
+func (md *MongoDatabase) UpdatePostgreSQLContract(contract model.PostgreSQLContract) error {
+	return updateSubscriptionContract(md, postgreSQLContractCollection, contract)
+}
+
+func (md *MongoDatabase) GetPostgreSQLContracts() ([]model.PostgreSQLContract, error) {
+	return getSubscriptionContracts[model.PostgreSQLContract](md, postgreSQLContractCollection)
+}
+
+func (md *MongoDatabase) DeletePostgreSQLContract(id primitive.ObjectID) error {
+	return deleteSubscriptionContract(md, postgreSQLContractCollection, id)
+}
@@ -134,3 +134,10 @@ func (md *MongoDatabase) SearchPostgreSqlInstances(keywords []string, sortBy str
 
 	return &postgreSqlInstanceResponse, nil
 }
+
+// GetPostgreSQLUsedLicenses return the PostgreSQL instances with the cores of their host, used to compute the subscriptions
+func (md *MongoDatabase) GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.PostgreSQLUsedLicense, error) {
+	return getSubscriptionUsedLicenses[dto.PostgreSQLUsedLicense](md, "postgresql", map[string]string{
+		"version": "setting.dbVersion",
+	}, hostname, filter)
+}
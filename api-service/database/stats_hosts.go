@@ -26,7 +26,7 @@ import (
 )
 
 // GetHostsCountStats return the number of the non-archived hosts
-func (md *MongoDatabase) GetHostsCountStats(location string, environment string, olderThan time.Time) (int, error) {
+func (md *MongoDatabase) GetHostsCountStats(location string, environment string, site string, olderThan time.Time) (int, error) {
 	var out map[string]int
 
 	//Calculate the stats
@@ -35,6 +35,7 @@ func (md *MongoDatabase) GetHostsCountStats(location string, environment string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APCount("value"),
 		),
 	)
@@ -57,7 +58,7 @@ func (md *MongoDatabase) GetHostsCountStats(location string, environment string,
 }
 
 // GetEnvironmentStats return a array containing the number of hosts per environment
-func (md *MongoDatabase) GetEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 	//Calculate the stats
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -65,6 +66,7 @@ func (md *MongoDatabase) GetEnvironmentStats(location string, olderThan time.Tim
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APGroupAndCountStages("environment", "count", "$environment"),
 			mu.APSort(bson.M{
 				"environment": 1,
@@ -89,7 +91,7 @@ func (md *MongoDatabase) GetEnvironmentStats(location string, olderThan time.Tim
 }
 
 // GetTypeStats return a array containing the number of hosts per type
-func (md *MongoDatabase) GetTypeStats(location string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetTypeStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 	//Calculate the stats
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -97,6 +99,7 @@ func (md *MongoDatabase) GetTypeStats(location string, olderThan time.Time) ([]i
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APGroupAndCountStages("hardwareAbstractionTechnology", "count", "$info.hardwareAbstractionTechnology"),
 			mu.APSort(bson.M{
 				"hardwareAbstractionTechnology": 1,
@@ -121,7 +124,7 @@ func (md *MongoDatabase) GetTypeStats(location string, olderThan time.Time) ([]i
 }
 
 // GetOperatingSystemStats return a array containing the number of hosts per operanting system
-func (md *MongoDatabase) GetOperatingSystemStats(location string, olderThan time.Time) ([]interface{}, error) {
+func (md *MongoDatabase) GetOperatingSystemStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
 	var out []interface{} = make([]interface{}, 0)
 
 	//Create the aggregation branches
@@ -157,6 +160,7 @@ func (md *MongoDatabase) GetOperatingSystemStats(location string, olderThan time
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, ""),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APGroupAndCountStages("operatingSystem", "count", switchExpr),
 			mu.APSort(bson.M{
 				"operatingSystem": 1,
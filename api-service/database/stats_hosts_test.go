@@ -36,7 +36,15 @@ func (m *MongodbSuite) TestGetEnvironmentStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_10.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetEnvironmentStats("France", utils.MAX_TIME)
+		out, err := m.db.GetEnvironmentStats("France", "", utils.MAX_TIME)
+		m.Require().NoError(err)
+		var expectedOut interface{} = []interface{}{}
+
+		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
+	})
+
+	m.T().Run("should_filter_out_by_site", func(t *testing.T) {
+		out, err := m.db.GetEnvironmentStats("", "milan", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -44,7 +52,7 @@ func (m *MongodbSuite) TestGetEnvironmentStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetEnvironmentStats("", utils.MIN_TIME)
+		out, err := m.db.GetEnvironmentStats("", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -52,7 +60,7 @@ func (m *MongodbSuite) TestGetEnvironmentStats() {
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetEnvironmentStats("", utils.MAX_TIME)
+		out, err := m.db.GetEnvironmentStats("", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -82,7 +90,7 @@ func (m *MongodbSuite) TestGetTypeStats() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_10.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetTypeStats("France", utils.MAX_TIME)
+		out, err := m.db.GetTypeStats("France", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -90,7 +98,7 @@ func (m *MongodbSuite) TestGetTypeStats() {
 	})
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetTypeStats("", utils.MIN_TIME)
+		out, err := m.db.GetTypeStats("", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -98,7 +106,7 @@ func (m *MongodbSuite) TestGetTypeStats() {
 	})
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
-		out, err := m.db.GetTypeStats("", utils.MAX_TIME)
+		out, err := m.db.GetTypeStats("", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -129,7 +137,7 @@ func (m *MongodbSuite) TestGetOperatingSystemStats() {
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
 		m.db.OperatingSystemAggregationRules = []config.AggregationRule{}
-		out, err := m.db.GetOperatingSystemStats("France", utils.MAX_TIME)
+		out, err := m.db.GetOperatingSystemStats("France", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -138,7 +146,7 @@ func (m *MongodbSuite) TestGetOperatingSystemStats() {
 
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
 		m.db.OperatingSystemAggregationRules = []config.AggregationRule{}
-		out, err := m.db.GetOperatingSystemStats("", utils.MIN_TIME)
+		out, err := m.db.GetOperatingSystemStats("", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []interface{}{}
 
@@ -147,7 +155,7 @@ func (m *MongodbSuite) TestGetOperatingSystemStats() {
 
 	m.T().Run("should_return_correct_results", func(t *testing.T) {
 		m.db.OperatingSystemAggregationRules = []config.AggregationRule{}
-		out, err := m.db.GetOperatingSystemStats("", utils.MAX_TIME)
+		out, err := m.db.GetOperatingSystemStats("", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -174,7 +182,7 @@ func (m *MongodbSuite) TestGetOperatingSystemStats() {
 				Group: "Ubuntu Server",
 			},
 		}
-		out, err := m.db.GetOperatingSystemStats("", utils.MAX_TIME)
+		out, err := m.db.GetOperatingSystemStats("", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = []map[string]interface{}{
 			{
@@ -27,7 +27,7 @@ import (
 )
 
 // GetHostsCountUsingTechnologies return a map that contains the number of usages for every features
-func (md *MongoDatabase) GetHostsCountUsingTechnologies(location string, environment string, olderThan time.Time) (map[string]float64, error) {
+func (md *MongoDatabase) GetHostsCountUsingTechnologies(location string, environment string, site string, olderThan time.Time) (map[string]float64, error) {
 	var out map[string]float64 = make(map[string]float64)
 
 	//Find the matching hostdata
@@ -35,6 +35,7 @@ func (md *MongoDatabase) GetHostsCountUsingTechnologies(location string, environ
 		context.TODO(),
 		mu.MAPipeline(
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			FilterByOldnessSteps(olderThan),
 			mu.APGroup(bson.M{
 				"_id": 1,
@@ -36,28 +36,28 @@ func (m *MongodbSuite) TestGetHostsCountUsingTechnologies() {
 	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_22.json"))
 
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
-		out, err := m.db.GetHostsCountUsingTechnologies("Foobarland", "", utils.MAX_TIME)
+		out, err := m.db.GetHostsCountUsingTechnologies("Foobarland", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = map[string]interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
-		out, err := m.db.GetHostsCountUsingTechnologies("", "FOOBAR", utils.MAX_TIME)
+		out, err := m.db.GetHostsCountUsingTechnologies("", "FOOBAR", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = map[string]interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
-		out, err := m.db.GetHostsCountUsingTechnologies("", "", utils.MIN_TIME)
+		out, err := m.db.GetHostsCountUsingTechnologies("", "", "", utils.MIN_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = map[string]interface{}{}
 
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 	m.T().Run("should_return_correct_res", func(t *testing.T) {
-		out, err := m.db.GetHostsCountUsingTechnologies("", "", utils.MAX_TIME)
+		out, err := m.db.GetHostsCountUsingTechnologies("", "", "", utils.MAX_TIME)
 		m.Require().NoError(err)
 		var expectedOut interface{} = map[string]interface{}{
 			model.TechnologyOracleExadata:        1,
@@ -40,6 +40,7 @@ type Cluster struct {
 	VMs                         []VM                      `json:"vms" bson:"vms"`
 	VMsCount                    int                       `json:"vmsCount" bson:"vmsCount"`
 	VMsErcoleAgentCount         int                       `json:"vmsErcoleAgentCount" bson:"vmsErcoleAgentCount"`
+	LicensingPolicy             string                    `json:"licensingPolicy" bson:"-"`
 }
 
 type VirtualizationNodesStat struct {
@@ -51,6 +52,7 @@ type VirtualizationNodesStat struct {
 
 type VM struct {
 	CappedCPU               bool   `json:"cappedCPU" bson:"cappedCPU"`
+	PinnedCPUs              int    `json:"pinnedCPUs,omitempty" bson:"pinnedCPUs,omitempty"`
 	Hostname                string `json:"hostname" bson:"hostname"`
 	Name                    string `json:"name" bson:"name"`
 	VirtualizationNode      string `json:"virtualizationNode" bson:"virtualizationNode"`
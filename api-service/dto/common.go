@@ -26,6 +26,7 @@ type GlobalFilter struct {
 	Location    string
 	Environment string
 	OlderThan   time.Time
+	Site        string
 }
 
 func GetGlobalFilter(r *http.Request) (f *GlobalFilter, err error) {
@@ -33,8 +34,9 @@ func GetGlobalFilter(r *http.Request) (f *GlobalFilter, err error) {
 
 	f.Location = r.URL.Query().Get("location")
 	f.Environment = r.URL.Query().Get("environment")
+	f.Site = r.URL.Query().Get("site")
 
-	if f.OlderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if f.OlderThan, err = utils.GetOlderThanParam(r); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+const (
+	// ContractsImportModeInsert insert every row as a new contract
+	ContractsImportModeInsert = "insert"
+	// ContractsImportModeUpsert update the contracts with the same ContractID and CSI, insert the others
+	ContractsImportModeUpsert = "upsert"
+)
+
+const (
+	ContractsImportActionInsert = "insert"
+	ContractsImportActionUpdate = "update"
+)
+
+// ContractsImportOptions contains the options of a contracts import
+type ContractsImportOptions struct {
+	DryRun bool
+	Mode   string
+}
+
+// ContractsImportReport contains the outcome of a contracts import
+type ContractsImportReport struct {
+	DryRun   bool                 `json:"dryRun"`
+	Mode     string               `json:"mode"`
+	Valid    bool                 `json:"valid"`
+	Inserted int                  `json:"inserted"`
+	Updated  int                  `json:"updated"`
+	Rows     []ContractsImportRow `json:"rows"`
+}
+
+// ContractsImportRow contains the validation outcome of a single row of the imported file
+type ContractsImportRow struct {
+	Row           int      `json:"row"`
+	ContractID    string   `json:"contractID"`
+	CSI           string   `json:"csi"`
+	LicenseTypeID string   `json:"licenseTypeID"`
+	Action        string   `json:"action"`
+	Errors        []string `json:"errors"`
+}
@@ -37,18 +37,19 @@ type DatabasesStatistics struct {
 }
 
 type DatabaseUsedLicense struct {
-	Hostname        string  `json:"hostname" bson:"hostname"`
-	DbName          string  `json:"dbName" bson:"dbName"`
-	ClusterName     string  `json:"clusterName" bson:"clusterName"`
-	ClusterType     string  `json:"clusterType" bson:"clusterType"`
-	LicenseTypeID   string  `json:"licenseTypeID" bson:"licenseTypeID"`
-	Description     string  `json:"description" bson:"description"`
-	Metric          string  `json:"metric" bson:"metric"`
-	UsedLicenses    float64 `json:"usedLicenses" bson:"usedLicenses"`
-	ClusterLicenses float64 `json:"clusterLicenses" bson:"clusterLicenses"`
-	Ignored         bool    `json:"ignored" bson:"ignored"`
-	IgnoredComment  string  `json:"ignoredComment" bson:"ignoredComment"`
-	OlvmCapped      bool    `json:"olvmCapped" bson:"olvmCapped"`
+	Hostname               string  `json:"hostname" bson:"hostname"`
+	DbName                 string  `json:"dbName" bson:"dbName"`
+	ClusterName            string  `json:"clusterName" bson:"clusterName"`
+	ClusterType            string  `json:"clusterType" bson:"clusterType"`
+	LicenseTypeID          string  `json:"licenseTypeID" bson:"licenseTypeID"`
+	Description            string  `json:"description" bson:"description"`
+	Metric                 string  `json:"metric" bson:"metric"`
+	UsedLicenses           float64 `json:"usedLicenses" bson:"usedLicenses"`
+	ClusterLicenses        float64 `json:"clusterLicenses" bson:"clusterLicenses"`
+	Ignored                bool    `json:"ignored" bson:"ignored"`
+	IgnoredComment         string  `json:"ignoredComment" bson:"ignoredComment"`
+	OlvmCapped             bool    `json:"olvmCapped" bson:"olvmCapped"`
+	ClusterLicensingPolicy string  `json:"clusterLicensingPolicy,omitempty" bson:"clusterLicensingPolicy,omitempty"`
 }
 
 type DatabaseUsedLicensePerHost struct {
@@ -63,10 +64,22 @@ type DatabaseUsedLicensePerHost struct {
 }
 
 type DatabaseUsedLicensePerCluster struct {
-	Cluster       string   `json:"cluster"`
-	Hostnames     []string `json:"hostnames"`
-	LicenseTypeID string   `json:"licenseTypeID"`
-	Description   string   `json:"description"`
-	Metric        string   `json:"metric"`
-	UsedLicenses  float64  `json:"usedLicenses"`
+	Cluster         string   `json:"cluster"`
+	LicensingPolicy string   `json:"licensingPolicy"`
+	Hostnames       []string `json:"hostnames"`
+	LicenseTypeID   string   `json:"licenseTypeID"`
+	Description     string   `json:"description"`
+	Metric          string   `json:"metric"`
+	UsedLicenses    float64  `json:"usedLicenses"`
+}
+
+type DatabaseUsedLicenseExplanation struct {
+	DatabaseUsedLicense
+	Steps []DatabaseUsedLicenseExplanationStep `json:"steps" bson:"steps"`
+}
+
+type DatabaseUsedLicenseExplanationStep struct {
+	Name        string  `json:"name" bson:"name"`
+	Description string  `json:"description" bson:"description"`
+	Value       float64 `json:"value" bson:"value"`
 }
@@ -0,0 +1,36 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package dto
+
+// HostLicensingInfo contains the informations of a host needed to compute its operating system licenses
+type HostLicensingInfo struct {
+	Hostname            string `json:"hostname" bson:"hostname"`
+	Location            string `json:"location" bson:"location"`
+	Environment         string `json:"environment" bson:"environment"`
+	OS                  string `json:"os" bson:"os"`
+	HardwareAbstraction string `json:"hardwareAbstraction" bson:"hardwareAbstraction"`
+	CPUCores            int    `json:"cpuCores" bson:"cpuCores"`
+	CPUSockets          int    `json:"cpuSockets" bson:"cpuSockets"`
+}
+
+// HostUsedLicense contains the licenses of an operating system or virtualization platform used by a host or a cluster
+type HostUsedLicense struct {
+	LicenseTypeID   string  `json:"licenseTypeID"`
+	ItemDescription string  `json:"itemDescription"`
+	Metric          string  `json:"metric"`
+	Hostname        string  `json:"hostname,omitempty"`
+	ClusterName     string  `json:"clusterName,omitempty"`
+	UsedLicenses    float64 `json:"usedLicenses"`
+}
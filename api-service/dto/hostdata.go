@@ -23,7 +23,7 @@ import (
 )
 
 // ServerSchemaVersion contains the version of the schema
-const ServerSchemaVersion int = 1
+const ServerSchemaVersion int = model.ServerSchemaVersion
 
 // HostData holds all informations about a host & services
 type HostData struct {
@@ -34,8 +34,10 @@ type HostData struct {
 	SchemaVersion           int                           `json:"schemaVersion" bson:"schemaVersion"`
 	ServerSchemaVersion     int                           `json:"serverSchemaVersion" bson:"serverSchemaVersion"`
 	Hostname                string                        `json:"hostname" bson:"hostname"`
+	HostID                  string                        `json:"hostID,omitempty" bson:"hostID,omitempty"`
 	Location                string                        `json:"location" bson:"location"`
 	Environment             string                        `json:"environment" bson:"environment"`
+	Site                    string                        `json:"site,omitempty" bson:"site,omitempty"`
 	AgentVersion            string                        `json:"agentVersion" bson:"agentVersion"`
 	Cluster                 string                        `json:"cluster" bson:"cluster"`
 	VirtualizationNode      string                        `json:"virtualizationNode" bson:"virtualizationNode"`
@@ -47,6 +49,7 @@ type HostData struct {
 	Filesystems             []model.Filesystem            `json:"filesystems" bson:"filesystems"`
 	Clusters                []model.ClusterInfo           `json:"clusters" bson:"clusters"`
 	Cloud                   model.Cloud                   `json:"cloud" bson:"cloud"`
+	CloudLicensing          *model.CloudLicensing         `json:"cloudLicensing,omitempty" bson:"-"`
 	Errors                  []model.AgentError            `json:"errors" bson:"errors"`
 	OtherInfo               map[string]interface{}        `json:"-" bson:"-"`
 	Alerts                  []model.Alert                 `json:"alerts" bson:"alerts"`
@@ -30,6 +30,7 @@ type SearchHostsFilters struct {
 	SortDesc    bool
 	Location    string
 	Environment string
+	Site        string
 	OlderThan   time.Time
 	PageNumber  int
 	PageSize    int
@@ -101,8 +102,9 @@ func GetSearchHostFilters(r *http.Request) (*SearchHostsFilters, error) {
 
 	f.Location = r.URL.Query().Get("location")
 	f.Environment = r.URL.Query().Get("environment")
+	f.Site = r.URL.Query().Get("site")
 
-	if f.OlderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if f.OlderThan, err = utils.GetOlderThanParam(r); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,38 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// OracleLMSImportReport contains the outcome of the import of a reviewed Oracle LMS workbook
+type OracleLMSImportReport struct {
+	ImportID      primitive.ObjectID       `json:"importID"`
+	Changes       []model.LMSPendingChange `json:"changes"`
+	UnmatchedRows []OracleLMSUnmatchedRow  `json:"unmatchedRows"`
+}
+
+// OracleLMSUnmatchedRow contains a row of the imported workbook which doesn't match any host or database
+type OracleLMSUnmatchedRow struct {
+	Row                int    `json:"row"`
+	PhysicalServerName string `json:"physicalServerName"`
+	VirtualServerName  string `json:"virtualServerName"`
+	DbInstanceName     string `json:"dbInstanceName"`
+	Reason             string `json:"reason"`
+}
@@ -28,7 +28,10 @@ type SqlServerDatabaseUsedLicense struct {
 	Hostname       string  `json:"hostname" bson:"hostname"`
 	Clustername    string  `json:"clustername" bson:"clustername"`
 	UsedLicenses   float64 `json:"usedLicenses" bson:"usedLicenses"`
+	Passive        bool    `json:"passive" bson:"passive"`
 	Ignored        bool    `json:"ignored" bson:"ignored"`
 	IgnoredComment string  `json:"ignoredComment" bson:"ignoredComment"`
 	ContractType   string  `json:"contractType" bson:"contractType"`
+	// Databases contains the names of the databases of the instance, used to find the primary of a passive secondary
+	Databases []string `json:"-" bson:"databases"`
 }
@@ -116,3 +116,31 @@ type HostUsingOracleDatabaseLicenses struct {
 	// If LicenseType Metric is Named User Plus Perpetual, value isn't PerUser (must be multiplied *25)
 	OriginalCount float64 `json:"originalCount" bson:"originalCount"`
 }
+
+// OracleDatabaseContractsAssignmentComparison contains the result of the greedy and of the optimized
+// assignment of the Oracle/Database contracts to the hosts
+type OracleDatabaseContractsAssignmentComparison struct {
+	// Mode is the assignment algorithm selected in the configuration
+	Mode      string                                  `json:"mode"`
+	Greedy    OracleDatabaseContractsAssignmentResult `json:"greedy"`
+	Optimized OracleDatabaseContractsAssignmentResult `json:"optimized"`
+}
+
+// OracleDatabaseContractsAssignmentResult contains the result of an assignment of the Oracle/Database contracts to the hosts
+type OracleDatabaseContractsAssignmentResult struct {
+	CoveredLicenses   float64                            `json:"coveredLicenses"`
+	UncoveredLicenses float64                            `json:"uncoveredLicenses"`
+	Contracts         []OracleDatabaseContractAssignment `json:"contracts"`
+	UncoveredHosts    []HostUsingOracleDatabaseLicenses  `json:"uncoveredHosts"`
+}
+
+// OracleDatabaseContractAssignment contains the licenses assigned by a contract
+type OracleDatabaseContractAssignment struct {
+	ID              primitive.ObjectID                       `json:"id"`
+	ContractID      string                                   `json:"contractID"`
+	LicenseTypeID   string                                   `json:"licenseTypeID"`
+	Basket          bool                                     `json:"basket"`
+	Restricted      bool                                     `json:"restricted"`
+	CoveredLicenses float64                                  `json:"coveredLicenses"`
+	Hosts           []OracleDatabaseContractAssociatedHostFE `json:"hosts"`
+}
@@ -19,5 +19,7 @@ import "github.com/ercole-io/ercole/v2/model"
 
 type OracleDatabasePluggableDatabase struct {
 	Hostname                              string `json:"hostname" bson:"hostname"`
+	DbName                                string `json:"dbName" bson:"dbName"`
+	PDBCount                              int    `json:"pdbCount" bson:"pdbCount"`
 	model.OracleDatabasePluggableDatabase `json:"pdb" bson:"pdb"`
 }
@@ -0,0 +1,35 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "github.com/ercole-io/ercole/v2/model"
+
+// OracleFeatureUsageLedgerFilter contains the filters of the Oracle feature usage ledger
+type OracleFeatureUsageLedgerFilter struct {
+	Hostname    string
+	DbName      string
+	Product     string
+	Location    string
+	Environment string
+	Site        string
+}
+
+// OracleFeatureUsageLedgerEntry is a ledger entry with the location and environment of its host
+type OracleFeatureUsageLedgerEntry struct {
+	model.OracleFeatureUsageLedgerEntry `bson:",inline"`
+	Location                            string `json:"location" bson:"location"`
+	Environment                         string `json:"environment" bson:"environment"`
+}
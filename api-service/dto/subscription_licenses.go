@@ -0,0 +1,28 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package dto
+
+// SubscriptionUsedLicense is the subscription used by an instance of a technology licensed by subscription,
+// like PostgreSQL and MongoDB
+type SubscriptionUsedLicense struct {
+	LicenseTypeID string  `json:"licenseTypeID" bson:"licenseTypeID"`
+	Hostname      string  `json:"hostname" bson:"hostname"`
+	InstanceName  string  `json:"instanceName" bson:"instanceName"`
+	Version       string  `json:"version" bson:"version"`
+	Edition       string  `json:"edition,omitempty" bson:"edition,omitempty"`
+	CPUCores      int     `json:"cpuCores" bson:"cpuCores"`
+	UsedLicenses  float64 `json:"usedLicenses" bson:"usedLicenses"`
+	ContractType  string  `json:"contractType" bson:"contractType"`
+}
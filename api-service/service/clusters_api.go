@@ -32,7 +32,14 @@ import (
 
 // SearchClusters search clusters
 func (as *APIService) SearchClusters(mode string, search string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) ([]dto.Cluster, error) {
-	return as.Database.SearchClusters(mode, strings.Split(search, " "), sortBy, sortDesc, page, pageSize, location, environment, olderThan)
+	clusters, err := as.Database.SearchClusters(mode, strings.Split(search, " "), sortBy, sortDesc, page, pageSize, location, environment, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	as.setClustersLicensingPolicy(clusters)
+
+	return clusters, nil
 }
 
 // GetCluster return the cluster specified in the clusterName param
@@ -42,6 +49,8 @@ func (as *APIService) GetCluster(clusterName string, olderThan time.Time) (*dto.
 		return nil, err
 	}
 
+	cluster.LicensingPolicy = as.clusterLicensingPolicy(*cluster)
+
 	var errEH error
 
 	for i, vm := range cluster.VMs {
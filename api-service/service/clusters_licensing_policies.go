@@ -0,0 +1,180 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// clusterLicensingPolicy return the licensing policy applied to the cluster.
+// Policies configured by cluster name take precedence over the ones configured by location
+func (as *APIService) clusterLicensingPolicy(cluster dto.Cluster) string {
+	policies := as.Config.APIService.ClusterLicensingPolicies
+
+	for _, p := range policies {
+		if utils.Contains(p.Clusters, cluster.Name) && model.IsValidClusterLicensingPolicy(p.Policy) {
+			return p.Policy
+		}
+	}
+
+	for _, p := range policies {
+		if utils.Contains(p.Locations, cluster.Location) && model.IsValidClusterLicensingPolicy(p.Policy) {
+			return p.Policy
+		}
+	}
+
+	if model.IsValidClusterLicensingPolicy(as.Config.APIService.DefaultClusterLicensingPolicy) {
+		return as.Config.APIService.DefaultClusterLicensingPolicy
+	}
+
+	return model.ClusterLicensingPolicySoftPartitioning
+}
+
+// setClustersLicensingPolicy set the licensing policy of each cluster
+func (as *APIService) setClustersLicensingPolicy(clusters []dto.Cluster) {
+	for i := range clusters {
+		clusters[i].LicensingPolicy = as.clusterLicensingPolicy(clusters[i])
+	}
+}
+
+// vCenterClusters return the clusters managed by the same vCenter of cluster, cluster included
+func vCenterClusters(cluster dto.Cluster, clusters []dto.Cluster) []dto.Cluster {
+	if cluster.FetchEndpoint == "" {
+		return []dto.Cluster{cluster}
+	}
+
+	res := make([]dto.Cluster, 0)
+
+	for _, c := range clusters {
+		if c.FetchEndpoint == cluster.FetchEndpoint && c.Type == cluster.Type {
+			res = append(res, c)
+		}
+	}
+
+	if len(res) == 0 {
+		res = append(res, cluster)
+	}
+
+	return res
+}
+
+// vmLicensingPolicy return the policy with which a VM of the cluster is licensed:
+// a VM without pinned or capped vCPUs isn't a hard partition, so it's licensed like a soft partition
+func vmLicensingPolicy(cluster dto.Cluster, host *model.HostDataBE) string {
+	if cluster.LicensingPolicy == model.ClusterLicensingPolicyHardPartitioning {
+		if _, ok := pinnedCores(cluster, host); !ok {
+			return model.ClusterLicensingPolicySoftPartitioning
+		}
+	}
+
+	return cluster.LicensingPolicy
+}
+
+// clusterLicensingCores return the cores which have to be licensed by a VM of the cluster, according to its policy.
+// A VM without pinned or capped vCPUs isn't a hard partition, so it licenses the whole cluster
+func clusterLicensingCores(cluster dto.Cluster, clusters []dto.Cluster, host *model.HostDataBE) float64 {
+	switch cluster.LicensingPolicy {
+	case model.ClusterLicensingPolicyHardPartitioning:
+		if cores, ok := pinnedCores(cluster, host); ok {
+			return cores
+		}
+	case model.ClusterLicensingPolicyVCenter:
+		var cores int
+		for _, c := range vCenterClusters(cluster, clusters) {
+			cores += c.CPU
+		}
+
+		return float64(cores)
+	}
+
+	return float64(cluster.CPU)
+}
+
+// clusterLicensingSE2Sockets return the sockets which have to be licensed by a Standard Edition database
+// running on a VM of the cluster, according to its policy
+func clusterLicensingSE2Sockets(cluster dto.Cluster, clusters []dto.Cluster, host *model.HostDataBE) float64 {
+	switch cluster.LicensingPolicy {
+	case model.ClusterLicensingPolicyHardPartitioning:
+		if _, ok := pinnedCores(cluster, host); ok {
+			return host.SE2Sockets()
+		}
+	case model.ClusterLicensingPolicyVCenter:
+		var sockets int
+		for _, c := range vCenterClusters(cluster, clusters) {
+			sockets += c.Sockets
+		}
+
+		return float64(sockets)
+	}
+
+	return float64(cluster.Sockets)
+}
+
+// clusterLicensingSE2Servers return the physical servers which have to be licensed by a Standard Edition database
+// running on a VM of the cluster, according to its policy
+func clusterLicensingSE2Servers(cluster dto.Cluster, clusters []dto.Cluster, host *model.HostDataBE) float64 {
+	switch cluster.LicensingPolicy {
+	case model.ClusterLicensingPolicyHardPartitioning:
+		if _, ok := pinnedCores(cluster, host); ok {
+			return 1
+		}
+	case model.ClusterLicensingPolicyVCenter:
+		var servers float64
+		for _, c := range vCenterClusters(cluster, clusters) {
+			servers += clusterServers(c)
+		}
+
+		return servers
+	}
+
+	return clusterServers(cluster)
+}
+
+// clusterServers return the physical servers of the cluster, at least one
+func clusterServers(cluster dto.Cluster) float64 {
+	if cluster.VirtualizationNodesCount < 1 {
+		return 1
+	}
+
+	return float64(cluster.VirtualizationNodesCount)
+}
+
+// pinnedCores return the cores of the vCPUs pinned or capped to the VM of the host,
+// converted with the threads per core of the host. It return false if the VM hasn't them
+func pinnedCores(cluster dto.Cluster, host *model.HostDataBE) (float64, bool) {
+	if host == nil {
+		return 0, false
+	}
+
+	for _, vm := range cluster.VMs {
+		if !strings.EqualFold(vm.Hostname, host.Hostname) || !vm.CappedCPU || vm.PinnedCPUs <= 0 {
+			continue
+		}
+
+		if host.Info.CPUCores > 0 && host.Info.CPUThreads > host.Info.CPUCores {
+			return math.Ceil(float64(vm.PinnedCPUs*host.Info.CPUCores) / float64(host.Info.CPUThreads)), true
+		}
+
+		return float64(vm.PinnedCPUs), true
+	}
+
+	return 0, false
+}
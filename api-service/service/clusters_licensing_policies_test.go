@@ -0,0 +1,124 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func TestClusterLicensingPolicy(t *testing.T) {
+	as := APIService{
+		Config: config.Configuration{
+			APIService: config.APIService{
+				DefaultClusterLicensingPolicy: model.ClusterLicensingPolicyVCenter,
+				ClusterLicensingPolicies: []config.ClusterLicensingPolicy{
+					{
+						Policy:    model.ClusterLicensingPolicySoftPartitioning,
+						Locations: []string{"Italy"},
+					},
+					{
+						Policy:   model.ClusterLicensingPolicyHardPartitioning,
+						Clusters: []string{"olvm01"},
+					},
+					{
+						Policy:    "Unknown",
+						Locations: []string{"Germany"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, model.ClusterLicensingPolicyHardPartitioning, as.clusterLicensingPolicy(dto.Cluster{Name: "olvm01", Location: "Italy"}))
+	assert.Equal(t, model.ClusterLicensingPolicySoftPartitioning, as.clusterLicensingPolicy(dto.Cluster{Name: "vmw01", Location: "Italy"}))
+	assert.Equal(t, model.ClusterLicensingPolicyVCenter, as.clusterLicensingPolicy(dto.Cluster{Name: "vmw02", Location: "Germany"}))
+
+	as.Config.APIService.DefaultClusterLicensingPolicy = ""
+	assert.Equal(t, model.ClusterLicensingPolicySoftPartitioning, as.clusterLicensingPolicy(dto.Cluster{Name: "vmw02", Location: "Germany"}))
+}
+
+func TestClusterLicensingCores(t *testing.T) {
+	clusters := []dto.Cluster{
+		{Name: "vmw01", Type: "vmware", FetchEndpoint: "vcenter01", CPU: 32, Sockets: 4, VirtualizationNodesCount: 2},
+		{Name: "vmw02", Type: "vmware", FetchEndpoint: "vcenter01", CPU: 64, Sockets: 8, VirtualizationNodesCount: 4},
+		{Name: "vmw03", Type: "vmware", FetchEndpoint: "vcenter02", CPU: 16, Sockets: 2, VirtualizationNodesCount: 1},
+	}
+
+	host := &model.HostDataBE{
+		Info: model.Host{
+			CPUCores:   4,
+			CPUSockets: 1,
+		},
+	}
+
+	testCases := []struct {
+		policy          string
+		expectedCores   float64
+		expectedSockets float64
+		expectedServers float64
+	}{
+		{policy: model.ClusterLicensingPolicySoftPartitioning, expectedCores: 32, expectedSockets: 4, expectedServers: 2},
+		{policy: model.ClusterLicensingPolicyHardPartitioning, expectedCores: 32, expectedSockets: 4, expectedServers: 2},
+		{policy: model.ClusterLicensingPolicyVCenter, expectedCores: 96, expectedSockets: 12, expectedServers: 6},
+	}
+
+	for _, tc := range testCases {
+		cluster := clusters[0]
+		cluster.LicensingPolicy = tc.policy
+
+		assert.Equal(t, tc.expectedCores, clusterLicensingCores(cluster, clusters, host), tc.policy)
+		assert.Equal(t, tc.expectedSockets, clusterLicensingSE2Sockets(cluster, clusters, host), tc.policy)
+		assert.Equal(t, tc.expectedServers, clusterLicensingSE2Servers(cluster, clusters, host), tc.policy)
+	}
+}
+
+func TestClusterLicensingCores_HardPartitioningPinnedCPUs(t *testing.T) {
+	host := &model.HostDataBE{
+		Hostname: "olvm-vm01",
+		Info: model.Host{
+			CPUCores:   16,
+			CPUThreads: 32,
+		},
+	}
+
+	cluster := dto.Cluster{
+		Name:            "olvm01",
+		CPU:             64,
+		LicensingPolicy: model.ClusterLicensingPolicyHardPartitioning,
+		VMs: []dto.VM{
+			{Hostname: "olvm-vm01", CappedCPU: true, PinnedCPUs: 8},
+			{Hostname: "olvm-vm02", CappedCPU: true, PinnedCPUs: 4},
+		},
+	}
+
+	assert.Equal(t, float64(4), clusterLicensingCores(cluster, nil, host))
+	assert.Equal(t, model.ClusterLicensingPolicyHardPartitioning, vmLicensingPolicy(cluster, host))
+
+	// the VMs without pinned or capped vCPUs aren't hard partitions
+	cluster.VMs[0].CappedCPU = false
+	assert.Equal(t, float64(64), clusterLicensingCores(cluster, nil, host))
+	assert.Equal(t, model.ClusterLicensingPolicySoftPartitioning, vmLicensingPolicy(cluster, host))
+
+	cluster.VMs[0] = dto.VM{Hostname: "olvm-vm01", CappedCPU: true}
+	assert.Equal(t, float64(64), clusterLicensingCores(cluster, nil, host))
+	assert.Equal(t, model.ClusterLicensingPolicySoftPartitioning, vmLicensingPolicy(cluster, host))
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package service
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+// subscriptionContract is a contract of a technology licensed by subscription, like PostgreSQL and MongoDB
+type subscriptionContract interface {
+	model.PostgreSQLContract | model.MongoDBContract
+}
+
+// subscriptionUsedLicense is the subscription used by an instance of a technology licensed by subscription
+type subscriptionUsedLicense interface {
+	dto.PostgreSQLUsedLicense | dto.MongoDBUsedLicense
+}
+
+// addSubscriptionContract assign a new ID to the contract and add it with the add function
+func addSubscriptionContract[T subscriptionContract](as *APIService, contract T, add func(T) error) (*T, error) {
+	c := model.SubscriptionContract(contract)
+	c.ID = as.NewObjectID()
+	contract = T(c)
+
+	if err := add(contract); err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+// updateSubscriptionContract update the contract with the update function
+func updateSubscriptionContract[T subscriptionContract](contract T, update func(T) error) (*T, error) {
+	if err := update(contract); err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+// subscriptionContractsAsXLSX return the contracts as XLSX, with a row for each host associated to them
+func subscriptionContractsAsXLSX[T subscriptionContract](as *APIService, contracts []T) (*excelize.File, error) {
+	sheet := "Contracts"
+	headers := []string{
+		"Type",
+		"Contract Number",
+		"CSI",
+		"Support Expiration",
+		"License Type",
+		"Number of licenses",
+		"Host",
+	}
+
+	sheets, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+
+	for _, contract := range contracts {
+		val := model.SubscriptionContract(contract)
+
+		nextAxis := axisHelp.NewRow()
+		sheets.SetCellValue(sheet, nextAxis(), val.Type)
+		sheets.SetCellValue(sheet, nextAxis(), val.ContractID)
+		sheets.SetCellValue(sheet, nextAxis(), val.CSI)
+
+		if val.SupportExpiration != nil {
+			sheets.SetCellValue(sheet, nextAxis(), val.SupportExpiration)
+		} else {
+			sheets.SetCellValue(sheet, nextAxis(), "")
+		}
+
+		sheets.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		sheets.SetCellValue(sheet, nextAxis(), val.NumberOfLicenses)
+
+		for _, val2 := range val.Hosts {
+			sheets.DuplicateRow(sheet, axisHelp.GetIndexRow())
+			duplicateRowNextAxis := axisHelp.NewRowSincePreviousColumn()
+
+			sheets.SetCellValue(sheet, duplicateRowNextAxis(), val2)
+		}
+	}
+
+	return sheets, err
+}
+
+// subscriptionUsedLicenses return the subscriptions used by the instances under subscription. An instance is counted
+// per instance if its host is associated to a per instance contract, otherwise all the cores of its host are counted
+func subscriptionUsedLicenses[T subscriptionUsedLicense, C subscriptionContract](licenseTypeID string,
+	instances []T, contracts []C, underSubscription func(dto.SubscriptionUsedLicense) bool) []T {
+	perInstanceHosts := make(map[string]bool)
+
+	for _, c := range contracts {
+		contract := model.SubscriptionContract(c)
+		if contract.Type != model.SubscriptionContractTypeInstance {
+			continue
+		}
+
+		for _, hostname := range contract.Hosts {
+			perInstanceHosts[hostname] = true
+		}
+	}
+
+	usedLicenses := make([]T, 0, len(instances))
+
+	for _, i := range instances {
+		instance := dto.SubscriptionUsedLicense(i)
+		if !underSubscription(instance) {
+			continue
+		}
+
+		instance.LicenseTypeID = licenseTypeID
+
+		if perInstanceHosts[instance.Hostname] {
+			instance.ContractType = model.SubscriptionContractTypeInstance
+			instance.UsedLicenses = 1
+		} else {
+			instance.ContractType = model.SubscriptionContractTypeCore
+			instance.UsedLicenses = float64(instance.CPUCores)
+		}
+
+		usedLicenses = append(usedLicenses, T(instance))
+	}
+
+	return usedLicenses
+}
+
+// subscriptionDatabaseUsedLicenses convert the subscriptions used by the instances to the generic database used licenses
+func subscriptionDatabaseUsedLicenses[T subscriptionUsedLicense](itemDescription string, lics []T) []dto.DatabaseUsedLicense {
+	genericLics := make([]dto.DatabaseUsedLicense, 0, len(lics))
+
+	for _, l := range lics {
+		lic := dto.SubscriptionUsedLicense(l)
+
+		g := dto.DatabaseUsedLicense{
+			Hostname:      lic.Hostname,
+			DbName:        lic.InstanceName,
+			LicenseTypeID: lic.LicenseTypeID,
+			Description:   itemDescription,
+			Metric:        lic.ContractType,
+			UsedLicenses:  lic.UsedLicenses,
+		}
+
+		genericLics = append(genericLics, g)
+	}
+
+	return genericLics
+}
+
+// subscriptionsCompliance compute the compliance of a database subscription for each metric.
+// Per core subscriptions are consumed once per host, whatever the number of instances running on it,
+// while per instance subscriptions are consumed by every instance.
+// Metrics neither consumed nor purchased are omitted
+func subscriptionsCompliance[T subscriptionUsedLicense, C subscriptionContract](licenseTypeID, itemDescription string,
+	usedLicenses []T, contracts []C) []dto.LicenseCompliance {
+	result := make([]dto.LicenseCompliance, 0, 2)
+
+	for _, metric := range []string{model.SubscriptionContractTypeCore, model.SubscriptionContractTypeInstance} {
+		license := dto.LicenseCompliance{
+			LicenseTypeID:   licenseTypeID,
+			ItemDescription: itemDescription,
+			Metric:          metric,
+		}
+
+		countedHosts := make(map[string]bool)
+
+		for _, l := range usedLicenses {
+			usedLicense := dto.SubscriptionUsedLicense(l)
+			if usedLicense.ContractType != metric {
+				continue
+			}
+
+			if metric == model.SubscriptionContractTypeCore {
+				if countedHosts[usedLicense.Hostname] {
+					continue
+				}
+
+				countedHosts[usedLicense.Hostname] = true
+			}
+
+			license.Consumed += usedLicense.UsedLicenses
+		}
+
+		for _, c := range contracts {
+			contract := model.SubscriptionContract(c)
+			if contract.LicenseTypeID == licenseTypeID && contract.Type == metric {
+				license.Purchased += float64(contract.NumberOfLicenses)
+			}
+		}
+
+		if license.Consumed == 0 && license.Purchased == 0 {
+			continue
+		}
+
+		computeLicenseCompliance(&license)
+
+		result = append(result, license)
+	}
+
+	return result
+}
+
+// computeLicenseCompliance fill the covered, available and compliance values of license from its consumed and purchased licenses
+func computeLicenseCompliance(license *dto.LicenseCompliance) {
+	if license.Purchased >= license.Consumed {
+		license.Covered = license.Consumed
+	} else {
+		license.Covered = license.Purchased
+	}
+
+	license.Available = license.Purchased - license.Covered
+
+	if license.Consumed == 0 {
+		license.Compliance = 1
+	} else {
+		license.Compliance = license.Covered / license.Consumed
+	}
+}
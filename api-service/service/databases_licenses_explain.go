@@ -0,0 +1,227 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+const (
+	explanationStepHost            = "host"
+	explanationStepCoreFactor      = "coreFactor"
+	explanationStepAgent           = "agent"
+	explanationStepStandby         = "standby"
+	explanationStepRac             = "rac"
+	explanationStepDependencies    = "dependencies"
+	explanationStepMetric          = "metric"
+	explanationStepStandardEdition = "standardEdition"
+	explanationStepCluster         = "cluster"
+	explanationStepIgnored         = "ignored"
+	explanationStepContract        = "contract"
+	explanationStepUncovered       = "uncovered"
+)
+
+// ExplainUsedLicensesPerDatabases return, for each license used by the oracle databases of the host,
+// the steps followed to compute the licenses count and the contracts which cover them.
+// The contracts are assigned to the hosts by the licenses used at the same point in time
+func (as *APIService) ExplainUsedLicensesPerDatabases(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicenseExplanation, error) {
+	host, err := as.GetHost(hostname, filter.OlderThan, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usedLicenses, err := as.getOracleDatabasesUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := as.getOracleDatabaseContractsAt(filter.OlderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	explanations := make([]dto.DatabaseUsedLicenseExplanation, 0)
+
+	if host.Features.Oracle == nil || host.Features.Oracle.Database == nil {
+		return explanations, nil
+	}
+
+	for _, db := range host.Features.Oracle.Database.Databases {
+		for _, license := range db.Licenses {
+			if license.Count <= 0 {
+				continue
+			}
+
+			explanations = append(explanations, as.explainUsedLicense(host, db, license, usedLicenses, contracts))
+		}
+	}
+
+	return explanations, nil
+}
+
+func (as *APIService) explainUsedLicense(host *dto.HostData, db model.OracleDatabase, license model.OracleDatabaseLicense,
+	usedLicenses []dto.DatabaseUsedLicense, contracts []dto.OracleDatabaseContractFE) dto.DatabaseUsedLicenseExplanation {
+	explanation := dto.DatabaseUsedLicenseExplanation{
+		DatabaseUsedLicense: dto.DatabaseUsedLicense{
+			Hostname:       host.Hostname,
+			DbName:         db.Name,
+			LicenseTypeID:  license.LicenseTypeID,
+			Ignored:        license.Ignored,
+			IgnoredComment: license.IgnoredComment,
+		},
+		Steps: make([]dto.DatabaseUsedLicenseExplanationStep, 0),
+	}
+
+	addStep := func(name string, value float64, format string, a ...interface{}) {
+		explanation.Steps = append(explanation.Steps, dto.DatabaseUsedLicenseExplanationStep{
+			Name:        name,
+			Description: fmt.Sprintf(format, a...),
+			Value:       value,
+		})
+	}
+
+	addStep(explanationStepHost, float64(host.Info.CPUCores),
+		"The host has %d cores, %d sockets and %d threads, hardware abstraction technology %q",
+		host.Info.CPUCores, host.Info.CPUSockets, host.Info.CPUThreads, host.Info.HardwareAbstractionTechnology)
+
+	if host.CloudLicensing != nil {
+		addStep(explanationStepCoreFactor, host.CloudLicensing.ProcessorLicenses, "%s", host.CloudLicensing.Explanation)
+	} else if coreFactor, err := db.CoreFactor(host.Info, 0.5); err == nil {
+		addStep(explanationStepCoreFactor, coreFactor, "Edition %s: core factor %v", db.Edition(), coreFactor)
+	} else {
+		addStep(explanationStepCoreFactor, 0, "Can't compute core factor: %s", err)
+	}
+
+	addStep(explanationStepAgent, license.Count, "The agent reported %v licenses named %q", license.Count, license.Name)
+
+	if db.Role != "" && db.Role != model.OracleDatabaseRolePrimary {
+		addStep(explanationStepStandby, license.Count,
+			"Database role is %s: licenses are copied from the primary database and computed as cores * core factor", db.Role)
+	}
+
+	if license.IsRAC() && db.Edition() == model.OracleDatabaseEditionStandard {
+		addStep(explanationStepRac, 0, "RAC license is ignored on Standard Edition databases")
+	}
+
+	var usedLicense *dto.DatabaseUsedLicense
+
+	for i := range usedLicenses {
+		if usedLicenses[i].DbName == db.Name && usedLicenses[i].LicenseTypeID == license.LicenseTypeID {
+			usedLicense = &usedLicenses[i]
+			break
+		}
+	}
+
+	if usedLicense == nil {
+		addStep(explanationStepDependencies, 0,
+			"License %s isn't counted because it's included by another license used on the host or its cluster", license.LicenseTypeID)
+
+		return explanation
+	}
+
+	explanation.DatabaseUsedLicense = *usedLicense
+
+	switch {
+	case db.Edition() == model.OracleDatabaseEditionStandard && usedLicense.Metric == model.LicenseTypeMetricNamedUserPlusPerpetual:
+		addStep(explanationStepStandardEdition, usedLicense.UsedLicenses,
+			"Standard Edition: Named User Plus licenses are counted as the minimum of %v per server",
+			model.OracleDatabaseSE2MinNamedUserPlusPerServer)
+	case db.Edition() == model.OracleDatabaseEditionStandard:
+		addStep(explanationStepStandardEdition, usedLicense.UsedLicenses,
+			"Standard Edition: licenses are counted by occupied sockets")
+	case usedLicense.Metric == model.LicenseTypeMetricNamedUserPlusPerpetual:
+		addStep(explanationStepMetric, usedLicense.UsedLicenses,
+			"Metric %s: licenses are multiplied by %v", usedLicense.Metric, model.FactorNamedUser)
+	}
+
+	if usedLicense.ClusterName != "" {
+		description := fmt.Sprintf("The host belongs to %s cluster %s", usedLicense.ClusterType, usedLicense.ClusterName)
+
+		switch usedLicense.ClusterLicensingPolicy {
+		case model.ClusterLicensingPolicyHardPartitioning:
+			description += ": hard partitioning, only the pinned vCPUs of the host are licensed"
+		case model.ClusterLicensingPolicyVCenter:
+			description += ": all the clusters of its vCenter are licensed"
+		default:
+			description += ": the whole cluster is licensed"
+		}
+
+		if usedLicense.OlvmCapped {
+			description += ", the host has capped CPUs"
+		}
+
+		addStep(explanationStepCluster, usedLicense.ClusterLicenses, "%s", description)
+	}
+
+	if usedLicense.Ignored {
+		addStep(explanationStepIgnored, 0, "License is ignored: %s", usedLicense.IgnoredComment)
+
+		return explanation
+	}
+
+	explainContractsCoverage(addStep, host.Hostname, usedLicense.LicenseTypeID, contracts)
+
+	return explanation
+}
+
+func explainContractsCoverage(addStep func(string, float64, string, ...interface{}),
+	hostname, licenseTypeID string, contracts []dto.OracleDatabaseContractFE) {
+	var covered, totalCovered, consumed float64
+
+	associated := false
+
+	for _, contract := range contracts {
+		if contract.LicenseTypeID != licenseTypeID {
+			continue
+		}
+
+		for _, contractHost := range contract.Hosts {
+			if contractHost.Hostname != hostname {
+				continue
+			}
+
+			// the total covered and the consumed licenses are the same in all the contracts of the host and license
+			associated = true
+			totalCovered = contractHost.TotalCoveredLicensesCount
+			consumed = contractHost.ConsumedLicensesCount
+
+			if contractHost.CoveredLicensesCount > 0 {
+				covered += contractHost.CoveredLicensesCount
+				addStep(explanationStepContract, contractHost.CoveredLicensesCount,
+					"Contract %s (CSI %s) covers %v licenses", contract.ContractID, contract.CSI, contractHost.CoveredLicensesCount)
+			}
+		}
+	}
+
+	if !associated {
+		addStep(explanationStepUncovered, 0, "The host isn't associated to any contract of license %s", licenseTypeID)
+
+		return
+	}
+
+	if totalCovered > covered {
+		addStep(explanationStepContract, totalCovered-covered,
+			"Basket contracts cover %v licenses", totalCovered-covered)
+	}
+
+	if consumed > totalCovered {
+		addStep(explanationStepUncovered, consumed-totalCovered,
+			"%v licenses aren't covered by any contract", consumed-totalCovered)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestExplainUsedLicensesPerDatabases_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	as.mockGetOracleDatabaseContracts = func(filters dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error) {
+		return []dto.OracleDatabaseContractFE{
+			{
+				ContractID:    "AID001",
+				CSI:           "CSI001",
+				LicenseTypeID: "A12345",
+				Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+					{
+						Hostname:                  "topolino-hostname",
+						CoveredLicensesCount:      1,
+						TotalCoveredLicensesCount: 1,
+						ConsumedLicensesCount:     2,
+					},
+				},
+			},
+		}, nil
+	}
+
+	host := dto.HostData{
+		Hostname: "topolino-hostname",
+		Info: model.Host{
+			CPUCores:                      4,
+			CPUSockets:                    1,
+			HardwareAbstractionTechnology: model.HardwareAbstractionTechnologyPhysical,
+		},
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name:    "topolino-dbname",
+							Version: "19.0.0.0 Enterprise Edition",
+							Role:    model.OracleDatabaseRolePrimary,
+							Licenses: []model.OracleDatabaseLicense{
+								{LicenseTypeID: "A12345", Name: "Oracle ENT", Count: 2},
+								{LicenseTypeID: "A98765", Name: "Oracle ENT NUP", Count: 2},
+								{LicenseTypeID: "A00000", Name: "Diagnostics Pack", Count: 0},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		db.EXPECT().GetHost("topolino-hostname", globalFilter.OlderThan, false).
+			Return(&host, nil),
+		db.EXPECT().
+			SearchOracleDatabaseUsedLicenses("topolino-hostname", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
+			Return(&oracleLics, nil),
+		db.EXPECT().GetOracleDatabaseLicenseTypes().
+			Return(licenseTypes, nil),
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
+			Return(hostdatas, nil),
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
+			Return([]dto.Cluster{}, nil),
+	)
+
+	actual, err := as.ExplainUsedLicensesPerDatabases("topolino-hostname", globalFilter)
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	stepNames := func(e dto.DatabaseUsedLicenseExplanation) []string {
+		names := make([]string, 0, len(e.Steps))
+		for _, s := range e.Steps {
+			names = append(names, s.Name)
+		}
+
+		return names
+	}
+
+	assert.Equal(t, "A12345", actual[0].LicenseTypeID)
+	assert.Equal(t, float64(2), actual[0].UsedLicenses)
+	assert.Equal(t, []string{
+		explanationStepHost, explanationStepCoreFactor, explanationStepAgent,
+		explanationStepContract, explanationStepUncovered,
+	}, stepNames(actual[0]))
+	assert.Equal(t, 0.5, actual[0].Steps[1].Value)
+
+	assert.Equal(t, "A98765", actual[1].LicenseTypeID)
+	assert.Equal(t, float64(50), actual[1].UsedLicenses)
+	assert.Equal(t, []string{
+		explanationStepHost, explanationStepCoreFactor, explanationStepAgent,
+		explanationStepMetric, explanationStepUncovered,
+	}, stepNames(actual[1]))
+}
+
+func TestExplainUsedLicensesPerDatabases_HostNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetHost("foobar", globalFilter.OlderThan, false).
+		Return(nil, utils.ErrHostNotFound)
+
+	_, err := as.ExplainUsedLicensesPerDatabases("foobar", globalFilter)
+	require.ErrorIs(t, err, utils.ErrHostNotFound)
+}
+
+func TestExplainUsedLicensesPerDatabases_ContractsAtOlderThan(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	gomock.InOrder(
+		db.EXPECT().GetHost("topolino-hostname", globalFilter.OlderThan, false).
+			Return(&dto.HostData{Hostname: "topolino-hostname"}, nil),
+		db.EXPECT().
+			SearchOracleDatabaseUsedLicenses("topolino-hostname", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
+			Return(&oracleLics, nil),
+		db.EXPECT().GetOracleDatabaseLicenseTypes().
+			Return(licenseTypes, nil),
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
+			Return(hostdatas, nil),
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
+			Return([]dto.Cluster{}, nil),
+		db.EXPECT().ListOracleDatabaseContracts().
+			Return([]dto.OracleDatabaseContractFE{}, nil),
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", globalFilter.OlderThan).
+			Return(nil, aerrMock),
+	)
+
+	_, err := as.ExplainUsedLicensesPerDatabases("topolino-hostname", globalFilter)
+	require.Equal(t, aerrMock, err)
+}
+
+func TestExplainContractsCoverage_SeveralContracts(t *testing.T) {
+	contracts := []dto.OracleDatabaseContractFE{
+		{
+			ContractID:    "AID001",
+			LicenseTypeID: "A12345",
+			Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+				{Hostname: "foobar", CoveredLicensesCount: 1, TotalCoveredLicensesCount: 4, ConsumedLicensesCount: 6},
+			},
+		},
+		{
+			ContractID:    "AID002",
+			LicenseTypeID: "A12345",
+			Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+				{Hostname: "foobar", CoveredLicensesCount: 2, TotalCoveredLicensesCount: 4, ConsumedLicensesCount: 6},
+			},
+		},
+	}
+
+	steps := make([]dto.DatabaseUsedLicenseExplanationStep, 0)
+	addStep := func(name string, value float64, format string, a ...interface{}) {
+		steps = append(steps, dto.DatabaseUsedLicenseExplanationStep{Name: name, Value: value})
+	}
+
+	explainContractsCoverage(addStep, "foobar", "A12345", contracts)
+
+	assert.Equal(t, []dto.DatabaseUsedLicenseExplanationStep{
+		{Name: explanationStepContract, Value: 1},
+		{Name: explanationStepContract, Value: 2},
+		{Name: explanationStepContract, Value: 1},
+		{Name: explanationStepUncovered, Value: 2},
+	}, steps)
+}
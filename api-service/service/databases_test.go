@@ -239,6 +239,12 @@ var globalFilterAny = dto.GlobalFilter{
 	OlderThan:   utils.MAX_TIME,
 }
 
+var globalFilterAnyAtThisMoment = dto.GlobalFilter{
+	Location:    "",
+	Environment: "",
+	OlderThan:   thisMoment,
+}
+
 var sqlServerLicenseTypes = []model.SqlServerDatabaseLicenseType{
 	{
 		ID:              licenseTypesID,
@@ -331,7 +337,7 @@ func TestSearchDatabases_Success(t *testing.T) {
 
 	// db.EXPECT().FindPsqlMigrabilities(gomock.Any(), gomock.Any()).Return([]model.PgsqlMigrability{}, nil).AnyTimes()
 
-	db.EXPECT().SearchOracleDatabases([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
+	db.EXPECT().SearchOracleDatabases([]string{""}, "", false, -1, -1, "Dubai", "TEST", "", thisMoment).
 		Return(&expectedRes, nil)
 
 	mysqlInstances := []dto.MySQLInstance{
@@ -372,7 +378,7 @@ func TestSearchDatabases_Success(t *testing.T) {
 	db.EXPECT().SearchMySQLInstances(globalFilter).
 		Return(mysqlInstances, nil)
 
-	db.EXPECT().SearchSqlServerInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
+	db.EXPECT().SearchSqlServerInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", "", thisMoment).
 		Return(&expectedSqlServerRes, nil)
 	db.EXPECT().SearchPostgreSqlInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
 		Return(&expectedPostgreSqlRes, nil)
@@ -465,7 +471,7 @@ func TestSearchDatabasesAsXLSX_Success(t *testing.T) {
 
 	// db.EXPECT().GetClusters(gomock.Any()).Return([]dto.Cluster{}, nil)
 
-	db.EXPECT().SearchOracleDatabases([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
+	db.EXPECT().SearchOracleDatabases([]string{""}, "", false, -1, -1, "Dubai", "TEST", "", thisMoment).
 		Return(&expectedRes, nil)
 
 	mysqlInstances := []dto.MySQLInstance{
@@ -508,7 +514,7 @@ func TestSearchDatabasesAsXLSX_Success(t *testing.T) {
 
 	db.EXPECT().FindPsqlMigrabilities(gomock.Any(), gomock.Any()).Return([]model.PgsqlMigrability{}, nil).AnyTimes()
 
-	db.EXPECT().SearchSqlServerInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
+	db.EXPECT().SearchSqlServerInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", "", thisMoment).
 		Return(&expectedSqlServerRes, nil)
 
 	db.EXPECT().SearchPostgreSqlInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
@@ -607,7 +613,7 @@ func TestGetDatabasesStatistics_Success(t *testing.T) {
 
 	// db.EXPECT().FindPsqlMigrabilities(gomock.Any(), gomock.Any()).Return([]model.PgsqlMigrability{}, nil).AnyTimes()
 
-	db.EXPECT().SearchOracleDatabases([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
+	db.EXPECT().SearchOracleDatabases([]string{""}, "", false, -1, -1, "Dubai", "TEST", "", thisMoment).
 		Return(&expectedRes, nil)
 
 	mysqlInstances := []dto.MySQLInstance{
@@ -664,7 +670,7 @@ func TestGetDatabasesStatistics_Success(t *testing.T) {
 	db.EXPECT().SearchMySQLInstances(globalFilter).
 		Return(mysqlInstances, nil)
 
-	db.EXPECT().SearchSqlServerInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
+	db.EXPECT().SearchSqlServerInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", "", thisMoment).
 		Return(&expectedSqlServerRes, nil)
 
 	db.EXPECT().SearchPostgreSqlInstances([]string{""}, "", false, -1, -1, "Dubai", "TEST", thisMoment).
@@ -782,32 +788,41 @@ func TestGetUsedLicensesPerDatabases_Success(t *testing.T) {
 	gomock.InOrder(
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLUsedLicenses("", globalFilter).
 			Return(usedLicensesMySQL, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
-		db.EXPECT().GetCluster("plutocluster", utils.MAX_TIME).
+		db.EXPECT().GetCluster("plutocluster", globalFilter.OlderThan).
 			Return(&cluster, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&sqlServerLics, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 	actual, err := as.GetUsedLicensesPerDatabases("", globalFilter)
 	require.NoError(t, err)
@@ -908,54 +923,65 @@ func TestGetUsedLicensesPerDatabases_VMWareCluster_Success(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLUsedLicenses("", globalFilter).
 			Return(usedLicensesMySQL, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&sqlServerLics, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 	actual, err := as.GetUsedLicensesPerDatabases("", globalFilter)
 	require.NoError(t, err)
 
 	expected := []dto.DatabaseUsedLicense{
 		{
-			Hostname:        "topolino-hostname",
-			DbName:          "topolino-dbname",
-			LicenseTypeID:   "A12345",
-			Description:     "ThisDesc",
-			Metric:          "ThisMetric",
-			UsedLicenses:    2,
-			ClusterLicenses: 8,
-			Ignored:         false,
+			Hostname:               "topolino-hostname",
+			DbName:                 "topolino-dbname",
+			LicenseTypeID:          "A12345",
+			Description:            "ThisDesc",
+			Metric:                 "ThisMetric",
+			UsedLicenses:           2,
+			ClusterLicenses:        8,
+			ClusterLicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Ignored:                false,
 		},
 		{
-			Hostname:        "topolino-hostname",
-			DbName:          "topolino-dbname",
-			LicenseTypeID:   "A98765",
-			Description:     "ThisDesc",
-			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
-			UsedLicenses:    50,
-			ClusterLicenses: 200,
-			Ignored:         false,
+			Hostname:               "topolino-hostname",
+			DbName:                 "topolino-dbname",
+			LicenseTypeID:          "A98765",
+			Description:            "ThisDesc",
+			Metric:                 model.LicenseTypeMetricNamedUserPlusPerpetual,
+			UsedLicenses:           50,
+			ClusterLicenses:        200,
+			ClusterLicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Ignored:                false,
 		},
 	}
 
@@ -1005,30 +1031,39 @@ func TestGetUsedLicensesPerDatabases_VeritasCluster_Success(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLUsedLicenses("", globalFilter).
 			Return(usedLicensesMySQL, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&sqlServerLics, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 	actual, err := as.GetUsedLicensesPerDatabases("", globalFilter)
 	require.NoError(t, err)
@@ -1174,32 +1209,41 @@ func TestGetUsedLicensesPerDatabasesAsXLSX_Success(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLUsedLicenses("", globalFilter).
 			Return(usedLicenses, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
-		db.EXPECT().GetCluster("plutocluster", utils.MAX_TIME).
+		db.EXPECT().GetCluster("plutocluster", globalFilter.OlderThan).
 			Return(&cluster, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&sqlServerLics, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
 	actual, err := as.GetUsedLicensesPerDatabasesAsXLSX(globalFilter)
@@ -1331,14 +1375,14 @@ func TestGetOracleDatabasesUsedLicenses_Host_WithActiveDataguardAndGoldenGate_Su
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
@@ -1501,14 +1545,14 @@ func TestGetOracleDatabasesUsedLicenses_VeritasCluster_WithActiveDataguardAndGol
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
@@ -1642,14 +1686,14 @@ func TestGetOracleDatabasesUsedLicenses_Host_WithRacAndRacOneNode_Success(t *tes
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypesRac, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
@@ -1777,14 +1821,14 @@ func TestGetOracleDatabasesUsedLicenses_VeritasCluster_WithRacAndRacOneNode_Succ
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypesRac, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
@@ -1964,14 +2008,14 @@ func TestGetOracleDatabasesUsedLicenses_VmwareCluster_WithRacAndRacOneNode_Succe
 
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, globalFilter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, globalFilter.Location, globalFilter.Environment, "", globalFilter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypesRac, nil),
 
-		db.EXPECT().GetHostDatas(utils.MAX_TIME).
+		db.EXPECT().GetHostDatas(globalFilter.OlderThan).
 			Return(hostdatas, nil),
-		db.EXPECT().GetClusters(globalFilterAny).
+		db.EXPECT().GetClusters(globalFilterAnyAtThisMoment).
 			Return(clusters, nil),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
@@ -1982,34 +2026,37 @@ func TestGetOracleDatabasesUsedLicenses_VmwareCluster_WithRacAndRacOneNode_Succe
 
 	expected := []dto.DatabaseUsedLicense{
 		{
-			Hostname:        "topolino-hostname",
-			DbName:          "topolino-dbname",
-			LicenseTypeID:   "A12345",
-			Description:     "ThisDesc",
-			Metric:          "ThisMetric",
-			UsedLicenses:    2,
-			ClusterLicenses: 32,
-			Ignored:         false,
+			Hostname:               "topolino-hostname",
+			DbName:                 "topolino-dbname",
+			LicenseTypeID:          "A12345",
+			Description:            "ThisDesc",
+			Metric:                 "ThisMetric",
+			UsedLicenses:           2,
+			ClusterLicenses:        32,
+			ClusterLicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Ignored:                false,
 		},
 		{
-			Hostname:        "topolino-hostname",
-			DbName:          "topolino-dbname",
-			LicenseTypeID:   "A98765",
-			Description:     "ThisDesc",
-			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
-			UsedLicenses:    50,
-			ClusterLicenses: 800,
-			Ignored:         false,
+			Hostname:               "topolino-hostname",
+			DbName:                 "topolino-dbname",
+			LicenseTypeID:          "A98765",
+			Description:            "ThisDesc",
+			Metric:                 model.LicenseTypeMetricNamedUserPlusPerpetual,
+			UsedLicenses:           50,
+			ClusterLicenses:        800,
+			ClusterLicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Ignored:                false,
 		},
 		{
-			Hostname:        "topolino-hostname",
-			DbName:          "topolino-dbname",
-			LicenseTypeID:   racIds[1],
-			Description:     "rac",
-			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
-			UsedLicenses:    50,
-			ClusterLicenses: 800,
-			Ignored:         false,
+			Hostname:               "topolino-hostname",
+			DbName:                 "topolino-dbname",
+			LicenseTypeID:          racIds[1],
+			Description:            "rac",
+			Metric:                 model.LicenseTypeMetricNamedUserPlusPerpetual,
+			UsedLicenses:           50,
+			ClusterLicenses:        800,
+			ClusterLicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Ignored:                false,
 		},
 	}
 
@@ -2155,7 +2202,7 @@ func TestGetDatabaseLicensesComplianceAsXLSX_Success(t *testing.T) {
 	gomock.InOrder(
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(oracleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -2179,7 +2226,7 @@ func TestGetDatabaseLicensesComplianceAsXLSX_Success(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -2192,9 +2239,18 @@ func TestGetDatabaseLicensesComplianceAsXLSX_Success(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
-	actual, err := as.GetDatabaseLicensesComplianceAsXLSX()
+	actual, err := as.GetDatabaseLicensesComplianceAsXLSX(utils.MAX_TIME)
 	require.NoError(t, err)
 
 	assert.Equal(t, "L47225", actual.GetCellValue("Licenses Compliance", "A2"))
@@ -2377,7 +2433,7 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 	gomock.InOrder(
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(oracleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -2397,7 +2453,7 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil).AnyTimes(),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -2410,9 +2466,18 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
-	actual, err := as.GetDatabaseLicensesCompliance()
+	actual, err := as.GetDatabaseLicensesCompliance(utils.MAX_TIME)
 	require.NoError(t, err)
 
 	expected := []dto.LicenseCompliance{
@@ -2483,7 +2548,7 @@ func TestGetUsedLicensesPerHost_Success(t *testing.T) {
 	filter := dto.GlobalFilter{
 		Location:    "Dubai",
 		Environment: "TEST",
-		OlderThan:   utils.MAX_TIME,
+		OlderThan:   utils.P("2023-06-01T00:00:00Z"),
 	}
 
 	oracleLics := dto.OracleDatabaseUsedLicenseSearchResponse{
@@ -2607,13 +2672,13 @@ func TestGetUsedLicensesPerHost_Success(t *testing.T) {
 		},
 	}
 
-	db.EXPECT().GetHostDatas(utils.MAX_TIME).
+	db.EXPECT().GetHostDatas(filter.OlderThan).
 		Return(hostdatas, nil).AnyTimes()
-	db.EXPECT().GetClusters(globalFilterAny).
+	db.EXPECT().GetClusters(dto.GlobalFilter{OlderThan: filter.OlderThan}).
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -2622,16 +2687,25 @@ func TestGetUsedLicensesPerHost_Success(t *testing.T) {
 			Return(usedLicenses, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
-		db.EXPECT().GetCluster("PLUTO-CLUSTER-NAME", utils.MAX_TIME).
+		db.EXPECT().GetCluster("PLUTO-CLUSTER-NAME", filter.OlderThan).
 			Return(&cluster, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
 	actual, err := as.GetUsedLicensesPerHost(filter)
@@ -2799,7 +2873,7 @@ func TestGetUsedLicensesPerHostAsXLSX_Success(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -2811,7 +2885,7 @@ func TestGetUsedLicensesPerHostAsXLSX_Success(t *testing.T) {
 		db.EXPECT().GetCluster("plutocluster", utils.MAX_TIME).
 			Return(&cluster, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -2820,6 +2894,15 @@ func TestGetUsedLicensesPerHostAsXLSX_Success(t *testing.T) {
 			Return(&cluster, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
 	actual, err := as.GetUsedLicensesPerHostAsXLSX(filter)
@@ -2880,7 +2963,7 @@ func TestGetUsedLicensesPerCluster_OneVm_Success(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&usedLicenses, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes1, nil),
@@ -2888,13 +2971,22 @@ func TestGetUsedLicensesPerCluster_OneVm_Success(t *testing.T) {
 			Return(usedLicensesMySQL, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 		db.EXPECT().GetClusters(filter).
 			Return(clusters, nil),
 	)
@@ -2904,12 +2996,13 @@ func TestGetUsedLicensesPerCluster_OneVm_Success(t *testing.T) {
 
 	expected := []dto.DatabaseUsedLicensePerCluster{
 		{
-			Cluster:       "name1",
-			Hostnames:     []string{"vm1"},
-			LicenseTypeID: "id1",
-			Description:   "desc1",
-			Metric:        model.LicenseTypeMetricNamedUserPlusPerpetual,
-			UsedLicenses:  150,
+			Cluster:         "name1",
+			LicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Hostnames:       []string{"vm1"},
+			LicenseTypeID:   "id1",
+			Description:     "desc1",
+			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
+			UsedLicenses:    150,
 		},
 	}
 	assert.ElementsMatch(t, expected, actual)
@@ -3006,7 +3099,7 @@ func TestGetUsedLicensesPerCluster_MultipleVms_Success(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&usedLicenses, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes1, nil),
@@ -3014,13 +3107,22 @@ func TestGetUsedLicensesPerCluster_MultipleVms_Success(t *testing.T) {
 			Return(usedLicensesMySQL, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 		db.EXPECT().GetClusters(filter).
 			Return(clusters, nil),
 	)
@@ -3030,12 +3132,13 @@ func TestGetUsedLicensesPerCluster_MultipleVms_Success(t *testing.T) {
 
 	expected := []dto.DatabaseUsedLicensePerCluster{
 		{
-			Cluster:       "name1",
-			Hostnames:     []string{"vm1", "vm2"},
-			LicenseTypeID: "id1",
-			Description:   "desc1",
-			Metric:        model.LicenseTypeMetricNamedUserPlusPerpetual,
-			UsedLicenses:  150,
+			Cluster:         "name1",
+			LicensingPolicy: model.ClusterLicensingPolicySoftPartitioning,
+			Hostnames:       []string{"vm1", "vm2"},
+			LicenseTypeID:   "id1",
+			Description:     "desc1",
+			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
+			UsedLicenses:    150,
 		},
 	}
 	assert.ElementsMatch(t, expected, actual)
@@ -3086,7 +3189,7 @@ func TestGetUsedLicensesPerClusterAsXLSX_Success(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&usedLicenses, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes1, nil),
@@ -3094,13 +3197,22 @@ func TestGetUsedLicensesPerClusterAsXLSX_Success(t *testing.T) {
 			Return(usedLicensesMySQL, nil),
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, filter.Location, filter.Environment, "", filter.OlderThan).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
 			Return(sqlServerContracts, nil),
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 		db.EXPECT().GetClusters(filter).
 			Return(clusters, nil),
 	)
@@ -3109,11 +3221,12 @@ func TestGetUsedLicensesPerClusterAsXLSX_Success(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, "name1", actual.GetCellValue("Licenses Used Per Cluster", "A2"))
-	assert.Equal(t, "id1", actual.GetCellValue("Licenses Used Per Cluster", "B2"))
-	assert.Equal(t, "desc1", actual.GetCellValue("Licenses Used Per Cluster", "C2"))
-	assert.Equal(t, "Named User Plus Perpetual", actual.GetCellValue("Licenses Used Per Cluster", "D2"))
-	assert.Equal(t, "vm1", actual.GetCellValue("Licenses Used Per Cluster", "E2"))
-	assert.Equal(t, "150", actual.GetCellValue("Licenses Used Per Cluster", "F2"))
+	assert.Equal(t, "SoftPartitioning", actual.GetCellValue("Licenses Used Per Cluster", "B2"))
+	assert.Equal(t, "id1", actual.GetCellValue("Licenses Used Per Cluster", "C2"))
+	assert.Equal(t, "desc1", actual.GetCellValue("Licenses Used Per Cluster", "D2"))
+	assert.Equal(t, "Named User Plus Perpetual", actual.GetCellValue("Licenses Used Per Cluster", "E2"))
+	assert.Equal(t, "vm1", actual.GetCellValue("Licenses Used Per Cluster", "F2"))
+	assert.Equal(t, "150", actual.GetCellValue("Licenses Used Per Cluster", "G2"))
 }
 
 func TestGetDatabaseLicensesComplianceSqlServerHostWithContractContract_Success(t *testing.T) {
@@ -3160,7 +3273,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostWithContractContract_Success(
 	gomock.InOrder(
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(oracleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -3180,7 +3293,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostWithContractContract_Success(
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -3191,9 +3304,18 @@ func TestGetDatabaseLicensesComplianceSqlServerHostWithContractContract_Success(
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
-	actual, err := as.GetDatabaseLicensesCompliance()
+	actual, err := as.GetDatabaseLicensesCompliance(utils.MAX_TIME)
 	require.NoError(t, err)
 
 	expected := []dto.LicenseCompliance{
@@ -3247,7 +3369,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostNoContract_Success(t *testing
 	gomock.InOrder(
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(oracleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -3267,7 +3389,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostNoContract_Success(t *testing
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -3278,9 +3400,18 @@ func TestGetDatabaseLicensesComplianceSqlServerHostNoContract_Success(t *testing
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
-	actual, err := as.GetDatabaseLicensesCompliance()
+	actual, err := as.GetDatabaseLicensesCompliance(utils.MAX_TIME)
 	require.NoError(t, err)
 
 	expected := []dto.LicenseCompliance{
@@ -3422,7 +3553,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostInClusterWithContract_Success
 	gomock.InOrder(
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(oracleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -3442,7 +3573,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostInClusterWithContract_Success
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -3455,13 +3586,23 @@ func TestGetDatabaseLicensesComplianceSqlServerHostInClusterWithContract_Success
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
 		db.EXPECT().GetCluster("PLUTO-CLUSTER-NAME", utils.MAX_TIME).
 			Return(&cluster, nil),
 		db.EXPECT().ExistHostdata("plutohost").
 			Return(true, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
-	actual, err := as.GetDatabaseLicensesCompliance()
+	actual, err := as.GetDatabaseLicensesCompliance(utils.MAX_TIME)
 	require.NoError(t, err)
 
 	expected := []dto.LicenseCompliance{
@@ -3480,3 +3621,67 @@ func TestGetDatabaseLicensesComplianceSqlServerHostInClusterWithContract_Success
 	}
 	assert.ElementsMatch(t, expected, actual)
 }
+
+func TestManageStandardDBVersionLicenses(t *testing.T) {
+	as := APIService{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	newHost := func(hostname string, sockets int, version string) *model.HostDataBE {
+		return &model.HostDataBE{
+			Hostname: hostname,
+			Info:     model.Host{CPUSockets: sockets, CPUCores: 16},
+			Features: model.Features{
+				Oracle: &model.OracleFeature{
+					Database: &model.OracleDatabaseFeature{
+						Databases: []model.OracleDatabase{
+							{
+								Name:     "db",
+								Version:  version,
+								Licenses: []model.OracleDatabaseLicense{{LicenseTypeID: "A90611", Count: 8}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	hostdatas := map[string]*model.HostDataBE{
+		"std-host":   newHost("std-host", 2, "19.0.0.0 Standard Edition"),
+		"ent-host":   newHost("ent-host", 2, "19.0.0.0 Enterprise Edition"),
+		"std-vm":     newHost("std-vm", 1, "19.0.0.0 Standard Edition"),
+		"cloud-host": newHost("cloud-host", 1, "19.0.0.0 Standard Edition"),
+	}
+	hostdatas["cloud-host"].Cloud.Membership = model.CloudMembershipAws
+	hostdatas["cloud-host"].Info.CPUThreads = 6
+
+	clusters := []dto.Cluster{
+		{
+			Name:                     "cluster",
+			Sockets:                  6,
+			VirtualizationNodesCount: 3,
+			VMs:                      []dto.VM{{Hostname: "std-vm"}},
+		},
+	}
+
+	usedLicenses := []dto.DatabaseUsedLicense{
+		{Hostname: "std-host", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricProcessorPerpetual, UsedLicenses: 8},
+		{Hostname: "ent-host", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricProcessorPerpetual, UsedLicenses: 8},
+		{Hostname: "std-vm", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricNamedUserPlusPerpetual, UsedLicenses: 200,
+			ClusterName: "cluster", ClusterLicenses: 200},
+		{Hostname: "cloud-host", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricProcessorPerpetual, UsedLicenses: 3},
+	}
+
+	actual := as.manageStandardDBVersionLicenses(usedLicenses, clusters, hostdatas)
+
+	expected := []dto.DatabaseUsedLicense{
+		{Hostname: "std-host", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricProcessorPerpetual, UsedLicenses: 2},
+		{Hostname: "ent-host", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricProcessorPerpetual, UsedLicenses: 8},
+		{Hostname: "std-vm", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricNamedUserPlusPerpetual, UsedLicenses: 10,
+			ClusterName: "cluster", ClusterLicenses: 30},
+		{Hostname: "cloud-host", DbName: "db", LicenseTypeID: "A90611", Metric: model.LicenseTypeMetricProcessorPerpetual, UsedLicenses: 2},
+	}
+
+	assert.Equal(t, expected, actual)
+}
@@ -21,19 +21,19 @@ import (
 )
 
 // GetInfoForFrontendDashboard return all informations needed for the frontend dashboard page
-func (as *APIService) GetInfoForFrontendDashboard(location string, environment string, olderThan time.Time) (map[string]interface{}, error) {
+func (as *APIService) GetInfoForFrontendDashboard(location string, environment string, site string, olderThan time.Time) (map[string]interface{}, error) {
 	var err error
 
 	out := map[string]interface{}{}
 
 	technologiesObject := map[string]interface{}{}
 
-	technologiesObject["total"], err = as.GetTotalTechnologiesComplianceStats(location, environment, olderThan)
+	technologiesObject["total"], err = as.GetTotalTechnologiesComplianceStats(location, environment, site, olderThan)
 	if err != nil {
 		return nil, err
 	}
 
-	technologiesObject["technologies"], err = as.ListManagedTechnologies("", false, location, environment, olderThan)
+	technologiesObject["technologies"], err = as.ListManagedTechnologies("", false, location, environment, site, olderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -216,13 +216,13 @@ func TestGetInfoForFrontendDashboard_Success(t *testing.T) {
 	gomock.InOrder(
 
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 			Return(getTechnologiesUsageRes, nil),
 
 		db.EXPECT().
 			ListOracleDatabaseContracts().
 			Return(contracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -238,7 +238,7 @@ func TestGetInfoForFrontendDashboard_Success(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(mySqlcontracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -250,17 +250,26 @@ func TestGetInfoForFrontendDashboard_Success(t *testing.T) {
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
 
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
+
 		db.EXPECT().
-			GetHostsCountStats("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+			GetHostsCountStats("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 			Return(20, nil),
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 			Return(getTechnologiesUsageRes2, nil),
 
 		db.EXPECT().
 			ListOracleDatabaseContracts().
 			Return(contracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -276,7 +285,7 @@ func TestGetInfoForFrontendDashboard_Success(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(mySqlcontracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -288,12 +297,21 @@ func TestGetInfoForFrontendDashboard_Success(t *testing.T) {
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
 
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
+
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("", "", utils.MAX_TIME).
+			GetHostsCountUsingTechnologies("", "", "", utils.MAX_TIME).
 			Return(getTechnologiesUsageRes, nil),
 	)
 
-	res, err := as.GetInfoForFrontendDashboard("Italy", "PRD", utils.P("2019-12-05T14:02:03Z"))
+	res, err := as.GetInfoForFrontendDashboard("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z"))
 
 	require.NoError(t, err)
 	assert.JSONEq(t, utils.ToJSON(expectedRes), utils.ToJSON(res))
@@ -308,10 +326,10 @@ func TestGetInfoForFrontendDashboard_Fail1(t *testing.T) {
 	}
 
 	db.EXPECT().
-		GetHostsCountUsingTechnologies("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+		GetHostsCountUsingTechnologies("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 		Return(nil, aerrMock).AnyTimes().MinTimes(1)
 
-	_, err := as.GetInfoForFrontendDashboard("Italy", "PRD", utils.P("2019-12-05T14:02:03Z"))
+	_, err := as.GetInfoForFrontendDashboard("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z"))
 
 	require.Equal(t, aerrMock, err)
 }
@@ -444,13 +462,13 @@ func TestGetInfoForFrontendDashboard_Fail2(t *testing.T) {
 	gomock.InOrder(
 
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 			Return(getTechnologiesUsageRes, nil).AnyTimes().MinTimes(1),
 
 		db.EXPECT().
 			ListOracleDatabaseContracts().
 			Return(contracts, nil).AnyTimes().MinTimes(1),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -466,7 +484,7 @@ func TestGetInfoForFrontendDashboard_Fail2(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(mySqlcontracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -478,16 +496,25 @@ func TestGetInfoForFrontendDashboard_Fail2(t *testing.T) {
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
 
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
+
 		db.EXPECT().
-			GetHostsCountStats("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+			GetHostsCountStats("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 			Return(20, nil).AnyTimes().MinTimes(1),
 
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PRD", utils.P("2019-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z")).
 			Return(nil, aerrMock),
 	)
 
-	_, err := as.GetInfoForFrontendDashboard("Italy", "PRD", utils.P("2019-12-05T14:02:03Z"))
+	_, err := as.GetInfoForFrontendDashboard("Italy", "PRD", "", utils.P("2019-12-05T14:02:03Z"))
 
 	require.Equal(t, aerrMock, err)
 }
@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func (as *APIService) AddHostContract(contract model.HostContract) (*model.HostContract, error) {
+	contract.ID = as.NewObjectID()
+
+	err := as.Database.AddHostContract(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+func (as *APIService) UpdateHostContract(contract model.HostContract) (*model.HostContract, error) {
+	if err := as.Database.UpdateHostContract(contract); err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+func (as *APIService) GetHostContracts() ([]model.HostContract, error) {
+	contracts, err := as.Database.GetHostContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	return contracts, nil
+}
+
+func (as *APIService) DeleteHostContract(id primitive.ObjectID) error {
+	if err := as.Database.DeleteHostContract(id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"errors"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (as *APIService) ListHostIdentities() ([]model.HostIdentity, error) {
+	return as.Database.ListHostIdentities()
+}
+
+func (as *APIService) GetHostIdentity(id string) (*model.HostIdentity, error) {
+	return as.Database.GetHostIdentity(id)
+}
+
+// AddHostAlias add the alias to the identity of the host, the next hostdata with it will be saved with the canonical hostname
+func (as *APIService) AddHostAlias(id string, alias string) (*model.HostIdentity, error) {
+	alias = model.NormalizeHostAlias(alias)
+	if alias == "" {
+		return nil, utils.ErrInvalidHostAlias
+	}
+
+	identity, err := as.Database.GetHostIdentity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.HasAlias(alias) {
+		return identity, nil
+	}
+
+	identity.Aliases = append(identity.Aliases, alias)
+	if err := as.Database.UpdateHostIdentityAliases(identity.ID, identity.Aliases); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// RemoveHostAlias remove the alias from the identity of the host, the canonical hostname can't be removed
+func (as *APIService) RemoveHostAlias(id string, alias string) (*model.HostIdentity, error) {
+	identity, err := as.Database.GetHostIdentity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.removeAlias(identity, alias); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// MergeHostIdentities merge the identity of the other host into the identity of the host.
+// The other host is dismissed and its hostdata history is moved to the canonical hostname of the identity
+func (as *APIService) MergeHostIdentities(id string, otherID string) (*model.HostIdentity, error) {
+	if id == otherID {
+		return nil, utils.ErrHostIdentityMergedWithItself
+	}
+
+	identity, err := as.Database.GetHostIdentity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	other, err := as.Database.GetHostIdentity(otherID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *identity
+	merged.Aliases = append([]string{}, identity.Aliases...)
+
+	for _, alias := range other.Aliases {
+		if !merged.HasAlias(alias) {
+			merged.Aliases = append(merged.Aliases, alias)
+		}
+	}
+
+	if err := as.Database.MergeHostIdentities(merged, *other); err != nil {
+		return nil, err
+	}
+
+	identity.Aliases = merged.Aliases
+
+	return identity, nil
+}
+
+// SplitHostIdentity remove the alias from the identity of the host and create a new identity for it.
+// The hostdata already saved stay with the identity of the host
+func (as *APIService) SplitHostIdentity(id string, alias string) (*model.HostIdentity, error) {
+	identity, err := as.Database.GetHostIdentity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.removeAlias(identity, alias); err != nil {
+		return nil, err
+	}
+
+	alias = model.NormalizeHostAlias(alias)
+	split := model.NewHostIdentity(alias, []string{alias}, as.TimeNow())
+
+	if err := as.Database.InsertHostIdentity(split); err != nil {
+		return nil, err
+	}
+
+	return &split, nil
+}
+
+func (as *APIService) removeAlias(identity *model.HostIdentity, alias string) error {
+	alias = model.NormalizeHostAlias(alias)
+	if !identity.HasAlias(alias) || alias == model.NormalizeHostAlias(identity.Hostname) {
+		return utils.ErrInvalidHostAlias
+	}
+
+	aliases := make([]string, 0, len(identity.Aliases)-1)
+
+	for _, a := range identity.Aliases {
+		if a != alias {
+			aliases = append(aliases, a)
+		}
+	}
+
+	if err := as.Database.UpdateHostIdentityAliases(identity.ID, aliases); err != nil {
+		return err
+	}
+
+	identity.Aliases = aliases
+
+	return nil
+}
+
+// canonicalHostname return the canonical hostname of the host with the name
+func (as *APIService) canonicalHostname(hostname string) (string, error) {
+	identity, err := as.Database.FindHostIdentityByAlias(model.NormalizeHostAlias(hostname))
+	if errors.Is(err, utils.ErrHostIdentityNotFound) {
+		return hostname, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return identity.Hostname, nil
+}
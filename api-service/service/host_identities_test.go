@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddHostAlias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+		db.EXPECT().UpdateHostIdentityAliases(identity.ID, []string{"foobar", "foobar.example.com"}).Return(nil)
+
+		res, err := as.AddHostAlias(identity.ID, " FooBar.example.com ")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foobar", "foobar.example.com"}, res.Aliases)
+	})
+
+	t.Run("already an alias", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+
+		res, err := as.AddHostAlias(identity.ID, "FOOBAR")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foobar"}, res.Aliases)
+	})
+
+	t.Run("empty alias", func(t *testing.T) {
+		_, err := as.AddHostAlias("id", "  ")
+		assert.ErrorIs(t, err, utils.ErrInvalidHostAlias)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+		db.EXPECT().UpdateHostIdentityAliases(identity.ID, []string{"foobar", "pippo"}).Return(utils.ErrHostAliasConflict)
+
+		_, err := as.AddHostAlias(identity.ID, "pippo")
+		assert.ErrorIs(t, err, utils.ErrHostAliasConflict)
+	})
+}
+
+func TestRemoveHostAlias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar", "pippo"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+		db.EXPECT().UpdateHostIdentityAliases(identity.ID, []string{"foobar"}).Return(nil)
+
+		res, err := as.RemoveHostAlias(identity.ID, "pippo")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foobar"}, res.Aliases)
+	})
+
+	t.Run("canonical hostname", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar", "pippo"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+
+		_, err := as.RemoveHostAlias(identity.ID, "foobar")
+		assert.ErrorIs(t, err, utils.ErrInvalidHostAlias)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db.EXPECT().GetHostIdentity("id").Return(nil, utils.ErrHostIdentityNotFound)
+
+		_, err := as.RemoveHostAlias("id", "pippo")
+		assert.ErrorIs(t, err, utils.ErrHostIdentityNotFound)
+	})
+}
+
+func TestMergeHostIdentities(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2019-11-05T14:02:03Z"))
+		other := model.NewHostIdentity("foobar-new", []string{"foobar-new", "foobar-new.example.com"}, utils.P("2019-11-05T14:02:03Z"))
+		merged := identity
+		merged.Aliases = []string{"foobar", "foobar-new", "foobar-new.example.com"}
+
+		gomock.InOrder(
+			db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil),
+			db.EXPECT().GetHostIdentity(other.ID).Return(&other, nil),
+			db.EXPECT().MergeHostIdentities(merged, other).Return(nil),
+		)
+
+		res, err := as.MergeHostIdentities(identity.ID, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "foobar", res.Hostname)
+		assert.Equal(t, []string{"foobar", "foobar-new", "foobar-new.example.com"}, res.Aliases)
+	})
+
+	t.Run("with itself", func(t *testing.T) {
+		_, err := as.MergeHostIdentities("id", "id")
+		assert.ErrorIs(t, err, utils.ErrHostIdentityMergedWithItself)
+	})
+
+	t.Run("other not found", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+		db.EXPECT().GetHostIdentity("other").Return(nil, utils.ErrHostIdentityNotFound)
+
+		_, err := as.MergeHostIdentities(identity.ID, "other")
+		assert.ErrorIs(t, err, utils.ErrHostIdentityNotFound)
+	})
+}
+
+func TestSplitHostIdentity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar", "pippo"}, utils.P("2019-11-05T14:02:03Z"))
+		expected := model.NewHostIdentity("pippo", []string{"pippo"}, utils.P("2019-11-05T14:02:03Z"))
+
+		gomock.InOrder(
+			db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil),
+			db.EXPECT().UpdateHostIdentityAliases(identity.ID, []string{"foobar"}).Return(nil),
+			db.EXPECT().InsertHostIdentity(expected).Return(nil),
+		)
+
+		res, err := as.SplitHostIdentity(identity.ID, "Pippo")
+		require.NoError(t, err)
+		assert.Equal(t, &expected, res)
+	})
+
+	t.Run("not an alias", func(t *testing.T) {
+		identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2019-11-05T14:02:03Z"))
+		db.EXPECT().GetHostIdentity(identity.ID).Return(&identity, nil)
+
+		_, err := as.SplitHostIdentity(identity.ID, "pippo")
+		assert.ErrorIs(t, err, utils.ErrInvalidHostAlias)
+	})
+}
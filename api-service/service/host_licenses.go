@@ -0,0 +1,152 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// GetHostLicenseTypes return the list of the operating systems and virtualization license types
+func (as *APIService) GetHostLicenseTypes() ([]model.HostLicenseType, error) {
+	return model.HostLicenseTypes, nil
+}
+
+// GetHostsUsedLicenses return the operating systems licenses used by the hosts
+// and the virtualization licenses used by the clusters
+func (as *APIService) GetHostsUsedLicenses(filter dto.GlobalFilter) ([]dto.HostUsedLicense, error) {
+	hosts, err := as.Database.GetHostsLicensingInfo(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := as.Database.GetClusters(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return hostsUsedLicenses(hosts, clusters), nil
+}
+
+func hostsUsedLicenses(hosts []dto.HostLicensingInfo, clusters []dto.Cluster) []dto.HostUsedLicense {
+	licenseTypes := make(map[string]model.HostLicenseType, len(model.HostLicenseTypes))
+	for _, licenseType := range model.HostLicenseTypes {
+		licenseTypes[licenseType.ID] = licenseType
+	}
+
+	newUsedLicense := func(licenseTypeID string, usedLicenses float64) dto.HostUsedLicense {
+		return dto.HostUsedLicense{
+			LicenseTypeID:   licenseTypeID,
+			ItemDescription: licenseTypes[licenseTypeID].ItemDescription,
+			Metric:          licenseTypes[licenseTypeID].Metric,
+			UsedLicenses:    usedLicenses,
+		}
+	}
+
+	usedLicenses := make([]dto.HostUsedLicense, 0)
+
+	for _, host := range hosts {
+		virtual := host.HardwareAbstraction != model.HardwareAbstractionPhysical
+
+		var usedLicense dto.HostUsedLicense
+
+		switch {
+		case model.IsRedHatEnterpriseLinux(host.OS) && virtual:
+			usedLicense = newUsedLicense(model.HostLicenseTypeRHELVirtual, 1)
+		case model.IsRedHatEnterpriseLinux(host.OS):
+			usedLicense = newUsedLicense(model.HostLicenseTypeRHELSocketPair, model.RHELSocketPairs(host.CPUSockets))
+		case model.IsWindowsServer(host.OS):
+			usedLicense = newUsedLicense(model.HostLicenseTypeWindowsServerCore,
+				model.WindowsServerCores(host.CPUCores, host.CPUSockets, virtual))
+		default:
+			continue
+		}
+
+		usedLicense.Hostname = host.Hostname
+		usedLicenses = append(usedLicenses, usedLicense)
+	}
+
+	countedClusters := make(map[string]bool)
+
+	for _, cluster := range clusters {
+		if !strings.EqualFold(cluster.Type, "vmware") || countedClusters[cluster.Name] {
+			continue
+		}
+
+		countedClusters[cluster.Name] = true
+
+		usedLicense := newUsedLicense(model.HostLicenseTypeVSphereCPU, model.VSphereCPUs(cluster.CPU, cluster.Sockets))
+		usedLicense.ClusterName = cluster.Name
+		usedLicenses = append(usedLicenses, usedLicense)
+	}
+
+	return usedLicenses
+}
+
+// GetHostLicensesCompliance return the compliance of the operating systems and virtualization licenses
+// used by the hosts at olderThan, compared with the current contracts
+func (as *APIService) GetHostLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error) {
+	any := dto.GlobalFilter{
+		Location:    "",
+		Environment: "",
+		OlderThan:   olderThan,
+	}
+
+	usedLicenses, err := as.GetHostsUsedLicenses(any)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := as.Database.GetHostContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := make([]dto.LicenseCompliance, 0, len(model.HostLicenseTypes))
+
+	for _, licenseType := range model.HostLicenseTypes {
+		license := dto.LicenseCompliance{
+			LicenseTypeID:   licenseType.ID,
+			ItemDescription: licenseType.ItemDescription,
+			Metric:          licenseType.Metric,
+		}
+
+		for _, usedLicense := range usedLicenses {
+			if usedLicense.LicenseTypeID == licenseType.ID {
+				license.Consumed += usedLicense.UsedLicenses
+			}
+		}
+
+		for _, contract := range contracts {
+			if contract.LicenseTypeID == licenseType.ID {
+				license.Purchased += float64(contract.NumberOfLicenses)
+			}
+		}
+
+		if license.Consumed == 0 && license.Purchased == 0 {
+			continue
+		}
+
+		computeLicenseCompliance(&license)
+
+		licenses = append(licenses, license)
+	}
+
+	return licenses, nil
+}
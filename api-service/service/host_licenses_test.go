@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var hostsLicensingInfo = []dto.HostLicensingInfo{
+	{Hostname: "rhel-ph", OS: "Red Hat Enterprise Linux", HardwareAbstraction: model.HardwareAbstractionPhysical, CPUCores: 24, CPUSockets: 3},
+	{Hostname: "rhel-vm", OS: "Red Hat Enterprise Linux", HardwareAbstraction: model.HardwareAbstractionVirtual, CPUCores: 4, CPUSockets: 1},
+	{Hostname: "win-ph", OS: "Microsoft Windows Server 2019 Standard", HardwareAbstraction: model.HardwareAbstractionPhysical, CPUCores: 12, CPUSockets: 2},
+	{Hostname: "ol-vm", OS: "Oracle Linux Server", HardwareAbstraction: model.HardwareAbstractionVirtual, CPUCores: 8, CPUSockets: 1},
+}
+
+var hostsLicensingClusters = []dto.Cluster{
+	{Name: "vmw01", Type: "vmware", CPU: 128, Sockets: 2},
+	{Name: "vmw01", Type: "vmware", CPU: 128, Sockets: 2},
+	{Name: "ovm01", Type: "ovm", CPU: 16, Sockets: 2},
+}
+
+func TestGetHostsUsedLicenses_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	gomock.InOrder(
+		db.EXPECT().GetHostsLicensingInfo(globalFilterAny).
+			Return(hostsLicensingInfo, nil),
+		db.EXPECT().GetClusters(globalFilterAny).
+			Return(hostsLicensingClusters, nil),
+	)
+
+	actual, err := as.GetHostsUsedLicenses(globalFilterAny)
+	require.NoError(t, err)
+
+	expected := []dto.HostUsedLicense{
+		{
+			LicenseTypeID:   model.HostLicenseTypeRHELSocketPair,
+			ItemDescription: "Red Hat Enterprise Linux Server - physical",
+			Metric:          model.HostLicenseMetricSocketPair,
+			Hostname:        "rhel-ph",
+			UsedLicenses:    2,
+		},
+		{
+			LicenseTypeID:   model.HostLicenseTypeRHELVirtual,
+			ItemDescription: "Red Hat Enterprise Linux Server - virtual",
+			Metric:          model.HostLicenseMetricVM,
+			Hostname:        "rhel-vm",
+			UsedLicenses:    1,
+		},
+		{
+			LicenseTypeID:   model.HostLicenseTypeWindowsServerCore,
+			ItemDescription: "Windows Server",
+			Metric:          model.HostLicenseMetricCore,
+			Hostname:        "win-ph",
+			UsedLicenses:    16,
+		},
+		{
+			LicenseTypeID:   model.HostLicenseTypeVSphereCPU,
+			ItemDescription: "VMware vSphere",
+			Metric:          model.HostLicenseMetricCPU,
+			ClusterName:     "vmw01",
+			UsedLicenses:    4,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetHostLicensesCompliance_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	contracts := []model.HostContract{
+		{ID: utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), ContractID: "RH001", LicenseTypeID: model.HostLicenseTypeRHELSocketPair, NumberOfLicenses: 1},
+		{ID: utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"), ContractID: "VMW01", LicenseTypeID: model.HostLicenseTypeVSphereCPU, NumberOfLicenses: 8},
+	}
+
+	gomock.InOrder(
+		db.EXPECT().GetHostsLicensingInfo(globalFilterAny).
+			Return(hostsLicensingInfo, nil),
+		db.EXPECT().GetClusters(globalFilterAny).
+			Return(hostsLicensingClusters, nil),
+		db.EXPECT().GetHostContracts().
+			Return(contracts, nil),
+	)
+
+	actual, err := as.GetHostLicensesCompliance(utils.MAX_TIME)
+	require.NoError(t, err)
+
+	expected := []dto.LicenseCompliance{
+		{
+			LicenseTypeID:   model.HostLicenseTypeRHELSocketPair,
+			ItemDescription: "Red Hat Enterprise Linux Server - physical",
+			Metric:          model.HostLicenseMetricSocketPair,
+			Consumed:        2,
+			Covered:         1,
+			Purchased:       1,
+			Compliance:      0.5,
+		},
+		{
+			LicenseTypeID:   model.HostLicenseTypeRHELVirtual,
+			ItemDescription: "Red Hat Enterprise Linux Server - virtual",
+			Metric:          model.HostLicenseMetricVM,
+			Consumed:        1,
+		},
+		{
+			LicenseTypeID:   model.HostLicenseTypeWindowsServerCore,
+			ItemDescription: "Windows Server",
+			Metric:          model.HostLicenseMetricCore,
+			Consumed:        16,
+		},
+		{
+			LicenseTypeID:   model.HostLicenseTypeVSphereCPU,
+			ItemDescription: "VMware vSphere",
+			Metric:          model.HostLicenseMetricCPU,
+			Consumed:        4,
+			Covered:         4,
+			Purchased:       8,
+			Available:       4,
+			Compliance:      1,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
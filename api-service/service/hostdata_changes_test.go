@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetHostDataChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	filter := dto.HostDataChangesFilter{
+		Hostname: "foobar",
+		From:     utils.P("2019-11-01T00:00:00Z"),
+		To:       utils.P("2019-11-30T00:00:00Z"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expected := []model.HostDataChange{
+			{
+				Hostname: "foobar",
+				Date:     utils.P("2019-11-05T14:02:03Z"),
+				Changes: []model.HostDataFieldChange{
+					{Field: model.HostDataChangeFieldCPUCores, OldValue: "2", NewValue: "4"},
+				},
+			},
+		}
+		db.EXPECT().FindHostDataChanges(filter).Return(expected, nil)
+
+		actual, err := as.GetHostDataChanges(filter)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db.EXPECT().FindHostDataChanges(filter).Return(nil, aerrMock)
+
+		_, err := as.GetHostDataChanges(filter)
+		require.Equal(t, aerrMock, err)
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func (as *APIService) ListQuarantinedHostData(hostname string, from, to time.Time) ([]model.QuarantinedHostData, error) {
+	return as.Database.ListQuarantinedHostData(hostname, from, to)
+}
+
+func (as *APIService) GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error) {
+	return as.Database.GetQuarantinedHostData(id)
+}
+
+func (as *APIService) DeleteQuarantinedHostData(id primitive.ObjectID) error {
+	return as.Database.DeleteQuarantinedHostData(id)
+}
+
+// ReplayQuarantinedHostData ask the data-service to validate again and insert a quarantined hostdata,
+// since only the data-service can ingest the hostdata
+func (as *APIService) ReplayQuarantinedHostData(id primitive.ObjectID) error {
+	return as.DataSvcClient.ReplayQuarantinedHostData(id)
+}
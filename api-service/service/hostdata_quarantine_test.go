@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestReplayQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dsc := NewMockDataSvcClientInterface(mockCtrl)
+	as := APIService{
+		DataSvcClient: dsc,
+	}
+
+	id := utils.Str2oid("6512f1a5ba2e2a1f4b6b8f31")
+
+	t.Run("success", func(t *testing.T) {
+		dsc.EXPECT().ReplayQuarantinedHostData(id).Return(nil)
+
+		require.NoError(t, as.ReplayQuarantinedHostData(id))
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		dsc.EXPECT().ReplayQuarantinedHostData(id).Return(utils.ErrQuarantinedHostDataOutdated)
+
+		err := as.ReplayQuarantinedHostData(id)
+		assert.ErrorIs(t, err, utils.ErrQuarantinedHostDataOutdated)
+	})
+}
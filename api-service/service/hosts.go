@@ -51,12 +51,22 @@ func (as *APIService) SearchHostsAsLMS(filters dto.SearchHostsAsLMS) (*excelize.
 		return nil, utils.NewError(err, "")
 	}
 
+	corrections, err := as.getApprovedLMSCorrections()
+	if err != nil {
+		return nil, utils.NewError(err, "")
+	}
+
+	applyLMSCorrections(hosts, corrections)
+
 	lms, err := excelize.OpenFile(as.Config.ResourceFilePath + "/templates/template_lms.xlsm")
 	if err != nil {
 		aerr := utils.NewError(err, "READ_TEMPLATE")
 		return nil, aerr
 	}
 
+	lms.SetCellValue(sheetDatabaseEbsDbTier, "AL3", "Number of Pluggable Databases (PDBs)")
+	lms.SetCellValue(sheetDatabaseEbsDbTier, "AM3", lmsIgnoredHeader)
+
 	if filters.From != utils.MIN_TIME || filters.To != utils.MAX_TIME {
 		//HostAdded management
 		createdHosts, err := as.Database.GetListValidHostsByRangeDates(filters.From, filters.To)
@@ -80,6 +90,8 @@ func (as *APIService) SearchHostsAsLMS(filters dto.SearchHostsAsLMS) (*excelize.
 					return nil, utils.NewError(err, "")
 				}
 
+				applyLMSCorrections(cHosts, corrections)
+
 				for i := 0; i < len(cHosts); i++ {
 					if !headerHostCreated {
 						indexsheetHostAdded := lms.NewSheet(sheetHostAdded)
@@ -95,7 +107,7 @@ func (as *APIService) SearchHostsAsLMS(filters dto.SearchHostsAsLMS) (*excelize.
 						headerHostCreated = true
 					}
 
-					if cHosts[i]["usingLicenseCount"] != 0.0 {
+					if isLMSRowExported(cHosts[i]) {
 						setCellValueLMS(lms, sheetHostAdded, j, csiByHostname, cHosts[i])
 						j++
 					}
@@ -125,6 +137,8 @@ func (as *APIService) SearchHostsAsLMS(filters dto.SearchHostsAsLMS) (*excelize.
 					return nil, utils.NewError(err, "")
 				}
 
+				applyLMSCorrections(dHosts, corrections)
+
 				for i := 0; i < len(dHosts); i++ {
 					if !headerHostDismissed {
 						indexsheetHostDismissed := lms.NewSheet(sheetHostDismissed)
@@ -140,7 +154,7 @@ func (as *APIService) SearchHostsAsLMS(filters dto.SearchHostsAsLMS) (*excelize.
 						headerHostDismissed = true
 					}
 
-					if dHosts[i]["usingLicenseCount"] != 0.0 {
+					if isLMSRowExported(dHosts[i]) {
 						setCellValueLMS(lms, sheetHostDismissed, z, csiByHostname, dHosts[i])
 						z++
 					}
@@ -152,7 +166,7 @@ func (as *APIService) SearchHostsAsLMS(filters dto.SearchHostsAsLMS) (*excelize.
 	indexRow := 4 // offset for headers
 
 	for i := 0; i < len(hosts); i++ {
-		if hosts[i]["usingLicenseCount"] != 0.0 {
+		if isLMSRowExported(hosts[i]) {
 			setCellValueLMS(lms, sheetDatabaseEbsDbTier, indexRow, csiByHostname, hosts[i])
 			indexRow++
 		}
@@ -229,6 +243,20 @@ func setCellValueLMS(lms *excelize.File, sheetName string, i int, csiByHostname
 	lms.SetCellValue(sheetName, fmt.Sprintf("AG%d", i), val["threadsPerCore"])
 	lms.SetCellValue(sheetName, fmt.Sprintf("AH%d", i), val["processorSpeed"])
 	lms.SetCellValue(sheetName, fmt.Sprintf("AJ%d", i), val["operatingSystem"])
+	lms.SetCellValue(sheetName, fmt.Sprintf("AL%d", i), val["pdbCount"])
+
+	if ignored, _ := val["ignored"].(bool); ignored {
+		lms.SetCellValue(sheetName, fmt.Sprintf("AK%d", i), val["ignoredComment"])
+		lms.SetCellValue(sheetName, fmt.Sprintf("AM%d", i), lmsIgnoredFlag)
+	}
+}
+
+// isLMSRowExported return true if the database of the row uses licenses or it has been ignored,
+// the ignored databases are exported to let the licensing team review them
+func isLMSRowExported(row map[string]interface{}) bool {
+	ignored, _ := row["ignored"].(bool)
+
+	return row["usingLicenseCount"] != 0.0 || ignored
 }
 
 func (as *APIService) SearchHostsAsXLSX(filters dto.SearchHostsFilters) (*excelize.File, error) {
@@ -384,6 +412,13 @@ func (as *APIService) GetHost(hostname string, olderThan time.Time, raw bool) (*
 		}
 	}
 
+	if model.IsAuthorizedCloud(host.Cloud.Membership) {
+		host.CloudLicensing, err = model.NewCloudLicensing(host.Cloud, host.Info)
+		if err != nil {
+			as.Log.Warn(err)
+		}
+	}
+
 	return host, nil
 }
 
@@ -436,6 +471,11 @@ func (as *APIService) ListEnvironments(location string, environment string, olde
 
 // DismissHost dismiss the specified host
 func (as *APIService) DismissHost(hostname string) error {
+	hostname, err := as.canonicalHostname(hostname)
+	if err != nil {
+		return err
+	}
+
 	filter := dto.AlertsFilter{OtherInfo: map[string]interface{}{"hostname": hostname}}
 	if err := as.RemoveAlertsNODATA(filter); err != nil {
 		as.Log.Errorf("Can't delete alerts by %s", hostname)
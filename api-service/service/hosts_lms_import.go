@@ -0,0 +1,408 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	lmsSheetDatabaseEbsDbTier = "Database_&_EBS_DB_Tier"
+	lmsFirstDataRow           = 4
+	lmsIgnoredHeader          = "Ignored"
+	lmsIgnoredFlag            = "Y"
+)
+
+type lmsRowKey struct {
+	hostname     string
+	databaseName string
+}
+
+// ImportOracleLMSReview parse an LMS workbook reviewed by the licensing team
+// and save the reviewed values as pending changes
+func (as *APIService) ImportOracleLMSReview(r io.Reader, user string) (*dto.OracleLMSImportReport, error) {
+	lms, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidLMSWorkbook, err)
+	}
+
+	if lms.GetSheetIndex(lmsSheetDatabaseEbsDbTier) == 0 {
+		return nil, fmt.Errorf("%w: missing sheet %s", utils.ErrInvalidLMSWorkbook, lmsSheetDatabaseEbsDbTier)
+	}
+
+	current, err := as.Database.SearchHosts("lms", dto.NewSearchHostsFilters())
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := as.Database.ListLMSPendingChanges("")
+	if err != nil {
+		return nil, err
+	}
+
+	applyLMSCorrections(current, getLMSCorrections(changes))
+
+	currentRows := make(map[lmsRowKey]map[string]interface{}, len(current))
+	for _, row := range current {
+		currentRows[lmsKeyOfHostRow(row)] = row
+	}
+
+	pending := make(map[string]bool)
+	for _, change := range changes {
+		if change.Status == model.LMSPendingChangeStatusPending {
+			pending[lmsPendingChangeKey(change)] = true
+		}
+	}
+
+	report := dto.OracleLMSImportReport{
+		ImportID:      as.NewObjectID(),
+		Changes:       make([]model.LMSPendingChange, 0),
+		UnmatchedRows: make([]dto.OracleLMSUnmatchedRow, 0),
+	}
+
+	hostdatas := make(map[string]*model.HostDataBE)
+	seen := make(map[lmsRowKey]bool)
+
+	for i, cells := range lms.GetRows(lmsSheetDatabaseEbsDbTier) {
+		rowNumber := i + 1
+		if rowNumber < lmsFirstDataRow {
+			continue
+		}
+
+		cell := func(column string) string {
+			index := excelize.TitleToNumber(column)
+			if index >= len(cells) {
+				return ""
+			}
+
+			return strings.TrimSpace(cells[index])
+		}
+
+		physicalServerName, virtualServerName, dbInstanceName := cell("B"), cell("C"), cell("E")
+		if physicalServerName == "" && virtualServerName == "" && dbInstanceName == "" {
+			continue
+		}
+
+		key := lmsRowKey{
+			hostname:     lmsHostname(physicalServerName, virtualServerName, cell("D")),
+			databaseName: dbInstanceName,
+		}
+
+		// the pluggable databases are listed in a row each, the reviewed values are read from the first one
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		currentRow, ok := currentRows[key]
+		if !ok {
+			report.UnmatchedRows = append(report.UnmatchedRows, dto.OracleLMSUnmatchedRow{
+				Row:                rowNumber,
+				PhysicalServerName: physicalServerName,
+				VirtualServerName:  virtualServerName,
+				DbInstanceName:     dbInstanceName,
+				Reason:             "host or database not found",
+			})
+
+			continue
+		}
+
+		rowChanges := make([]model.LMSPendingChange, 0)
+		comment := cell("AK")
+
+		if lmsCellString(currentRow["virtualizationTechnology"]) != "" &&
+			physicalServerName != lmsCellString(currentRow["physicalServerName"]) {
+			rowChanges = append(rowChanges, model.LMSPendingChange{
+				Field:         model.LMSPendingChangeFieldPhysicalServerName,
+				CurrentValue:  lmsCellString(currentRow["physicalServerName"]),
+				ProposedValue: physicalServerName,
+			})
+		}
+
+		if processorModel := cell("AC"); processorModel != "" && processorModel != lmsCellString(currentRow["processorModel"]) {
+			rowChanges = append(rowChanges, model.LMSPendingChange{
+				Field:         model.LMSPendingChangeFieldProcessorModel,
+				CurrentValue:  lmsCellString(currentRow["processorModel"]),
+				ProposedValue: processorModel,
+			})
+		}
+
+		// the exported workbook flags the ignored databases, so the ignored flags and
+		// the comments are compared with the current ones of the licenses
+		ignored := isLMSFlagSet(cell("AM"))
+		if currentIgnored, _ := currentRow["ignored"].(bool); ignored || currentIgnored {
+			hostdata, ok := hostdatas[key.hostname]
+			if !ok {
+				hostdata, err = as.Database.GetHostData(key.hostname, utils.MAX_TIME)
+				if err != nil {
+					return nil, err
+				}
+
+				hostdatas[key.hostname] = hostdata
+			}
+
+			rowChanges = append(rowChanges, lmsIgnoredChanges(hostdata, dbInstanceName, ignored, comment)...)
+		}
+
+		for _, change := range rowChanges {
+			change.ID = as.NewObjectID()
+			change.ImportID = report.ImportID
+			change.Row = rowNumber
+			change.Hostname = key.hostname
+			change.DatabaseName = key.databaseName
+			change.Comment = comment
+			change.Status = model.LMSPendingChangeStatusPending
+			change.CreatedBy = user
+			change.CreatedAt = as.TimeNow()
+
+			if pending[lmsPendingChangeKey(change)] {
+				continue
+			}
+
+			report.Changes = append(report.Changes, change)
+		}
+	}
+
+	if err := as.Database.InsertLMSPendingChanges(report.Changes); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// ListLMSPendingChanges return the LMS pending changes with the status, or all of them if status is empty
+func (as *APIService) ListLMSPendingChanges(status string) ([]model.LMSPendingChange, error) {
+	return as.Database.ListLMSPendingChanges(status)
+}
+
+// ApproveLMSPendingChange apply the reviewed value of an LMS pending change.
+// The ignored flags and comments are set on the licenses of the databases. The corrections of the
+// physical server names and of the processor models aren't saved in the hosts: they are only
+// overlaid on the rows of the next LMS exports and imports, the data sent by the agents is kept as is
+func (as *APIService) ApproveLMSPendingChange(id primitive.ObjectID, user string) (*model.LMSPendingChange, error) {
+	change, err := as.getPendingLMSChange(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch change.Field {
+	case model.LMSPendingChangeFieldIgnored:
+		ignored, err := strconv.ParseBool(change.ProposedValue)
+		if err != nil {
+			return nil, utils.NewError(err, "Unable to parse string to bool")
+		}
+
+		if err := as.Database.UpdateLicenseIgnoredField(change.Hostname, change.DatabaseName, change.LicenseTypeID, ignored, change.Comment); err != nil {
+			return nil, err
+		}
+	case model.LMSPendingChangeFieldIgnoredComment:
+		if err := as.Database.UpdateLicenseIgnoredField(change.Hostname, change.DatabaseName, change.LicenseTypeID, true, change.ProposedValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return as.reviewLMSPendingChange(change, model.LMSPendingChangeStatusApproved, user)
+}
+
+// RejectLMSPendingChange discard an LMS pending change
+func (as *APIService) RejectLMSPendingChange(id primitive.ObjectID, user string) (*model.LMSPendingChange, error) {
+	change, err := as.getPendingLMSChange(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.reviewLMSPendingChange(change, model.LMSPendingChangeStatusRejected, user)
+}
+
+func (as *APIService) getPendingLMSChange(id primitive.ObjectID) (*model.LMSPendingChange, error) {
+	change, err := as.Database.GetLMSPendingChange(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Status != model.LMSPendingChangeStatusPending {
+		return nil, fmt.Errorf("%w: %s", utils.ErrLMSPendingChangeAlreadyReviewed, change.Status)
+	}
+
+	return change, nil
+}
+
+func (as *APIService) reviewLMSPendingChange(change *model.LMSPendingChange, status, user string) (*model.LMSPendingChange, error) {
+	reviewedAt := as.TimeNow()
+
+	if err := as.Database.UpdateLMSPendingChangeStatus(change.ID, status, user, reviewedAt); err != nil {
+		return nil, err
+	}
+
+	change.Status = status
+	change.ReviewedBy = user
+	change.ReviewedAt = &reviewedAt
+
+	return change, nil
+}
+
+// getApprovedLMSCorrections return the approved corrections of the LMS values by hostname
+func (as *APIService) getApprovedLMSCorrections() (map[string]map[string]string, error) {
+	changes, err := as.Database.ListLMSPendingChanges(model.LMSPendingChangeStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	return getLMSCorrections(changes), nil
+}
+
+// getLMSCorrections return the corrections of the approved changes by hostname, the last approved wins
+func getLMSCorrections(changes []model.LMSPendingChange) map[string]map[string]string {
+	approved := make([]model.LMSPendingChange, 0, len(changes))
+
+	for _, change := range changes {
+		if change.Status == model.LMSPendingChangeStatusApproved &&
+			(change.Field == model.LMSPendingChangeFieldPhysicalServerName || change.Field == model.LMSPendingChangeFieldProcessorModel) {
+			approved = append(approved, change)
+		}
+	}
+
+	sort.SliceStable(approved, func(i, j int) bool {
+		if approved[i].ReviewedAt == nil || approved[j].ReviewedAt == nil {
+			return approved[j].ReviewedAt != nil
+		}
+
+		return approved[i].ReviewedAt.Before(*approved[j].ReviewedAt)
+	})
+
+	corrections := make(map[string]map[string]string)
+
+	for _, change := range approved {
+		if _, ok := corrections[change.Hostname]; !ok {
+			corrections[change.Hostname] = make(map[string]string)
+		}
+
+		corrections[change.Hostname][change.Field] = change.ProposedValue
+	}
+
+	return corrections
+}
+
+// applyLMSCorrections overwrite the values of the rows of the LMS with the approved corrections
+func applyLMSCorrections(rows []map[string]interface{}, corrections map[string]map[string]string) {
+	if len(corrections) == 0 {
+		return
+	}
+
+	for _, row := range rows {
+		for field, value := range corrections[lmsKeyOfHostRow(row).hostname] {
+			row[field] = value
+		}
+	}
+}
+
+func lmsKeyOfHostRow(row map[string]interface{}) lmsRowKey {
+	return lmsRowKey{
+		hostname: lmsHostname(
+			lmsCellString(row["physicalServerName"]),
+			lmsCellString(row["virtualServerName"]),
+			lmsCellString(row["virtualizationTechnology"]),
+		),
+		databaseName: lmsCellString(row["dbInstanceName"]),
+	}
+}
+
+// lmsHostname return the hostname of an LMS row: the virtual server name
+// of the virtual hosts and the physical server name of the others
+func lmsHostname(physicalServerName, virtualServerName, virtualizationTechnology string) string {
+	if virtualizationTechnology == "" && physicalServerName != "" {
+		return physicalServerName
+	}
+
+	if virtualServerName != "" {
+		return virtualServerName
+	}
+
+	return physicalServerName
+}
+
+func lmsPendingChangeKey(change model.LMSPendingChange) string {
+	return strings.Join([]string{change.Hostname, change.DatabaseName, change.LicenseTypeID, change.Field, change.ProposedValue}, "/")
+}
+
+// lmsIgnoredChanges return the changes of the ignored flags and of the comments of the licenses counted on the database
+func lmsIgnoredChanges(hostdata *model.HostDataBE, dbName string, ignored bool, comment string) []model.LMSPendingChange {
+	res := make([]model.LMSPendingChange, 0)
+
+	if hostdata.Features.Oracle == nil || hostdata.Features.Oracle.Database == nil {
+		return res
+	}
+
+	for _, db := range hostdata.Features.Oracle.Database.Databases {
+		if db.Name != dbName {
+			continue
+		}
+
+		for _, license := range db.Licenses {
+			if license.Count <= 0 {
+				continue
+			}
+
+			switch {
+			case license.Ignored != ignored:
+				res = append(res, model.LMSPendingChange{
+					LicenseTypeID: license.LicenseTypeID,
+					Field:         model.LMSPendingChangeFieldIgnored,
+					CurrentValue:  strconv.FormatBool(license.Ignored),
+					ProposedValue: strconv.FormatBool(ignored),
+				})
+			case ignored && license.IgnoredComment != comment:
+				res = append(res, model.LMSPendingChange{
+					LicenseTypeID: license.LicenseTypeID,
+					Field:         model.LMSPendingChangeFieldIgnoredComment,
+					CurrentValue:  license.IgnoredComment,
+					ProposedValue: comment,
+				})
+			}
+		}
+	}
+
+	return res
+}
+
+func isLMSFlagSet(value string) bool {
+	switch strings.ToLower(value) {
+	case "y", "yes", "x", "true", "1":
+		return true
+	}
+
+	return false
+}
+
+func lmsCellString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(fmt.Sprint(value))
+}
@@ -0,0 +1,417 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newReviewedLMSWorkbook(t *testing.T, rows ...map[string]string) *bytes.Reader {
+	lms, err := excelize.OpenFile("../../resources/templates/template_lms.xlsm")
+	require.NoError(t, err)
+
+	for i, row := range rows {
+		for column, value := range row {
+			lms.SetCellValue("Database_&_EBS_DB_Tier", column+strconv.Itoa(lmsFirstDataRow+i), value)
+		}
+	}
+
+	buf, err := lms.WriteToBuffer()
+	require.NoError(t, err)
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestImportOracleLMSReview(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+		Config: config.Configuration{
+			ResourceFilePath: "../../resources",
+		},
+	}
+
+	current := []map[string]interface{}{
+		{
+			"physicalServerName":       "cluster1",
+			"virtualServerName":        "vm1",
+			"virtualizationTechnology": "VMware",
+			"dbInstanceName":           "DB1",
+			"processorModel":           "Xeon A",
+		},
+		{
+			"physicalServerName":       "ph1",
+			"virtualServerName":        "",
+			"virtualizationTechnology": "",
+			"dbInstanceName":           "DB2",
+			"processorModel":           "Xeon A",
+		},
+	}
+
+	hostdata := &model.HostDataBE{
+		Hostname: "ph1",
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name: "DB2",
+							Licenses: []model.OracleDatabaseLicense{
+								{LicenseTypeID: "A90611", Count: 2},
+								{LicenseTypeID: "A90620", Count: 0},
+								{LicenseTypeID: "L10001", Count: 1, Ignored: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	workbook := newReviewedLMSWorkbook(t,
+		map[string]string{"B": "esx01", "C": "vm1", "D": "VMware", "E": "DB1", "F": "", "AC": "Xeon A"},
+		map[string]string{"B": "esx02", "C": "vm1", "D": "VMware", "E": "DB1", "F": "PDB1", "AC": "Xeon A"},
+		map[string]string{"B": "ph1", "E": "DB2", "AC": "Xeon B", "AK": "Development database", "AM": "Y"},
+		map[string]string{"B": "unknown", "E": "DB3"},
+	)
+
+	newChange := func(id string, row int, hostname, dbname, licenseTypeID, field, currentValue, proposedValue, comment string) model.LMSPendingChange {
+		return model.LMSPendingChange{
+			ID:            utils.Str2oid(id),
+			ImportID:      utils.Str2oid("000000000000000000000001"),
+			Row:           row,
+			Hostname:      hostname,
+			DatabaseName:  dbname,
+			LicenseTypeID: licenseTypeID,
+			Field:         field,
+			CurrentValue:  currentValue,
+			ProposedValue: proposedValue,
+			Comment:       comment,
+			Status:        model.LMSPendingChangeStatusPending,
+			CreatedBy:     "reviewer",
+			CreatedAt:     utils.P("2019-11-05T14:02:03Z"),
+		}
+	}
+
+	expected := dto.OracleLMSImportReport{
+		ImportID: utils.Str2oid("000000000000000000000001"),
+		Changes: []model.LMSPendingChange{
+			newChange("000000000000000000000002", 4, "vm1", "DB1", "", model.LMSPendingChangeFieldPhysicalServerName, "cluster1", "esx01", ""),
+			newChange("000000000000000000000003", 6, "ph1", "DB2", "", model.LMSPendingChangeFieldProcessorModel, "Xeon A", "Xeon B", "Development database"),
+			newChange("000000000000000000000004", 6, "ph1", "DB2", "A90611", model.LMSPendingChangeFieldIgnored, "false", "true", "Development database"),
+			newChange("000000000000000000000005", 6, "ph1", "DB2", "L10001", model.LMSPendingChangeFieldIgnoredComment, "", "Development database", "Development database"),
+		},
+		UnmatchedRows: []dto.OracleLMSUnmatchedRow{
+			{Row: 7, PhysicalServerName: "unknown", DbInstanceName: "DB3", Reason: "host or database not found"},
+		},
+	}
+
+	gomock.InOrder(
+		db.EXPECT().SearchHosts("lms", dto.NewSearchHostsFilters()).Return(current, nil),
+		db.EXPECT().ListLMSPendingChanges("").Return([]model.LMSPendingChange{}, nil),
+		db.EXPECT().GetHostData("ph1", utils.MAX_TIME).Return(hostdata, nil),
+		db.EXPECT().InsertLMSPendingChanges(expected.Changes).Return(nil),
+	)
+
+	actual, err := as.ImportOracleLMSReview(workbook, "reviewer")
+	require.NoError(t, err)
+	assert.Equal(t, &expected, actual)
+}
+
+func TestImportOracleLMSReview_SkipPendingAndCorrected(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	current := []map[string]interface{}{
+		{
+			"physicalServerName":       "cluster1",
+			"virtualServerName":        "vm1",
+			"virtualizationTechnology": "VMware",
+			"dbInstanceName":           "DB1",
+			"processorModel":           "Xeon A",
+		},
+	}
+
+	reviewedAt := utils.P("2019-11-04T10:00:00Z")
+	changes := []model.LMSPendingChange{
+		{
+			Hostname:      "vm1",
+			DatabaseName:  "DB1",
+			Field:         model.LMSPendingChangeFieldPhysicalServerName,
+			ProposedValue: "esx01",
+			Status:        model.LMSPendingChangeStatusApproved,
+			ReviewedAt:    &reviewedAt,
+		},
+		{
+			Hostname:      "vm1",
+			DatabaseName:  "DB1",
+			Field:         model.LMSPendingChangeFieldProcessorModel,
+			ProposedValue: "Xeon B",
+			Status:        model.LMSPendingChangeStatusPending,
+		},
+	}
+
+	workbook := newReviewedLMSWorkbook(t,
+		map[string]string{"B": "esx01", "C": "vm1", "D": "VMware", "E": "DB1", "AC": "Xeon B"},
+	)
+
+	gomock.InOrder(
+		db.EXPECT().SearchHosts("lms", dto.NewSearchHostsFilters()).Return(current, nil),
+		db.EXPECT().ListLMSPendingChanges("").Return(changes, nil),
+		db.EXPECT().InsertLMSPendingChanges([]model.LMSPendingChange{}).Return(nil),
+	)
+
+	actual, err := as.ImportOracleLMSReview(workbook, "reviewer")
+	require.NoError(t, err)
+	assert.Empty(t, actual.Changes)
+	assert.Empty(t, actual.UnmatchedRows)
+}
+
+func TestImportOracleLMSReview_IgnoredDatabases(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	current := []map[string]interface{}{
+		{"physicalServerName": "ph1", "dbInstanceName": "DB1", "ignored": true, "ignoredComment": "Test"},
+		{"physicalServerName": "ph1", "dbInstanceName": "DB2", "ignored": true, "ignoredComment": "Test"},
+		{"physicalServerName": "ph1", "dbInstanceName": "DB3", "ignored": true, "ignoredComment": "Test"},
+	}
+
+	ignoredDatabase := func(name string) model.OracleDatabase {
+		return model.OracleDatabase{
+			Name: name,
+			Licenses: []model.OracleDatabaseLicense{
+				{LicenseTypeID: "A90611", Count: 2, Ignored: true, IgnoredComment: "Test"},
+			},
+		}
+	}
+
+	hostdata := &model.HostDataBE{
+		Hostname: "ph1",
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{ignoredDatabase("DB1"), ignoredDatabase("DB2"), ignoredDatabase("DB3")},
+				},
+			},
+		},
+	}
+
+	workbook := newReviewedLMSWorkbook(t,
+		map[string]string{"B": "ph1", "E": "DB1", "AK": "Test", "AM": "Y"},
+		map[string]string{"B": "ph1", "E": "DB2", "AK": "Test database", "AM": "Y"},
+		map[string]string{"B": "ph1", "E": "DB3", "AK": "Production database"},
+	)
+
+	gomock.InOrder(
+		db.EXPECT().SearchHosts("lms", dto.NewSearchHostsFilters()).Return(current, nil),
+		db.EXPECT().ListLMSPendingChanges("").Return([]model.LMSPendingChange{}, nil),
+		db.EXPECT().GetHostData("ph1", utils.MAX_TIME).Return(hostdata, nil),
+		db.EXPECT().InsertLMSPendingChanges(gomock.Any()).Return(nil),
+	)
+
+	actual, err := as.ImportOracleLMSReview(workbook, "reviewer")
+	require.NoError(t, err)
+	require.Len(t, actual.Changes, 2)
+
+	assert.Equal(t, 5, actual.Changes[0].Row)
+	assert.Equal(t, model.LMSPendingChangeFieldIgnoredComment, actual.Changes[0].Field)
+	assert.Equal(t, "Test", actual.Changes[0].CurrentValue)
+	assert.Equal(t, "Test database", actual.Changes[0].ProposedValue)
+
+	assert.Equal(t, 6, actual.Changes[1].Row)
+	assert.Equal(t, model.LMSPendingChangeFieldIgnored, actual.Changes[1].Field)
+	assert.Equal(t, "true", actual.Changes[1].CurrentValue)
+	assert.Equal(t, "false", actual.Changes[1].ProposedValue)
+	assert.Equal(t, "Production database", actual.Changes[1].Comment)
+}
+
+func TestImportOracleLMSReview_InvalidWorkbook(t *testing.T) {
+	as := APIService{}
+
+	actual, err := as.ImportOracleLMSReview(bytes.NewReader([]byte("foobar")), "reviewer")
+	require.Nil(t, actual)
+	assert.True(t, errors.Is(err, utils.ErrInvalidLMSWorkbook))
+}
+
+func TestApproveLMSPendingChange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	id := utils.Str2oid("000000000000000000000002")
+
+	t.Run("ignored", func(t *testing.T) {
+		change := model.LMSPendingChange{
+			ID:            id,
+			Hostname:      "ph1",
+			DatabaseName:  "DB2",
+			LicenseTypeID: "A90611",
+			Field:         model.LMSPendingChangeFieldIgnored,
+			CurrentValue:  "false",
+			ProposedValue: "true",
+			Comment:       "Development database",
+			Status:        model.LMSPendingChangeStatusPending,
+		}
+
+		gomock.InOrder(
+			db.EXPECT().GetLMSPendingChange(id).Return(&change, nil),
+			db.EXPECT().UpdateLicenseIgnoredField("ph1", "DB2", "A90611", true, "Development database").Return(nil),
+			db.EXPECT().UpdateLMSPendingChangeStatus(id, model.LMSPendingChangeStatusApproved, "admin", utils.P("2019-11-05T14:02:03Z")).Return(nil),
+		)
+
+		actual, err := as.ApproveLMSPendingChange(id, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, model.LMSPendingChangeStatusApproved, actual.Status)
+		assert.Equal(t, "admin", actual.ReviewedBy)
+	})
+
+	t.Run("ignored comment", func(t *testing.T) {
+		change := model.LMSPendingChange{
+			ID:            id,
+			Hostname:      "ph1",
+			DatabaseName:  "DB2",
+			LicenseTypeID: "A90611",
+			Field:         model.LMSPendingChangeFieldIgnoredComment,
+			CurrentValue:  "Test",
+			ProposedValue: "Test database",
+			Comment:       "Test database",
+			Status:        model.LMSPendingChangeStatusPending,
+		}
+
+		gomock.InOrder(
+			db.EXPECT().GetLMSPendingChange(id).Return(&change, nil),
+			db.EXPECT().UpdateLicenseIgnoredField("ph1", "DB2", "A90611", true, "Test database").Return(nil),
+			db.EXPECT().UpdateLMSPendingChangeStatus(id, model.LMSPendingChangeStatusApproved, "admin", utils.P("2019-11-05T14:02:03Z")).Return(nil),
+		)
+
+		actual, err := as.ApproveLMSPendingChange(id, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, model.LMSPendingChangeStatusApproved, actual.Status)
+	})
+
+	t.Run("correction", func(t *testing.T) {
+		change := model.LMSPendingChange{
+			ID:            id,
+			Hostname:      "vm1",
+			DatabaseName:  "DB1",
+			Field:         model.LMSPendingChangeFieldPhysicalServerName,
+			ProposedValue: "esx01",
+			Status:        model.LMSPendingChangeStatusPending,
+		}
+
+		gomock.InOrder(
+			db.EXPECT().GetLMSPendingChange(id).Return(&change, nil),
+			db.EXPECT().UpdateLMSPendingChangeStatus(id, model.LMSPendingChangeStatusApproved, "admin", utils.P("2019-11-05T14:02:03Z")).Return(nil),
+		)
+
+		actual, err := as.ApproveLMSPendingChange(id, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, model.LMSPendingChangeStatusApproved, actual.Status)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		db.EXPECT().GetLMSPendingChange(id).
+			Return(&model.LMSPendingChange{ID: id, Status: model.LMSPendingChangeStatusRejected}, nil)
+
+		actual, err := as.ApproveLMSPendingChange(id, "admin")
+		require.Nil(t, actual)
+		assert.True(t, errors.Is(err, utils.ErrLMSPendingChangeAlreadyReviewed))
+	})
+}
+
+func TestRejectLMSPendingChange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	id := utils.Str2oid("000000000000000000000002")
+	change := model.LMSPendingChange{
+		ID:     id,
+		Field:  model.LMSPendingChangeFieldIgnored,
+		Status: model.LMSPendingChangeStatusPending,
+	}
+
+	gomock.InOrder(
+		db.EXPECT().GetLMSPendingChange(id).Return(&change, nil),
+		db.EXPECT().UpdateLMSPendingChangeStatus(id, model.LMSPendingChangeStatusRejected, "admin", utils.P("2019-11-05T14:02:03Z")).Return(nil),
+	)
+
+	actual, err := as.RejectLMSPendingChange(id, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, model.LMSPendingChangeStatusRejected, actual.Status)
+}
+
+func TestApplyLMSCorrections(t *testing.T) {
+	first := utils.P("2019-11-04T10:00:00Z")
+	second := utils.P("2019-11-05T10:00:00Z")
+
+	changes := []model.LMSPendingChange{
+		{Hostname: "vm1", Field: model.LMSPendingChangeFieldPhysicalServerName, ProposedValue: "esx02", Status: model.LMSPendingChangeStatusApproved, ReviewedAt: &second},
+		{Hostname: "vm1", Field: model.LMSPendingChangeFieldPhysicalServerName, ProposedValue: "esx01", Status: model.LMSPendingChangeStatusApproved, ReviewedAt: &first},
+		{Hostname: "vm1", Field: model.LMSPendingChangeFieldProcessorModel, ProposedValue: "Xeon C", Status: model.LMSPendingChangeStatusRejected},
+		{Hostname: "vm1", Field: model.LMSPendingChangeFieldIgnored, LicenseTypeID: "A90611", ProposedValue: "true", Status: model.LMSPendingChangeStatusApproved},
+	}
+
+	rows := []map[string]interface{}{
+		{"physicalServerName": "cluster1", "virtualServerName": "vm1", "virtualizationTechnology": "VMware", "processorModel": "Xeon A"},
+		{"physicalServerName": "ph1", "virtualServerName": "", "virtualizationTechnology": "", "processorModel": "Xeon A"},
+	}
+
+	applyLMSCorrections(rows, getLMSCorrections(changes))
+
+	assert.Equal(t, "esx02", rows[0]["physicalServerName"])
+	assert.Equal(t, "Xeon A", rows[0]["processorModel"])
+	assert.Nil(t, rows[0]["ignored"])
+	assert.Equal(t, "ph1", rows[1]["physicalServerName"])
+}
@@ -183,6 +183,7 @@ func TestSearchHostsAsLMS(t *testing.T) {
 			"physicalCores":            2,
 			"physicalServerName":       "erclin7dbx",
 			"pluggableDatabaseName":    "",
+			"pdbCount":                 4,
 			"processorModel":           "Intel(R) Xeon(R) CPU           E5630  @ 2.53GHz",
 			"processorSpeed":           "2.53GHz",
 			"processors":               2,
@@ -243,6 +244,31 @@ func TestSearchHostsAsLMS(t *testing.T) {
 			"createdAt":                utils.PDT("2020-12-05T00:00:00+02:00"),
 			"dismissedAt":              utils.PDT("2021-05-10T00:00:00+02:00"),
 		},
+		{
+			"coresPerProcessor":        2,
+			"dbInstanceName":           "ignoredb",
+			"environment":              "SVIL",
+			"licenseMetricAllocated":   "processor",
+			"operatingSystem":          "Red Hat Enterprise Linux",
+			"options":                  "",
+			"physicalCores":            4,
+			"physicalServerName":       "ph-ignored",
+			"pluggableDatabaseName":    "",
+			"processorModel":           "Intel(R) Xeon(R) CPU           X5570  @ 2.93GHz",
+			"processorSpeed":           "2.93GHz",
+			"processors":               2,
+			"productLicenseAllocated":  "EE",
+			"productVersion":           "19",
+			"threadsPerCore":           2,
+			"usedManagementPacks":      "",
+			"usingLicenseCount":        0.0,
+			"ignored":                  true,
+			"ignoredComment":           "Test database",
+			"virtualServerName":        "",
+			"virtualizationTechnology": "",
+			"_id":                      utils.Str2oid("5efc38ab79f92e4cbf283b06"),
+			"createdAt":                utils.PDT("2020-12-05T00:00:00+02:00"),
+		},
 	}
 
 	filters := dto.SearchHostsFilters{
@@ -283,6 +309,9 @@ func TestSearchHostsAsLMS(t *testing.T) {
 			db.EXPECT().
 				ListOracleDatabaseContracts().
 				Return([]dto.OracleDatabaseContractFE{}, nil),
+			db.EXPECT().
+				ListLMSPendingChanges(model.LMSPendingChangeStatusApproved).
+				Return([]model.LMSPendingChange{}, nil),
 			db.EXPECT().
 				GetListValidHostsByRangeDates(filterslms.From, filterslms.To).
 				DoAndReturn(func(from time.Time, to time.Time) ([]string, error) {
@@ -327,6 +356,11 @@ func TestSearchHostsAsLMS(t *testing.T) {
 		assert.Equal(t, "2", sp.GetCellValue("Database_&_EBS_DB_Tier", "AG4"))
 		assert.Equal(t, "2.53GHz", sp.GetCellValue("Database_&_EBS_DB_Tier", "AH4"))
 		assert.Equal(t, "Red Hat Enterprise Linux", sp.GetCellValue("Database_&_EBS_DB_Tier", "AJ4"))
+		assert.Equal(t, "Number of Pluggable Databases (PDBs)", sp.GetCellValue("Database_&_EBS_DB_Tier", "AL3"))
+		assert.Equal(t, "4", sp.GetCellValue("Database_&_EBS_DB_Tier", "AL4"))
+		assert.Equal(t, "Ignored", sp.GetCellValue("Database_&_EBS_DB_Tier", "AM3"))
+		assert.Equal(t, "", sp.GetCellValue("Database_&_EBS_DB_Tier", "AK4"))
+		assert.Equal(t, "", sp.GetCellValue("Database_&_EBS_DB_Tier", "AM4"))
 
 		assert.Equal(t, "", sp.GetCellValue("Database_&_EBS_DB_Tier", "B5"))
 		assert.Equal(t, "publicitate-36d06ca83eafa454423d2097f4965517", sp.GetCellValue("Database_&_EBS_DB_Tier", "C5"))
@@ -367,6 +401,12 @@ func TestSearchHostsAsLMS(t *testing.T) {
 		assert.Equal(t, "2", sp.GetCellValue("Database_&_EBS_DB_Tier", "AG6"))
 		assert.Equal(t, "2.93GHz", sp.GetCellValue("Database_&_EBS_DB_Tier", "AH6"))
 		assert.Equal(t, "Red Hat Enterprise Linux", sp.GetCellValue("Database_&_EBS_DB_Tier", "AJ6"))
+
+		assert.Equal(t, "ph-ignored", sp.GetCellValue("Database_&_EBS_DB_Tier", "B7"))
+		assert.Equal(t, "ignoredb", sp.GetCellValue("Database_&_EBS_DB_Tier", "E7"))
+		assert.Equal(t, "0", sp.GetCellValue("Database_&_EBS_DB_Tier", "Q7"))
+		assert.Equal(t, "Test database", sp.GetCellValue("Database_&_EBS_DB_Tier", "AK7"))
+		assert.Equal(t, "Y", sp.GetCellValue("Database_&_EBS_DB_Tier", "AM7"))
 	})
 
 	t.Run("with contracts", func(t *testing.T) {
@@ -418,6 +458,9 @@ func TestSearchHostsAsLMS(t *testing.T) {
 			db.EXPECT().
 				ListOracleDatabaseContracts().
 				Return(contracts, nil),
+			db.EXPECT().
+				ListLMSPendingChanges(model.LMSPendingChangeStatusApproved).
+				Return([]model.LMSPendingChange{}, nil),
 		)
 
 		sp, err := as.SearchHostsAsLMS(filterslms)
@@ -991,6 +1034,8 @@ func TestDismissHost_Success(t *testing.T) {
 
 	listContracts := []dto.OracleDatabaseContractFE{}
 
+	db.EXPECT().FindHostIdentityByAlias("foobar").Return(nil, utils.ErrHostIdentityNotFound).Times(1)
+
 	filter := dto.AlertsFilter{OtherInfo: map[string]interface{}{"hostname": "foobar"}}
 	db.EXPECT().RemoveAlertsNODATA(filter).Return(nil).Times(1)
 	db.EXPECT().CountAlertsNODATA(filter).Return(count, nil).Times(1)
@@ -1038,6 +1083,8 @@ func TestDismissHost_Fail(t *testing.T) {
 
 	listContracts := []dto.OracleDatabaseContractFE{}
 
+	db.EXPECT().FindHostIdentityByAlias("foobar").Return(nil, utils.ErrHostIdentityNotFound).Times(1)
+
 	filter := dto.AlertsFilter{OtherInfo: map[string]interface{}{"hostname": "foobar"}}
 	db.EXPECT().RemoveAlertsNODATA(filter).Return(nil).Times(1)
 	db.EXPECT().CountAlertsNODATA(filter).Return(count, nil).Times(1)
@@ -0,0 +1,49 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func (as *APIService) ListLicenseIgnoreRules() ([]model.LicenseIgnoreRule, error) {
+	return as.Database.ListLicenseIgnoreRules()
+}
+
+func (as *APIService) AddLicenseIgnoreRule(rule model.LicenseIgnoreRule) (*model.LicenseIgnoreRule, error) {
+	rule.ID = as.NewObjectID()
+	rule.CreatedAt = as.TimeNow()
+
+	if err := as.Database.InsertLicenseIgnoreRule(rule); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (as *APIService) UpdateLicenseIgnoreRule(rule model.LicenseIgnoreRule) (*model.LicenseIgnoreRule, error) {
+	if err := as.Database.UpdateLicenseIgnoreRule(rule); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (as *APIService) DeleteLicenseIgnoreRule(id primitive.ObjectID) error {
+	return as.Database.RemoveLicenseIgnoreRule(id)
+}
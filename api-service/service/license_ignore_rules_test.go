@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddLicenseIgnoreRule_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	rule := model.LicenseIgnoreRule{
+		HostnameRegex: "^test-",
+		Justification: "Test hosts covered by the development agreement",
+		ExpiresAt:     utils.P("2020-01-01T00:00:00Z"),
+	}
+
+	expected := rule
+	expected.ID = utils.Str2oid("000000000000000000000001")
+	expected.CreatedAt = utils.P("2019-11-05T14:02:03Z")
+
+	db.EXPECT().InsertLicenseIgnoreRule(expected).Return(nil)
+
+	res, err := as.AddLicenseIgnoreRule(rule)
+	require.NoError(t, err)
+	assert.Equal(t, &expected, res)
+}
+
+func TestAddLicenseIgnoreRule_Fail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	db.EXPECT().InsertLicenseIgnoreRule(gomock.Any()).Return(aerrMock)
+
+	res, err := as.AddLicenseIgnoreRule(model.LicenseIgnoreRule{})
+	require.Nil(t, res)
+	assert.Equal(t, aerrMock, err)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"time"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+)
+
+func (as *APIService) getHistoricDatabaseLicensesCompliance(at time.Time) ([]dto.LicenseCompliance, error) {
+	historic, err := as.Database.GetLicensesComplianceAt(at)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := make([]dto.LicenseCompliance, 0, len(historic))
+
+	for _, license := range historic {
+		if license.Covered == 0 && license.Consumed == 0 {
+			continue
+		}
+
+		license.Available = license.Purchased - license.Covered
+
+		if license.Consumed == 0 {
+			license.Compliance = 1
+		} else {
+			license.Compliance = license.Covered / license.Consumed
+		}
+
+		licenses = append(licenses, license)
+	}
+
+	return licenses, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetDatabaseLicensesCompliance_At(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	at := utils.P("2020-03-01T23:59:59Z")
+
+	t.Run("success", func(t *testing.T) {
+		db.EXPECT().GetLicensesComplianceAt(at).Return([]dto.LicenseCompliance{
+			{LicenseTypeID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Consumed: 10, Covered: 8, Purchased: 12},
+			{LicenseTypeID: "A90619", ItemDescription: "Real Application Clusters", Consumed: 0, Covered: 0, Purchased: 4},
+			{LicenseTypeID: "A90649", ItemDescription: "Partitioning", Consumed: 0, Covered: 2, Purchased: 2},
+		}, nil)
+
+		actual, err := as.GetDatabaseLicensesCompliance(at)
+		require.NoError(t, err)
+
+		expected := []dto.LicenseCompliance{
+			{LicenseTypeID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Consumed: 10, Covered: 8, Purchased: 12, Compliance: 0.8, Available: 4},
+			{LicenseTypeID: "A90649", ItemDescription: "Partitioning", Consumed: 0, Covered: 2, Purchased: 2, Compliance: 1, Available: 0},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db.EXPECT().GetLicensesComplianceAt(at).Return(nil, aerrMock)
+
+		_, err := as.GetDatabaseLicensesCompliance(at)
+		require.Equal(t, aerrMock, err)
+	})
+}
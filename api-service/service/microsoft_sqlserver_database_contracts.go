@@ -26,17 +26,7 @@ import (
 )
 
 func (as *APIService) AddSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
-		return nil, err
-	}
-
-	if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
-		return nil, err
-	}
-
-	contract.ID = as.NewObjectID()
-
-	err := as.Database.InsertSqlServerDatabaseContract(contract)
+	id, err := as.insertSqlServerDatabaseContract(contract)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +37,7 @@ func (as *APIService) AddSqlServerDatabaseContract(contract model.SqlServerDatab
 	}
 
 	for _, agr := range agrs {
-		if agr.ID == contract.ID {
+		if agr.ID == id {
 			return &agr, nil
 		}
 	}
@@ -55,6 +45,25 @@ func (as *APIService) AddSqlServerDatabaseContract(contract model.SqlServerDatab
 	return nil, utils.NewError(errors.New("Can't find contract which has just been saved"))
 }
 
+// insertSqlServerDatabaseContract check the hosts and the license type of the contract and insert it, it return its ID
+func (as *APIService) insertSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (primitive.ObjectID, error) {
+	if err := checkHosts(as, contract.Hosts); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	contract.ID = as.NewObjectID()
+
+	if err := as.Database.InsertSqlServerDatabaseContract(contract); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	return contract.ID, nil
+}
+
 func (as *APIService) sqlServerLicenseTypeIDExists(licenseTypeID string) error {
 	_, err := as.GetSqlServerDatabaseLicenseType(licenseTypeID)
 	if err != nil {
@@ -85,6 +94,7 @@ func (as *APIService) GetSqlServerDatabaseContractsAsXLSX() (*excelize.File, err
 		"ContractID",
 		"LicensesNumber",
 		"Support Expiration",
+		"Software Assurance",
 		"Hosts",
 		"Clusters",
 	}
@@ -108,6 +118,8 @@ func (as *APIService) GetSqlServerDatabaseContractsAsXLSX() (*excelize.File, err
 			sheets.SetCellValue(sheet, nextAxis(), "")
 		}
 
+		sheets.SetCellValue(sheet, nextAxis(), val.SoftwareAssurance)
+
 		for _, val2 := range val.Hosts {
 			sheets.DuplicateRow(sheet, axisHelp.GetIndexRow())
 			duplicateRowNextAxis := axisHelp.NewRowSincePreviousColumn()
@@ -131,15 +143,7 @@ func (as *APIService) DeleteSqlServerDatabaseContract(id primitive.ObjectID) err
 }
 
 func (as *APIService) UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
-		return nil, err
-	}
-
-	if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
-		return nil, err
-	}
-
-	if err := as.Database.UpdateSqlServerDatabaseContract(contract); err != nil {
+	if err := as.updateSqlServerDatabaseContract(contract); err != nil {
 		return nil, err
 	}
 
@@ -156,3 +160,16 @@ func (as *APIService) UpdateSqlServerDatabaseContract(contract model.SqlServerDa
 
 	return nil, utils.NewError(errors.New("Can't find contract which has just been saved"))
 }
+
+// updateSqlServerDatabaseContract check the hosts and the license type of the contract and update it
+func (as *APIService) updateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) error {
+	if err := checkHosts(as, contract.Hosts); err != nil {
+		return err
+	}
+
+	if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
+		return err
+	}
+
+	return as.Database.UpdateSqlServerDatabaseContract(contract)
+}
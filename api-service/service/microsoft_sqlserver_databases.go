@@ -30,14 +30,14 @@ import (
 
 func (as *APIService) SearchSqlServerInstances(f dto.SearchSqlServerInstancesFilter) (*dto.SqlServerInstanceResponse, error) {
 	return as.Database.SearchSqlServerInstances(strings.Split(f.Search, " "), f.SortBy, f.SortDesc,
-		f.PageNumber, f.PageSize, f.Location, f.Environment, f.OlderThan)
+		f.PageNumber, f.PageSize, f.Location, f.Environment, f.Site, f.OlderThan)
 }
 
 func (as *APIService) SearchSqlServerInstancesAsXLSX(filter dto.SearchSqlServerInstancesFilter) (*excelize.File, error) {
 	instances, err := as.Database.SearchSqlServerInstances(strings.Split(filter.Search, " "),
 		filter.SortBy, filter.SortDesc,
 		-1, -1,
-		filter.Location, filter.Environment, filter.OlderThan)
+		filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -75,12 +75,12 @@ func (as *APIService) SearchSqlServerInstancesAsXLSX(filter dto.SearchSqlServerI
 }
 
 func (as *APIService) GetSqlServerUsedLicenses(hostname string, filter dto.GlobalFilter) (*dto.SqlServerDatabaseUsedLicenseSearchResponse, error) {
-	usedLicenses, err := as.Database.SearchSqlServerDatabaseUsedLicenses(hostname, "", false, -1, -1, filter.Location, filter.Environment, filter.OlderThan)
+	usedLicenses, err := as.Database.SearchSqlServerDatabaseUsedLicenses(hostname, "", false, -1, -1, filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
 
-	any := dto.GlobalFilter{Location: "", Environment: "", OlderThan: utils.MAX_TIME}
+	any := dto.GlobalFilter{Location: "", Environment: "", OlderThan: filter.OlderThan}
 
 	clustersList, err := as.Database.GetClusters(any)
 	if err != nil {
@@ -102,7 +102,7 @@ func (as *APIService) GetSqlServerUsedLicenses(hostname string, filter dto.Globa
 	for _, contract := range contracts {
 		if contract.Type == model.SqlServerContractTypeCluster {
 			for _, clusterName := range contract.Clusters {
-				cluster, err := as.Database.GetCluster(clusterName, utils.MAX_TIME)
+				cluster, err := as.Database.GetCluster(clusterName, filter.OlderThan)
 				if err != nil {
 					continue
 				}
@@ -129,9 +129,81 @@ func (as *APIService) GetSqlServerUsedLicenses(hostname string, filter dto.Globa
 		usedLicense.ContractType = model.SqlServerContractTypeHost
 	}
 
+	instances, err := as.getSqlServerPrimaryCandidates(usedLicenses.Content, hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range usedLicenses.Content {
+		usedLicense := &usedLicenses.Content[i]
+
+		if usedLicense.Passive && isSqlServerPassiveSecondaryFree(*usedLicense, instances, contracts, hostWithCluster) {
+			usedLicense.UsedLicenses = 0
+		}
+	}
+
 	return usedLicenses, nil
 }
 
+// getSqlServerPrimaryCandidates return the instances which can be the primary of the passive secondaries of usedLicenses.
+// The primary can be on another host or in another location, so all the instances are searched when usedLicenses is filtered
+func (as *APIService) getSqlServerPrimaryCandidates(usedLicenses []dto.SqlServerDatabaseUsedLicense, hostname string,
+	filter dto.GlobalFilter) ([]dto.SqlServerDatabaseUsedLicense, error) {
+	if hostname == "" && filter.Location == "" && filter.Environment == "" {
+		return usedLicenses, nil
+	}
+
+	hasPassive := false
+
+	for _, usedLicense := range usedLicenses {
+		if usedLicense.Passive {
+			hasPassive = true
+			break
+		}
+	}
+
+	if !hasPassive {
+		return usedLicenses, nil
+	}
+
+	all, err := as.Database.SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", filter.OlderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	return all.Content, nil
+}
+
+// isSqlServerPassiveSecondaryFree return true if a passive secondary doesn't need licenses, that is its primary,
+// an active instance with its databases, is covered by a contract with Software Assurance
+func isSqlServerPassiveSecondaryFree(passive dto.SqlServerDatabaseUsedLicense, instances []dto.SqlServerDatabaseUsedLicense,
+	contracts []model.SqlServerDatabaseContract, hostWithCluster map[string]string) bool {
+	for _, primary := range instances {
+		if primary.Passive || !sharesSqlServerDatabases(primary.Databases, passive.Databases) {
+			continue
+		}
+
+		for _, contract := range contracts {
+			if contract.CoversPassiveSecondaries(primary.LicenseTypeID) &&
+				contract.CoversInstance(primary.Hostname, hostWithCluster[primary.Hostname]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func sharesSqlServerDatabases(a, b []string) bool {
+	for _, name := range a {
+		if utils.Contains(b, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (as *APIService) GetSqlServerDatabaseLicensesCompliance() ([]dto.LicenseCompliance, error) {
 	licenses := make(map[string]*dto.LicenseCompliance)
 	purchasedContracts := make(map[string]int)
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,13 +73,13 @@ func TestSearchSqlServerInstances_Success(t *testing.T) {
 	db.EXPECT().SearchSqlServerInstances(
 		[]string{"foo", "bar", "foobarx"}, "Hostname",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(&expectedRes, nil).Times(1)
 
 	res, err := as.SearchSqlServerInstances(
 		dto.SearchSqlServerInstancesFilter{
 			dto.GlobalFilter{
-				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"), "",
 			},
 			"foo bar foobarx", "Hostname",
 			true, 1, 1,
@@ -100,14 +101,14 @@ func TestSearchSqlServerInstances_Fail(t *testing.T) {
 	db.EXPECT().SearchSqlServerInstances(
 		[]string{"foo", "bar", "foobarx"}, "Memory",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.SearchSqlServerInstances(
 
 		dto.SearchSqlServerInstancesFilter{
 			dto.GlobalFilter{
-				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"), "",
 			},
 			"foo bar foobarx", "Memory",
 			true, 1, 1,
@@ -117,3 +118,99 @@ func TestSearchSqlServerInstances_Fail(t *testing.T) {
 	require.Nil(t, res)
 	assert.Equal(t, aerrMock, err)
 }
+
+func TestGetSqlServerUsedLicenses_PassiveSecondaries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	globalFilter := dto.GlobalFilter{OlderThan: utils.MAX_TIME}
+
+	usedLicenses := func() *dto.SqlServerDatabaseUsedLicenseSearchResponse {
+		return &dto.SqlServerDatabaseUsedLicenseSearchResponse{
+			Content: []dto.SqlServerDatabaseUsedLicense{
+				{LicenseTypeID: "STD-2019", DbName: "primary", Hostname: "host1", UsedLicenses: 2, Databases: []string{"sales"}},
+				{LicenseTypeID: "STD-2019", DbName: "secondary", Hostname: "host2", UsedLicenses: 2, Passive: true, Databases: []string{"sales"}},
+				{LicenseTypeID: "STD-2019", DbName: "other", Hostname: "host3", UsedLicenses: 2, Databases: []string{"hr"}},
+			},
+		}
+	}
+
+	t.Run("Primary with Software Assurance", func(t *testing.T) {
+		contracts := []model.SqlServerDatabaseContract{
+			{Type: model.SqlServerContractTypeHost, LicenseTypeID: "STD-2019", LicensesNumber: 2, SoftwareAssurance: true, Hosts: []string{"host1"}},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).Return(usedLicenses(), nil),
+			db.EXPECT().GetClusters(globalFilter).Return([]dto.Cluster{}, nil),
+			db.EXPECT().ListSqlServerDatabaseContracts().Return(contracts, nil),
+		)
+
+		res, err := as.GetSqlServerUsedLicenses("", globalFilter)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(2), res.Content[0].UsedLicenses)
+		assert.Equal(t, float64(0), res.Content[1].UsedLicenses)
+		assert.Equal(t, model.SqlServerContractTypeHost, res.Content[1].ContractType)
+	})
+
+	t.Run("Software Assurance of another host", func(t *testing.T) {
+		contracts := []model.SqlServerDatabaseContract{
+			{Type: model.SqlServerContractTypeHost, LicenseTypeID: "STD-2019", LicensesNumber: 2, SoftwareAssurance: true, Hosts: []string{"host3"}},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).Return(usedLicenses(), nil),
+			db.EXPECT().GetClusters(globalFilter).Return([]dto.Cluster{}, nil),
+			db.EXPECT().ListSqlServerDatabaseContracts().Return(contracts, nil),
+		)
+
+		res, err := as.GetSqlServerUsedLicenses("", globalFilter)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(2), res.Content[1].UsedLicenses)
+	})
+
+	t.Run("Without Software Assurance", func(t *testing.T) {
+		contracts := []model.SqlServerDatabaseContract{
+			{Type: model.SqlServerContractTypeHost, LicenseTypeID: "STD-2019", LicensesNumber: 2, Hosts: []string{"host1"}},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).Return(usedLicenses(), nil),
+			db.EXPECT().GetClusters(globalFilter).Return([]dto.Cluster{}, nil),
+			db.EXPECT().ListSqlServerDatabaseContracts().Return(contracts, nil),
+		)
+
+		res, err := as.GetSqlServerUsedLicenses("", globalFilter)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(2), res.Content[0].UsedLicenses)
+		assert.Equal(t, float64(2), res.Content[1].UsedLicenses)
+	})
+
+	t.Run("Primary on another host", func(t *testing.T) {
+		contracts := []model.SqlServerDatabaseContract{
+			{Type: model.SqlServerContractTypeHost, LicenseTypeID: "STD-2019", LicensesNumber: 2, SoftwareAssurance: true, Hosts: []string{"host1"}},
+		}
+		secondary := &dto.SqlServerDatabaseUsedLicenseSearchResponse{
+			Content: []dto.SqlServerDatabaseUsedLicense{usedLicenses().Content[1]},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().SearchSqlServerDatabaseUsedLicenses("host2", "", false, -1, -1, "", "", "", utils.MAX_TIME).Return(secondary, nil),
+			db.EXPECT().GetClusters(globalFilter).Return([]dto.Cluster{}, nil),
+			db.EXPECT().ListSqlServerDatabaseContracts().Return(contracts, nil),
+			db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).Return(usedLicenses(), nil),
+		)
+
+		res, err := as.GetSqlServerUsedLicenses("host2", globalFilter)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(0), res.Content[0].UsedLicenses)
+	})
+}
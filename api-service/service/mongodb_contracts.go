@@ -0,0 +1,49 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package service is a package that provides methods for querying data
+package service
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func (as *APIService) AddMongoDBContract(contract model.MongoDBContract) (*model.MongoDBContract, error) {
+	return addSubscriptionContract(as, contract, as.Database.AddMongoDBContract)
+}
+
+func (as *APIService) UpdateMongoDBContract(contract model.MongoDBContract) (*model.MongoDBContract, error) {
+	return updateSubscriptionContract(contract, as.Database.UpdateMongoDBContract)
+}
+
+func (as *APIService) GetMongoDBContracts() ([]model.MongoDBContract, error) {
+	return as.Database.GetMongoDBContracts()
+}
+
+func (as *APIService) DeleteMongoDBContract(id primitive.ObjectID) error {
+	return as.Database.DeleteMongoDBContract(id)
+}
+
+func (as *APIService) GetMongoDBContractsAsXLSX() (*excelize.File, error) {
+	contracts, err := as.GetMongoDBContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionContractsAsXLSX(as, contracts)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// GetMongoDBLicenseTypes return the list of MongoDBLicenseTypes
+func (as *APIService) GetMongoDBLicenseTypes() ([]model.MongoDBLicenseType, error) {
+	parts := []model.MongoDBLicenseType{
+		{
+			ID:              model.MongoDBEnterprisePartNumber,
+			ItemDescription: model.MongoDBEnterpriseItemDescription,
+		},
+	}
+
+	return parts, nil
+}
+
+// GetMongoDBUsedLicenses return the subscriptions used by the MongoDB Enterprise instances.
+// The instances which don't report their edition are counted only if MongoDBAssumeEnterpriseEdition is enabled. An instance is
+// counted per instance if its host is associated to a per instance contract, otherwise all the cores of its host are counted
+func (as *APIService) GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MongoDBUsedLicense, error) {
+	contracts, err := as.Database.GetMongoDBContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	return as.mongodbUsedLicenses(hostname, filter, contracts)
+}
+
+func (as *APIService) mongodbUsedLicenses(hostname string, filter dto.GlobalFilter, contracts []model.MongoDBContract) ([]dto.MongoDBUsedLicense, error) {
+	instances, err := as.Database.GetMongoDBUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	assumeEnterprise := as.Config.APIService.MongoDBAssumeEnterpriseEdition
+
+	return subscriptionUsedLicenses(model.MongoDBEnterprisePartNumber, instances, contracts,
+		func(instance dto.SubscriptionUsedLicense) bool {
+			return isMongoDBEnterprise(instance.Edition, assumeEnterprise)
+		}), nil
+}
+
+func isMongoDBEnterprise(edition string, assumeEnterprise bool) bool {
+	if edition == "" {
+		return assumeEnterprise
+	}
+
+	return strings.EqualFold(edition, model.MongoDBEditionEnterprise)
+}
+
+func (as *APIService) getMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error) {
+	lics, err := as.GetMongoDBUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionDatabaseUsedLicenses(model.MongoDBEnterpriseItemDescription, lics), nil
+}
+
+// GetMongoDBDatabaseLicensesCompliance return the compliance of the MongoDB subscriptions
+// used by the instances at olderThan, compared with the current contracts
+func (as *APIService) GetMongoDBDatabaseLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error) {
+	any := dto.GlobalFilter{
+		Location:    "",
+		Environment: "",
+		OlderThan:   olderThan,
+	}
+
+	contracts, err := as.Database.GetMongoDBContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	usedLicenses, err := as.mongodbUsedLicenses("", any, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionsCompliance(model.MongoDBEnterprisePartNumber, model.MongoDBEnterpriseItemDescription, usedLicenses, contracts), nil
+}
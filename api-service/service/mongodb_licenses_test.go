@@ -0,0 +1,64 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func TestMongodbUsedLicenses_Edition(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	instances := []dto.MongoDBUsedLicense{
+		{Hostname: "foo", InstanceName: "enterprise", Edition: model.MongoDBEditionEnterprise, CPUCores: 4},
+		{Hostname: "foo", InstanceName: "community", Edition: model.MongoDBEditionCommunity, CPUCores: 4},
+		{Hostname: "bar", InstanceName: "unknown", CPUCores: 8},
+	}
+	contracts := []model.MongoDBContract{
+		{Type: model.MongoDBContractTypeInstance, Hosts: []string{"foo"}},
+	}
+
+	db.EXPECT().GetMongoDBUsedLicenses("", globalFilterAny).Return(instances, nil).Times(2)
+
+	actual, err := as.mongodbUsedLicenses("", globalFilterAny, contracts)
+	require.NoError(t, err)
+	expected := []dto.MongoDBUsedLicense{
+		{LicenseTypeID: model.MongoDBEnterprisePartNumber, Hostname: "foo", InstanceName: "enterprise",
+			Edition: model.MongoDBEditionEnterprise, CPUCores: 4, UsedLicenses: 1, ContractType: model.MongoDBContractTypeInstance},
+	}
+	assert.Equal(t, expected, actual)
+
+	as.Config = config.Configuration{APIService: config.APIService{MongoDBAssumeEnterpriseEdition: true}}
+
+	actual, err = as.mongodbUsedLicenses("", globalFilterAny, contracts)
+	require.NoError(t, err)
+	expected = append(expected, dto.MongoDBUsedLicense{LicenseTypeID: model.MongoDBEnterprisePartNumber, Hostname: "bar",
+		InstanceName: "unknown", CPUCores: 8, UsedLicenses: 8, ContractType: model.MongoDBContractTypeCore})
+	assert.Equal(t, expected, actual)
+}
@@ -115,7 +115,7 @@ func (as *APIService) GetMySQLUsedLicenses(hostname string, filter dto.GlobalFil
 		return nil, err
 	}
 
-	any := dto.GlobalFilter{Location: "", Environment: "", OlderThan: utils.MAX_TIME}
+	any := dto.GlobalFilter{Location: "", Environment: "", OlderThan: filter.OlderThan}
 
 	clustersList, err := as.Database.GetClusters(any)
 	if err != nil {
@@ -137,7 +137,7 @@ func (as *APIService) GetMySQLUsedLicenses(hostname string, filter dto.GlobalFil
 	for _, contract := range contracts {
 		if contract.Type == model.MySQLContractTypeCluster {
 			for _, clusterName := range contract.Clusters {
-				cluster, err := as.Database.GetCluster(clusterName, utils.MAX_TIME)
+				cluster, err := as.Database.GetCluster(clusterName, filter.OlderThan)
 				if err != nil {
 					continue
 				}
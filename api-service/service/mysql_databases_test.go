@@ -310,7 +310,7 @@ func TestGetMySQLUsedLicenses(t *testing.T) {
 		any := dto.GlobalFilter{
 			Location:    "",
 			Environment: "",
-			OlderThan:   utils.MAX_TIME,
+			OlderThan:   filter.OlderThan,
 		}
 
 		gomock.InOrder(
@@ -320,7 +320,7 @@ func TestGetMySQLUsedLicenses(t *testing.T) {
 				Return(tc.clusters, nil),
 			db.EXPECT().GetMySQLContracts().
 				Return(tc.contracts, nil),
-			db.EXPECT().GetCluster("plutocluster", utils.MAX_TIME).
+			db.EXPECT().GetCluster("plutocluster", filter.OlderThan).
 				Return(&cluster, nil).AnyTimes(),
 		)
 
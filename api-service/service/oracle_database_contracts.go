@@ -20,6 +20,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/360EntSecGroup-Skylar/excelize"
 
@@ -33,17 +34,7 @@ import (
 )
 
 func (as *APIService) AddOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
-		return nil, err
-	}
-
-	if err := checkLicenseTypeIDExists(as, &contract); err != nil {
-		return nil, err
-	}
-
-	contract.ID = as.NewObjectID()
-
-	err := as.Database.InsertOracleDatabaseContract(contract)
+	id, err := as.insertOracleDatabaseContract(contract)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +45,7 @@ func (as *APIService) AddOracleDatabaseContract(contract model.OracleDatabaseCon
 	}
 
 	for _, agr := range agrs {
-		if agr.ID == contract.ID {
+		if agr.ID == id {
 			return &agr, nil
 		}
 	}
@@ -62,6 +53,25 @@ func (as *APIService) AddOracleDatabaseContract(contract model.OracleDatabaseCon
 	return nil, utils.NewError(errors.New("Can't find contract which has just been saved"))
 }
 
+// insertOracleDatabaseContract check the hosts and the license type of the contract and insert it, it return its ID
+func (as *APIService) insertOracleDatabaseContract(contract model.OracleDatabaseContract) (primitive.ObjectID, error) {
+	if err := checkHosts(as, contract.Hosts); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	if err := checkLicenseTypeIDExists(as, &contract); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	contract.ID = as.NewObjectID()
+
+	if err := as.Database.InsertOracleDatabaseContract(contract); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	return contract.ID, nil
+}
+
 func checkLicenseTypeIDExists(as *APIService, contract *model.OracleDatabaseContract) error {
 	_, err := as.GetOracleDatabaseLicenseType(contract.LicenseTypeID)
 	if err != nil {
@@ -72,15 +82,7 @@ func checkLicenseTypeIDExists(as *APIService, contract *model.OracleDatabaseCont
 }
 
 func (as *APIService) UpdateOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
-		return nil, err
-	}
-
-	if err := checkLicenseTypeIDExists(as, &contract); err != nil {
-		return nil, err
-	}
-
-	if err := as.Database.UpdateOracleDatabaseContract(contract); err != nil {
+	if err := as.updateOracleDatabaseContract(contract); err != nil {
 		return nil, err
 	}
 
@@ -98,25 +100,29 @@ func (as *APIService) UpdateOracleDatabaseContract(contract model.OracleDatabase
 	return nil, utils.NewError(errors.New("Can't find contract which has just been saved"))
 }
 
+// updateOracleDatabaseContract check the hosts and the license type of the contract and update it
+func (as *APIService) updateOracleDatabaseContract(contract model.OracleDatabaseContract) error {
+	if err := checkHosts(as, contract.Hosts); err != nil {
+		return err
+	}
+
+	if err := checkLicenseTypeIDExists(as, &contract); err != nil {
+		return err
+	}
+
+	return as.Database.UpdateOracleDatabaseContract(contract)
+}
+
 func (as *APIService) GetOracleDatabaseContracts(filter dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error) {
 	if as.mockGetOracleDatabaseContracts != nil {
 		return as.mockGetOracleDatabaseContracts(filter)
 	}
 
-	contracts, err := as.Database.ListOracleDatabaseContracts()
-	if err != nil {
-		return nil, err
-	}
-
-	usages, err := as.getLicensesUsage()
+	contracts, err := as.getOracleDatabaseContractsAt(utils.MAX_TIME)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := as.assignOracleDatabaseContractsToHosts(contracts, usages); err != nil {
-		return nil, utils.NewError(err, "DB ERROR")
-	}
-
 	filteredAgrs := make([]dto.OracleDatabaseContractFE, 0)
 
 	for _, agr := range contracts {
@@ -128,6 +134,29 @@ func (as *APIService) GetOracleDatabaseContracts(filter dto.GetOracleDatabaseCon
 	return filteredAgrs, nil
 }
 
+// getOracleDatabaseContractsAt return the contracts assigned to the hosts by the licenses they used at the point in time
+func (as *APIService) getOracleDatabaseContractsAt(olderThan time.Time) ([]dto.OracleDatabaseContractFE, error) {
+	if as.mockGetOracleDatabaseContracts != nil {
+		return as.mockGetOracleDatabaseContracts(dto.NewGetOracleDatabaseContractsFilter())
+	}
+
+	contracts, err := as.Database.ListOracleDatabaseContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := as.getLicensesUsageAt(olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.assignOracleDatabaseContractsToHosts(contracts, usages); err != nil {
+		return nil, err
+	}
+
+	return contracts, nil
+}
+
 func (as *APIService) GetOracleDatabaseContractsAsXLSX(filter dto.GetOracleDatabaseContractsFilter) (*excelize.File, error) {
 	contracts, err := as.GetOracleDatabaseContracts(filter)
 	if err != nil {
@@ -202,9 +231,27 @@ func (as *APIService) GetOracleDatabaseContractsAsXLSX(filter dto.GetOracleDatab
 }
 
 // assignOracleDatabaseContractsToHosts assign available licenses in each contracts to hosts using licenses
+// with the algorithm selected in the configuration
 func (as *APIService) assignOracleDatabaseContractsToHosts(
 	agrs []dto.OracleDatabaseContractFE,
 	usages []dto.HostUsingOracleDatabaseLicenses) error {
+	return as.assignOracleDatabaseContractsToHostsWithMode(agrs, usages, as.oracleDatabaseContractsAssignmentMode())
+}
+
+// oracleDatabaseContractsAssignmentMode return the configured contracts assignment algorithm, Greedy by default
+func (as *APIService) oracleDatabaseContractsAssignmentMode() string {
+	if model.IsValidOracleDatabaseContractsAssignmentMode(as.Config.APIService.OracleDatabaseContractsAssignmentMode) {
+		return as.Config.APIService.OracleDatabaseContractsAssignmentMode
+	}
+
+	return model.OracleDatabaseContractsAssignmentGreedy
+}
+
+// assignOracleDatabaseContractsToHostsWithMode assign available licenses in each contracts to hosts using licenses
+func (as *APIService) assignOracleDatabaseContractsToHostsWithMode(
+	agrs []dto.OracleDatabaseContractFE,
+	usages []dto.HostUsingOracleDatabaseLicenses,
+	mode string) error {
 	licenseTypes, err := as.Database.GetOracleDatabaseLicenseTypes()
 	if err != nil {
 		return err
@@ -222,6 +269,16 @@ func (as *APIService) assignOracleDatabaseContractsToHosts(
 
 	fillContractsInfo(as, agrs, licenseTypesMap)
 
+	if mode == model.OracleDatabaseContractsAssignmentOptimized {
+		if err := as.assignContractsLicensesOptimally(agrs, usages); err != nil {
+			return err
+		}
+
+		calculateTotalCoveredAndConsumedLicenses(agrs, usagesMap)
+
+		return nil
+	}
+
 	err = assignContractsLicensesToItsAssociatedHosts(as, agrs, usagesMap)
 	if err != nil {
 		return err
@@ -0,0 +1,499 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// Costs of the assignment of a license, cheaper assignments are preferred
+const (
+	assignmentCostRestricted = 0
+	assignmentCostAssociated = 1
+	assignmentCostBasket     = 2
+)
+
+const assignmentEpsilon = 1e-9
+
+// assignContractsLicensesOptimally assign the licenses of the contracts to the hosts solving, for each license type,
+// a min-cost max-flow problem: the covered licenses are maximized and restricted contracts are preferred to
+// the other associated contracts, which are preferred to basket contracts
+func (as *APIService) assignContractsLicensesOptimally(
+	contracts []dto.OracleDatabaseContractFE,
+	usages []dto.HostUsingOracleDatabaseLicenses) error {
+	clusters := make(map[string]*dto.Cluster)
+
+	for _, ltID := range contractsLicenseTypeIDs(contracts) {
+		contractIdxs := make([]int, 0)
+
+		for i := range contracts {
+			if contracts[i].LicenseTypeID == ltID && hasAvailableLicenses(&contracts[i]) {
+				contractIdxs = append(contractIdxs, i)
+			}
+		}
+
+		usageIdxs := make([]int, 0)
+		demand := 0.0
+
+		for i := range usages {
+			if usages[i].LicenseTypeID == ltID && usages[i].LicenseCount > 0 {
+				usageIdxs = append(usageIdxs, i)
+				demand += usages[i].LicenseCount
+			}
+		}
+
+		if len(contractIdxs) == 0 || len(usageIdxs) == 0 {
+			continue
+		}
+
+		// the Named User Plus licenses are assigned in blocks of FactorNamedUser, like the greedy assignment does
+		unit := 1.0
+		if contracts[contractIdxs[0]].Metric == model.LicenseTypeMetricNamedUserPlusPerpetual {
+			unit = model.FactorNamedUser
+		}
+
+		// nodes: source, contracts, usages, sink
+		source := 0
+		sink := 1 + len(contractIdxs) + len(usageIdxs)
+		graph := newAssignmentFlowGraph(sink + 1)
+
+		type assignmentArc struct {
+			contract, usage int
+			edge            int
+		}
+
+		arcs := make([]assignmentArc, 0)
+
+		for c, ci := range contractIdxs {
+			contract := &contracts[ci]
+			graph.addEdge(source, 1+c, math.Floor(contractCapacity(contract, demand)/unit), 0)
+
+			for u, ui := range usageIdxs {
+				cost, ok, err := as.assignmentCost(contract, &usages[ui], clusters)
+				if err != nil {
+					return err
+				}
+
+				if !ok {
+					continue
+				}
+
+				edge := graph.addEdge(1+c, 1+len(contractIdxs)+u, math.Floor(usages[ui].LicenseCount/unit), cost)
+				arcs = append(arcs, assignmentArc{contract: ci, usage: ui, edge: edge})
+			}
+		}
+
+		for u, ui := range usageIdxs {
+			graph.addEdge(1+len(contractIdxs)+u, sink, math.Floor(usages[ui].LicenseCount/unit), 0)
+		}
+
+		graph.minCostMaxFlow(source, sink)
+
+		for _, arc := range arcs {
+			from := 1 + indexOf(contractIdxs, arc.contract)
+
+			flow := graph.flow(from, arc.edge) * unit
+			if flow <= assignmentEpsilon {
+				continue
+			}
+
+			doAssignFlow(&contracts[arc.contract], &usages[arc.usage], flow)
+
+			if as.Config.APIService.DebugOracleDatabaseContractsAssignmentAlgorithm {
+				as.Log.Debugf("Distributing %f licenses of contract %s to obj %s. licenseTypeID=%s\n",
+					flow, contracts[arc.contract].ContractID, usages[arc.usage].Name, ltID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// assignmentCost return the cost of covering usage with the licenses of contract and false if contract can't cover it
+func (as *APIService) assignmentCost(contract *dto.OracleDatabaseContractFE, usage *dto.HostUsingOracleDatabaseLicenses,
+	clusters map[string]*dto.Cluster) (float64, bool, error) {
+	for _, host := range contract.Hosts {
+		if host.Hostname == usage.Name {
+			if contract.Restricted {
+				return assignmentCostRestricted, true, nil
+			}
+
+			return assignmentCostAssociated, true, nil
+		}
+	}
+
+	if usage.Type == "cluster" && !contract.Restricted && len(contract.Hosts) > 0 {
+		cluster, ok := clusters[usage.Name]
+		if !ok {
+			var err error
+
+			cluster, err = as.GetCluster(usage.Name, utils.MAX_TIME)
+			if err != nil {
+				return 0, false, err
+			}
+
+			clusters[usage.Name] = cluster
+		}
+
+		for _, vm := range cluster.VMs {
+			for _, host := range contract.Hosts {
+				if vm.Hostname == host.Hostname {
+					return assignmentCostAssociated, true, nil
+				}
+			}
+		}
+	}
+
+	if contract.Basket {
+		return assignmentCostBasket, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// contractCapacity return the licenses that contract can still assign, demand if it's unlimited
+func contractCapacity(contract *dto.OracleDatabaseContractFE, demand float64) float64 {
+	if contract.Unlimited {
+		return demand
+	}
+
+	if contract.Metric == model.LicenseTypeMetricNamedUserPlusPerpetual {
+		return math.Floor(contract.AvailableLicensesPerUser/model.FactorNamedUser) * model.FactorNamedUser
+	}
+
+	return contract.AvailableLicensesPerCore
+}
+
+// doAssignFlow cover flow licenses of usage with the licenses of contract
+func doAssignFlow(contract *dto.OracleDatabaseContractFE, usage *dto.HostUsingOracleDatabaseLicenses, flow float64) {
+	switch {
+	case contract.Unlimited && contract.Metric == model.LicenseTypeMetricNamedUserPlusPerpetual:
+		contract.AvailableLicensesPerUser = 0
+	case contract.Unlimited:
+		contract.AvailableLicensesPerCore = 0
+	case contract.Metric == model.LicenseTypeMetricNamedUserPlusPerpetual:
+		contract.AvailableLicensesPerUser -= flow
+	default:
+		contract.AvailableLicensesPerCore -= flow
+	}
+
+	contract.CoveredLicenses += flow
+	usage.LicenseCount -= flow
+
+	for i := range contract.Hosts {
+		if contract.Hosts[i].Hostname == usage.Name {
+			contract.Hosts[i].CoveredLicensesCount += flow
+		}
+	}
+}
+
+func contractsLicenseTypeIDs(contracts []dto.OracleDatabaseContractFE) []string {
+	ids := make([]string, 0)
+	found := make(map[string]bool)
+
+	for _, contract := range contracts {
+		if !found[contract.LicenseTypeID] {
+			found[contract.LicenseTypeID] = true
+			ids = append(ids, contract.LicenseTypeID)
+		}
+	}
+
+	return ids
+}
+
+func indexOf(values []int, value int) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type assignmentFlowEdge struct {
+	to       int
+	rev      int
+	capacity float64
+	initial  float64
+	cost     float64
+}
+
+// assignmentFlowGraph is a flow network solved with the successive shortest paths algorithm
+type assignmentFlowGraph struct {
+	edges [][]assignmentFlowEdge
+}
+
+func newAssignmentFlowGraph(nodes int) *assignmentFlowGraph {
+	return &assignmentFlowGraph{edges: make([][]assignmentFlowEdge, nodes)}
+}
+
+// addEdge add an edge and its residual edge, it return the index of the edge in the edges of from
+func (g *assignmentFlowGraph) addEdge(from, to int, capacity, cost float64) int {
+	g.edges[from] = append(g.edges[from], assignmentFlowEdge{to: to, rev: len(g.edges[to]), capacity: capacity, initial: capacity, cost: cost})
+	g.edges[to] = append(g.edges[to], assignmentFlowEdge{to: from, rev: len(g.edges[from]) - 1, capacity: 0, cost: -cost})
+
+	return len(g.edges[from]) - 1
+}
+
+// flow return the flow passing through the edge
+func (g *assignmentFlowGraph) flow(from, edge int) float64 {
+	e := g.edges[from][edge]
+
+	return e.initial - e.capacity
+}
+
+// minCostMaxFlow push the maximum flow from source to sink along the cheapest paths.
+// The distances are found by Dijkstra on the costs reduced by the potentials of the nodes, which are never negative
+// also on the residual edges, then the flow is pushed on all the shortest paths at once, like Dinic does
+func (g *assignmentFlowGraph) minCostMaxFlow(source, sink int) {
+	nodes := len(g.edges)
+	// the potentials start from zero because the costs of the edges aren't negative
+	potentials := make([]float64, nodes)
+
+	for {
+		dist := g.shortestPaths(source, potentials)
+		if math.IsInf(dist[sink], 1) {
+			return
+		}
+
+		// the nodes not reached get the farthest distance, so the reduced costs of their edges stay not negative
+		maxDist := 0.0
+		for _, d := range dist {
+			if !math.IsInf(d, 1) {
+				maxDist = math.Max(maxDist, d)
+			}
+		}
+
+		for v, d := range dist {
+			if math.IsInf(d, 1) {
+				d = maxDist
+			}
+
+			potentials[v] += d
+		}
+
+		for g.pushShortestPathsFlow(source, sink, potentials) {
+		}
+	}
+}
+
+// shortestPaths return the distances from source with the costs reduced by the potentials
+func (g *assignmentFlowGraph) shortestPaths(source int, potentials []float64) []float64 {
+	dist := make([]float64, len(g.edges))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+
+	dist[source] = 0
+
+	queue := &assignmentFlowQueue{{node: source}}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(assignmentFlowQueueItem)
+		u := item.node
+
+		if item.dist > dist[u] {
+			continue
+		}
+
+		for _, e := range g.edges[u] {
+			if e.capacity <= assignmentEpsilon {
+				continue
+			}
+
+			// the reduced cost can be slightly negative only because of the rounding
+			d := dist[u] + math.Max(0, e.cost+potentials[u]-potentials[e.to])
+			if d+assignmentEpsilon < dist[e.to] {
+				dist[e.to] = d
+				heap.Push(queue, assignmentFlowQueueItem{node: e.to, dist: d})
+			}
+		}
+	}
+
+	return dist
+}
+
+// pushShortestPathsFlow push a blocking flow on the edges of the shortest paths, the ones with zero reduced cost.
+// It return false if the sink can't be reached anymore through them
+func (g *assignmentFlowGraph) pushShortestPathsFlow(source, sink int, potentials []float64) bool {
+	level := make([]int, len(g.edges))
+	for i := range level {
+		level[i] = -1
+	}
+
+	level[source] = 0
+	queue := []int{source}
+
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+
+		for _, e := range g.edges[u] {
+			if level[e.to] < 0 && g.isShortestPathEdge(u, e, potentials) {
+				level[e.to] = level[u] + 1
+				queue = append(queue, e.to)
+			}
+		}
+	}
+
+	if level[sink] < 0 {
+		return false
+	}
+
+	next := make([]int, len(g.edges))
+	for g.augment(source, sink, math.Inf(1), level, next, potentials) > assignmentEpsilon {
+	}
+
+	return true
+}
+
+// augment push at most limit flow from u to sink along a path of increasing levels, it return the pushed flow
+func (g *assignmentFlowGraph) augment(u, sink int, limit float64, level, next []int, potentials []float64) float64 {
+	if u == sink {
+		return limit
+	}
+
+	for ; next[u] < len(g.edges[u]); next[u]++ {
+		e := &g.edges[u][next[u]]
+		if level[e.to] != level[u]+1 || !g.isShortestPathEdge(u, *e, potentials) {
+			continue
+		}
+
+		if flow := g.augment(e.to, sink, math.Min(limit, e.capacity), level, next, potentials); flow > assignmentEpsilon {
+			e.capacity -= flow
+			g.edges[e.to][e.rev].capacity += flow
+
+			return flow
+		}
+	}
+
+	return 0
+}
+
+func (g *assignmentFlowGraph) isShortestPathEdge(from int, e assignmentFlowEdge, potentials []float64) bool {
+	return e.capacity > assignmentEpsilon && math.Abs(e.cost+potentials[from]-potentials[e.to]) <= assignmentEpsilon
+}
+
+type assignmentFlowQueueItem struct {
+	node int
+	dist float64
+}
+
+// assignmentFlowQueue is the priority queue of the nodes by distance, used by Dijkstra
+type assignmentFlowQueue []assignmentFlowQueueItem
+
+func (q assignmentFlowQueue) Len() int           { return len(q) }
+func (q assignmentFlowQueue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q assignmentFlowQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *assignmentFlowQueue) Push(x interface{}) {
+	*q = append(*q, x.(assignmentFlowQueueItem))
+}
+
+func (q *assignmentFlowQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
+
+// CompareOracleDatabaseContractsAssignments return the result of both the greedy and the optimized
+// assignment of the Oracle/Database contracts to the hosts
+func (as *APIService) CompareOracleDatabaseContractsAssignments() (*dto.OracleDatabaseContractsAssignmentComparison, error) {
+	contracts, err := as.Database.ListOracleDatabaseContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := as.getLicensesUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := dto.OracleDatabaseContractsAssignmentComparison{
+		Mode: as.oracleDatabaseContractsAssignmentMode(),
+	}
+
+	for _, mode := range []string{model.OracleDatabaseContractsAssignmentGreedy, model.OracleDatabaseContractsAssignmentOptimized} {
+		modeContracts := copyOracleDatabaseContractsFE(contracts)
+		modeUsages := make([]dto.HostUsingOracleDatabaseLicenses, len(usages))
+		copy(modeUsages, usages)
+
+		if err := as.assignOracleDatabaseContractsToHostsWithMode(modeContracts, modeUsages, mode); err != nil {
+			return nil, err
+		}
+
+		result := newOracleDatabaseContractsAssignmentResult(modeContracts, modeUsages)
+
+		if mode == model.OracleDatabaseContractsAssignmentGreedy {
+			comparison.Greedy = result
+		} else {
+			comparison.Optimized = result
+		}
+	}
+
+	return &comparison, nil
+}
+
+func newOracleDatabaseContractsAssignmentResult(contracts []dto.OracleDatabaseContractFE,
+	usages []dto.HostUsingOracleDatabaseLicenses) dto.OracleDatabaseContractsAssignmentResult {
+	result := dto.OracleDatabaseContractsAssignmentResult{
+		Contracts:      make([]dto.OracleDatabaseContractAssignment, 0, len(contracts)),
+		UncoveredHosts: make([]dto.HostUsingOracleDatabaseLicenses, 0),
+	}
+
+	for _, contract := range contracts {
+		result.Contracts = append(result.Contracts, dto.OracleDatabaseContractAssignment{
+			ID:              contract.ID,
+			ContractID:      contract.ContractID,
+			LicenseTypeID:   contract.LicenseTypeID,
+			Basket:          contract.Basket,
+			Restricted:      contract.Restricted,
+			CoveredLicenses: contract.CoveredLicenses,
+			Hosts:           contract.Hosts,
+		})
+	}
+
+	for _, usage := range usages {
+		result.CoveredLicenses += usage.OriginalCount - usage.LicenseCount
+
+		if usage.LicenseCount > assignmentEpsilon {
+			result.UncoveredLicenses += usage.LicenseCount
+			result.UncoveredHosts = append(result.UncoveredHosts, usage)
+		}
+	}
+
+	return result
+}
+
+func copyOracleDatabaseContractsFE(contracts []dto.OracleDatabaseContractFE) []dto.OracleDatabaseContractFE {
+	res := make([]dto.OracleDatabaseContractFE, len(contracts))
+
+	for i, contract := range contracts {
+		res[i] = contract
+		res[i].Hosts = make([]dto.OracleDatabaseContractAssociatedHostFE, len(contract.Hosts))
+		copy(res[i].Hosts, contract.Hosts)
+	}
+
+	return res
+}
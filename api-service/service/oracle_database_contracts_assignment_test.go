@@ -0,0 +1,290 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var assignmentLicenseTypes = []model.OracleDatabaseLicenseType{
+	{
+		ID:              "PID002",
+		ItemDescription: "Oracle Partitioning",
+		Metric:          model.LicenseTypeMetricProcessorPerpetual,
+	},
+}
+
+// assignmentContracts return two contracts that the greedy algorithm can't use to cover all the licenses:
+// the bigger contract is used for host1, leaving host2 uncovered, even if the smaller one can cover host1
+func assignmentContracts() []dto.OracleDatabaseContractFE {
+	return []dto.OracleDatabaseContractFE{
+		{
+			ID:                       utils.Str2oid("5f4d0ab1c6bc19e711bbcce6"),
+			ContractID:               "C1",
+			LicenseTypeID:            "PID002",
+			Hosts:                    []dto.OracleDatabaseContractAssociatedHostFE{{Hostname: "host1"}, {Hostname: "host2"}},
+			LicensesPerCore:          12,
+			AvailableLicensesPerCore: 12,
+		},
+		{
+			ID:                       utils.Str2oid("5f4d0ab1c6bc19e711bbcce7"),
+			ContractID:               "C2",
+			LicenseTypeID:            "PID002",
+			Restricted:               true,
+			Hosts:                    []dto.OracleDatabaseContractAssociatedHostFE{{Hostname: "host1"}},
+			LicensesPerCore:          10,
+			AvailableLicensesPerCore: 10,
+		},
+	}
+}
+
+func assignmentUsages() []dto.HostUsingOracleDatabaseLicenses {
+	return []dto.HostUsingOracleDatabaseLicenses{
+		{Name: "host1", LicenseTypeID: "PID002", LicenseCount: 12, OriginalCount: 12, Type: "host"},
+		{Name: "host2", LicenseTypeID: "PID002", LicenseCount: 10, OriginalCount: 10, Type: "host"},
+	}
+}
+
+func TestAssignOracleDatabaseContractsToHosts_Optimized(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config: config.Configuration{
+			APIService: config.APIService{
+				OracleDatabaseContractsAssignmentMode: model.OracleDatabaseContractsAssignmentOptimized,
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(assignmentLicenseTypes, nil)
+
+	contracts := assignmentContracts()
+	usages := assignmentUsages()
+
+	err := as.assignOracleDatabaseContractsToHosts(contracts, usages)
+	require.NoError(t, err)
+
+	expectedContracts := []dto.OracleDatabaseContractFE{
+		{
+			ID:              utils.Str2oid("5f4d0ab1c6bc19e711bbcce6"),
+			ContractID:      "C1",
+			LicenseTypeID:   "PID002",
+			ItemDescription: "Oracle Partitioning",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+			Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+				{Hostname: "host1", CoveredLicensesCount: 2, TotalCoveredLicensesCount: 12, ConsumedLicensesCount: 12},
+				{Hostname: "host2", CoveredLicensesCount: 10, TotalCoveredLicensesCount: 10, ConsumedLicensesCount: 10},
+			},
+			LicensesPerCore:          12,
+			AvailableLicensesPerCore: 0,
+			CoveredLicenses:          12,
+		},
+		{
+			ID:              utils.Str2oid("5f4d0ab1c6bc19e711bbcce7"),
+			ContractID:      "C2",
+			LicenseTypeID:   "PID002",
+			ItemDescription: "Oracle Partitioning",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+			Restricted:      true,
+			Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+				{Hostname: "host1", CoveredLicensesCount: 10, TotalCoveredLicensesCount: 12, ConsumedLicensesCount: 12},
+			},
+			LicensesPerCore:          10,
+			AvailableLicensesPerCore: 0,
+			CoveredLicenses:          10,
+		},
+	}
+
+	assert.Equal(t, expectedContracts, contracts)
+}
+
+func TestAssignOracleDatabaseContractsToHosts_OptimizedPrefersRestricted(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config:   config.Configuration{},
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(assignmentLicenseTypes, nil)
+
+	contracts := []dto.OracleDatabaseContractFE{
+		{
+			ContractID:               "BASKET",
+			LicenseTypeID:            "PID002",
+			Basket:                   true,
+			LicensesPerCore:          10,
+			AvailableLicensesPerCore: 10,
+		},
+		{
+			ContractID:               "RESTRICTED",
+			LicenseTypeID:            "PID002",
+			Restricted:               true,
+			Hosts:                    []dto.OracleDatabaseContractAssociatedHostFE{{Hostname: "host1"}},
+			LicensesPerCore:          10,
+			AvailableLicensesPerCore: 10,
+		},
+	}
+	usages := []dto.HostUsingOracleDatabaseLicenses{
+		{Name: "host1", LicenseTypeID: "PID002", LicenseCount: 6, OriginalCount: 6, Type: "host"},
+	}
+
+	err := as.assignOracleDatabaseContractsToHostsWithMode(contracts, usages, model.OracleDatabaseContractsAssignmentOptimized)
+	require.NoError(t, err)
+
+	for _, contract := range contracts {
+		switch contract.ContractID {
+		case "BASKET":
+			assert.Equal(t, float64(10), contract.AvailableLicensesPerCore)
+		case "RESTRICTED":
+			assert.Equal(t, float64(4), contract.AvailableLicensesPerCore)
+			assert.Equal(t, float64(6), contract.CoveredLicenses)
+		}
+	}
+
+	assert.Equal(t, float64(0), usages[0].LicenseCount)
+}
+
+func TestAssignOracleDatabaseContractsToHosts_OptimizedNamedUserPlus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config:   config.Configuration{},
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return([]model.OracleDatabaseLicenseType{
+		{
+			ID:              "PID001",
+			ItemDescription: "Oracle Database Enterprise Edition",
+			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
+		},
+	}, nil)
+
+	contracts := []dto.OracleDatabaseContractFE{
+		{
+			ContractID:               "BASKET",
+			LicenseTypeID:            "PID001",
+			Basket:                   true,
+			LicensesPerUser:          75,
+			AvailableLicensesPerUser: 75,
+		},
+	}
+	usages := []dto.HostUsingOracleDatabaseLicenses{
+		{Name: "host1", LicenseTypeID: "PID001", LicenseCount: 40, OriginalCount: 40, Type: "host"},
+		{Name: "host2", LicenseTypeID: "PID001", LicenseCount: 35, OriginalCount: 35, Type: "host"},
+	}
+
+	err := as.assignOracleDatabaseContractsToHostsWithMode(contracts, usages, model.OracleDatabaseContractsAssignmentOptimized)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(25), contracts[0].AvailableLicensesPerUser)
+	assert.Equal(t, float64(50), contracts[0].CoveredLicenses)
+	assert.Equal(t, float64(15), usages[0].LicenseCount)
+	assert.Equal(t, float64(10), usages[1].LicenseCount)
+}
+
+func TestNewOracleDatabaseContractsAssignmentResult(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config:   config.Configuration{},
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(assignmentLicenseTypes, nil).Times(2)
+
+	greedyContracts, greedyUsages := assignmentContracts(), assignmentUsages()
+	require.NoError(t, as.assignOracleDatabaseContractsToHostsWithMode(greedyContracts, greedyUsages, model.OracleDatabaseContractsAssignmentGreedy))
+
+	greedy := newOracleDatabaseContractsAssignmentResult(greedyContracts, greedyUsages)
+	assert.Equal(t, float64(12), greedy.CoveredLicenses)
+	assert.Equal(t, float64(10), greedy.UncoveredLicenses)
+	require.Len(t, greedy.UncoveredHosts, 1)
+	assert.Equal(t, "host2", greedy.UncoveredHosts[0].Name)
+
+	optimizedContracts, optimizedUsages := assignmentContracts(), assignmentUsages()
+	require.NoError(t, as.assignOracleDatabaseContractsToHostsWithMode(optimizedContracts, optimizedUsages, model.OracleDatabaseContractsAssignmentOptimized))
+
+	optimized := newOracleDatabaseContractsAssignmentResult(optimizedContracts, optimizedUsages)
+	assert.Equal(t, float64(22), optimized.CoveredLicenses)
+	assert.Equal(t, float64(0), optimized.UncoveredLicenses)
+	assert.Empty(t, optimized.UncoveredHosts)
+}
+
+func BenchmarkAssignContractsLicensesOptimally(b *testing.B) {
+	const contractsCount, hostsCount = 50, 2000
+
+	as := APIService{Log: logger.NewLogger("TEST")}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+
+		contracts := make([]dto.OracleDatabaseContractFE, contractsCount)
+		for c := range contracts {
+			contracts[c] = dto.OracleDatabaseContractFE{
+				ContractID:               fmt.Sprintf("C%d", c),
+				LicenseTypeID:            "PID002",
+				Metric:                   model.LicenseTypeMetricProcessorPerpetual,
+				Basket:                   c%2 == 0,
+				LicensesPerCore:          60,
+				AvailableLicensesPerCore: 60,
+			}
+
+			for h := c; h < hostsCount; h += contractsCount / 5 {
+				contracts[c].Hosts = append(contracts[c].Hosts, dto.OracleDatabaseContractAssociatedHostFE{Hostname: fmt.Sprintf("host%d", h)})
+			}
+		}
+
+		usages := make([]dto.HostUsingOracleDatabaseLicenses, hostsCount)
+		for h := range usages {
+			count := float64(1 + h%4)
+			usages[h] = dto.HostUsingOracleDatabaseLicenses{
+				Name:          fmt.Sprintf("host%d", h),
+				LicenseTypeID: "PID002",
+				LicenseCount:  count,
+				OriginalCount: count,
+				Type:          "host",
+			}
+		}
+
+		b.StartTimer()
+
+		if err := as.assignContractsLicensesOptimally(contracts, usages); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
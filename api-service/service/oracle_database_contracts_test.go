@@ -415,7 +415,7 @@ func TestGetOracleDatabaseContracts_Success(t *testing.T) {
 			Return(returnedContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -586,7 +586,7 @@ func TestGetOracleDatabaseContractsCluster_Success(t *testing.T) {
 			Return(returnedContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -757,7 +757,7 @@ func TestGetOracleDatabaseContractsClusterCappedCPU_Success(t *testing.T) {
 			Return(returnedContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -961,7 +961,7 @@ func TestGetOracleDatabaseContractsClusterCappedCPU2_Success(t *testing.T) {
 		Return(returnedContracts, nil)
 
 	db.EXPECT().
-		SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 		Return(&oracleLics, nil)
 	db.EXPECT().GetOracleDatabaseLicenseTypes().
 		Return(licenseTypes, nil)
@@ -1065,7 +1065,7 @@ func TestGetOracleDatabaseContracts_SuccessFilter1(t *testing.T) {
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(returnedContracts, nil),
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -1092,7 +1092,7 @@ func TestGetOracleDatabaseContracts_SuccessFilter1(t *testing.T) {
 			Return(returnedContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -1119,7 +1119,7 @@ func TestGetOracleDatabaseContracts_SuccessFilter1(t *testing.T) {
 			Return(returnedContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -1176,7 +1176,7 @@ func TestGetOracleDatabaseContracts_Failed2(t *testing.T) {
 	gomock.InOrder(
 		db.EXPECT().ListOracleDatabaseContracts().
 			Return(returnedContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(nil, aerrMock),
 	)
 
@@ -17,6 +17,7 @@ package service
 
 import (
 	"math"
+	"time"
 
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
@@ -140,10 +141,15 @@ func (as *APIService) GetOracleDatabaseLicensesCompliance() ([]dto.LicenseCompli
 }
 
 func (as *APIService) getLicensesUsage() ([]dto.HostUsingOracleDatabaseLicenses, error) {
+	return as.getLicensesUsageAt(utils.MAX_TIME)
+}
+
+// getLicensesUsageAt return the licenses used by the hosts at the point in time
+func (as *APIService) getLicensesUsageAt(olderThan time.Time) ([]dto.HostUsingOracleDatabaseLicenses, error) {
 	filter := dto.GlobalFilter{
 		Location:    "",
 		Environment: "",
-		OlderThan:   utils.MAX_TIME,
+		OlderThan:   olderThan,
 	}
 
 	usedLicenses, err := as.getOracleDatabasesUsedLicenses("", filter)
@@ -154,7 +160,7 @@ func (as *APIService) getLicensesUsage() ([]dto.HostUsingOracleDatabaseLicenses,
 	usages := make([]dto.HostUsingOracleDatabaseLicenses, 0, len(usedLicenses))
 	hostnamesPerLicense := make(map[string]map[string]bool)
 
-	hostdatas, err := as.Database.GetHostDatas(utils.MAX_TIME)
+	hostdatas, err := as.Database.GetHostDatas(olderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +168,7 @@ func (as *APIService) getLicensesUsage() ([]dto.HostUsingOracleDatabaseLicenses,
 	clusters, err := as.Database.GetClusters(dto.GlobalFilter{
 		Location:    "",
 		Environment: "",
-		OlderThan:   utils.MAX_TIME,
+		OlderThan:   olderThan,
 	})
 	if err != nil {
 		return nil, err
@@ -225,6 +231,11 @@ func (as *APIService) getLicensesUsage() ([]dto.HostUsingOracleDatabaseLicenses,
 				name = usedLicense.Hostname
 			}
 
+			if usedLicense.ClusterLicensingPolicy == model.ClusterLicensingPolicyHardPartitioning {
+				typeClusterHost = "host"
+				name = usedLicense.Hostname
+			}
+
 			_, found := hostnamesPerLicense[name]
 			if !found {
 				hostnamesPerLicense[name] = make(map[string]bool)
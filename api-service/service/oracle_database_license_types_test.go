@@ -241,7 +241,7 @@ func TestGetLicensesCompliance(t *testing.T) {
 		db.EXPECT().
 			ListOracleDatabaseContracts().
 			Return(sampleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
@@ -480,7 +480,7 @@ func TestGetLicensesCompliance_Veritas(t *testing.T) {
 		db.EXPECT().
 			ListOracleDatabaseContracts().
 			Return(sampleContracts, nil),
-		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&searchResponse, nil),
 		db.EXPECT().
 			GetOracleDatabaseLicenseTypes().
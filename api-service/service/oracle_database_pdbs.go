@@ -64,6 +64,8 @@ func (as *APIService) CreateOracleDatabasePdbsXlsx(filter dto.GlobalFilter) (*ex
 		"Schemas",
 		"Services",
 		"GrantDba",
+		"DB Name",
+		"PDB Count",
 	}
 
 	sheets, err := exutils.NewXLSX(as.Config, sheet, headers...)
@@ -86,6 +88,8 @@ func (as *APIService) CreateOracleDatabasePdbsXlsx(filter dto.GlobalFilter) (*ex
 		sheets.SetCellValue(sheet, nextAxis(), val.Schemas)
 		sheets.SetCellValue(sheet, nextAxis(), val.Services)
 		sheets.SetCellValue(sheet, nextAxis(), val.GrantDba)
+		sheets.SetCellValue(sheet, nextAxis(), val.DbName)
+		sheets.SetCellValue(sheet, nextAxis(), val.PDBCount)
 	}
 
 	return sheets, err
@@ -29,21 +29,21 @@ import (
 )
 
 // SearchOracleDatabaseAddms search addms
-func (as *APIService) SearchOracleDatabaseAddms(search string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) ([]map[string]interface{}, error) {
-	return as.Database.SearchOracleDatabaseAddms(strings.Split(search, " "), sortBy, sortDesc, page, pageSize, location, environment, olderThan)
+func (as *APIService) SearchOracleDatabaseAddms(search string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) ([]map[string]interface{}, error) {
+	return as.Database.SearchOracleDatabaseAddms(strings.Split(search, " "), sortBy, sortDesc, page, pageSize, location, environment, site, olderThan)
 }
 
 // SearchOracleDatabaseSegmentAdvisors search segment advisors
-func (as *APIService) SearchOracleDatabaseSegmentAdvisors(search string, sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error) {
+func (as *APIService) SearchOracleDatabaseSegmentAdvisors(search string, sortBy string, sortDesc bool, location string, environment string, site string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error) {
 	result := make([]dto.OracleDatabaseSegmentAdvisor, 0)
 
-	if dbSegmentAdvisors, err := as.Database.SearchOracleDatabaseSegmentAdvisors(strings.Split(search, " "), sortBy, sortDesc, location, environment, olderThan); err != nil {
+	if dbSegmentAdvisors, err := as.Database.SearchOracleDatabaseSegmentAdvisors(strings.Split(search, " "), sortBy, sortDesc, location, environment, site, olderThan); err != nil {
 		return nil, err
 	} else {
 		result = append(result, dbSegmentAdvisors...)
 	}
 
-	if pdbSegmentAdvisors, err := as.Database.SearchOraclePdbSegmentAdvisors(sortBy, sortDesc, location, environment, olderThan); err != nil {
+	if pdbSegmentAdvisors, err := as.Database.SearchOraclePdbSegmentAdvisors(sortBy, sortDesc, location, environment, site, olderThan); err != nil {
 		return nil, err
 	} else {
 		result = append(result, pdbSegmentAdvisors...)
@@ -53,7 +53,7 @@ func (as *APIService) SearchOracleDatabaseSegmentAdvisors(search string, sortBy
 }
 
 func (as *APIService) SearchOracleDatabaseSegmentAdvisorsAsXLSX(filter dto.GlobalFilter) (*excelize.File, error) {
-	segmentAdvisors, err := as.SearchOracleDatabaseSegmentAdvisors("", "", false, filter.Location, filter.Environment, filter.OlderThan)
+	segmentAdvisors, err := as.SearchOracleDatabaseSegmentAdvisors("", "", false, filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +105,12 @@ func (as *APIService) SearchOracleDatabaseSegmentAdvisorsAsXLSX(filter dto.Globa
 }
 
 // SearchOracleDatabasePatchAdvisors search patch advisors
-func (as *APIService) SearchOracleDatabasePatchAdvisors(search string, sortBy string, sortDesc bool, page int, pageSize int, windowTime time.Time, location string, environment string, olderThan time.Time, status string) (*dto.PatchAdvisorResponse, error) {
-	return as.Database.SearchOracleDatabasePatchAdvisors(strings.Split(search, " "), sortBy, sortDesc, page, pageSize, windowTime, location, environment, olderThan, status)
+func (as *APIService) SearchOracleDatabasePatchAdvisors(search string, sortBy string, sortDesc bool, page int, pageSize int, windowTime time.Time, location string, environment string, site string, olderThan time.Time, status string) (*dto.PatchAdvisorResponse, error) {
+	return as.Database.SearchOracleDatabasePatchAdvisors(strings.Split(search, " "), sortBy, sortDesc, page, pageSize, windowTime, location, environment, site, olderThan, status)
 }
 
 func (as *APIService) SearchOracleDatabasePatchAdvisorsAsXLSX(windowTime time.Time, filter dto.GlobalFilter) (*excelize.File, error) {
-	patchAdvisorResponse, err := as.Database.SearchOracleDatabasePatchAdvisors([]string{}, "", false, -1, -1, windowTime, filter.Location, filter.Environment, filter.OlderThan, "")
+	patchAdvisorResponse, err := as.Database.SearchOracleDatabasePatchAdvisors([]string{}, "", false, -1, -1, windowTime, filter.Location, filter.Environment, filter.Site, filter.OlderThan, "")
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +154,7 @@ func (as *APIService) SearchOracleDatabasePatchAdvisorsAsXLSX(windowTime time.Ti
 // SearchOracleDatabases search databases
 func (as *APIService) SearchOracleDatabases(f dto.SearchOracleDatabasesFilter) (*dto.OracleDatabaseResponse, error) {
 	response, err := as.Database.SearchOracleDatabases(strings.Split(f.Search, " "), f.SortBy, f.SortDesc,
-		f.PageNumber, f.PageSize, f.Location, f.Environment, f.OlderThan)
+		f.PageNumber, f.PageSize, f.Location, f.Environment, f.Site, f.OlderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +182,7 @@ func (as *APIService) SearchOracleDatabasesAsXLSX(filter dto.SearchOracleDatabas
 	databases, err := as.Database.SearchOracleDatabases(strings.Split(filter.Search, " "),
 		filter.SortBy, filter.SortDesc,
 		-1, -1,
-		filter.Location, filter.Environment, filter.OlderThan)
+		filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +250,7 @@ func (as *APIService) SearchOracleDatabasesAsXLSX(filter dto.SearchOracleDatabas
 
 // SearchOracleDatabaseUsedLicenses return the list of used licenses
 func (as *APIService) SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int,
-	location string, environment string, olderThan time.Time,
+	location string, environment string, site string, olderThan time.Time,
 ) (*dto.OracleDatabaseUsedLicenseSearchResponse, error) {
-	return as.Database.SearchOracleDatabaseUsedLicenses(hostname, sortBy, sortDesc, page, pageSize, location, environment, olderThan)
+	return as.Database.SearchOracleDatabaseUsedLicenses(hostname, sortBy, sortDesc, page, pageSize, location, environment, site, olderThan)
 }
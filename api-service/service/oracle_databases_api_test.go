@@ -66,13 +66,13 @@ func TestSearchOracleDatabaseAddms_Success(t *testing.T) {
 	db.EXPECT().SearchOracleDatabaseAddms(
 		[]string{"foo", "bar", "foobarx"}, "Benefit",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.SearchOracleDatabaseAddms(
 		"foo bar foobarx", "Benefit",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -90,13 +90,13 @@ func TestSearchOracleDatabaseAddms_Fail(t *testing.T) {
 	db.EXPECT().SearchOracleDatabaseAddms(
 		[]string{"foo", "bar", "foobarx"}, "Benefit",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.SearchOracleDatabaseAddms(
 		"foo bar foobarx", "Benefit",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.Nil(t, res)
@@ -140,11 +140,11 @@ func TestSearchOracleDatabaseSegmentAdvisorsXLSX_Success(t *testing.T) {
 
 	db.EXPECT().SearchOracleDatabaseSegmentAdvisors(
 		[]string{""}, "",
-		false, "Italy", "TST", utils.P("2019-12-05T14:02:03Z"),
+		false, "Italy", "TST", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(data, nil).AnyTimes()
 
 	db.EXPECT().SearchOraclePdbSegmentAdvisors("",
-		false, "Italy", "TST", utils.P("2019-12-05T14:02:03Z")).Return([]dto.OracleDatabaseSegmentAdvisor{}, nil).AnyTimes()
+		false, "Italy", "TST", "", utils.P("2019-12-05T14:02:03Z")).Return([]dto.OracleDatabaseSegmentAdvisor{}, nil).AnyTimes()
 
 	filter := dto.GlobalFilter{
 		Location:    "Italy",
@@ -189,11 +189,11 @@ func TestSearchOracleDatabaseSegmentAdvisorsXLSX_Fail(t *testing.T) {
 
 	db.EXPECT().SearchOracleDatabaseSegmentAdvisors(
 		[]string{""}, "",
-		false, "Italy", "TST", utils.P("2019-12-05T14:02:03Z"),
+		false, "Italy", "TST", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).AnyTimes()
 
 	db.EXPECT().SearchOraclePdbSegmentAdvisors("",
-		false, "Italy", "TST", utils.P("2019-12-05T14:02:03Z"),
+		false, "Italy", "TST", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).AnyTimes()
 
 	filter := dto.GlobalFilter{
@@ -237,6 +237,7 @@ func TestSearchOracleDatabasePatchAdvisorsAsXLSX_Success(t *testing.T) {
 		[]string{}, "",
 		false, -1, -1,
 		utils.P("2019-12-05T14:02:03Z"), "Italy", "TST",
+		"",
 		utils.P("2019-12-05T14:02:03Z"), "",
 	).Return(data, nil).Times(1)
 
@@ -280,7 +281,7 @@ func TestSearchOracleDatabasePatchAdvisors_Fail(t *testing.T) {
 	db.EXPECT().SearchOracleDatabasePatchAdvisors(
 		[]string{}, "",
 		false, -1, -1,
-		utils.P("2019-12-05T14:02:03Z"), "Italy", "TST", utils.P("2019-12-05T14:02:03Z"),
+		utils.P("2019-12-05T14:02:03Z"), "Italy", "TST", "", utils.P("2019-12-05T14:02:03Z"),
 		"",
 	).Return(nil, aerrMock).Times(1)
 
@@ -298,7 +299,7 @@ func TestSearchOracleDatabases_Success(t *testing.T) {
 		Database: db,
 	}
 
-	db.EXPECT().SearchOracleDatabaseUsedLicenses(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	db.EXPECT().SearchOracleDatabaseUsedLicenses(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "", gomock.Any()).
 		Return(&dto.OracleDatabaseUsedLicenseSearchResponse{}, nil).AnyTimes()
 
 	// db.EXPECT().GetOracleDatabaseLicenseTypes().Return([]model.OracleDatabaseLicenseType{}, nil).AnyTimes()
@@ -370,13 +371,13 @@ func TestSearchOracleDatabases_Success(t *testing.T) {
 	db.EXPECT().SearchOracleDatabases(
 		[]string{"foo", "bar", "foobarx"}, "Memory",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(&expectedRes, nil).Times(1)
 
 	res, err := as.SearchOracleDatabases(
 		dto.SearchOracleDatabasesFilter{
 			dto.GlobalFilter{
-				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"), "",
 			},
 			"foo bar foobarx", "Memory",
 			true, 1, 1,
@@ -398,14 +399,14 @@ func TestSearchOracleDatabases_Fail(t *testing.T) {
 	db.EXPECT().SearchOracleDatabases(
 		[]string{"foo", "bar", "foobarx"}, "Memory",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.SearchOracleDatabases(
 
 		dto.SearchOracleDatabasesFilter{
 			dto.GlobalFilter{
-				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"), "",
 			},
 			"foo bar foobarx", "Memory",
 			true, 1, 1,
@@ -447,13 +448,13 @@ func TestSearchOracleDatabaseUsedLicenses_Success(t *testing.T) {
 	db.EXPECT().SearchOracleDatabaseUsedLicenses("",
 		"Used",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(&expectedRes, nil).Times(1)
 
 	res, err := as.SearchOracleDatabaseUsedLicenses("",
 		"Used",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -471,13 +472,13 @@ func TestSearchOracleDatabaseUsedLicenses_Fail(t *testing.T) {
 	db.EXPECT().SearchOracleDatabaseUsedLicenses("",
 		"Used",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.SearchOracleDatabaseUsedLicenses("",
 		"Used",
 		true, 1, 1,
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.Nil(t, res)
@@ -0,0 +1,82 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+// GetOracleFeatureUsageLedger return the first and last detections of the Oracle databases features
+func (as *APIService) GetOracleFeatureUsageLedger(filter dto.OracleFeatureUsageLedgerFilter) ([]dto.OracleFeatureUsageLedgerEntry, error) {
+	return as.Database.GetOracleFeatureUsageLedger(filter)
+}
+
+// GetOracleFeatureUsageLedgerAsXLSX return the Oracle feature usage ledger as an evidence report,
+// with the uploads that first and last reported each feature
+func (as *APIService) GetOracleFeatureUsageLedgerAsXLSX(filter dto.OracleFeatureUsageLedgerFilter) (*excelize.File, error) {
+	entries, err := as.Database.GetOracleFeatureUsageLedger(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := "Feature Usage Evidence"
+	headers := []string{
+		"Hostname",
+		"Location",
+		"Environment",
+		"DB Name",
+		"Product",
+		"Feature",
+		"First Detected",
+		"First Upload ID",
+		"Last Detected",
+		"Last Upload ID",
+		"Detected Usages",
+		"Currently Used",
+		"First Usage Date",
+		"Last Usage Date",
+	}
+
+	file, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+
+	for _, entry := range entries {
+		nextAxis := axisHelp.NewRow()
+		file.SetCellValue(sheet, nextAxis(), entry.Hostname)
+		file.SetCellValue(sheet, nextAxis(), entry.Location)
+		file.SetCellValue(sheet, nextAxis(), entry.Environment)
+		file.SetCellValue(sheet, nextAxis(), entry.DbName)
+		file.SetCellValue(sheet, nextAxis(), entry.Product)
+		file.SetCellValue(sheet, nextAxis(), entry.Feature)
+		file.SetCellValue(sheet, nextAxis(), entry.FirstDetected)
+		file.SetCellValue(sheet, nextAxis(), entry.FirstUploadID.Hex())
+		file.SetCellValue(sheet, nextAxis(), entry.LastDetected)
+		file.SetCellValue(sheet, nextAxis(), entry.LastUploadID.Hex())
+		file.SetCellValue(sheet, nextAxis(), entry.DetectedUsages)
+		file.SetCellValue(sheet, nextAxis(), entry.CurrentlyUsed)
+		file.SetCellValue(sheet, nextAxis(), entry.FirstUsageDate)
+		file.SetCellValue(sheet, nextAxis(), entry.LastUsageDate)
+	}
+
+	return file, nil
+}
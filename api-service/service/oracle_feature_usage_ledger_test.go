@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetOracleFeatureUsageLedgerAsXLSX_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config: config.Configuration{
+			ResourceFilePath: "../../resources",
+		},
+	}
+
+	entries := []dto.OracleFeatureUsageLedgerEntry{
+		{
+			OracleFeatureUsageLedgerEntry: model.OracleFeatureUsageLedgerEntry{
+				Hostname:       "foobar",
+				DbName:         "ERCOLE",
+				Product:        "Diagnostics Pack",
+				Feature:        "AWR Report",
+				FirstDetected:  utils.P("2019-10-05T14:02:03Z"),
+				FirstUploadID:  utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+				LastDetected:   utils.P("2019-11-05T14:02:03Z"),
+				LastUploadID:   utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+				DetectedUsages: 3,
+				CurrentlyUsed:  true,
+			},
+			Location:    "Italy",
+			Environment: "PRD",
+		},
+	}
+
+	filter := dto.OracleFeatureUsageLedgerFilter{Location: "Italy"}
+	db.EXPECT().GetOracleFeatureUsageLedger(filter).Return(entries, nil)
+
+	actual, err := as.GetOracleFeatureUsageLedgerAsXLSX(filter)
+	require.NoError(t, err)
+
+	sheet := "Feature Usage Evidence"
+	assert.Equal(t, "Hostname", actual.GetCellValue(sheet, "A1"))
+	assert.Equal(t, "Last Usage Date", actual.GetCellValue(sheet, "N1"))
+	assert.Equal(t, "foobar", actual.GetCellValue(sheet, "A2"))
+	assert.Equal(t, "Italy", actual.GetCellValue(sheet, "B2"))
+	assert.Equal(t, "AWR Report", actual.GetCellValue(sheet, "F2"))
+	assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaa", actual.GetCellValue(sheet, "H2"))
+	assert.Equal(t, "bbbbbbbbbbbbbbbbbbbbbbbb", actual.GetCellValue(sheet, "J2"))
+	assert.Equal(t, "3", actual.GetCellValue(sheet, "K2"))
+}
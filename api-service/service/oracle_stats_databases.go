@@ -23,48 +23,48 @@ import (
 )
 
 // GetOracleDatabaseArchivelogStatusStats return a array containing the number of databases per archivelog status
-func (as *APIService) GetOracleDatabaseArchivelogStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabaseArchivelogStatusStats(location, environment, olderThan)
+func (as *APIService) GetOracleDatabaseArchivelogStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabaseArchivelogStatusStats(location, environment, site, olderThan)
 }
 
 // GetOracleDatabaseEnvironmentStats return a array containing the number of databases per environment
-func (as *APIService) GetOracleDatabaseEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabaseEnvironmentStats(location, olderThan)
+func (as *APIService) GetOracleDatabaseEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabaseEnvironmentStats(location, site, olderThan)
 }
 
 // GetOracleDatabaseHighReliabilityStats return a array containing the number of databases per high-reliability status
-func (as *APIService) GetOracleDatabaseHighReliabilityStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabaseHighReliabilityStats(location, environment, olderThan)
+func (as *APIService) GetOracleDatabaseHighReliabilityStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabaseHighReliabilityStats(location, environment, site, olderThan)
 }
 
 // GetOracleDatabaseVersionStats return a array containing the number of databases per version
-func (as *APIService) GetOracleDatabaseVersionStats(location string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabaseVersionStats(location, olderThan)
+func (as *APIService) GetOracleDatabaseVersionStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabaseVersionStats(location, site, olderThan)
 }
 
 // GetTopReclaimableOracleDatabaseStats return a array containing the total sum of reclaimable of segments advisors of the top reclaimable databases
-func (as *APIService) GetTopReclaimableOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetTopReclaimableOracleDatabaseStats(location, limit, olderThan)
+func (as *APIService) GetTopReclaimableOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetTopReclaimableOracleDatabaseStats(location, site, limit, olderThan)
 }
 
 // GetOracleDatabasePatchStatusStats return a array containing the number of databases per patch status
-func (as *APIService) GetOracleDatabasePatchStatusStats(location string, windowTime time.Time, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabasePatchStatusStats(location, windowTime, olderThan)
+func (as *APIService) GetOracleDatabasePatchStatusStats(location string, site string, windowTime time.Time, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabasePatchStatusStats(location, site, windowTime, olderThan)
 }
 
 // GetTopWorkloadOracleDatabaseStats return a array containing top databases by workload
-func (as *APIService) GetTopWorkloadOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetTopWorkloadOracleDatabaseStats(location, limit, olderThan)
+func (as *APIService) GetTopWorkloadOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetTopWorkloadOracleDatabaseStats(location, site, limit, olderThan)
 }
 
 // GetOracleDatabaseRACStatusStats return a array containing the number of databases per RAC status
-func (as *APIService) GetOracleDatabaseRACStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabaseRACStatusStats(location, environment, olderThan)
+func (as *APIService) GetOracleDatabaseRACStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabaseRACStatusStats(location, environment, site, olderThan)
 }
 
 // GetOracleDatabaseDataguardStatusStats return a array containing the number of databases per dataguard status
-func (as *APIService) GetOracleDatabaseDataguardStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOracleDatabaseDataguardStatusStats(location, environment, olderThan)
+func (as *APIService) GetOracleDatabaseDataguardStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOracleDatabaseDataguardStatusStats(location, environment, site, olderThan)
 }
 
 func (as *APIService) GetOracleDatabasesStatistics(filter dto.GlobalFilter) (*dto.OracleDatabasesStatistics, error) {
@@ -72,22 +72,22 @@ func (as *APIService) GetOracleDatabasesStatistics(filter dto.GlobalFilter) (*dt
 
 	var err error
 
-	stats.TotalMemorySize, err = as.Database.GetTotalOracleDatabaseMemorySizeStats(filter.Location, filter.Environment, filter.OlderThan)
+	stats.TotalMemorySize, err = as.Database.GetTotalOracleDatabaseMemorySizeStats(filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.TotalSegmentsSize, err = as.Database.GetTotalOracleDatabaseSegmentSizeStats(filter.Location, filter.Environment, filter.OlderThan)
+	stats.TotalSegmentsSize, err = as.Database.GetTotalOracleDatabaseSegmentSizeStats(filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.TotalDatafileSize, err = as.Database.GetTotalOracleDatabaseDatafileSizeStats(filter.Location, filter.Environment, filter.OlderThan)
+	stats.TotalDatafileSize, err = as.Database.GetTotalOracleDatabaseDatafileSizeStats(filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
 
-	stats.TotalWork, err = as.Database.GetTotalOracleDatabaseWorkStats(filter.Location, filter.Environment, filter.OlderThan)
+	stats.TotalWork, err = as.Database.GetTotalOracleDatabaseWorkStats(filter.Location, filter.Environment, filter.Site, filter.OlderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +96,6 @@ func (as *APIService) GetOracleDatabasesStatistics(filter dto.GlobalFilter) (*dt
 }
 
 // GetTopUnusedOracleDatabaseInstanceResourceStats return a array containing top unused instance resource by workload
-func (as *APIService) GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, limit int, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetTopUnusedOracleDatabaseInstanceResourceStats(location, environment, limit, olderThan)
+func (as *APIService) GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, site string, limit int, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetTopUnusedOracleDatabaseInstanceResourceStats(location, environment, site, limit, olderThan)
 }
@@ -46,11 +46,11 @@ func TestGetOracleDatabaseArchivelogStatusStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseArchivelogStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOracleDatabaseArchivelogStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -66,11 +66,11 @@ func TestGetOracleDatabaseArchivelogStatusStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseArchivelogStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOracleDatabaseArchivelogStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -97,11 +97,11 @@ func TestGetOracleDatabaseEnvironmentStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOracleDatabaseEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -117,11 +117,11 @@ func TestGetOracleDatabaseEnvironmentStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOracleDatabaseEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -148,11 +148,11 @@ func TestGetOracleDatabaseVersionStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseVersionStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOracleDatabaseVersionStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -168,11 +168,11 @@ func TestGetOracleDatabaseVersionStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseVersionStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOracleDatabaseVersionStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -203,11 +203,11 @@ func TestGetTopReclaimableOracleDatabaseStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetTopReclaimableOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetTopReclaimableOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -223,11 +223,11 @@ func TestGetTopReclaimableOracleDatabaseStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetTopReclaimableOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetTopReclaimableOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -254,11 +254,11 @@ func TestGetOracleDatabasePatchStatusStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabasePatchStatusStats(
-		"Italy", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOracleDatabasePatchStatusStats(
-		"Italy", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -274,11 +274,11 @@ func TestGetOracleDatabasePatchStatusStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabasePatchStatusStats(
-		"Italy", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOracleDatabasePatchStatusStats(
-		"Italy", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-06-05T14:02:03Z"), utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -309,11 +309,11 @@ func TestGetTopWorkloadOracleDatabaseStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetTopWorkloadOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetTopWorkloadOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -329,11 +329,11 @@ func TestGetTopWorkloadOracleDatabaseStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetTopWorkloadOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetTopWorkloadOracleDatabaseStats(
-		"Italy", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -360,11 +360,11 @@ func TestGetOracleDatabaseRACStatusStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseRACStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOracleDatabaseRACStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -380,11 +380,11 @@ func TestGetOracleDatabaseRACStatusStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseRACStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOracleDatabaseRACStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -411,11 +411,11 @@ func TestGetOracleDatabaseDataguardStatusStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseDataguardStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOracleDatabaseDataguardStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -431,11 +431,11 @@ func TestGetOracleDatabaseDataguardStatusStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOracleDatabaseDataguardStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOracleDatabaseDataguardStatusStats(
-		"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -452,16 +452,16 @@ func TestGetOracleDatabasesStatistics(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().GetTotalOracleDatabaseMemorySizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(1.1), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseSegmentSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(2.2), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseDatafileSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(3.3), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseWorkStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(4.4), nil).Times(1),
 	)
 
@@ -494,16 +494,16 @@ func TestGetOracleDatabasesStatistics_Fail1(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().GetTotalOracleDatabaseMemorySizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(1.1), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseSegmentSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(2.2), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseDatafileSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(3.3), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseWorkStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(0), aerrMock).Times(1),
 	)
 
@@ -529,13 +529,13 @@ func TestGetOracleDatabasesStatistics_Fail2(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().GetTotalOracleDatabaseMemorySizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(1.1), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseSegmentSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(2.2), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseDatafileSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(0), aerrMock).Times(1),
 	)
 
@@ -561,10 +561,10 @@ func TestGetOracleDatabasesStatistics_Fail3(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().GetTotalOracleDatabaseMemorySizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(1.1), nil).Times(1),
 		db.EXPECT().GetTotalOracleDatabaseSegmentSizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(0), aerrMock).Times(1),
 	)
 
@@ -590,7 +590,7 @@ func TestGetOracleDatabasesStatistics_Fail4(t *testing.T) {
 
 	gomock.InOrder(
 		db.EXPECT().GetTotalOracleDatabaseMemorySizeStats(
-			"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2019-12-05T14:02:03Z"),
 		).Return(float64(0), aerrMock).Times(1),
 	)
 
@@ -0,0 +1,49 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package service is a package that provides methods for querying data
+package service
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func (as *APIService) AddPostgreSQLContract(contract model.PostgreSQLContract) (*model.PostgreSQLContract, error) {
+	return addSubscriptionContract(as, contract, as.Database.AddPostgreSQLContract)
+}
+
+func (as *APIService) UpdatePostgreSQLContract(contract model.PostgreSQLContract) (*model.PostgreSQLContract, error) {
+	return updateSubscriptionContract(contract, as.Database.UpdatePostgreSQLContract)
+}
+
+func (as *APIService) GetPostgreSQLContracts() ([]model.PostgreSQLContract, error) {
+	return as.Database.GetPostgreSQLContracts()
+}
+
+func (as *APIService) DeletePostgreSQLContract(id primitive.ObjectID) error {
+	return as.Database.DeletePostgreSQLContract(id)
+}
+
+func (as *APIService) GetPostgreSQLContractsAsXLSX() (*excelize.File, error) {
+	contracts, err := as.GetPostgreSQLContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionContractsAsXLSX(as, contracts)
+}
@@ -74,7 +74,7 @@ func TestSearchPostgreSqlInstances_Success(t *testing.T) {
 	res, err := as.SearchPostgreSqlInstances(
 		dto.SearchPostgreSqlInstancesFilter{
 			dto.GlobalFilter{
-				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"), "",
 			},
 			"foo bar foobarx", "Hostname",
 			true, 1, 1,
@@ -103,7 +103,7 @@ func TestSearchPostgreSqlInstances_Fail(t *testing.T) {
 
 		dto.SearchPostgreSqlInstancesFilter{
 			dto.GlobalFilter{
-				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"),
+				"Italy", "PROD", utils.P("2019-12-05T14:02:03Z"), "",
 			},
 			"foo bar foobarx", "Memory",
 			true, 1, 1,
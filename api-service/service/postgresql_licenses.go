@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"time"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// GetPostgreSQLLicenseTypes return the list of PostgreSQLLicenseTypes
+func (as *APIService) GetPostgreSQLLicenseTypes() ([]model.PostgreSQLLicenseType, error) {
+	parts := []model.PostgreSQLLicenseType{
+		{
+			ID:              model.PostgreSQLEDBPartNumber,
+			ItemDescription: model.PostgreSQLEDBItemDescription,
+		},
+	}
+
+	return parts, nil
+}
+
+// GetPostgreSQLUsedLicenses return the subscriptions used by the PostgreSQL instances.
+// Only EDB Postgres Advanced Server instances are under subscription. An instance is counted per instance
+// if its host is associated to a per instance contract, otherwise all the cores of its host are counted
+func (as *APIService) GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.PostgreSQLUsedLicense, error) {
+	contracts, err := as.Database.GetPostgreSQLContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	return as.postgresqlUsedLicenses(hostname, filter, contracts)
+}
+
+func (as *APIService) postgresqlUsedLicenses(hostname string, filter dto.GlobalFilter, contracts []model.PostgreSQLContract) ([]dto.PostgreSQLUsedLicense, error) {
+	instances, err := as.Database.GetPostgreSQLUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionUsedLicenses(model.PostgreSQLEDBPartNumber, instances, contracts,
+		func(instance dto.SubscriptionUsedLicense) bool {
+			return model.IsEDBPostgresAdvancedServer(instance.Version)
+		}), nil
+}
+
+func (as *APIService) getPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error) {
+	lics, err := as.GetPostgreSQLUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionDatabaseUsedLicenses(model.PostgreSQLEDBItemDescription, lics), nil
+}
+
+// GetPostgreSQLDatabaseLicensesCompliance return the compliance of the PostgreSQL subscriptions
+// used by the instances at olderThan, compared with the current contracts
+func (as *APIService) GetPostgreSQLDatabaseLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error) {
+	any := dto.GlobalFilter{
+		Location:    "",
+		Environment: "",
+		OlderThan:   olderThan,
+	}
+
+	contracts, err := as.Database.GetPostgreSQLContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	usedLicenses, err := as.postgresqlUsedLicenses("", any, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionsCompliance(model.PostgreSQLEDBPartNumber, model.PostgreSQLEDBItemDescription, usedLicenses, contracts), nil
+}
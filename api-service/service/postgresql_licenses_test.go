@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var postgreSQLInstances = []dto.PostgreSQLUsedLicense{
+	{Hostname: "pg01", InstanceName: "main", Version: "PostgreSQL 14.7 (EnterpriseDB Advanced Server 14.7.0)", CPUCores: 4},
+	{Hostname: "pg01", InstanceName: "reports", Version: "PostgreSQL 14.7 (EnterpriseDB Advanced Server 14.7.0)", CPUCores: 4},
+	{Hostname: "pg02", InstanceName: "main", Version: "PostgreSQL 15.2", CPUCores: 8},
+	{Hostname: "pg03", InstanceName: "main", Version: "PostgreSQL 13.10 (EnterpriseDB Advanced Server 13.10.14)", CPUCores: 16},
+}
+
+var postgreSQLContracts = []model.PostgreSQLContract{
+	{
+		ID:               utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		Type:             model.PostgreSQLContractTypeCore,
+		ContractID:       "EDB001",
+		LicenseTypeID:    model.PostgreSQLEDBPartNumber,
+		NumberOfLicenses: 2,
+	},
+	{
+		ID:               utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+		Type:             model.PostgreSQLContractTypeInstance,
+		ContractID:       "EDB002",
+		LicenseTypeID:    model.PostgreSQLEDBPartNumber,
+		NumberOfLicenses: 5,
+		Hosts:            []string{"pg03"},
+	},
+}
+
+func TestGetPostgreSQLUsedLicenses_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	gomock.InOrder(
+		db.EXPECT().GetPostgreSQLContracts().
+			Return(postgreSQLContracts, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses("", globalFilterAny).
+			Return(postgreSQLInstances, nil),
+	)
+
+	actual, err := as.GetPostgreSQLUsedLicenses("", globalFilterAny)
+	require.NoError(t, err)
+
+	expected := []dto.PostgreSQLUsedLicense{
+		{
+			LicenseTypeID: model.PostgreSQLEDBPartNumber,
+			Hostname:      "pg01",
+			InstanceName:  "main",
+			Version:       "PostgreSQL 14.7 (EnterpriseDB Advanced Server 14.7.0)",
+			CPUCores:      4,
+			UsedLicenses:  4,
+			ContractType:  model.PostgreSQLContractTypeCore,
+		},
+		{
+			LicenseTypeID: model.PostgreSQLEDBPartNumber,
+			Hostname:      "pg01",
+			InstanceName:  "reports",
+			Version:       "PostgreSQL 14.7 (EnterpriseDB Advanced Server 14.7.0)",
+			CPUCores:      4,
+			UsedLicenses:  4,
+			ContractType:  model.PostgreSQLContractTypeCore,
+		},
+		{
+			LicenseTypeID: model.PostgreSQLEDBPartNumber,
+			Hostname:      "pg03",
+			InstanceName:  "main",
+			Version:       "PostgreSQL 13.10 (EnterpriseDB Advanced Server 13.10.14)",
+			CPUCores:      16,
+			UsedLicenses:  1,
+			ContractType:  model.PostgreSQLContractTypeInstance,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetPostgreSQLDatabaseLicensesCompliance_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	filter := dto.GlobalFilter{OlderThan: utils.P("2023-06-01T00:00:00Z")}
+
+	gomock.InOrder(
+		db.EXPECT().GetPostgreSQLContracts().
+			Return(postgreSQLContracts, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses("", filter).
+			Return(postgreSQLInstances, nil),
+	)
+
+	actual, err := as.GetPostgreSQLDatabaseLicensesCompliance(filter.OlderThan)
+	require.NoError(t, err)
+
+	expected := []dto.LicenseCompliance{
+		{
+			LicenseTypeID:   model.PostgreSQLEDBPartNumber,
+			ItemDescription: model.PostgreSQLEDBItemDescription,
+			Metric:          model.PostgreSQLContractTypeCore,
+			Consumed:        4,
+			Covered:         2,
+			Purchased:       2,
+			Available:       0,
+			Compliance:      0.5,
+		},
+		{
+			LicenseTypeID:   model.PostgreSQLEDBPartNumber,
+			ItemDescription: model.PostgreSQLEDBItemDescription,
+			Metric:          model.PostgreSQLContractTypeInstance,
+			Consumed:        1,
+			Covered:         1,
+			Purchased:       5,
+			Available:       4,
+			Compliance:      1,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -18,6 +18,7 @@ package service
 
 import (
 	"encoding/csv"
+	"io"
 	"time"
 
 	"github.com/360EntSecGroup-Skylar/excelize"
@@ -29,6 +30,7 @@ import (
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	alert_filter "github.com/ercole-io/ercole/v2/api-service/dto/filter"
 	"github.com/ercole-io/ercole/v2/config"
+	dataServiceClient "github.com/ercole-io/ercole/v2/data-service/client"
 	"github.com/ercole-io/ercole/v2/logger"
 	"github.com/ercole-io/ercole/v2/model"
 )
@@ -46,7 +48,7 @@ type APIServiceInterface interface {
 	// GetHost return the host specified in the hostname param
 	GetHost(hostname string, olderThan time.Time, raw bool) (*dto.HostData, error)
 	// ListManagedTechnologies returns the list of technologies with some stats
-	ListManagedTechnologies(sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]model.TechnologyStatus, error)
+	ListManagedTechnologies(sortBy string, sortDesc bool, location string, environment string, site string, olderThan time.Time) ([]model.TechnologyStatus, error)
 	// SearchAlerts search alerts
 	SearchAlerts(alertFilter alert_filter.Alert) (*dto.Pagination, error)
 	SearchAlertsAsXLSX(status string, from, to time.Time, filter dto.GlobalFilter) (*excelize.File, error)
@@ -59,19 +61,19 @@ type APIServiceInterface interface {
 	// GetClusterXLSX return  cluster vms as xlxs file
 	GetClusterXLSX(clusterName string, olderThan time.Time) (*excelize.File, error)
 	// SearchOracleDatabaseAddms search addm
-	SearchOracleDatabaseAddms(search string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) ([]map[string]interface{}, error)
+	SearchOracleDatabaseAddms(search string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) ([]map[string]interface{}, error)
 	// SearchOracleDatabaseSegmentAdvisors search segment advisors
-	SearchOracleDatabaseSegmentAdvisors(search string, sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error)
+	SearchOracleDatabaseSegmentAdvisors(search string, sortBy string, sortDesc bool, location string, environment string, site string, olderThan time.Time) ([]dto.OracleDatabaseSegmentAdvisor, error)
 	SearchOracleDatabaseSegmentAdvisorsAsXLSX(filter dto.GlobalFilter) (*excelize.File, error)
 	// SearchOracleDatabasePatchAdvisors search patch advisors
-	SearchOracleDatabasePatchAdvisors(search string, sortBy string, sortDesc bool, page int, pageSize int, windowTime time.Time, location string, environment string, olderThan time.Time, status string) (*dto.PatchAdvisorResponse, error)
+	SearchOracleDatabasePatchAdvisors(search string, sortBy string, sortDesc bool, page int, pageSize int, windowTime time.Time, location string, environment string, site string, olderThan time.Time, status string) (*dto.PatchAdvisorResponse, error)
 	SearchOracleDatabasePatchAdvisorsAsXLSX(windowTime time.Time, filter dto.GlobalFilter) (*excelize.File, error)
 	// SearchOracleDatabases search databases
 	SearchOracleDatabases(filter dto.SearchOracleDatabasesFilter) (*dto.OracleDatabaseResponse, error)
 	// SearchOracleDatabases search databases
 	SearchOracleDatabasesAsXLSX(filter dto.SearchOracleDatabasesFilter) (*excelize.File, error)
 	// SearchOracleDatabaseUsedLicenses return the list of consumed licenses
-	SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.OracleDatabaseUsedLicenseSearchResponse, error)
+	SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, site string, olderThan time.Time) (*dto.OracleDatabaseUsedLicenseSearchResponse, error)
 
 	GetOraclePsqlMigrabilities(hostname, dbname string) ([]model.PgsqlMigrability, error)
 	GetOraclePsqlMigrabilitiesSemaphore(hostname, dbname string) (string, error)
@@ -84,35 +86,35 @@ type APIServiceInterface interface {
 	ListEnvironments(location string, environment string, olderThan time.Time) ([]string, error)
 
 	// GetHostsCountStats return the number of the non-archived hosts
-	GetHostsCountStats(location string, environment string, olderThan time.Time) (int, error)
+	GetHostsCountStats(location string, environment string, site string, olderThan time.Time) (int, error)
 	// GetEnvironmentStats return a array containing the number of hosts per environment
-	GetEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOperatingSystemStats return a array containing the number of hosts per operating system
-	GetOperatingSystemStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetOperatingSystemStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTypeStats return a array containing the number of hosts per type
-	GetTypeStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetTypeStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTopUnusedOracleDatabaseInstanceResourceStats return a array containing top unused instance resource by workload
-	GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, limit int, olderThan time.Time) ([]interface{}, error)
+	GetTopUnusedOracleDatabaseInstanceResourceStats(location string, environment string, site string, limit int, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseEnvironmentStats return a array containing the number of databases per environment
-	GetOracleDatabaseEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseHighReliabilityStats return a array containing the number of databases per high-reliability status
-	GetOracleDatabaseHighReliabilityStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseHighReliabilityStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseVersionStats return a array containing the number of databases per version
-	GetOracleDatabaseVersionStats(location string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseVersionStats(location string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetTopReclaimableOracleDatabaseStats return a array containing the total sum of reclaimable of segments advisors of the top reclaimable databases
-	GetTopReclaimableOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error)
+	GetTopReclaimableOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error)
 	// GetTotalTechnologiesComplianceStats return the total compliance of all technologie
-	GetTotalTechnologiesComplianceStats(location string, environment string, olderThan time.Time) (map[string]interface{}, error)
+	GetTotalTechnologiesComplianceStats(location string, environment string, site string, olderThan time.Time) (map[string]interface{}, error)
 	// GetOracleDatabasePatchStatusStats return a array containing the number of databases per patch status
-	GetOracleDatabasePatchStatusStats(location string, windowTime time.Time, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabasePatchStatusStats(location string, site string, windowTime time.Time, olderThan time.Time) ([]interface{}, error)
 	// GetTopWorkloadOracleDatabaseStats return a array containing top databases by workload
-	GetTopWorkloadOracleDatabaseStats(location string, limit int, olderThan time.Time) ([]interface{}, error)
+	GetTopWorkloadOracleDatabaseStats(location string, site string, limit int, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseDataguardStatusStats return a array containing the number of databases per dataguard status
-	GetOracleDatabaseDataguardStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseDataguardStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseRACStatusStats return a array containing the number of databases per RAC status
-	GetOracleDatabaseRACStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseRACStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetOracleDatabaseArchivelogStatusStats return a array containing the number of databases per archivelog status
-	GetOracleDatabaseArchivelogStatusStats(location string, environment string, olderThan time.Time) ([]interface{}, error)
+	GetOracleDatabaseArchivelogStatusStats(location string, environment string, site string, olderThan time.Time) ([]interface{}, error)
 	// GetDefaultDatabaseTags return the default list of database tags from configuration
 	GetDefaultDatabaseTags() ([]string, error)
 	// GetErcoleFeatures return a map of active/inactive features
@@ -127,12 +129,13 @@ type APIServiceInterface interface {
 	UpdateOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error)
 	GetOracleDatabaseContracts(filter dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error)
 	GetOracleDatabaseContractsAsXLSX(filter dto.GetOracleDatabaseContractsFilter) (*excelize.File, error)
+	CompareOracleDatabaseContractsAssignments() (*dto.OracleDatabaseContractsAssignmentComparison, error)
 	DeleteOracleDatabaseContract(id primitive.ObjectID) error
 	AddHostToOracleDatabaseContract(id primitive.ObjectID, hostname string) error
 	DeleteHostFromOracleDatabaseContract(id primitive.ObjectID, hostname string) error
 	DeleteHostFromOracleDatabaseContracts(hostname string) error
 
-	ImportOracleDatabaseContracts(reader *csv.Reader) error
+	ImportOracleDatabaseContracts(reader *csv.Reader, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error)
 	GetLicenseContractSample(dbtype string) ([]byte, error)
 
 	// ORACLE DATABASE LICENSES
@@ -153,6 +156,10 @@ type APIServiceInterface interface {
 	// ORACLE DATABASE OPTION
 	GetOracleOptionList(filter dto.GlobalFilter) ([]dto.OracleDatabaseFeatureUsageStatDto, error)
 	CreateGetOracleOptionListXLSX(filter dto.GlobalFilter) (*excelize.File, error)
+	// GetOracleFeatureUsageLedger return the first and last detections of the Oracle databases features
+	GetOracleFeatureUsageLedger(filter dto.OracleFeatureUsageLedgerFilter) ([]dto.OracleFeatureUsageLedgerEntry, error)
+	// GetOracleFeatureUsageLedgerAsXLSX return the Oracle feature usage ledger as an evidence report
+	GetOracleFeatureUsageLedgerAsXLSX(filter dto.OracleFeatureUsageLedgerFilter) (*excelize.File, error)
 
 	// ORACLE DATABASE CHANGES
 	GetOracleChanges(filter dto.GlobalFilter, hostname string) ([]dto.OracleChangesDto, error)
@@ -200,7 +207,7 @@ type APIServiceInterface interface {
 	DeleteSqlServerDatabaseContract(id primitive.ObjectID) error
 	UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error)
 
-	ImportSQLServerDatabaseContracts(reader *csv.Reader) error
+	ImportSQLServerDatabaseContracts(reader *csv.Reader, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error)
 
 	// AckAlerts ack the specified alerts
 	AckAlerts(alertsFilter dto.AlertsFilter) error
@@ -213,7 +220,7 @@ type APIServiceInterface interface {
 	UpdateAlertsStatus(alertsFilter dto.AlertsFilter, newStatus string) error
 
 	// GetInfoForFrontendDashboard return all informations needed for the frontend dashboard page
-	GetInfoForFrontendDashboard(location string, environment string, olderThan time.Time) (map[string]interface{}, error)
+	GetInfoForFrontendDashboard(location string, environment string, site string, olderThan time.Time) (map[string]interface{}, error)
 
 	// UpdateLicenseIgnoredField update license ignored field (true/false)
 	UpdateLicenseIgnoredField(hostname string, dbname string, licensetypeid string, ignored bool, ignoredComment string) error
@@ -232,12 +239,13 @@ type APIServiceInterface interface {
 	SearchDatabasesAsXLSX(filter dto.GlobalFilter) (*excelize.File, error)
 	GetDatabasesStatistics(filter dto.GlobalFilter) (*dto.DatabasesStatistics, error)
 	GetUsedLicensesPerDatabases(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error)
+	ExplainUsedLicensesPerDatabases(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicenseExplanation, error)
 	GetUsedLicensesPerHost(filter dto.GlobalFilter) ([]dto.DatabaseUsedLicensePerHost, error)
 	GetUsedLicensesPerHostAsXLSX(filter dto.GlobalFilter) (*excelize.File, error)
 	GetUsedLicensesPerCluster(filter dto.GlobalFilter) ([]dto.DatabaseUsedLicensePerCluster, error)
 	GetUsedLicensesPerClusterAsXLSX(filter dto.GlobalFilter) (*excelize.File, error)
-	GetDatabaseLicensesCompliance() ([]dto.LicenseCompliance, error)
-	GetDatabaseLicensesComplianceAsXLSX() (*excelize.File, error)
+	GetDatabaseLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error)
+	GetDatabaseLicensesComplianceAsXLSX(olderThan time.Time) (*excelize.File, error)
 
 	// MYSQL
 
@@ -256,7 +264,7 @@ type APIServiceInterface interface {
 	GetMySQLContractsAsXLSX() (*excelize.File, error)
 	DeleteMySQLContract(id primitive.ObjectID) error
 
-	ImportMySQLDatabaseContracts(reader *csv.Reader) error
+	ImportMySQLDatabaseContracts(reader *csv.Reader, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error)
 
 	// POSTGRESQL
 	// SearchSqlServerInstances search databases
@@ -264,12 +272,41 @@ type APIServiceInterface interface {
 	// SearchOracleDatabases search databases
 	SearchPostgreSqlInstancesAsXLSX(filter dto.SearchPostgreSqlInstancesFilter) (*excelize.File, error)
 
+	// POSTGRESQL SUBSCRIPTIONS
+	GetPostgreSQLLicenseTypes() ([]model.PostgreSQLLicenseType, error)
+	GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.PostgreSQLUsedLicense, error)
+	GetPostgreSQLDatabaseLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error)
+	AddPostgreSQLContract(contract model.PostgreSQLContract) (*model.PostgreSQLContract, error)
+	UpdatePostgreSQLContract(contract model.PostgreSQLContract) (*model.PostgreSQLContract, error)
+	GetPostgreSQLContracts() ([]model.PostgreSQLContract, error)
+	GetPostgreSQLContractsAsXLSX() (*excelize.File, error)
+	DeletePostgreSQLContract(id primitive.ObjectID) error
+
 	// MONGODB
 	// SearchMongoDBInstances search databases
 	SearchMongoDBInstances(filter dto.SearchMongoDBInstancesFilter) (*dto.MongoDBInstanceResponse, error)
 	// SearchOracleDatabases search databases
 	SearchMongoDBInstancesAsXLSX(filter dto.SearchMongoDBInstancesFilter) (*excelize.File, error)
 
+	// MONGODB SUBSCRIPTIONS
+	GetMongoDBLicenseTypes() ([]model.MongoDBLicenseType, error)
+	GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MongoDBUsedLicense, error)
+	GetMongoDBDatabaseLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error)
+	AddMongoDBContract(contract model.MongoDBContract) (*model.MongoDBContract, error)
+	UpdateMongoDBContract(contract model.MongoDBContract) (*model.MongoDBContract, error)
+	GetMongoDBContracts() ([]model.MongoDBContract, error)
+	GetMongoDBContractsAsXLSX() (*excelize.File, error)
+	DeleteMongoDBContract(id primitive.ObjectID) error
+
+	// HOST LICENSES
+	GetHostLicenseTypes() ([]model.HostLicenseType, error)
+	GetHostsUsedLicenses(filter dto.GlobalFilter) ([]dto.HostUsedLicense, error)
+	GetHostLicensesCompliance(olderThan time.Time) ([]dto.LicenseCompliance, error)
+	AddHostContract(contract model.HostContract) (*model.HostContract, error)
+	UpdateHostContract(contract model.HostContract) (*model.HostContract, error)
+	GetHostContracts() ([]model.HostContract, error)
+	DeleteHostContract(id primitive.ObjectID) error
+
 	// ROLES
 	GetRole(name string) (*model.Role, error)
 	GetRoles() ([]model.Role, error)
@@ -314,6 +351,32 @@ type APIServiceInterface interface {
 	UpdateExadataComponentClusterName(RackID, hostID string, clusternames []string) error
 	UpdateExadataRdma(rackID string, rdma model.OracleExadataRdma) error
 	GetAllExadataInstanceAsXlsx() (*excelize.File, error)
+
+	ListLicenseIgnoreRules() ([]model.LicenseIgnoreRule, error)
+	AddLicenseIgnoreRule(rule model.LicenseIgnoreRule) (*model.LicenseIgnoreRule, error)
+	UpdateLicenseIgnoreRule(rule model.LicenseIgnoreRule) (*model.LicenseIgnoreRule, error)
+	DeleteLicenseIgnoreRule(id primitive.ObjectID) error
+
+	ImportOracleLMSReview(r io.Reader, user string) (*dto.OracleLMSImportReport, error)
+	ListLMSPendingChanges(status string) ([]model.LMSPendingChange, error)
+	ApproveLMSPendingChange(id primitive.ObjectID, user string) (*model.LMSPendingChange, error)
+	RejectLMSPendingChange(id primitive.ObjectID, user string) (*model.LMSPendingChange, error)
+
+	GetHostDataChanges(filter dto.HostDataChangesFilter) ([]model.HostDataChange, error)
+
+	ListHostDataSnapshots() ([]model.HostDataSnapshot, error)
+
+	ListQuarantinedHostData(hostname string, from, to time.Time) ([]model.QuarantinedHostData, error)
+	GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error)
+	DeleteQuarantinedHostData(id primitive.ObjectID) error
+	ReplayQuarantinedHostData(id primitive.ObjectID) error
+
+	ListHostIdentities() ([]model.HostIdentity, error)
+	GetHostIdentity(id string) (*model.HostIdentity, error)
+	AddHostAlias(id string, alias string) (*model.HostIdentity, error)
+	RemoveHostAlias(id string, alias string) (*model.HostIdentity, error)
+	MergeHostIdentities(id string, otherID string) (*model.HostIdentity, error)
+	SplitHostIdentity(id string, alias string) (*model.HostIdentity, error)
 }
 
 // APIService is the concrete implementation of APIServiceInterface.
@@ -336,6 +399,7 @@ type APIService struct {
 	mockGetOracleDatabaseContracts func(filters dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error)
 
 	AlertSvcClient alertServiceClient.AlertSvcClientInterface
+	DataSvcClient  dataServiceClient.DataSvcClientInterface
 }
 
 // Init initializes the service and database
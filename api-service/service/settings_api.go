@@ -60,7 +60,7 @@ func (as *APIService) GetDefaultDatabaseTags() ([]string, error) {
 
 // GetErcoleFeatures return a map of active/inactive features
 func (as *APIService) GetErcoleFeatures() (map[string]bool, error) {
-	partialList, err := as.Database.GetHostsCountUsingTechnologies("", "", utils.MAX_TIME)
+	partialList, err := as.Database.GetHostsCountUsingTechnologies("", "", "", utils.MAX_TIME)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +84,7 @@ func TestGetErcoleFeatures_Success(t *testing.T) {
 	}
 
 	db.EXPECT().
-		GetHostsCountUsingTechnologies("", "", utils.MAX_TIME).
+		GetHostsCountUsingTechnologies("", "", "", utils.MAX_TIME).
 		Return(getTechnologiesUsageRes, nil)
 
 	res, err := as.GetErcoleFeatures()
@@ -102,7 +102,7 @@ func TestGetErcoleFeatures_FailInternalServerError(t *testing.T) {
 	}
 
 	db.EXPECT().
-		GetHostsCountUsingTechnologies("", "", utils.MAX_TIME).
+		GetHostsCountUsingTechnologies("", "", "", utils.MAX_TIME).
 		Return(nil, aerrMock)
 
 	_, err := as.GetErcoleFeatures()
@@ -21,13 +21,13 @@ import (
 )
 
 // GetTotalTechnologiesComplianceStats return the total compliance of all technologies
-func (as *APIService) GetTotalTechnologiesComplianceStats(location string, environment string, olderThan time.Time) (map[string]interface{}, error) {
-	technologies, err := as.ListManagedTechnologies("", false, location, environment, olderThan)
+func (as *APIService) GetTotalTechnologiesComplianceStats(location string, environment string, site string, olderThan time.Time) (map[string]interface{}, error) {
+	technologies, err := as.ListManagedTechnologies("", false, location, environment, site, olderThan)
 	if err != nil {
 		return nil, err
 	}
 
-	hostsCount, err := as.GetHostsCountStats(location, environment, olderThan)
+	hostsCount, err := as.GetHostsCountStats(location, environment, site, olderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -47,10 +47,10 @@ func TestGetTotalTechnologiesComplianceStats_Success(t *testing.T) {
 		},
 	}
 	db.EXPECT().
-		GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+		GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 		Return(getTechnologiesUsageRes, nil)
 	db.EXPECT().
-		GetHostsCountStats("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+		GetHostsCountStats("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 		Return(20, nil).AnyTimes().MinTimes(1)
 	db.EXPECT().
 		GetOracleDatabaseLicenseTypes().
@@ -174,7 +174,7 @@ func TestGetTotalTechnologiesComplianceStats_Success(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -186,7 +186,7 @@ func TestGetTotalTechnologiesComplianceStats_Success(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -197,10 +197,19 @@ func TestGetTotalTechnologiesComplianceStats_Success(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
 	res, err := as.GetTotalTechnologiesComplianceStats(
-		"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -222,11 +231,11 @@ func TestGetTotalTechnologiesComplianceStats_FailInternalServerError(t *testing.
 	}
 
 	db.EXPECT().
-		GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+		GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 		Return(nil, aerrMock)
 
 	_, err := as.GetTotalTechnologiesComplianceStats(
-		"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 	)
 
 	require.Equal(t, aerrMock, err)
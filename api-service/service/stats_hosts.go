@@ -21,21 +21,21 @@ import (
 )
 
 // GetHostsCountStats return the number of the non-archived hosts
-func (as *APIService) GetHostsCountStats(location string, environment string, olderThan time.Time) (int, error) {
-	return as.Database.GetHostsCountStats(location, environment, olderThan)
+func (as *APIService) GetHostsCountStats(location string, environment string, site string, olderThan time.Time) (int, error) {
+	return as.Database.GetHostsCountStats(location, environment, site, olderThan)
 }
 
 // GetEnvironmentStats return a array containing the number of hosts per environment
-func (as *APIService) GetEnvironmentStats(location string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetEnvironmentStats(location, olderThan)
+func (as *APIService) GetEnvironmentStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetEnvironmentStats(location, site, olderThan)
 }
 
 // GetTypeStats return a array containing the number of hosts per type
-func (as *APIService) GetTypeStats(location string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetTypeStats(location, olderThan)
+func (as *APIService) GetTypeStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetTypeStats(location, site, olderThan)
 }
 
 // GetOperatingSystemStats return a array containing the number of hosts per operating system
-func (as *APIService) GetOperatingSystemStats(location string, olderThan time.Time) ([]interface{}, error) {
-	return as.Database.GetOperatingSystemStats(location, olderThan)
+func (as *APIService) GetOperatingSystemStats(location string, site string, olderThan time.Time) ([]interface{}, error) {
+	return as.Database.GetOperatingSystemStats(location, site, olderThan)
 }
@@ -45,11 +45,11 @@ func TestGetEnvironmentStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -65,11 +65,11 @@ func TestGetEnvironmentStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetEnvironmentStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -96,11 +96,11 @@ func TestGetTypeStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetTypeStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetTypeStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -116,11 +116,11 @@ func TestGetTypeStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetTypeStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetTypeStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -147,11 +147,11 @@ func TestGetOperatingSystemStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetOperatingSystemStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetOperatingSystemStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -167,11 +167,11 @@ func TestGetOperatingSystemStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetOperatingSystemStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetOperatingSystemStats(
-		"Italy", utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "", utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -200,11 +200,11 @@ func TestGetTopUnusedOracleDatabaseInstanceResourceStats_Success(t *testing.T) {
 	}
 
 	db.EXPECT().GetTopUnusedOracleDatabaseInstanceResourceStats(
-		"Italy", "PROD", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	).Return(expectedRes, nil).Times(1)
 
 	res, err := as.GetTopUnusedOracleDatabaseInstanceResourceStats(
-		"Italy", "PROD", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	require.NoError(t, err)
@@ -220,11 +220,11 @@ func TestGetTopUnusedOracleDatabaseInstanceResourceStats_Fail(t *testing.T) {
 	}
 
 	db.EXPECT().GetTopUnusedOracleDatabaseInstanceResourceStats(
-		"Italy", "PROD", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	).Return(nil, aerrMock).Times(1)
 
 	res, err := as.GetTopUnusedOracleDatabaseInstanceResourceStats(
-		"Italy", "PROD", 10, utils.P("2019-12-05T14:02:03Z"),
+		"Italy", "PROD", "", 10, utils.P("2019-12-05T14:02:03Z"),
 	)
 
 	assert.Nil(t, res)
@@ -24,8 +24,8 @@ import (
 )
 
 // ListManagedTechnologies returns the list of Technologies with some stats
-func (as *APIService) ListManagedTechnologies(sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]model.TechnologyStatus, error) {
-	hostsCountByTechnology, err := as.Database.GetHostsCountUsingTechnologies(location, environment, olderThan)
+func (as *APIService) ListManagedTechnologies(sortBy string, sortDesc bool, location string, environment string, site string, olderThan time.Time) ([]model.TechnologyStatus, error) {
+	hostsCountByTechnology, err := as.Database.GetHostsCountUsingTechnologies(location, environment, site, olderThan)
 	if err != nil {
 		return nil, err
 	}
@@ -53,31 +53,19 @@ func (as *APIService) ListManagedTechnologies(sortBy string, sortDesc bool, loca
 
 	statuses = append(statuses, *sqlServerStatus)
 
-	postgreSQLStatus := model.TechnologyStatus{
-		Product:            model.TechnologyPostgreSQLPostgreSQL,
-		ConsumedByHosts:    0,
-		CoveredByContracts: 0,
-		TotalCost:          0,
-		PaidCost:           0,
-		Compliance:         1,
-		UnpaidDues:         0,
-		HostsCount:         int(hostsCountByTechnology[model.TechnologyPostgreSQLPostgreSQL]),
+	postgreSQLStatus, err := createPostgreSQLTechnologyStatus(as, hostsCountByTechnology[model.TechnologyPostgreSQLPostgreSQL], olderThan)
+	if err != nil {
+		return nil, err
 	}
 
-	statuses = append(statuses, postgreSQLStatus)
+	statuses = append(statuses, *postgreSQLStatus)
 
-	mongoDBStatus := model.TechnologyStatus{
-		Product:            model.TechnologyMongoDBMongoDB,
-		ConsumedByHosts:    0,
-		CoveredByContracts: 0,
-		TotalCost:          0,
-		PaidCost:           0,
-		Compliance:         1,
-		UnpaidDues:         0,
-		HostsCount:         int(hostsCountByTechnology[model.TechnologyMongoDBMongoDB]),
+	mongoDBStatus, err := createMongoDBTechnologyStatus(as, hostsCountByTechnology[model.TechnologyMongoDBMongoDB], olderThan)
+	if err != nil {
+		return nil, err
 	}
 
-	statuses = append(statuses, mongoDBStatus)
+	statuses = append(statuses, *mongoDBStatus)
 
 	mariaDBStatus := model.TechnologyStatus{
 		Product:            model.TechnologyMariaDBFoundationMariaDB,
@@ -179,3 +167,53 @@ func createMySqlTechnologyStatus(as *APIService, hostsCount float64) (*model.Tec
 
 	return &status, nil
 }
+
+func createPostgreSQLTechnologyStatus(as *APIService, hostsCount float64, olderThan time.Time) (*model.TechnologyStatus, error) {
+	licensesCompliance, err := as.GetPostgreSQLDatabaseLicensesCompliance(olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	status := model.TechnologyStatus{
+		Product:    model.TechnologyPostgreSQLPostgreSQL,
+		HostsCount: int(hostsCount),
+	}
+
+	for _, licenseCompliance := range licensesCompliance {
+		status.ConsumedByHosts += licenseCompliance.Consumed
+		status.CoveredByContracts += licenseCompliance.Covered
+	}
+
+	if status.ConsumedByHosts == 0 {
+		status.Compliance = 1
+	} else {
+		status.Compliance = status.CoveredByContracts / status.ConsumedByHosts
+	}
+
+	return &status, nil
+}
+
+func createMongoDBTechnologyStatus(as *APIService, hostsCount float64, olderThan time.Time) (*model.TechnologyStatus, error) {
+	licensesCompliance, err := as.GetMongoDBDatabaseLicensesCompliance(olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	status := model.TechnologyStatus{
+		Product:    model.TechnologyMongoDBMongoDB,
+		HostsCount: int(hostsCount),
+	}
+
+	for _, licenseCompliance := range licensesCompliance {
+		status.ConsumedByHosts += licenseCompliance.Consumed
+		status.CoveredByContracts += licenseCompliance.Covered
+	}
+
+	if status.ConsumedByHosts == 0 {
+		status.Compliance = 1
+	} else {
+		status.Compliance = status.CoveredByContracts / status.ConsumedByHosts
+	}
+
+	return &status, nil
+}
@@ -247,7 +247,7 @@ func TestListManagedTechnologies_Success(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 			Return(map[string]float64{
 				model.TechnologyOracleDatabase:     42,
 				model.TechnologyOracleExadata:      43,
@@ -259,7 +259,7 @@ func TestListManagedTechnologies_Success(t *testing.T) {
 			Return(sampleListOracleDatabaseContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -273,7 +273,7 @@ func TestListManagedTechnologies_Success(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -284,11 +284,20 @@ func TestListManagedTechnologies_Success(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
 	actual, err := as.ListManagedTechnologies(
 		"Count", true,
-		"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 	)
 	require.NoError(t, err)
 
@@ -458,7 +467,7 @@ func TestListManagedTechnologies_Success2(t *testing.T) {
 		Return(clusters, nil).AnyTimes()
 	gomock.InOrder(
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 			Return(map[string]float64{
 				model.TechnologyOracleDatabase:     42,
 				model.TechnologyOracleExadata:      43,
@@ -470,7 +479,7 @@ func TestListManagedTechnologies_Success2(t *testing.T) {
 			Return(returnedContracts, nil),
 
 		db.EXPECT().
-			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&oracleLics, nil),
 		db.EXPECT().GetOracleDatabaseLicenseTypes().
 			Return(licenseTypes, nil),
@@ -484,7 +493,7 @@ func TestListManagedTechnologies_Success2(t *testing.T) {
 		db.EXPECT().GetMySQLContracts().
 			Return(contracts, nil),
 
-		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+		db.EXPECT().SearchSqlServerDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 			Return(&sqlServerLics, nil),
 		db.EXPECT().ListSqlServerDatabaseContracts().
 			Times(1).
@@ -495,11 +504,20 @@ func TestListManagedTechnologies_Success2(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+
+		db.EXPECT().GetPostgreSQLContracts().
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.PostgreSQLUsedLicense{}, nil),
+		db.EXPECT().GetMongoDBContracts().
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBUsedLicenses(gomock.Any(), gomock.Any()).
+			Return([]dto.MongoDBUsedLicense{}, nil),
 	)
 
 	actual, err := as.ListManagedTechnologies(
 		"Count", true,
-		"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+		"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 	)
 
 	expected := []model.TechnologyStatus{
@@ -525,12 +543,12 @@ func TestListManagedTechnologies_FailInternalServerErrors(t *testing.T) {
 
 	t.Run("Fail GetHostsCountUsingTechnologies", func(t *testing.T) {
 		db.EXPECT().
-			GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+			GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 			Return(nil, aerrMock)
 
 		_, err := as.ListManagedTechnologies(
 			"Count", true,
-			"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 		)
 
 		require.Equal(t, aerrMock, err)
@@ -539,7 +557,7 @@ func TestListManagedTechnologies_FailInternalServerErrors(t *testing.T) {
 	t.Run("Fail ListOracleDatabaseContracts", func(t *testing.T) {
 		gomock.InOrder(
 			db.EXPECT().
-				GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+				GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 				Return(map[string]float64{
 					model.TechnologyMariaDBFoundationMariaDB: 42,
 					model.TechnologyMicrosoftSQLServer:       43,
@@ -551,7 +569,7 @@ func TestListManagedTechnologies_FailInternalServerErrors(t *testing.T) {
 
 		_, err := as.ListManagedTechnologies(
 			"Count", true,
-			"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 		)
 
 		require.Equal(t, aerrMock, err)
@@ -596,7 +614,7 @@ func TestListManagedTechnologies_FailInternalServerErrors(t *testing.T) {
 
 		gomock.InOrder(
 			db.EXPECT().
-				GetHostsCountUsingTechnologies("Italy", "PROD", utils.P("2020-12-05T14:02:03Z")).
+				GetHostsCountUsingTechnologies("Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z")).
 				Return(map[string]float64{
 					model.TechnologyMariaDBFoundationMariaDB: 42,
 					model.TechnologyMicrosoftSQLServer:       43,
@@ -604,13 +622,13 @@ func TestListManagedTechnologies_FailInternalServerErrors(t *testing.T) {
 			db.EXPECT().
 				ListOracleDatabaseContracts().
 				Return(sampleListOracleDatabaseContracts, nil),
-			db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", "", utils.MAX_TIME).
 				Return(nil, aerrMock),
 		)
 
 		_, err := as.ListManagedTechnologies(
 			"Count", true,
-			"Italy", "PROD", utils.P("2020-12-05T14:02:03Z"),
+			"Italy", "PROD", "", utils.P("2020-12-05T14:02:03Z"),
 		)
 
 		require.Equal(t, aerrMock, err)
@@ -20,76 +20,414 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/ercole-io/ercole/v2/model"
 	"github.com/gocarina/gocsv"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
 )
 
-func (as *APIService) ImportOracleDatabaseContracts(reader *csv.Reader) error {
+// importedContract contains the fields of an imported contract which have to be validated
+type importedContract struct {
+	contractID    string
+	csi           string
+	licenseTypeID string
+	hosts         []string
+}
+
+func contractImportKey(contractID, csi string) string {
+	return contractID + "|" + csi
+}
+
+// contractsImportRollback collect the operations which undo the writes of an import,
+// so an import which fails half way doesn't leave only some of the contracts saved
+type contractsImportRollback struct {
+	undo []func() error
+}
+
+func (r *contractsImportRollback) add(undo func() error) {
+	r.undo = append(r.undo, undo)
+}
+
+// run undo the writes in reverse order and return the error which caused the rollback
+func (r *contractsImportRollback) run(as *APIService, cause error) error {
+	for i := len(r.undo) - 1; i >= 0; i-- {
+		if err := r.undo[i](); err != nil {
+			as.Log.Errorf("Can't rollback the contracts import: %s", err)
+		}
+	}
+
+	return cause
+}
+
+func splitLiteral(literal model.LiteralStrSlice) []string {
+	if len(literal) == 0 {
+		return nil
+	}
+
+	return strings.Split(string(literal), "|||")
+}
+
+// checkImportedContracts validate every imported contract and, according to the import mode,
+// decide if it will be inserted or if it will update the existing contract with the same ContractID and CSI
+func (as *APIService) checkImportedContracts(contracts []importedContract, licenseTypeIDs map[string]bool,
+	existing map[string]primitive.ObjectID, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error) {
+	report := &dto.ContractsImportReport{
+		DryRun: options.DryRun,
+		Mode:   options.Mode,
+		Valid:  true,
+		Rows:   make([]dto.ContractsImportRow, 0, len(contracts)),
+	}
+
+	hasHosts := false
+
+	for _, contract := range contracts {
+		if len(contract.hosts) > 0 {
+			hasHosts = true
+			break
+		}
+	}
+
+	hostnames := make(map[string]bool)
+
+	if hasHosts {
+		hosts, err := as.SearchHosts("hostnames", dto.NewSearchHostsFilters())
+		if err != nil {
+			return nil, utils.NewError(err, "")
+		}
+
+		for _, h := range hosts {
+			hostnames[h["hostname"].(string)] = true
+		}
+	}
+
+	rowsByKey := make(map[string]int)
+
+	for i, contract := range contracts {
+		row := dto.ContractsImportRow{
+			Row:           i + 2, // the first row contains the headers
+			ContractID:    contract.contractID,
+			CSI:           contract.csi,
+			LicenseTypeID: contract.licenseTypeID,
+			Action:        dto.ContractsImportActionInsert,
+			Errors:        make([]string, 0),
+		}
+
+		if contract.contractID == "" {
+			row.Errors = append(row.Errors, "ContractID is empty")
+		}
+
+		if !licenseTypeIDs[contract.licenseTypeID] {
+			row.Errors = append(row.Errors, fmt.Sprintf("Unknown license type: %q", contract.licenseTypeID))
+		}
+
+		for _, host := range contract.hosts {
+			if !hostnames[host] {
+				row.Errors = append(row.Errors, fmt.Sprintf("Unknown host: %q", host))
+			}
+		}
+
+		key := contractImportKey(contract.contractID, contract.csi)
+
+		if previous, ok := rowsByKey[key]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("Duplicated ContractID %q, CSI %q: already present at row %d",
+				contract.contractID, contract.csi, previous))
+		} else {
+			rowsByKey[key] = row.Row
+		}
+
+		if _, ok := existing[key]; ok {
+			if options.Mode == dto.ContractsImportModeUpsert {
+				row.Action = dto.ContractsImportActionUpdate
+			} else {
+				row.Errors = append(row.Errors, fmt.Sprintf("Duplicated ContractID %q, CSI %q: contract already exists",
+					contract.contractID, contract.csi))
+			}
+		}
+
+		if len(row.Errors) > 0 {
+			report.Valid = false
+		}
+
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
+
+// ImportOracleDatabaseContracts validate and, if not in dry run, save the contracts read from reader
+func (as *APIService) ImportOracleDatabaseContracts(reader *csv.Reader, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error) {
 	contracts := make([]model.OracleDatabaseContract, 0)
 
 	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, contract := range contracts {
-		if len(contract.HostsLiteral) > 0 {
-			contract.Hosts = strings.Split(string(contract.HostsLiteral), "|||")
+	licenseTypes, err := as.GetOracleDatabaseLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypeIDs := make(map[string]bool, len(licenseTypes))
+	for id := range licenseTypes {
+		licenseTypeIDs[id] = true
+	}
+
+	existingContracts, err := as.Database.ListOracleDatabaseContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]primitive.ObjectID, len(existingContracts))
+	for _, contract := range existingContracts {
+		existing[contractImportKey(contract.ContractID, contract.CSI)] = contract.ID
+	}
+
+	imported := make([]importedContract, len(contracts))
+
+	for i := range contracts {
+		contracts[i].Hosts = splitLiteral(contracts[i].HostsLiteral)
+		imported[i] = importedContract{
+			contractID:    contracts[i].ContractID,
+			csi:           contracts[i].CSI,
+			licenseTypeID: contracts[i].LicenseTypeID,
+			hosts:         contracts[i].Hosts,
+		}
+	}
+
+	report, err := as.checkImportedContracts(imported, licenseTypeIDs, existing, options)
+	if err != nil || !report.Valid || options.DryRun {
+		return report, err
+	}
+
+	rollback := &contractsImportRollback{}
+
+	for i, contract := range contracts {
+		if report.Rows[i].Action == dto.ContractsImportActionInsert {
+			if contract.Hosts == nil {
+				contract.Hosts = []string{}
+			}
+
+			id, err := as.insertOracleDatabaseContract(contract)
+			if err != nil {
+				return nil, rollback.run(as, err)
+			}
+
+			rollback.add(func() error { return as.Database.RemoveOracleDatabaseContract(id) })
+
+			report.Inserted++
+
+			continue
 		}
 
-		if _, err := as.AddOracleDatabaseContract(contract); err != nil {
-			return err
+		old, err := as.Database.GetOracleDatabaseContract(existing[contractImportKey(contract.ContractID, contract.CSI)])
+		if err != nil {
+			return nil, rollback.run(as, err)
 		}
+
+		previous := *old
+		old.LicenseTypeID = contract.LicenseTypeID
+		old.Unlimited = contract.Unlimited
+		old.Count = contract.Count
+
+		if contract.Hosts != nil {
+			old.Hosts = contract.Hosts
+		}
+
+		if err := as.updateOracleDatabaseContract(*old); err != nil {
+			return nil, rollback.run(as, err)
+		}
+
+		rollback.add(func() error { return as.Database.UpdateOracleDatabaseContract(previous) })
+
+		report.Updated++
 	}
 
-	return nil
+	return report, nil
 }
 
-func (as *APIService) ImportSQLServerDatabaseContracts(reader *csv.Reader) error {
+// ImportSQLServerDatabaseContracts validate and, if not in dry run, save the contracts read from reader.
+// SQL Server contracts haven't a CSI, so they are matched only by ContractID
+func (as *APIService) ImportSQLServerDatabaseContracts(reader *csv.Reader, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error) {
 	contracts := make([]model.SqlServerDatabaseContract, 0)
 
 	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, contract := range contracts {
-		if len(contract.HostsLiteral) > 0 {
-			contract.Hosts = strings.Split(string(contract.HostsLiteral), "|||")
+	licenseTypes, err := as.GetSqlServerDatabaseLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypeIDs := make(map[string]bool, len(licenseTypes))
+	for id := range licenseTypes {
+		licenseTypeIDs[id] = true
+	}
+
+	existingContracts, err := as.Database.ListSqlServerDatabaseContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]primitive.ObjectID, len(existingContracts))
+	existingByID := make(map[primitive.ObjectID]model.SqlServerDatabaseContract, len(existingContracts))
+
+	for _, contract := range existingContracts {
+		existing[contractImportKey(contract.ContractID, "")] = contract.ID
+		existingByID[contract.ID] = contract
+	}
+
+	imported := make([]importedContract, len(contracts))
+
+	for i := range contracts {
+		contracts[i].Hosts = splitLiteral(contracts[i].HostsLiteral)
+		contracts[i].Clusters = splitLiteral(contracts[i].ClusterLiteral)
+		imported[i] = importedContract{
+			contractID:    contracts[i].ContractID,
+			licenseTypeID: contracts[i].LicenseTypeID,
+			hosts:         contracts[i].Hosts,
 		}
+	}
+
+	report, err := as.checkImportedContracts(imported, licenseTypeIDs, existing, options)
+	if err != nil || !report.Valid || options.DryRun {
+		return report, err
+	}
+
+	rollback := &contractsImportRollback{}
+
+	for i, contract := range contracts {
+		if report.Rows[i].Action == dto.ContractsImportActionInsert {
+			id, err := as.insertSqlServerDatabaseContract(contract)
+			if err != nil {
+				return nil, rollback.run(as, err)
+			}
+
+			rollback.add(func() error { return as.Database.RemoveSqlServerDatabaseContract(id) })
+
+			report.Inserted++
 
-		if len(contract.ClusterLiteral) > 0 {
-			contract.Clusters = strings.Split(string(contract.ClusterLiteral), "|||")
+			continue
 		}
 
-		if _, err := as.AddSqlServerDatabaseContract(contract); err != nil {
-			return err
+		previous := existingByID[existing[contractImportKey(contract.ContractID, "")]]
+		old := previous
+		old.Type = contract.Type
+		old.LicenseTypeID = contract.LicenseTypeID
+		old.LicensesNumber = contract.LicensesNumber
+		old.SoftwareAssurance = contract.SoftwareAssurance
+
+		if contract.Hosts != nil {
+			old.Hosts = contract.Hosts
+		}
+
+		if contract.Clusters != nil {
+			old.Clusters = contract.Clusters
+		}
+
+		if err := as.updateSqlServerDatabaseContract(old); err != nil {
+			return nil, rollback.run(as, err)
 		}
+
+		rollback.add(func() error { return as.Database.UpdateSqlServerDatabaseContract(previous) })
+
+		report.Updated++
 	}
 
-	return nil
+	return report, nil
 }
 
-func (as *APIService) ImportMySQLDatabaseContracts(reader *csv.Reader) error {
+// ImportMySQLDatabaseContracts validate and, if not in dry run, save the contracts read from reader
+func (as *APIService) ImportMySQLDatabaseContracts(reader *csv.Reader, options dto.ContractsImportOptions) (*dto.ContractsImportReport, error) {
 	contracts := make([]model.MySQLContract, 0)
 
 	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, contract := range contracts {
-		if len(contract.HostsLiteral) > 0 {
-			contract.Hosts = strings.Split(string(contract.HostsLiteral), "|||")
+	licenseTypes, err := as.GetMySqlLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypeIDs := make(map[string]bool, len(licenseTypes))
+	for _, lt := range licenseTypes {
+		licenseTypeIDs[lt.ID] = true
+	}
+
+	existingContracts, err := as.Database.GetMySQLContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]primitive.ObjectID, len(existingContracts))
+	existingByID := make(map[primitive.ObjectID]model.MySQLContract, len(existingContracts))
+
+	for _, contract := range existingContracts {
+		existing[contractImportKey(contract.ContractID, contract.CSI)] = contract.ID
+		existingByID[contract.ID] = contract
+	}
+
+	imported := make([]importedContract, len(contracts))
+
+	for i := range contracts {
+		contracts[i].Hosts = splitLiteral(contracts[i].HostsLiteral)
+		contracts[i].Clusters = splitLiteral(contracts[i].ClusterLiteral)
+		imported[i] = importedContract{
+			contractID:    contracts[i].ContractID,
+			csi:           contracts[i].CSI,
+			licenseTypeID: contracts[i].LicenseTypeID,
+			hosts:         contracts[i].Hosts,
+		}
+	}
+
+	report, err := as.checkImportedContracts(imported, licenseTypeIDs, existing, options)
+	if err != nil || !report.Valid || options.DryRun {
+		return report, err
+	}
+
+	rollback := &contractsImportRollback{}
+
+	for i, contract := range contracts {
+		if report.Rows[i].Action == dto.ContractsImportActionInsert {
+			added, err := as.AddMySQLContract(contract)
+			if err != nil {
+				return nil, rollback.run(as, err)
+			}
+
+			rollback.add(func() error { return as.DeleteMySQLContract(added.ID) })
+
+			report.Inserted++
+
+			continue
+		}
+
+		previous := existingByID[existing[contractImportKey(contract.ContractID, contract.CSI)]]
+		old := previous
+		old.Type = contract.Type
+		old.LicenseTypeID = contract.LicenseTypeID
+		old.NumberOfLicenses = contract.NumberOfLicenses
+
+		if contract.Hosts != nil {
+			old.Hosts = contract.Hosts
 		}
 
-		if len(contract.ClusterLiteral) > 0 {
-			contract.Clusters = strings.Split(string(contract.ClusterLiteral), "|||")
+		if contract.Clusters != nil {
+			old.Clusters = contract.Clusters
 		}
 
-		if _, err := as.AddMySQLContract(contract); err != nil {
-			return err
+		if _, err := as.UpdateMySQLContract(old); err != nil {
+			return nil, rollback.run(as, err)
 		}
+
+		rollback.add(func() error { return as.Database.UpdateMySQLContract(previous) })
+
+		report.Updated++
 	}
 
-	return nil
+	return report, nil
 }
 
 func (as *APIService) GetLicenseContractSample(dbtype string) ([]byte, error) {
@@ -0,0 +1,258 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const oracleContractsCSV = `Contract Number,CSI,Part Number,ULA,License number,Hosts
+AID001,CSI001,PID001,false,10,foobar
+AID002,CSI002,PID002,false,20,
+AID003,CSI003,PIDXXX,false,30,unknown
+AID002,CSI002,PID001,false,40,
+`
+
+func TestImportOracleDatabaseContracts_DryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	licenseTypes := []model.OracleDatabaseLicenseType{{ID: "PID001"}, {ID: "PID002"}}
+	existing := []dto.OracleDatabaseContractFE{
+		{ID: utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), ContractID: "AID001", CSI: "CSI001"},
+	}
+	hosts := []map[string]interface{}{{"hostname": "foobar"}}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+	db.EXPECT().ListOracleDatabaseContracts().Return(existing, nil)
+	db.EXPECT().SearchHosts("hostnames", gomock.Any()).Return(hosts, nil)
+
+	options := dto.ContractsImportOptions{DryRun: true, Mode: dto.ContractsImportModeUpsert}
+	actual, err := as.ImportOracleDatabaseContracts(csv.NewReader(strings.NewReader(oracleContractsCSV)), options)
+	require.NoError(t, err)
+
+	expected := &dto.ContractsImportReport{
+		DryRun: true,
+		Mode:   dto.ContractsImportModeUpsert,
+		Valid:  false,
+		Rows: []dto.ContractsImportRow{
+			{Row: 2, ContractID: "AID001", CSI: "CSI001", LicenseTypeID: "PID001", Action: dto.ContractsImportActionUpdate, Errors: []string{}},
+			{Row: 3, ContractID: "AID002", CSI: "CSI002", LicenseTypeID: "PID002", Action: dto.ContractsImportActionInsert, Errors: []string{}},
+			{Row: 4, ContractID: "AID003", CSI: "CSI003", LicenseTypeID: "PIDXXX", Action: dto.ContractsImportActionInsert,
+				Errors: []string{`Unknown license type: "PIDXXX"`, `Unknown host: "unknown"`}},
+			{Row: 5, ContractID: "AID002", CSI: "CSI002", LicenseTypeID: "PID001", Action: dto.ContractsImportActionInsert,
+				Errors: []string{`Duplicated ContractID "AID002", CSI "CSI002": already present at row 3`}},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestImportOracleDatabaseContracts_InsertModeExistingContract(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	licenseTypes := []model.OracleDatabaseLicenseType{{ID: "PID001"}}
+	existing := []dto.OracleDatabaseContractFE{
+		{ID: utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), ContractID: "AID001", CSI: "CSI001"},
+	}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+	db.EXPECT().ListOracleDatabaseContracts().Return(existing, nil)
+
+	data := "Contract Number,CSI,Part Number,ULA,License number\nAID001,CSI001,PID001,false,10\n"
+	options := dto.ContractsImportOptions{Mode: dto.ContractsImportModeInsert}
+	actual, err := as.ImportOracleDatabaseContracts(csv.NewReader(strings.NewReader(data)), options)
+	require.NoError(t, err)
+
+	assert.False(t, actual.Valid)
+	assert.Equal(t, 0, actual.Inserted)
+	assert.Equal(t, []string{`Duplicated ContractID "AID001", CSI "CSI001": contract already exists`}, actual.Rows[0].Errors)
+}
+
+func TestImportOracleDatabaseContracts_Upsert(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	licenseTypes := []model.OracleDatabaseLicenseType{{ID: "PID001"}, {ID: "PID002"}}
+	existingID := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+	existing := []dto.OracleDatabaseContractFE{
+		{ID: existingID, ContractID: "AID001", CSI: "CSI001"},
+	}
+	old := model.OracleDatabaseContract{
+		ID:            existingID,
+		ContractID:    "AID001",
+		CSI:           "CSI001",
+		LicenseTypeID: "PID001",
+		Count:         1,
+		Basket:        true,
+		Hosts:         []string{"foobar"},
+	}
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+	db.EXPECT().ListOracleDatabaseContracts().Return(existing, nil)
+	db.EXPECT().GetOracleDatabaseContract(existingID).Return(&old, nil)
+	db.EXPECT().SearchHosts("hostnames", gomock.Any()).Return([]map[string]interface{}{{"hostname": "foobar"}}, nil).Times(2)
+	db.EXPECT().GetOracleDatabaseLicenseType("PID001").Return(&licenseTypes[0], nil)
+	db.EXPECT().GetOracleDatabaseLicenseType("PID002").Return(&licenseTypes[1], nil)
+	db.EXPECT().UpdateOracleDatabaseContract(model.OracleDatabaseContract{
+		ID:            existingID,
+		ContractID:    "AID001",
+		CSI:           "CSI001",
+		LicenseTypeID: "PID001",
+		Count:         10,
+		Basket:        true,
+		Hosts:         []string{"foobar"},
+	}).Return(nil)
+	db.EXPECT().InsertOracleDatabaseContract(model.OracleDatabaseContract{
+		ID:            utils.Str2oid("000000000000000000000001"),
+		ContractID:    "AID002",
+		CSI:           "CSI002",
+		LicenseTypeID: "PID002",
+		Count:         20,
+		Hosts:         []string{},
+	}).Return(nil)
+
+	data := "Contract Number,CSI,Part Number,ULA,License number\nAID001,CSI001,PID001,false,10\nAID002,CSI002,PID002,false,20\n"
+	options := dto.ContractsImportOptions{Mode: dto.ContractsImportModeUpsert}
+	actual, err := as.ImportOracleDatabaseContracts(csv.NewReader(strings.NewReader(data)), options)
+	require.NoError(t, err)
+
+	assert.True(t, actual.Valid)
+	assert.Equal(t, 1, actual.Inserted)
+	assert.Equal(t, 1, actual.Updated)
+}
+
+func TestImportOracleDatabaseContracts_RollbackOnError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+		Log:         logger.NewLogger("TEST"),
+	}
+
+	licenseTypes := []model.OracleDatabaseLicenseType{{ID: "PID001"}, {ID: "PID002"}}
+	existingID := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+	existing := []dto.OracleDatabaseContractFE{
+		{ID: existingID, ContractID: "AID001", CSI: "CSI001"},
+	}
+	old := model.OracleDatabaseContract{
+		ID:            existingID,
+		ContractID:    "AID001",
+		CSI:           "CSI001",
+		LicenseTypeID: "PID001",
+		Count:         1,
+		Hosts:         []string{},
+	}
+	insertedID := utils.Str2oid("000000000000000000000001")
+
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+	db.EXPECT().ListOracleDatabaseContracts().Return(existing, nil)
+	db.EXPECT().SearchHosts("hostnames", gomock.Any()).Return([]map[string]interface{}{}, nil).AnyTimes()
+	db.EXPECT().GetOracleDatabaseLicenseType("PID001").Return(&licenseTypes[0], nil).AnyTimes()
+	db.EXPECT().GetOracleDatabaseLicenseType("PID002").Return(&licenseTypes[1], nil).AnyTimes()
+
+	gomock.InOrder(
+		db.EXPECT().InsertOracleDatabaseContract(model.OracleDatabaseContract{
+			ID:            insertedID,
+			ContractID:    "AID002",
+			CSI:           "CSI002",
+			LicenseTypeID: "PID002",
+			Count:         20,
+			Hosts:         []string{},
+		}).Return(nil),
+		db.EXPECT().GetOracleDatabaseContract(existingID).Return(&old, nil),
+		db.EXPECT().UpdateOracleDatabaseContract(gomock.Any()).Return(aerrMock),
+		db.EXPECT().RemoveOracleDatabaseContract(insertedID).Return(nil),
+	)
+
+	data := "Contract Number,CSI,Part Number,ULA,License number\nAID002,CSI002,PID002,false,20\nAID001,CSI001,PID001,false,10\n"
+	options := dto.ContractsImportOptions{Mode: dto.ContractsImportModeUpsert}
+	actual, err := as.ImportOracleDatabaseContracts(csv.NewReader(strings.NewReader(data)), options)
+
+	assert.ErrorIs(t, err, aerrMock)
+	assert.Nil(t, actual)
+}
+
+func TestImportMySQLDatabaseContracts_Upsert(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	existingID := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+	existing := []model.MySQLContract{
+		{
+			ID:               existingID,
+			Type:             model.MySQLContractTypeCluster,
+			ContractID:       "AID001",
+			CSI:              "CSI001",
+			LicenseTypeID:    model.MySqlPartNumber,
+			NumberOfLicenses: 1,
+			Clusters:         []string{"pippo"},
+		},
+	}
+
+	db.EXPECT().GetMySQLContracts().Return(existing, nil)
+	db.EXPECT().UpdateMySQLContract(model.MySQLContract{
+		ID:               existingID,
+		Type:             model.MySQLContractTypeCluster,
+		ContractID:       "AID001",
+		CSI:              "CSI001",
+		LicenseTypeID:    model.MySqlPartNumber,
+		NumberOfLicenses: 5,
+		Clusters:         []string{"pippo"},
+	}).Return(nil)
+
+	data := "Type,Contract Number,CSI,License Type,Number of Licenses\nCLUSTER,AID001,CSI001," + model.MySqlPartNumber + ",5\n"
+	options := dto.ContractsImportOptions{Mode: dto.ContractsImportModeUpsert}
+	actual, err := as.ImportMySQLDatabaseContracts(csv.NewReader(strings.NewReader(data)), options)
+	require.NoError(t, err)
+
+	assert.True(t, actual.Valid)
+	assert.Equal(t, 0, actual.Inserted)
+	assert.Equal(t, 1, actual.Updated)
+}
@@ -32,6 +32,8 @@ type ChartControllerInterface interface {
 	// GetOracleDatabaseChart return the chart data related to oracle databases
 	GetOracleDatabaseChart(w http.ResponseWriter, r *http.Request)
 	GetLicenseComplianceHistory(w http.ResponseWriter, r *http.Request)
+	// GetUsedLicensesHistory return the daily history of the licenses used by hosts and databases
+	GetUsedLicensesHistory(w http.ResponseWriter, r *http.Request)
 
 	// GetChangeChart return the chart data related to changes
 	GetChangeChart(w http.ResponseWriter, r *http.Request)
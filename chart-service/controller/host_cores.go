@@ -29,6 +29,8 @@ func (ctrl *ChartController) GetHostCores(w http.ResponseWriter, r *http.Request
 
 	var environment string
 
+	var site string
+
 	var olderThan time.Time
 
 	var newerThan time.Time
@@ -40,8 +42,9 @@ func (ctrl *ChartController) GetHostCores(w http.ResponseWriter, r *http.Request
 
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
+	site = r.URL.Query().Get("site")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
@@ -51,7 +54,7 @@ func (ctrl *ChartController) GetHostCores(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	hosts, err := ctrl.Service.GetHostCores(location, environment, olderThan, newerThan)
+	hosts, err := ctrl.Service.GetHostCores(location, environment, site, olderThan, newerThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -43,15 +43,16 @@ func TestGetHostsHistory_Success(t *testing.T) {
 	host := []dto.HostCores{}
 	location := "Italy"
 	environment := "TST"
+	site := "milan"
 	olderThan := utils.MAX_TIME
 	newerThan := utils.MIN_TIME
 
-	as.EXPECT().GetHostCores(location, environment, olderThan, newerThan).
+	as.EXPECT().GetHostCores(location, environment, site, olderThan, newerThan).
 		Return(host, nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.GetHostCores)
-	req, err := http.NewRequest("GET", "/?location=Italy&environment=TST", nil)
+	req, err := http.NewRequest("GET", "/?location=Italy&environment=TST&site=milan", nil)
 	require.NoError(t, err)
 
 	handler.ServeHTTP(rr, req)
@@ -116,10 +117,11 @@ func TestGetHostsHistory_InternalServerError(t *testing.T) {
 
 	location := ""
 	environment := ""
+	site := ""
 	olderThan := utils.MAX_TIME
 	newerThan := utils.MIN_TIME
 
-	as.EXPECT().GetHostCores(location, environment, olderThan, newerThan).
+	as.EXPECT().GetHostCores(location, environment, site, olderThan, newerThan).
 		Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
@@ -34,13 +34,16 @@ func (ctrl *ChartController) GetOracleDatabaseChart(w http.ResponseWriter, r *ht
 
 	var environment string
 
+	var site string
+
 	var olderThan time.Time
 
 	metric = r.URL.Query().Get("metric")
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
+	site = r.URL.Query().Get("site")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
@@ -50,7 +53,7 @@ func (ctrl *ChartController) GetOracleDatabaseChart(w http.ResponseWriter, r *ht
 		return
 	}
 
-	data, err := ctrl.Service.GetOracleDatabaseChart(metric, location, environment, olderThan)
+	data, err := ctrl.Service.GetOracleDatabaseChart(metric, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -53,6 +53,7 @@ func (ctrl *ChartController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/settings/technologies-metrics", ctrl.GetTechnologiesMetrics).Methods("GET")
 
 	router.HandleFunc("/technologies/all/license-history", ctrl.GetLicenseComplianceHistory).Methods("GET")
+	router.HandleFunc("/technologies/all/used-licenses-history", ctrl.GetUsedLicensesHistory).Methods("GET")
 	router.HandleFunc("/technologies/oracle/database", ctrl.GetOracleDatabaseChart).Methods("GET")
 
 	router.HandleFunc("/technologies/changes", ctrl.GetChangeChart).Methods("GET")
@@ -28,7 +28,7 @@ func (ctrl *ChartController) GetChangeChart(w http.ResponseWriter, r *http.Reque
 
 	var from, olderThan time.Time
 
-	var location, environment string
+	var location, environment, site string
 
 	if from, err = utils.Str2time(r.URL.Query().Get("from"), utils.MAX_TIME); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
@@ -37,13 +37,14 @@ func (ctrl *ChartController) GetChangeChart(w http.ResponseWriter, r *http.Reque
 
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
+	site = r.URL.Query().Get("site")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
-	data, err := ctrl.Service.GetChangeChart(from, location, environment, olderThan)
+	data, err := ctrl.Service.GetChangeChart(from, location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -56,19 +57,20 @@ func (ctrl *ChartController) GetChangeChart(w http.ResponseWriter, r *http.Reque
 func (ctrl *ChartController) GetTechnologyTypes(w http.ResponseWriter, r *http.Request) {
 	var err error
 
-	var location, environment string
+	var location, environment, site string
 
 	var olderThan time.Time
 
 	location = r.URL.Query().Get("location")
 	environment = r.URL.Query().Get("environment")
+	site = r.URL.Query().Get("site")
 
-	if olderThan, err = utils.Str2time(r.URL.Query().Get("older-than"), utils.MAX_TIME); err != nil {
+	if olderThan, err = utils.GetOlderThanParam(r); err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 		return
 	}
 
-	data, err := ctrl.Service.GetTechnologyTypesChart(location, environment, olderThan)
+	data, err := ctrl.Service.GetTechnologyTypesChart(location, environment, site, olderThan)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
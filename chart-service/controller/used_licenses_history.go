@@ -0,0 +1,56 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ercole-io/ercole/v2/chart-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetUsedLicensesHistory return the daily history of the licenses used by a host, a database or a license type
+func (ctrl *ChartController) GetUsedLicensesHistory(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	filter := dto.UsedLicensesHistoryFilter{
+		Hostname:      r.URL.Query().Get("hostname"),
+		DbName:        r.URL.Query().Get("dbname"),
+		LicenseTypeID: r.URL.Query().Get("license-type-id"),
+	}
+
+	if filter.From, err = utils.Str2time(r.URL.Query().Get("from"), utils.MIN_TIME); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if filter.To, err = utils.Str2time(r.URL.Query().Get("to"), utils.MAX_TIME); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	history, err := ctrl.Service.GetUsedLicensesHistory(filter)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"usedLicensesHistory": history,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
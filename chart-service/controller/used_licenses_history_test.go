@@ -0,0 +1,99 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	dto "github.com/ercole-io/ercole/v2/chart-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetUsedLicensesHistory_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	firstUsed := utils.P("2019-10-01T00:00:00Z")
+	history := []dto.UsedLicenseHistory{
+		{
+			Hostname:      "foobar",
+			DbName:        "ERCOLE",
+			LicenseTypeID: "A90620",
+			FirstUsed:     &firstUsed,
+			History: []dto.UsedLicenseHistoricValue{
+				{Date: utils.P("2019-11-01T00:00:00Z"), UsedLicenses: 2},
+			},
+		},
+	}
+	filter := dto.UsedLicensesHistoryFilter{
+		Hostname:      "foobar",
+		LicenseTypeID: "A90620",
+		From:          utils.P("2019-11-01T00:00:00Z"),
+		To:            utils.MAX_TIME,
+	}
+	as.EXPECT().GetUsedLicensesHistory(filter).
+		Return(history, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetUsedLicensesHistory)
+	req, err := http.NewRequest("GET", "/?hostname=foobar&license-type-id=A90620&from=2019-11-01T00:00:00Z", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := map[string]interface{}{
+		"usedLicensesHistory": history,
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestGetUsedLicensesHistory_UnprocessableEntity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetUsedLicensesHistory)
+	req, err := http.NewRequest("GET", "/?from=yesterday", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
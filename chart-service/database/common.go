@@ -16,6 +16,7 @@
 package database
 
 import (
+	"strings"
 	"time"
 
 	"github.com/amreo/mu"
@@ -36,6 +37,27 @@ func FilterByLocationAndEnvironmentSteps(location string, environment string) in
 	}
 }
 
+// FilterBySiteSteps return the steps required to filter the data by the comma separated names of the sites.
+// The data without site belong to the localSite
+func FilterBySiteSteps(site string, localSite string) interface{} {
+	sites := bson.A{}
+
+	if site != "" {
+		for _, s := range strings.Split(site, ",") {
+			sites = append(sites, s)
+			if s == localSite {
+				sites = append(sites, nil)
+			}
+		}
+	}
+
+	return bson.A{
+		mu.APOptionalStage(site != "", mu.APMatch(bson.M{
+			"site": bson.M{"$in": sites},
+		})),
+	}
+}
+
 func FilterByOldnessSteps(olderThan time.Time) bson.A {
 	return mu.MAPipeline(
 		mu.APOptionalStage(olderThan == utils.MAX_TIME, mu.APMatch(bson.M{
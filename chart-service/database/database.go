@@ -36,15 +36,17 @@ type MongoDatabaseInterface interface {
 	Init()
 
 	// GetTechnologyCount return the number of occurence per technology
-	GetTechnologyCount(location string, environment string, olderThan time.Time) (map[string]float64, error)
+	GetTechnologyCount(location string, environment string, site string, olderThan time.Time) (map[string]float64, error)
 
 	// GetOracleDatabaseChartByVersion return the chart data about oracle database version
-	GetOracleDatabaseChartByVersion(location string, environment string, olderThan time.Time) ([]dto.ChartBubble, error)
+	GetOracleDatabaseChartByVersion(location string, environment string, site string, olderThan time.Time) ([]dto.ChartBubble, error)
 	// GetOracleDatabaseChartByWork return the chart data about the work of all database
-	GetOracleDatabaseChartByWork(location string, environment string, olderThan time.Time) ([]dto.ChartBubble, error)
+	GetOracleDatabaseChartByWork(location string, environment string, site string, olderThan time.Time) ([]dto.ChartBubble, error)
 	GetLicenseComplianceHistory() ([]dto.LicenseComplianceHistory, error)
 
-	GetHostCores(location, environment string, olderThan, newerThan time.Time) ([]dto.HostCores, error)
+	GetHostCores(location, environment, site string, olderThan, newerThan time.Time) ([]dto.HostCores, error)
+	// GetUsedLicensesHistory return the daily history of the licenses used by hosts and databases
+	GetUsedLicensesHistory(filter dto.UsedLicensesHistoryFilter) ([]dto.UsedLicenseHistory, error)
 }
 
 // MongoDatabase is a implementation
@@ -26,7 +26,7 @@ import (
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
-func (md *MongoDatabase) GetHostCores(location, environment string, olderThan, newerThan time.Time) ([]dto.HostCores, error) {
+func (md *MongoDatabase) GetHostCores(location, environment, site string, olderThan, newerThan time.Time) ([]dto.HostCores, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
 		context.TODO(),
 		mu.MAPipeline(
@@ -37,6 +37,7 @@ func (md *MongoDatabase) GetHostCores(location, environment string, olderThan, n
 				},
 			}),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APGroup(
 				bson.M{
 					"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$createdAt"}},
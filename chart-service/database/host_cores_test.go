@@ -35,10 +35,11 @@ func (m *MongodbSuite) TestGetHostCores() {
 	m.T().Run("should_filter_out_by_environment", func(t *testing.T) {
 		location := ""
 		environment := "TST"
+		site := ""
 		olderThan := utils.MAX_TIME
 		newerThan := utils.MIN_TIME
 
-		out, err := m.db.GetHostCores(location, environment, olderThan, newerThan)
+		out, err := m.db.GetHostCores(location, environment, site, olderThan, newerThan)
 		m.Require().NoError(err)
 		expectedOut := []dto.HostCores{
 			{
@@ -53,10 +54,11 @@ func (m *MongodbSuite) TestGetHostCores() {
 	m.T().Run("should_filter_out_by_location", func(t *testing.T) {
 		location := "Germany"
 		environment := ""
+		site := ""
 		olderThan := utils.MAX_TIME
 		newerThan := utils.MIN_TIME
 
-		out, err := m.db.GetHostCores(location, environment, olderThan, newerThan)
+		out, err := m.db.GetHostCores(location, environment, site, olderThan, newerThan)
 		m.Require().NoError(err)
 		expectedOut := []dto.HostCores{
 			{
@@ -75,10 +77,11 @@ func (m *MongodbSuite) TestGetHostCores() {
 	m.T().Run("should_filter_out_by_older_than", func(t *testing.T) {
 		location := ""
 		environment := ""
+		site := ""
 		olderThan := utils.P("2020-04-16T00:00:00Z")
 		newerThan := utils.MIN_TIME
 
-		out, err := m.db.GetHostCores(location, environment, olderThan, newerThan)
+		out, err := m.db.GetHostCores(location, environment, site, olderThan, newerThan)
 		m.Require().NoError(err)
 		expectedOut := []dto.HostCores{
 			{
@@ -92,10 +95,11 @@ func (m *MongodbSuite) TestGetHostCores() {
 	m.T().Run("should_filter_out_by_newer_than", func(t *testing.T) {
 		location := ""
 		environment := ""
+		site := ""
 		olderThan := utils.MAX_TIME
 		newerThan := utils.P("2020-05-12T00:00:00Z")
 
-		out, err := m.db.GetHostCores(location, environment, olderThan, newerThan)
+		out, err := m.db.GetHostCores(location, environment, site, olderThan, newerThan)
 		m.Require().NoError(err)
 		expectedOut := []dto.HostCores{
 			{
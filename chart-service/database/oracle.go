@@ -28,7 +28,7 @@ import (
 )
 
 // GetOracleDatabaseChartByVersion return the chart data about oracle database version
-func (md *MongoDatabase) GetOracleDatabaseChartByVersion(location string, environment string, olderThan time.Time) ([]dto.ChartBubble, error) {
+func (md *MongoDatabase) GetOracleDatabaseChartByVersion(location string, environment string, site string, olderThan time.Time) ([]dto.ChartBubble, error) {
 	var out = make([]dto.ChartBubble, 0)
 	//Find the matching hostdata
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -36,6 +36,7 @@ func (md *MongoDatabase) GetOracleDatabaseChartByVersion(location string, enviro
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APProject(bson.M{
 				"_id":     0,
@@ -62,7 +63,7 @@ func (md *MongoDatabase) GetOracleDatabaseChartByVersion(location string, enviro
 }
 
 // GetOracleDatabaseChartByWork return the chart data about the work of all database
-func (md *MongoDatabase) GetOracleDatabaseChartByWork(location string, environment string, olderThan time.Time) ([]dto.ChartBubble, error) {
+func (md *MongoDatabase) GetOracleDatabaseChartByWork(location string, environment string, site string, olderThan time.Time) ([]dto.ChartBubble, error) {
 	var out []dto.ChartBubble = make([]dto.ChartBubble, 0)
 	//Find the matching hostdata
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
@@ -70,6 +71,7 @@ func (md *MongoDatabase) GetOracleDatabaseChartByWork(location string, environme
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APUnwind("$features.oracle.database.databases"),
 			mu.APMatch(bson.M{
 				"features.oracle.database.databases.work": mu.QONotEqual(nil),
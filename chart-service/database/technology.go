@@ -28,7 +28,7 @@ import (
 )
 
 // GetTechnologyCount return the number of occurence per technology
-func (md *MongoDatabase) GetTechnologyCount(location string, environment string, olderThan time.Time) (map[string]float64, error) {
+func (md *MongoDatabase) GetTechnologyCount(location string, environment string, site string, olderThan time.Time) (map[string]float64, error) {
 	var out map[string]float64
 	//Create the operating system technology detector
 	var technologyDetector bson.M = bson.M{}
@@ -72,6 +72,7 @@ func (md *MongoDatabase) GetTechnologyCount(location string, environment string,
 		mu.MAPipeline(
 			FilterByOldnessSteps(olderThan),
 			FilterByLocationAndEnvironmentSteps(location, environment),
+			FilterBySiteSteps(site, md.Config.Federation.LocalSite),
 			mu.APProject(technologyDetector),
 			mu.APGroup(technologyCounter),
 		),
@@ -0,0 +1,102 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/chart-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetUsedLicensesHistory return the daily history of the licenses used by each host and database.
+// FirstUsed is computed on the whole history, regardless of the requested dates
+func (md *MongoDatabase) GetUsedLicensesHistory(filter dto.UsedLicensesHistoryFilter) ([]dto.UsedLicenseHistory, error) {
+	match := bson.M{}
+
+	if filter.Hostname != "" {
+		match["hostname"] = filter.Hostname
+	}
+
+	if filter.DbName != "" {
+		match["dbName"] = filter.DbName
+	}
+
+	if filter.LicenseTypeID != "" {
+		match["licenseTypeID"] = filter.LicenseTypeID
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("used_licenses_history").Aggregate(
+		context.TODO(),
+		mu.MAPipeline(
+			mu.APMatch(match),
+			mu.APSort(bson.M{
+				"date": 1,
+			}),
+			mu.APGroup(bson.M{
+				"_id": bson.M{
+					"hostname":      "$hostname",
+					"dbName":        "$dbName",
+					"licenseTypeID": "$licenseTypeID",
+				},
+				"description": bson.M{"$last": "$description"},
+				"metric":      bson.M{"$last": "$metric"},
+				"firstUsed": bson.M{"$min": bson.M{
+					"$cond": bson.A{bson.M{"$gt": bson.A{"$usedLicenses", 0}}, "$date", nil},
+				}},
+				"history": bson.M{"$push": bson.M{
+					"date":         "$date",
+					"usedLicenses": "$usedLicenses",
+					"ignored":      "$ignored",
+				}},
+			}),
+			mu.APProject(bson.M{
+				"_id":           0,
+				"hostname":      "$_id.hostname",
+				"dbName":        "$_id.dbName",
+				"licenseTypeID": "$_id.licenseTypeID",
+				"description":   1,
+				"metric":        1,
+				"firstUsed":     1,
+				"history": mu.APOFilter("$history", "value", mu.APOAnd(
+					mu.APOGreaterOrEqual("$$value.date", filter.From),
+					bson.M{"$lte": bson.A{"$$value.date", filter.To}},
+				)),
+			}),
+			mu.APMatch(bson.M{
+				"history.0": bson.M{"$exists": true},
+			}),
+			mu.APSort(bson.D{
+				{Key: "hostname", Value: 1},
+				{Key: "dbName", Value: 1},
+				{Key: "licenseTypeID", Value: 1},
+			}),
+		),
+	)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	items := make([]dto.UsedLicenseHistory, 0)
+	if err := cur.All(context.TODO(), &items); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return items, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "time"
+
+// UsedLicensesHistoryFilter contains the filters of the used licenses history
+type UsedLicensesHistoryFilter struct {
+	Hostname      string
+	DbName        string
+	LicenseTypeID string
+	From          time.Time
+	To            time.Time
+}
+
+// UsedLicenseHistory contains the daily history of the licenses used by a database
+type UsedLicenseHistory struct {
+	Hostname      string                     `json:"hostname" bson:"hostname"`
+	DbName        string                     `json:"dbName" bson:"dbName"`
+	LicenseTypeID string                     `json:"licenseTypeID" bson:"licenseTypeID"`
+	Description   string                     `json:"description" bson:"description"`
+	Metric        string                     `json:"metric" bson:"metric"`
+	FirstUsed     *time.Time                 `json:"firstUsed" bson:"firstUsed"`
+	History       []UsedLicenseHistoricValue `json:"history" bson:"history"`
+}
+
+type UsedLicenseHistoricValue struct {
+	Date         time.Time `json:"date" bson:"date"`
+	UsedLicenses float64   `json:"usedLicenses" bson:"usedLicenses"`
+	Ignored      bool      `json:"ignored" bson:"ignored"`
+}
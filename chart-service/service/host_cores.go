@@ -21,8 +21,8 @@ import (
 	"github.com/ercole-io/ercole/v2/chart-service/dto"
 )
 
-func (as *ChartService) GetHostCores(location, environment string, olderThan, newerThan time.Time) ([]dto.HostCores, error) {
-	out, err := as.Database.GetHostCores(location, environment, olderThan, newerThan)
+func (as *ChartService) GetHostCores(location, environment, site string, olderThan, newerThan time.Time) ([]dto.HostCores, error) {
+	out, err := as.Database.GetHostCores(location, environment, site, olderThan, newerThan)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +36,7 @@ func TestGetHostsHistory_Success(t *testing.T) {
 
 	location := ""
 	environment := ""
+	site := ""
 	olderThan := utils.MAX_TIME
 	newerThan := utils.MIN_TIME
 	expectedRes := []dto.HostCores{
@@ -45,10 +46,10 @@ func TestGetHostsHistory_Success(t *testing.T) {
 		},
 	}
 
-	db.EXPECT().GetHostCores(location, environment, olderThan, newerThan).
+	db.EXPECT().GetHostCores(location, environment, site, olderThan, newerThan).
 		Return(expectedRes, nil).Times(1)
 
-	res, err := as.GetHostCores(location, environment, olderThan, newerThan)
+	res, err := as.GetHostCores(location, environment, site, olderThan, newerThan)
 
 	require.NoError(t, err)
 	assert.Equal(t, expectedRes, res)
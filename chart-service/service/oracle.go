@@ -26,10 +26,10 @@ import (
 )
 
 // GetOracleDatabaseChart return a chart associated to teh
-func (as *ChartService) GetOracleDatabaseChart(metric string, location string, environment string, olderThan time.Time) (dto.Chart, error) {
+func (as *ChartService) GetOracleDatabaseChart(metric string, location string, environment string, site string, olderThan time.Time) (dto.Chart, error) {
 	switch metric {
 	case "version":
-		data, err := as.Database.GetOracleDatabaseChartByVersion(location, environment, olderThan)
+		data, err := as.Database.GetOracleDatabaseChartByVersion(location, environment, site, olderThan)
 		if err != nil {
 			return dto.Chart{}, err
 		}
@@ -47,7 +47,7 @@ func (as *ChartService) GetOracleDatabaseChart(metric string, location string, e
 			},
 		}, nil
 	case "work":
-		data, err := as.Database.GetOracleDatabaseChartByWork(location, environment, olderThan)
+		data, err := as.Database.GetOracleDatabaseChartByWork(location, environment, site, olderThan)
 		if err != nil {
 			return dto.Chart{}, err
 		}
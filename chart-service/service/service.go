@@ -34,18 +34,20 @@ type ChartServiceInterface interface {
 	Init()
 
 	// GetChangeChart return the chart data related to changes to databases
-	GetChangeChart(from time.Time, location string, environment string, olderThan time.Time) (dto.ChangeChart, error)
+	GetChangeChart(from time.Time, location string, environment string, site string, olderThan time.Time) (dto.ChangeChart, error)
 
 	// GetOracleDatabaseChart return a chart associated to teh
-	GetOracleDatabaseChart(metric string, location string, environment string, olderThan time.Time) (dto.Chart, error)
+	GetOracleDatabaseChart(metric string, location string, environment string, site string, olderThan time.Time) (dto.Chart, error)
 	GetLicenseComplianceHistory() ([]dto.LicenseComplianceHistory, error)
 
 	// GetTechnologiesMetrics return metrics of all technologies
 	GetTechnologiesMetrics() (map[string]model.TechnologySupportedMetrics, error)
 	// GetTechnologyTypes return the types of techonlogies
-	GetTechnologyTypesChart(location string, environment string, olderThan time.Time) (dto.TechnologyTypesChart, error)
+	GetTechnologyTypesChart(location string, environment string, site string, olderThan time.Time) (dto.TechnologyTypesChart, error)
 
-	GetHostCores(location string, environment string, olderThan time.Time, newerThan time.Time) ([]dto.HostCores, error)
+	GetHostCores(location string, environment string, site string, olderThan time.Time, newerThan time.Time) ([]dto.HostCores, error)
+	// GetUsedLicensesHistory return the daily history of the licenses used by hosts and databases
+	GetUsedLicensesHistory(filter dto.UsedLicensesHistoryFilter) ([]dto.UsedLicenseHistory, error)
 }
 
 type ChartService struct {
@@ -24,15 +24,15 @@ import (
 )
 
 // GetChangeChart return the chart data related to changes to databases
-func (as *ChartService) GetChangeChart(from time.Time, location string, environment string, olderThan time.Time) (dto.ChangeChart, error) {
+func (as *ChartService) GetChangeChart(from time.Time, location string, environment string, site string, olderThan time.Time) (dto.ChangeChart, error) {
 	// get the old counts
-	oldCounts, err := as.Database.GetTechnologyCount(location, environment, from)
+	oldCounts, err := as.Database.GetTechnologyCount(location, environment, site, from)
 	if err != nil {
 		return dto.ChangeChart{}, err
 	}
 
 	// get the new counts
-	newCounts, err := as.Database.GetTechnologyCount(location, environment, olderThan)
+	newCounts, err := as.Database.GetTechnologyCount(location, environment, site, olderThan)
 	if err != nil {
 		return dto.ChangeChart{}, err
 	}
@@ -62,9 +62,9 @@ func (as *ChartService) GetChangeChart(from time.Time, location string, environm
 }
 
 // GetTechnologyTypesChart return the types of techonlogies
-func (as *ChartService) GetTechnologyTypesChart(location string, environment string, olderThan time.Time) (dto.TechnologyTypesChart, error) {
+func (as *ChartService) GetTechnologyTypesChart(location string, environment string, site string, olderThan time.Time) (dto.TechnologyTypesChart, error) {
 	// get the counts
-	counts, err := as.Database.GetTechnologyCount(location, environment, olderThan)
+	counts, err := as.Database.GetTechnologyCount(location, environment, site, olderThan)
 	if err != nil {
 		return dto.TechnologyTypesChart{}, err
 	}
@@ -0,0 +1,200 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	alertservice_client "github.com/ercole-io/ercole/v2/alert-service/client"
+	apiservice_client "github.com/ercole-io/ercole/v2/api-service/client"
+	"github.com/ercole-io/ercole/v2/data-service/bundle"
+	dataservice_database "github.com/ercole-io/ercole/v2/data-service/database"
+	dataservice_service "github.com/ercole-io/ercole/v2/data-service/service"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var bundleSite string
+var bundleFrom string
+var bundleTo string
+var bundleOutput string
+var bundlePrivateKey string
+var bundlePublicKeys []string
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Manage the offline bundles of hostdata",
+	Long:  `Export and import the signed bundles of hostdata used by the sites which can't reach the central ercole`,
+}
+
+// bundleExportCmd represents the bundle export command
+var bundleExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a bundle of hostdata",
+	Long:  `Export in a signed tarball the hostdata, current and archived, and the exadata stored in the database of this site`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.NewLogger("BNDL", logger.LogVerbosely(verbose))
+
+		from, err := utils.Str2time(bundleFrom, utils.MIN_TIME)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		to, err := utils.Str2time(bundleTo, utils.MAX_TIME)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if bundlePrivateKey == "" {
+			bundlePrivateKey = ercoleConfig.Bundle.PrivateKey
+		}
+
+		key, err := bundle.LoadPrivateKey(bundlePrivateKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if bundleOutput == "" {
+			bundleOutput = fmt.Sprintf("ercole-bundle-%s-%s.tar.gz", bundleSite, time.Now().Format("20060102150405"))
+		}
+
+		out, err := os.Create(bundleOutput)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer out.Close()
+
+		db := &dataservice_database.MongoDatabase{
+			Config:  ercoleConfig,
+			TimeNow: time.Now,
+			Log:     log,
+		}
+		db.Init()
+
+		exporter := &bundle.Exporter{
+			Database:      db,
+			PrivateKey:    key,
+			ServerVersion: ercoleConfig.Version,
+			TimeNow:       time.Now,
+			Log:           log,
+		}
+
+		if _, err := exporter.Export(out, bundleSite, from, to); err != nil {
+			out.Close()
+			os.Remove(bundleOutput)
+			log.Fatal(err)
+		}
+
+		fmt.Println(bundleOutput)
+	},
+}
+
+// bundleImportCmd represents the bundle import command
+var bundleImportCmd = &cobra.Command{
+	Use:   "import [bundles...]",
+	Short: "Import bundles of hostdata",
+	Long: `Verify the signature of the bundles and ingest their hostdata with their original timestamps.
+The alert-service and the api-service must be reachable, like when the hostdata are received by the data-service`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.NewLogger("BNDL", logger.LogVerbosely(verbose))
+
+		if len(bundlePublicKeys) == 0 {
+			bundlePublicKeys = ercoleConfig.Bundle.PublicKeys
+		}
+
+		keys, err := bundle.LoadPublicKeys(bundlePublicKeys)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if len(keys) == 0 {
+			log.Fatal("No public key configured to verify the bundles")
+		}
+
+		db := &dataservice_database.MongoDatabase{
+			Config:  ercoleConfig,
+			TimeNow: time.Now,
+			Log:     log,
+		}
+		db.Init()
+
+		service := &dataservice_service.HostDataService{
+			Config:         ercoleConfig,
+			ServerVersion:  ercoleConfig.Version,
+			Database:       db,
+			AlertSvcClient: alertservice_client.NewClient(ercoleConfig.AlertService),
+			ApiSvcClient:   apiservice_client.NewClient(ercoleConfig.APIService),
+			TimeNow:        time.Now,
+			Log:            log,
+		}
+
+		if err := service.Init(); err != nil {
+			log.Fatal(err)
+		}
+
+		importer := &bundle.Importer{
+			Service:    service,
+			PublicKeys: keys,
+			Log:        log,
+		}
+
+		for _, filename := range args {
+			if err := importBundle(importer, filename); err != nil {
+				log.Fatalf("Failed to import the bundle %s: %s", filename, err)
+			}
+		}
+	},
+}
+
+func importBundle(importer *bundle.Importer, filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := importer.Import(file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("File: %s Site: %s Hostdata: %d imported, %d skipped Exadata: %d imported, %d skipped\n",
+		filename, result.Manifest.Site, result.ImportedHostData, result.SkippedHostData, result.ImportedExadata, result.SkippedExadata)
+
+	return nil
+}
+
+func init() {
+	bundleExportCmd.Flags().StringVarP(&bundleSite, "site", "s", "", "Name of the site exporting the bundle")
+	bundleExportCmd.Flags().StringVar(&bundleFrom, "from", "", "Export the hostdata created from this time (RFC3339)")
+	bundleExportCmd.Flags().StringVar(&bundleTo, "to", "", "Export the hostdata created before this time (RFC3339)")
+	bundleExportCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Filename of the bundle")
+	bundleExportCmd.Flags().StringVarP(&bundlePrivateKey, "key", "k", "", "RSA private key used to sign the bundle, default is Bundle.PrivateKey")
+	_ = bundleExportCmd.MarkFlagRequired("site")
+
+	bundleImportCmd.Flags().StringSliceVarP(&bundlePublicKeys, "public-key", "k", nil, "RSA public keys used to verify the bundles, default are Bundle.PublicKeys")
+
+	bundleCmd.AddCommand(bundleExportCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+	rootCmd.AddCommand(bundleCmd)
+}
@@ -32,6 +32,7 @@ import (
 
 	migration "github.com/ercole-io/ercole/v2/database-migration"
 
+	dataservice_client "github.com/ercole-io/ercole/v2/data-service/client"
 	dataservice_controller "github.com/ercole-io/ercole/v2/data-service/controller"
 	dataservice_database "github.com/ercole-io/ercole/v2/data-service/database"
 	dataservice_job "github.com/ercole-io/ercole/v2/data-service/job"
@@ -186,10 +187,21 @@ func serveDataService(config config.Configuration, wg *sync.WaitGroup) {
 		Log:            log,
 	}
 
+	if err := service.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if config.DataService.HostDataQueue.Enabled {
+		service.StartHostDataQueueWorkers(ctx, wg)
+	}
+
 	job := &dataservice_job.Job{
 		Config:        config,
 		ServerVersion: config.Version,
 		Database:      db,
+		Service:       service,
 		TimeNow:       time.Now,
 		Log:           log,
 	}
@@ -214,6 +226,7 @@ func serveDataService(config config.Configuration, wg *sync.WaitGroup) {
 			log.Error("Stopped data-service: ", err)
 		}
 
+		cancel()
 		wg.Done()
 	}()
 }
@@ -299,6 +312,7 @@ func serveAPIService(config config.Configuration, wg *sync.WaitGroup) {
 		TimeNow:        time.Now,
 		Log:            log,
 		AlertSvcClient: alertservice_client.NewClient(config.AlertService),
+		DataSvcClient:  dataservice_client.NewClient(config.DataService),
 	}
 	service.Init()
 
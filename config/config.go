@@ -46,6 +46,10 @@ type Configuration struct {
 	ChartService ChartService
 	// ThunderService contains configuration about the thunder service
 	ThunderService ThunderService
+	// Bundle contains configuration about the offline bundles of hostdata
+	Bundle Bundle
+	// Federation contains configuration about the remote sites pulled by this instance
+	Federation Federation
 	// Mongodb contains configuration about database connection, some data logic and migration
 	Mongodb Mongodb `bson:"-" json:"-"`
 	// Version contains the version of the server
@@ -70,17 +74,29 @@ type DataService struct {
 	AgentUsername string
 	// AgentPassword contains the password of the agent
 	AgentPassword string
+	// MaxBodySize contains the maximum size in megabytes of the (decompressed) request bodies, 0 means unlimited
+	MaxBodySize int64
 	// CurrentHostCleaningJob contains the parameters of the current host cleaning
 	CurrentHostCleaningJob CurrentHostCleaningJob
 	// ArchivedCleaningJob contains the parameters of the archived host cleaning
 	ArchivedHostCleaningJob ArchivedHostCleaningJob
 	// FreshnessCheckJob contains the parameters of the freshness check
 	FreshnessCheckJob FreshnessCheckJob
+	// UsedLicensesHistoryJob contains the parameters of the daily snapshot of the used licenses
+	UsedLicensesHistoryJob UsedLicensesHistoryJob
 	// LicenseTypeMetricsDefault default priority order of metric of licenseType when importing HostData
 	LicenseTypeMetricsDefault []string
 	// LicenseTypeMetricsByEnvironment custom priority order of metric of licenseType when importing HostData
 	// per environment
 	LicenseTypeMetricsByEnvironment map[string][]string
+	// HostDataQueue contains the parameters of the asynchronous ingestion of the hostdata
+	HostDataQueue HostDataQueue
+	// HostDataSnapshotJob contains the parameters of the periodic snapshots of the current hostdata
+	HostDataSnapshotJob HostDataSnapshotJob
+	// CmdbReconciliationJob contains the parameters of the periodic reconciliation of the current hosts with the CMDBs
+	CmdbReconciliationJob CmdbReconciliationJob
+	// LicenseIgnoreRulesJob contains the parameters of the periodic application of the license ignore rules to the current hosts
+	LicenseIgnoreRulesJob LicenseIgnoreRulesJob
 }
 
 // AlertService contains configuration about the alert service
@@ -128,12 +144,20 @@ type APIService struct {
 	ReadOnly bool
 	// DebugOracleDatabaseContractsAssignmentAlgorithm enable the debugging of the Oracle/Database contracts assignment algorithm
 	DebugOracleDatabaseContractsAssignmentAlgorithm bool
+	// OracleDatabaseContractsAssignmentMode contains the algorithm used to assign the Oracle/Database contracts to the hosts (Greedy or Optimized)
+	OracleDatabaseContractsAssignmentMode string
 	// AuthenticationProvider contains info about how the users are authenticated
 	AuthenticationProvider AuthenticationProviderConfig
 	// OperatingSystemAggregationRules contains rules used to aggregate various operating systems
 	OperatingSystemAggregationRules []AggregationRule
 	// DefaultDatabaseTags contains the default list of database tags
 	DefaultDatabaseTags []string
+	// DefaultClusterLicensingPolicy contains the licensing policy applied to the clusters not matched by any ClusterLicensingPolicies
+	DefaultClusterLicensingPolicy string
+	// ClusterLicensingPolicies contains the licensing policies applied to specific clusters or locations
+	ClusterLicensingPolicies []ClusterLicensingPolicy
+	// MongoDBAssumeEnterpriseEdition count as Enterprise the MongoDB instances which don't report their edition
+	MongoDBAssumeEnterpriseEdition bool
 }
 
 // RepoService contains configuration about the repo service
@@ -193,6 +217,71 @@ type FreshnessCheckJob struct {
 	RunAtStartup bool
 }
 
+// UsedLicensesHistoryJob contains parameters for the daily snapshot of the used licenses
+type UsedLicensesHistoryJob struct {
+	// Crontab contains the crontab string used to schedule the snapshot
+	Crontab string
+	// RunAtStartup contains true if the job should run when the service start, otherwise false
+	RunAtStartup bool
+}
+
+// HostDataSnapshotJob contains parameters for the periodic snapshots of the current hostdata
+type HostDataSnapshotJob struct {
+	// Crontab contains the crontab string used to schedule the snapshot
+	Crontab string
+	// RunAtStartup contains true if the job should run when the service start, otherwise false
+	RunAtStartup bool
+	// MonthlyRetention contains the number of months the snapshots are kept
+	MonthlyRetention int
+	// QuarterlyRetention contains the number of quarters the snapshots taken at the start of a quarter are kept
+	QuarterlyRetention int
+}
+
+// CmdbReconciliationJob contains parameters for the periodic reconciliation of the current hosts with the CMDBs
+type CmdbReconciliationJob struct {
+	// Crontab contains the crontab string used to schedule the reconciliation
+	Crontab string
+	// RunAtStartup contains true if the job should run when the service start, otherwise false
+	RunAtStartup bool
+	// Connectors contains the CMDBs from which the hosts are pulled
+	Connectors []CmdbConnector
+}
+
+// LicenseIgnoreRulesJob contains parameters for the periodic application of the license ignore rules to the current hosts
+type LicenseIgnoreRulesJob struct {
+	// Crontab contains the crontab string used to schedule the application of the rules
+	Crontab string
+	// RunAtStartup contains true if the job should run when the service start, otherwise false
+	RunAtStartup bool
+}
+
+// CmdbConnector contains the parameters used to pull the hosts from a CMDB
+type CmdbConnector struct {
+	// Name is the name of the CMDB, used in the alerts
+	Name string
+	// Type is the type of the connector: servicenow, csv or ldap
+	Type string
+	// URL contains the URL of the ServiceNow instance, the URL or the path of the CSV file or the URL of the LDAP server
+	URL string
+	// Username contains the username used to authenticate to the CMDB
+	Username string
+	// Password contains the password used to authenticate to the CMDB
+	Password string
+	// Table contains the ServiceNow table of the hosts, cmdb_ci_server if empty
+	Table string
+	// Query contains the encoded query used to filter the ServiceNow records
+	Query string
+	// Delimiter contains the delimiter of the CSV fields, comma if empty
+	Delimiter string
+	// BaseDN contains the base DN of the LDAP search
+	BaseDN string
+	// Filter contains the LDAP filter of the computer objects, (objectClass=computer) if empty
+	Filter string
+	// Fields maps the attributes of the hosts (hostname, environment, location, owner) to the fields of the CMDB,
+	// it overrides the defaults of the connector type and an empty field disables the attribute
+	Fields map[string]string
+}
+
 // CurrentHostCleaningJob contains parameters for the current host cleaning
 type CurrentHostCleaningJob struct {
 	// Crontab contains the crontab string used to schedule the cleaning
@@ -213,6 +302,52 @@ type ArchivedHostCleaningJob struct {
 	RunAtStartup bool
 }
 
+// Bundle contains the keys used to sign and verify the offline bundles of hostdata
+type Bundle struct {
+	// PrivateKey is the filename of the RSA key used to sign the exported bundles
+	PrivateKey string
+	// PublicKeys contains the filenames of the RSA public keys of the sites whose bundles can be imported
+	PublicKeys []string
+}
+
+// Federation contains the remote sites whose data are periodically pulled by this instance
+type Federation struct {
+	// LocalSite is the name of the site of the data collected directly by this instance
+	LocalSite string
+	// Crontab contains the crontab string used to schedule the pull of the sites
+	Crontab string
+	// RunAtStartup contains true if the job should run when the service start, otherwise false
+	RunAtStartup bool
+	// Sites contains the remote sites
+	Sites []FederatedSite
+}
+
+// FederatedSite contains the parameters used to connect to the api-service of a remote site
+type FederatedSite struct {
+	// Name is the name of the site, used as value of the site attribute
+	Name string
+	// RemoteEndpoint contains the endpoint of the api-service of the site
+	RemoteEndpoint string
+	// Username contains the username used to authenticate to the api-service of the site
+	Username string
+	// Password contains the password used to authenticate to the api-service of the site
+	Password string
+}
+
+// HostDataQueue contains parameters for the asynchronous ingestion of the hostdata
+type HostDataQueue struct {
+	// Enabled contains true if the hostdata are accepted with 202 and processed in background, otherwise false
+	Enabled bool
+	// Workers contains the number of uploads processed concurrently
+	Workers int
+	// PollInterval contains the seconds a worker waits when the queue is empty
+	PollInterval int
+	// MaxAttempts contains the number of times an upload is processed before it's marked as failed
+	MaxAttempts int
+	// StaleThreshold contains the minutes after which an upload still in processing is queued again
+	StaleThreshold int
+}
+
 // OciDataRetrieveJob contains parameters for the archived host cleaning
 type OciDataRetrieveJob struct {
 	// Crontab contains the crontab string used to schedule the cleaning
@@ -256,6 +391,16 @@ type HTTPRepoService struct {
 	LogHTTPRequest bool
 }
 
+// ClusterLicensingPolicy contains a licensing policy applied to the matching clusters
+type ClusterLicensingPolicy struct {
+	// Policy contains the name of the policy: SoftPartitioning, HardPartitioning or VCenter
+	Policy string
+	// Clusters contains the names of the clusters to which the policy is applied
+	Clusters []string
+	// Locations contains the locations of the clusters to which the policy is applied
+	Locations []string
+}
+
 // AggregationRule contains a rule used to aggregate string per group
 type AggregationRule struct {
 	// Regex contains the regular expression used for matching the aggregation group
@@ -311,6 +456,10 @@ type AlertType struct {
 	NewHostCpu                 bool
 	MissingPrimaryDatabase     bool
 	MissingDatabase            bool
+	SE2SocketLimit             bool
+	InconsistentLicenses       bool
+	MultitenantPDBLimit        bool
+	LicenseIgnoreRuleExpired   bool
 	AgentError                 bool
 	NoData                     bool
 }
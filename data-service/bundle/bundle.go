@@ -0,0 +1,337 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package bundle contains the export and the import of the offline bundles of hostdata,
+// used to move the data of the sites which can't reach the central ercole
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ManifestVersion is the version of the format of the bundles
+const ManifestVersion = 1
+
+const (
+	manifestFile  = "manifest.json"
+	signatureFile = "manifest.sig"
+	hostdataFile  = "hostdata.ndjson"
+	exadataFile   = "exadata.ndjson"
+)
+
+// Manifest describes the content of a bundle, it's signed by the site which exported it
+type Manifest struct {
+	Version       int       `json:"version"`
+	Site          string    `json:"site"`
+	ServerVersion string    `json:"serverVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Files         []File    `json:"files"`
+}
+
+// File is a NDJSON file of documents contained in a bundle
+type File struct {
+	Name      string `json:"name"`
+	Documents int    `json:"documents"`
+	SHA256    string `json:"sha256"`
+}
+
+func (m Manifest) hasFile(name string) bool {
+	for _, f := range m.Files {
+		if f.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadPrivateKey read the RSA private key used to sign the bundles from a PEM file
+func LoadPrivateKey(filename string) (*rsa.PrivateKey, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, utils.NewError(err, "Can't read the bundle private key")
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(raw)
+	if err != nil {
+		return nil, utils.NewError(err, "Can't parse the bundle private key")
+	}
+
+	return key, nil
+}
+
+// LoadPublicKeys read the RSA public keys used to verify the bundles from PEM files
+func LoadPublicKeys(filenames []string) ([]*rsa.PublicKey, error) {
+	keys := make([]*rsa.PublicKey, 0, len(filenames))
+
+	for _, filename := range filenames {
+		raw, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, utils.NewError(err, "Can't read the bundle public key")
+		}
+
+		key, err := jwt.ParseRSAPublicKeyFromPEM(raw)
+		if err != nil {
+			return nil, utils.NewError(err, "Can't parse the bundle public key")
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func signManifest(raw []byte, key *rsa.PrivateKey) ([]byte, error) {
+	digest := sha256.Sum256(raw)
+
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+}
+
+func verifyManifest(raw, signature []byte, keys []*rsa.PublicKey) error {
+	digest := sha256.Sum256(raw)
+
+	for _, key := range keys {
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil {
+			return nil
+		}
+	}
+
+	return utils.ErrInvalidBundleSignature
+}
+
+// ndjsonFile is a NDJSON file of a bundle being written
+type ndjsonFile struct {
+	name      string
+	file      *os.File
+	hash      hash.Hash
+	encoder   *json.Encoder
+	documents int
+}
+
+func createNDJSONFile(dir, name string) (*ndjsonFile, error) {
+	file, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	f := &ndjsonFile{
+		name: name,
+		file: file,
+		hash: sha256.New(),
+	}
+	f.encoder = json.NewEncoder(io.MultiWriter(file, f.hash))
+
+	return f, nil
+}
+
+func (f *ndjsonFile) write(document interface{}) error {
+	if err := f.encoder.Encode(document); err != nil {
+		return err
+	}
+
+	f.documents++
+
+	return nil
+}
+
+func (f *ndjsonFile) close() (File, error) {
+	if err := f.file.Close(); err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Name:      f.name,
+		Documents: f.documents,
+		SHA256:    hex.EncodeToString(f.hash.Sum(nil)),
+	}, nil
+}
+
+// writeBundle write a gzipped tarball with the signed manifest and its files, which are read from dir
+func writeBundle(w io.Writer, manifest Manifest, key *rsa.PrivateKey, dir string) error {
+	rawManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	signature, err := signManifest(rawManifest, key)
+	if err != nil {
+		return utils.NewError(err, "Can't sign the bundle")
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	writeEntry := func(name string, size int64, content io.Reader) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    size,
+			ModTime: manifest.CreatedAt,
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		_, err := io.Copy(tw, content)
+
+		return err
+	}
+
+	if err := writeEntry(manifestFile, int64(len(rawManifest)), bytes.NewReader(rawManifest)); err != nil {
+		return err
+	}
+
+	if err := writeEntry(signatureFile, int64(len(signature)), bytes.NewReader(signature)); err != nil {
+		return err
+	}
+
+	for _, f := range manifest.Files {
+		if err := writeFileEntry(writeEntry, filepath.Join(dir, f.Name)); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func writeFileEntry(writeEntry func(name string, size int64, content io.Reader) error, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return writeEntry(filepath.Base(path), info.Size(), file)
+}
+
+// readBundle extract the bundle in dir, verify the signature of its manifest and the checksums of its files
+// and return the manifest
+func readBundle(r io.Reader, keys []*rsa.PublicKey, dir string) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidBundle, err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+
+	var rawManifest, signature []byte
+
+	checksums := make(map[string]string)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %s", utils.ErrInvalidBundle, err)
+		}
+
+		switch header.Name {
+		case manifestFile:
+			if rawManifest, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("%w: %s", utils.ErrInvalidBundle, err)
+			}
+		case signatureFile:
+			if signature, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("%w: %s", utils.ErrInvalidBundle, err)
+			}
+		case hostdataFile, exadataFile:
+			if checksums[header.Name], err = extractFile(tr, filepath.Join(dir, header.Name)); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: unexpected file %q", utils.ErrInvalidBundle, header.Name)
+		}
+	}
+
+	if rawManifest == nil || signature == nil {
+		return nil, fmt.Errorf("%w: the manifest or its signature is missing", utils.ErrInvalidBundle)
+	}
+
+	if err := verifyManifest(rawManifest, signature, keys); err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidBundle, err)
+	}
+
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", utils.ErrInvalidBundle, manifest.Version)
+	}
+
+	for _, f := range manifest.Files {
+		if checksum, ok := checksums[f.Name]; !ok || checksum != f.SHA256 {
+			return nil, fmt.Errorf("%w: the file %q is missing or it has been modified", utils.ErrInvalidBundle, f.Name)
+		}
+	}
+
+	for name := range checksums {
+		if !manifest.hasFile(name) {
+			return nil, fmt.Errorf("%w: the file %q isn't listed in the manifest", utils.ErrInvalidBundle, name)
+		}
+	}
+
+	return &manifest, nil
+}
+
+func extractFile(r io.Reader, path string) (string, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(file, hash), r); err != nil {
+		return "", fmt.Errorf("%w: %s", utils.ErrInvalidBundle, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
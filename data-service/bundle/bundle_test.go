@@ -0,0 +1,259 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/service"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return key
+}
+
+func exportTestBundle(t *testing.T, key *rsa.PrivateKey, hostdata []model.HostDataBE, exadata []model.OracleExadataInstance) []byte {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+
+	exporter := Exporter{
+		Database:      db,
+		PrivateKey:    key,
+		ServerVersion: "latest",
+		TimeNow:       utils.Btc(utils.P("2020-12-10T10:00:00Z")),
+		Log:           logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().IterateHostDataCreatedBetween(utils.P("2020-12-01T00:00:00Z"), utils.MAX_TIME, gomock.Any()).
+		DoAndReturn(func(_, _ time.Time, fn func(model.HostDataBE) error) error {
+			for _, hd := range hostdata {
+				if err := fn(hd); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	db.EXPECT().FindAllExadataInstances().Return(exadata, nil)
+
+	var buf bytes.Buffer
+
+	manifest, err := exporter.Export(&buf, "isolated", utils.P("2020-12-01T00:00:00Z"), utils.MAX_TIME)
+	require.NoError(t, err)
+
+	assert.Equal(t, ManifestVersion, manifest.Version)
+	assert.Equal(t, "isolated", manifest.Site)
+	require.Len(t, manifest.Files, 2)
+	assert.Equal(t, len(hostdata), manifest.Files[0].Documents)
+	assert.Equal(t, len(exadata), manifest.Files[1].Documents)
+
+	return buf.Bytes()
+}
+
+func TestExportImport(t *testing.T) {
+	key := generateKey(t)
+
+	hostdata := []model.HostDataBE{
+		{Hostname: "foobar", Location: "Italy", Environment: "PRD", CreatedAt: utils.P("2020-12-05T10:00:00Z"), Archived: true},
+		{Hostname: "foobar", Location: "Italy", Environment: "PRD", CreatedAt: utils.P("2020-12-06T10:00:00Z")},
+		{Hostname: "barfoo", Location: "Italy", Environment: "TST", CreatedAt: utils.P("2020-12-06T11:00:00Z")},
+	}
+	exadata := []model.OracleExadataInstance{
+		{RackID: "rack1", Hostname: "exa1", UpdatedAt: utils.P("2020-12-04T10:00:00Z")},
+		{RackID: "rack2", Hostname: "exa2", UpdatedAt: utils.P("2020-12-04T10:00:00Z")},
+	}
+
+	raw := exportTestBundle(t, key, hostdata, exadata)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+
+	importer := Importer{
+		Service: &service.HostDataService{
+			Config:   config.Configuration{},
+			Database: db,
+			TimeNow:  utils.Btc(utils.P("2021-01-01T00:00:00Z")),
+			Log:      logger.NewLogger("TEST"),
+		},
+		PublicKeys: []*rsa.PublicKey{&generateKey(t).PublicKey, &key.PublicKey},
+		Log:        logger.NewLogger("TEST"),
+	}
+
+	inserted := make([]model.HostDataBE, 0)
+
+	gomock.InOrder(
+		db.EXPECT().FindMostRecentHostDataOlderThan("foobar", utils.MAX_TIME).Return(nil, nil),
+		db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(&model.HostIdentity{ID: "1", Hostname: "foobar", Aliases: []string{"foobar"}}, nil),
+		db.EXPECT().FindMostRecentHostDataOlderThan("foobar", hostdata[0].CreatedAt).Return(nil, nil),
+		db.EXPECT().DismissHost("foobar").Return(nil),
+		db.EXPECT().InsertHostData(gomock.Any()).Do(func(hd model.HostDataBE) { inserted = append(inserted, hd) }).Return(nil),
+		db.EXPECT().DeleteNoDataAlertByHost("foobar").Return(nil),
+
+		db.EXPECT().FindMostRecentHostDataOlderThan("foobar", utils.MAX_TIME).Return(&hostdata[0], nil),
+		db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(&model.HostIdentity{ID: "1", Hostname: "foobar", Aliases: []string{"foobar"}}, nil),
+		db.EXPECT().FindMostRecentHostDataOlderThan("foobar", hostdata[1].CreatedAt).Return(&hostdata[0], nil),
+		db.EXPECT().DismissHost("foobar").Return(nil),
+		db.EXPECT().InsertHostData(gomock.Any()).Do(func(hd model.HostDataBE) { inserted = append(inserted, hd) }).Return(nil),
+		db.EXPECT().DeleteNoDataAlertByHost("foobar").Return(nil),
+
+		db.EXPECT().FindMostRecentHostDataOlderThan("barfoo", utils.MAX_TIME).Return(&hostdata[2], nil),
+
+		db.EXPECT().FindExadataByRackID("rack1").Return(nil, mongo.ErrNoDocuments),
+		db.EXPECT().FindExadataByRackID("rack1").Return(nil, mongo.ErrNoDocuments),
+		db.EXPECT().AddExadata(gomock.Any()).Do(func(exa model.OracleExadataInstance) {
+			assert.Equal(t, "exa1", exa.Hostname)
+			assert.Equal(t, utils.P("2020-12-04T10:00:00Z"), exa.CreatedAt)
+			assert.Equal(t, utils.P("2020-12-04T10:00:00Z"), exa.UpdatedAt)
+		}).Return(nil),
+
+		db.EXPECT().FindExadataByRackID("rack2").
+			Return(&model.OracleExadataInstance{RackID: "rack2", UpdatedAt: utils.P("2020-12-04T10:00:00Z")}, nil),
+	)
+
+	result, err := importer.Import(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	assert.Equal(t, "isolated", result.Manifest.Site)
+	assert.Equal(t, 2, result.ImportedHostData)
+	assert.Equal(t, 1, result.SkippedHostData)
+	assert.Equal(t, 1, result.ImportedExadata)
+	assert.Equal(t, 1, result.SkippedExadata)
+
+	require.Len(t, inserted, 2)
+	assert.Equal(t, utils.P("2020-12-05T10:00:00Z"), inserted[0].CreatedAt)
+	assert.False(t, inserted[0].Archived)
+	assert.Equal(t, utils.P("2020-12-06T10:00:00Z"), inserted[1].CreatedAt)
+}
+
+func TestImport_InvalidSignature(t *testing.T) {
+	raw := exportTestBundle(t, generateKey(t), []model.HostDataBE{}, []model.OracleExadataInstance{})
+
+	importer := Importer{
+		Service:    &service.HostDataService{},
+		PublicKeys: []*rsa.PublicKey{&generateKey(t).PublicKey},
+		Log:        logger.NewLogger("TEST"),
+	}
+
+	_, err := importer.Import(bytes.NewReader(raw))
+	assert.ErrorIs(t, err, utils.ErrInvalidBundleSignature)
+}
+
+func TestReadBundle_ModifiedFile(t *testing.T) {
+	key := generateKey(t)
+	dir := t.TempDir()
+
+	f, err := createNDJSONFile(dir, hostdataFile)
+	require.NoError(t, err)
+	require.NoError(t, f.write(model.HostDataBE{Hostname: "foobar"}))
+	file, err := f.close()
+	require.NoError(t, err)
+
+	manifest := Manifest{
+		Version: ManifestVersion,
+		Site:    "isolated",
+		Files:   []File{file},
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hostdataFile), []byte("{\"hostname\":\"barfoo\"}\n"), 0600))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBundle(&buf, manifest, key, dir))
+
+	_, err = readBundle(&buf, []*rsa.PublicKey{&key.PublicKey}, t.TempDir())
+	assert.ErrorIs(t, err, utils.ErrInvalidBundle)
+}
+
+func TestReadBundle_UnlistedFile(t *testing.T) {
+	key := generateKey(t)
+	dir := t.TempDir()
+
+	f, err := createNDJSONFile(dir, hostdataFile)
+	require.NoError(t, err)
+	require.NoError(t, f.write(model.HostDataBE{Hostname: "foobar"}))
+	file, err := f.close()
+	require.NoError(t, err)
+
+	manifest := Manifest{
+		Version: ManifestVersion,
+		Site:    "isolated",
+		Files:   []File{file},
+	}
+
+	var signed bytes.Buffer
+	require.NoError(t, writeBundle(&signed, manifest, key, dir))
+
+	// an exadata file is added to the signed bundle, without changing its manifest
+	gr, err := gzip.NewReader(&signed)
+	require.NoError(t, err)
+
+	tr := tar.NewReader(gr)
+
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		require.NoError(t, tw.WriteHeader(header))
+		_, err = io.Copy(tw, tr)
+		require.NoError(t, err)
+	}
+
+	exadata := []byte("{\"rackID\":\"rack1\"}\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: exadataFile, Mode: 0600, Size: int64(len(exadata))}))
+	_, err = tw.Write(exadata)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	_, err = readBundle(&buf, []*rsa.PublicKey{&key.PublicKey}, t.TempDir())
+	assert.ErrorIs(t, err, utils.ErrInvalidBundle)
+}
+
+func TestReadBundle_NotABundle(t *testing.T) {
+	_, err := readBundle(bytes.NewReader([]byte("foobar")), nil, t.TempDir())
+	assert.ErrorIs(t, err, utils.ErrInvalidBundle)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"crypto/rsa"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// Exporter export the hostdata and the exadata of a site in a bundle
+type Exporter struct {
+	Database      database.MongoDatabaseInterface
+	PrivateKey    *rsa.PrivateKey
+	ServerVersion string
+	TimeNow       func() time.Time
+	Log           logger.Logger
+}
+
+// Export write in w the bundle of the hostdata, current and archived, created between from and to,
+// and of all the exadata
+func (e *Exporter) Export(w io.Writer, site string, from, to time.Time) (*Manifest, error) {
+	dir, err := os.MkdirTemp("", "ercole-bundle-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	hostdata, err := createNDJSONFile(dir, hostdataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.Database.IterateHostDataCreatedBetween(from, to, func(hd model.HostDataBE) error {
+		return hostdata.write(hd)
+	}); err != nil {
+		hostdata.file.Close()
+		return nil, err
+	}
+
+	hostdataManifest, err := hostdata.close()
+	if err != nil {
+		return nil, err
+	}
+
+	exadata, err := createNDJSONFile(dir, exadataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	exadatas, err := e.Database.FindAllExadataInstances()
+	if err != nil {
+		exadata.file.Close()
+		return nil, err
+	}
+
+	for i := range exadatas {
+		if err := exadata.write(exadatas[i]); err != nil {
+			exadata.file.Close()
+			return nil, err
+		}
+	}
+
+	exadataManifest, err := exadata.close()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := Manifest{
+		Version:       ManifestVersion,
+		Site:          site,
+		ServerVersion: e.ServerVersion,
+		CreatedAt:     e.TimeNow(),
+		From:          from,
+		To:            to,
+		Files:         []File{hostdataManifest, exadataManifest},
+	}
+
+	if err := writeBundle(w, manifest, e.PrivateKey, dir); err != nil {
+		return nil, err
+	}
+
+	e.Log.Infof("Exported %d hostdata and %d exadata", hostdataManifest.Documents, exadataManifest.Documents)
+
+	return &manifest, nil
+}
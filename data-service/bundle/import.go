@@ -0,0 +1,163 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/data-service/service"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// Importer ingest the bundles exported by the sites
+type Importer struct {
+	Service    *service.HostDataService
+	PublicKeys []*rsa.PublicKey
+	Log        logger.Logger
+}
+
+// ImportResult contains the outcome of the import of a bundle
+type ImportResult struct {
+	Manifest         Manifest
+	ImportedHostData int
+	SkippedHostData  int
+	ImportedExadata  int
+	SkippedExadata   int
+}
+
+// Import verify the bundle and ingest its hostdata, in the order they were created and with their original
+// timestamps, so their history is preserved. The hostdata and the exadata which aren't more recent than the ones
+// already stored, e.g. because the bundle has been imported already, are skipped.
+// Only the files listed in the signed manifest are read
+func (i *Importer) Import(r io.Reader) (*ImportResult, error) {
+	dir, err := os.MkdirTemp("", "ercole-bundle-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, err := readBundle(r, i.PublicKeys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Manifest: *manifest}
+
+	// the documents are ingested as if they were received at the time they were created on the site
+	var now time.Time
+
+	hds := *i.Service
+	hds.TimeNow = func() time.Time { return now }
+
+	if err := decodeFile(*manifest, dir, hostdataFile, func(decoder *json.Decoder) error {
+		var hostdata model.HostDataBE
+		if err := decoder.Decode(&hostdata); err != nil {
+			return err
+		}
+
+		latest, err := hds.Database.FindMostRecentHostDataOlderThan(hostdata.Hostname, utils.MAX_TIME)
+		if err != nil {
+			return err
+		}
+
+		if latest != nil && !latest.CreatedAt.Before(hostdata.CreatedAt) {
+			result.SkippedHostData++
+			return nil
+		}
+
+		now = hostdata.CreatedAt
+		hostdata.DismissedAt = time.Time{}
+
+		if err := hds.InsertHostData(hostdata); err != nil {
+			return fmt.Errorf("can't import the hostdata of %s created at %s: %w", hostdata.Hostname, hostdata.CreatedAt, err)
+		}
+
+		result.ImportedHostData++
+
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	if err := decodeFile(*manifest, dir, exadataFile, func(decoder *json.Decoder) error {
+		var exadata model.OracleExadataInstance
+		if err := decoder.Decode(&exadata); err != nil {
+			return err
+		}
+
+		existing, err := hds.Database.FindExadataByRackID(exadata.RackID)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+
+		if existing != nil && !existing.UpdatedAt.Before(exadata.UpdatedAt) {
+			result.SkippedExadata++
+			return nil
+		}
+
+		now = exadata.UpdatedAt
+
+		if err := hds.SaveExadata(&exadata); err != nil {
+			return fmt.Errorf("can't import the exadata %s: %w", exadata.RackID, err)
+		}
+
+		result.ImportedExadata++
+
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	i.Log.Infof("Imported %d hostdata (%d skipped) and %d exadata (%d skipped) from the site %q",
+		result.ImportedHostData, result.SkippedHostData, result.ImportedExadata, result.SkippedExadata, manifest.Site)
+
+	return result, nil
+}
+
+// decodeFile call decode until all the documents of the NDJSON file have been read.
+// The file is ignored if it isn't listed in the manifest, since its content hasn't been verified
+func decodeFile(manifest Manifest, dir, name string, decode func(decoder *json.Decoder) error) error {
+	if !manifest.hasFile(name) {
+		return nil
+	}
+
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+
+	for decoder.More() {
+		if err := decode(decoder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+type DataSvcClientInterface interface {
+	// ReplayQuarantinedHostData validate again a quarantined hostdata and insert it if it's valid
+	ReplayQuarantinedHostData(id primitive.ObjectID) error
+}
+
+type Client struct {
+	remoteEndpoint string
+	client         *http.Client
+	config         config.DataService
+}
+
+func NewClient(config config.DataService) *Client {
+	return &Client{
+		remoteEndpoint: strings.TrimSuffix(config.RemoteEndpoint, "/"),
+		client:         &http.Client{Timeout: 1 * time.Minute},
+		config:         config,
+	}
+}
+
+func (c *Client) doRequest(ctx context.Context, path, method string, body []byte) (*http.Response, error) {
+	url := utils.NewAPIUrlNoParams(
+		c.remoteEndpoint,
+		c.config.AgentUsername,
+		c.config.AgentPassword,
+		path)
+
+	req, err := http.NewRequest(method, url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) ReplayQuarantinedHostData(id primitive.ObjectID) error {
+	resp, err := c.doRequest(context.TODO(), fmt.Sprintf("/hosts/quarantine/%s/replay", id.Hex()), "POST", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return utils.ErrQuarantinedHostDataNotFound
+	case http.StatusConflict:
+		return utils.ErrQuarantinedHostDataOutdated
+	case http.StatusUnprocessableEntity:
+		return fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, string(body))
+	}
+
+	return fmt.Errorf("Api error (code: %d): %s", resp.StatusCode, string(body))
+}
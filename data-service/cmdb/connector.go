@@ -0,0 +1,122 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package cmdb contains the connectors used to pull the hosts registered in the CMDBs
+package cmdb
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// Types of the connectors
+const (
+	TypeServiceNow = "servicenow"
+	TypeCSV        = "csv"
+	TypeLDAP       = "ldap"
+)
+
+// Attributes of the hosts which can be mapped to the fields of a CMDB
+const (
+	AttributeHostname    = "hostname"
+	AttributeEnvironment = "environment"
+	AttributeLocation    = "location"
+	AttributeOwner       = "owner"
+)
+
+const requestTimeout = 60 * time.Second
+
+// Connector pull the hosts registered in a CMDB
+type Connector interface {
+	// Hosts return the hosts registered in the CMDB
+	Hosts() ([]dto.CmdbHost, error)
+}
+
+// NewConnector return the connector of the type in the configuration
+func NewConnector(conf config.CmdbConnector) (Connector, error) {
+	switch strings.ToLower(conf.Type) {
+	case TypeServiceNow:
+		return &ServiceNowConnector{
+			Config: conf,
+			Fields: mergeFields(serviceNowDefaultFields, conf.Fields),
+			Client: &http.Client{Timeout: requestTimeout},
+		}, nil
+	case TypeCSV:
+		return &CSVConnector{
+			Config: conf,
+			Fields: mergeFields(csvDefaultFields, conf.Fields),
+			Client: &http.Client{Timeout: requestTimeout},
+		}, nil
+	case TypeLDAP:
+		return &LDAPConnector{
+			Config:  conf,
+			Fields:  mergeFields(ldapDefaultFields, conf.Fields),
+			Timeout: requestTimeout,
+		}, nil
+	default:
+		return nil, utils.NewError(fmt.Errorf("%w: %q", utils.ErrUnknownCmdbConnectorType, conf.Type), "CMDB")
+	}
+}
+
+// mergeFields return the default mapping of the attributes overridden by the configured one
+func mergeFields(defaults, fields map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults))
+
+	for attribute, field := range defaults {
+		merged[attribute] = field
+	}
+
+	for attribute, field := range fields {
+		merged[strings.ToLower(attribute)] = field
+	}
+
+	return merged
+}
+
+// newHost return the host with the attributes read from the fields of a record,
+// it return false if the record hasn't the hostname
+func newHost(fields map[string]string, value func(field string) string) (dto.CmdbHost, bool) {
+	get := func(attribute string) string {
+		if fields[attribute] == "" {
+			return ""
+		}
+
+		return strings.TrimSpace(value(fields[attribute]))
+	}
+
+	host := dto.CmdbHost{
+		Hostname:    get(AttributeHostname),
+		Environment: get(AttributeEnvironment),
+		Location:    get(AttributeLocation),
+		Owner:       get(AttributeOwner),
+	}
+
+	return host, host.Hostname != ""
+}
+
+// checkResponse return an error if the status of the response isn't 200
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return utils.NewError(fmt.Errorf("%s responded with status %d", resp.Request.URL.Redacted(), resp.StatusCode), "CMDB")
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmdb
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestNewConnector(t *testing.T) {
+	connector, err := NewConnector(config.CmdbConnector{Type: "ServiceNow", Fields: map[string]string{"Owner": ""}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"hostname":    "name",
+		"environment": "environment",
+		"location":    "location",
+		"owner":       "",
+	}, connector.(*ServiceNowConnector).Fields)
+
+	_, err = NewConnector(config.CmdbConnector{Type: "excel"})
+	assert.True(t, errors.Is(err, utils.ErrUnknownCmdbConnectorType))
+}
+
+func TestServiceNowConnector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "ercole" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		assert.Equal(t, "/api/now/table/cmdb_ci_linux_server", r.URL.Path)
+		assert.Equal(t, "operational_status=1^ORDERBYsys_id", r.URL.Query().Get("sysparm_query"))
+		assert.Equal(t, "0", r.URL.Query().Get("sysparm_offset"))
+		assert.ElementsMatch(t, []string{"name", "environment", "location"}, strings.Split(r.URL.Query().Get("sysparm_fields"), ","))
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": []map[string]interface{}{
+				{"name": "pippo", "environment": "PRD", "location": "Italy"},
+				{"name": "pluto.topolinia.top", "environment": "TST", "location": map[string]interface{}{"display_value": "Germany"}},
+				{"name": "", "environment": "PRD"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	conf := config.CmdbConnector{
+		Name:     "servicenow",
+		Type:     TypeServiceNow,
+		URL:      server.URL + "/",
+		Username: "ercole",
+		Password: "secret",
+		Table:    "cmdb_ci_linux_server",
+		Query:    "operational_status=1",
+		Fields:   map[string]string{"owner": ""},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		connector, err := NewConnector(conf)
+		require.NoError(t, err)
+
+		hosts, err := connector.Hosts()
+		require.NoError(t, err)
+		assert.Equal(t, []dto.CmdbHost{
+			{Hostname: "pippo", Environment: "PRD", Location: "Italy"},
+			{Hostname: "pluto.topolinia.top", Environment: "TST", Location: "Germany"},
+		}, hosts)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		conf := conf
+		conf.Password = "wrong"
+
+		connector, err := NewConnector(conf)
+		require.NoError(t, err)
+
+		_, err = connector.Hosts()
+		assert.Error(t, err)
+	})
+}
+
+func TestCSVConnector(t *testing.T) {
+	const content = "Hostname;Environment;Location;Owner\n" +
+		"pippo;PRD;Italy;Topolino\n" +
+		"pluto.topolinia.top; TST;Germany\n" +
+		";PRD;Italy;Topolino\n"
+
+	expected := []dto.CmdbHost{
+		{Hostname: "pippo", Environment: "PRD", Location: "Italy", Owner: "Topolino"},
+		{Hostname: "pluto.topolinia.top", Environment: "TST", Location: "Germany"},
+	}
+
+	t.Run("http", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		connector, err := NewConnector(config.CmdbConnector{Type: TypeCSV, URL: server.URL + "/hosts.csv", Delimiter: ";"})
+		require.NoError(t, err)
+
+		hosts, err := connector.Hosts()
+		require.NoError(t, err)
+		assert.Equal(t, expected, hosts)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hosts.csv")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		connector, err := NewConnector(config.CmdbConnector{Type: TypeCSV, URL: "file://" + path, Delimiter: ";"})
+		require.NoError(t, err)
+
+		hosts, err := connector.Hosts()
+		require.NoError(t, err)
+		assert.Equal(t, expected, hosts)
+	})
+
+	t.Run("missing hostname column", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("name,environment\npippo,PRD\n"))
+		}))
+		defer server.Close()
+
+		connector, err := NewConnector(config.CmdbConnector{Type: TypeCSV, URL: server.URL})
+		require.NoError(t, err)
+
+		_, err = connector.Hosts()
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		connector, err := NewConnector(config.CmdbConnector{Type: TypeCSV, URL: server.URL})
+		require.NoError(t, err)
+
+		_, err = connector.Hosts()
+		assert.Error(t, err)
+	})
+}
+
+func TestLDAPConnector_hosts(t *testing.T) {
+	connector, err := NewConnector(config.CmdbConnector{Type: TypeLDAP, Fields: map[string]string{"hostname": "dNSHostName"}})
+	require.NoError(t, err)
+
+	entries := []*ldap.Entry{
+		ldap.NewEntry("CN=PIPPO,OU=Servers,DC=topolinia,DC=top", map[string][]string{
+			"dNSHostName": {"pippo.topolinia.top"},
+			"location":    {"Italy"},
+			"managedBy":   {"CN=Topolino,OU=Users,DC=topolinia,DC=top"},
+		}),
+		ldap.NewEntry("CN=PLUTO,OU=Servers,DC=topolinia,DC=top", map[string][]string{
+			"location": {"Italy"},
+		}),
+	}
+
+	assert.Equal(t, []dto.CmdbHost{
+		{Hostname: "pippo.topolinia.top", Location: "Italy"},
+	}, connector.(*LDAPConnector).hosts(entries))
+
+	connector, err = NewConnector(config.CmdbConnector{Type: TypeLDAP, Fields: map[string]string{"hostname": "dNSHostName", "owner": "managedBy"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []dto.CmdbHost{
+		{Hostname: "pippo.topolinia.top", Location: "Italy", Owner: "CN=Topolino,OU=Users,DC=topolinia,DC=top"},
+	}, connector.(*LDAPConnector).hosts(entries))
+}
+
+func TestLDAPConnector_Timeout(t *testing.T) {
+	// the server accepts the connection but never responds
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+
+	connector := &LDAPConnector{
+		Config:  config.CmdbConnector{Type: TypeLDAP, URL: "ldap://" + listener.Addr().String(), Username: "ercole", Password: "secret"},
+		Fields:  ldapDefaultFields,
+		Timeout: 100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err = connector.Hosts()
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	connector.Config.URL = "ftp://" + listener.Addr().String()
+	_, err = connector.Hosts()
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmdb
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var csvDefaultFields = map[string]string{
+	AttributeHostname:    "hostname",
+	AttributeEnvironment: "environment",
+	AttributeLocation:    "location",
+	AttributeOwner:       "owner",
+}
+
+// CSVConnector pull the hosts from a CSV file with a header, downloaded over HTTP or read from the filesystem
+type CSVConnector struct {
+	Config config.CmdbConnector
+	Fields map[string]string
+	Client *http.Client
+}
+
+// Hosts return the rows of the file
+func (c *CSVConnector) Hosts() ([]dto.CmdbHost, error) {
+	file, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	if c.Config.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(c.Config.Delimiter)
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns[strings.ToLower(c.Fields[AttributeHostname])]; !ok {
+		return nil, utils.NewError(fmt.Errorf("missing the column %q of the hostname", c.Fields[AttributeHostname]), "CMDB")
+	}
+
+	hosts := make([]dto.CmdbHost, 0)
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return hosts, nil
+		} else if err != nil {
+			return nil, utils.NewError(err, "CMDB")
+		}
+
+		value := func(field string) string {
+			i, ok := columns[strings.ToLower(field)]
+			if !ok || i >= len(row) {
+				return ""
+			}
+
+			return row[i]
+		}
+
+		if host, ok := newHost(c.Fields, value); ok {
+			hosts = append(hosts, host)
+		}
+	}
+}
+
+// open return the file at the URL, or at the path if it isn't an HTTP URL
+func (c *CSVConnector) open() (io.ReadCloser, error) {
+	if !strings.HasPrefix(c.Config.URL, "http://") && !strings.HasPrefix(c.Config.URL, "https://") {
+		file, err := os.Open(strings.TrimPrefix(c.Config.URL, "file://"))
+		if err != nil {
+			return nil, utils.NewError(err, "CMDB")
+		}
+
+		return file, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.Config.URL, nil)
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+
+	if c.Config.Username != "" {
+		req.SetBasicAuth(c.Config.Username, c.Config.Password)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmdb
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	ldapDefaultFilter = "(objectClass=computer)"
+	ldapPageSize      = 500
+)
+
+// ldapDefaultFields don't map the owner: the attributes like managedBy contain the DN of the owner,
+// which doesn't match the owner of the hosts
+var ldapDefaultFields = map[string]string{
+	AttributeHostname: "cn",
+	AttributeLocation: "location",
+}
+
+// LDAPConnector pull the hosts from the computer objects of a directory
+type LDAPConnector struct {
+	Config config.CmdbConnector
+	Fields map[string]string
+	// Timeout is the timeout of the connection and of each request to the server
+	Timeout time.Duration
+}
+
+// Hosts return the computer objects found under the base DN
+func (c *LDAPConnector) Hosts() ([]dto.CmdbHost, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+	defer conn.Close()
+
+	if c.Config.Username != "" {
+		if err := conn.Bind(c.Config.Username, c.Config.Password); err != nil {
+			return nil, utils.NewError(err, "CMDB")
+		}
+	}
+
+	filter := c.Config.Filter
+	if filter == "" {
+		filter = ldapDefaultFilter
+	}
+
+	attributes := make([]string, 0, len(c.Fields))
+
+	for _, field := range c.Fields {
+		if field != "" {
+			attributes = append(attributes, field)
+		}
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		c.Config.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		attributes,
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(searchRequest, ldapPageSize)
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+
+	return c.hosts(result.Entries), nil
+}
+
+// dial connect to the server of the URL like ldap.DialURL, but within the timeout,
+// which is also set as the timeout of the requests
+func (c *LDAPConnector) dial() (*ldap.Conn, error) {
+	u, err := url.Parse(c.Config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		host, port = u.Host, ""
+	}
+
+	dialer := &net.Dialer{Timeout: c.Timeout}
+
+	var netConn net.Conn
+
+	switch u.Scheme {
+	case "ldap":
+		if port == "" {
+			port = ldap.DefaultLdapPort
+		}
+
+		netConn, err = dialer.Dial("tcp", net.JoinHostPort(host, port))
+	case "ldaps":
+		if port == "" {
+			port = ldap.DefaultLdapsPort
+		}
+
+		netConn, err = tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), &tls.Config{ServerName: host})
+	default:
+		return nil, fmt.Errorf("Unknown LDAP scheme %q", u.Scheme)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	conn := ldap.NewConn(netConn, u.Scheme == "ldaps")
+	conn.Start()
+	conn.SetTimeout(c.Timeout)
+
+	return conn, nil
+}
+
+func (c *LDAPConnector) hosts(entries []*ldap.Entry) []dto.CmdbHost {
+	hosts := make([]dto.CmdbHost, 0, len(entries))
+
+	for _, entry := range entries {
+		if host, ok := newHost(c.Fields, entry.GetAttributeValue); ok {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	serviceNowDefaultTable = "cmdb_ci_server"
+	serviceNowPageSize     = 1000
+)
+
+var serviceNowDefaultFields = map[string]string{
+	AttributeHostname:    "name",
+	AttributeEnvironment: "environment",
+	AttributeLocation:    "location",
+	AttributeOwner:       "owned_by",
+}
+
+// ServiceNowConnector pull the hosts from a table of ServiceNow with the REST Table API
+type ServiceNowConnector struct {
+	Config config.CmdbConnector
+	Fields map[string]string
+	Client *http.Client
+}
+
+// Hosts return the records of the table, requested a page at a time
+func (c *ServiceNowConnector) Hosts() ([]dto.CmdbHost, error) {
+	hosts := make([]dto.CmdbHost, 0)
+
+	for offset := 0; ; offset += serviceNowPageSize {
+		records, err := c.getPage(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			if host, ok := newHost(c.Fields, func(field string) string { return serviceNowValue(record[field]) }); ok {
+				hosts = append(hosts, host)
+			}
+		}
+
+		if len(records) < serviceNowPageSize {
+			return hosts, nil
+		}
+	}
+}
+
+func (c *ServiceNowConnector) getPage(offset int) ([]map[string]interface{}, error) {
+	table := c.Config.Table
+	if table == "" {
+		table = serviceNowDefaultTable
+	}
+
+	fields := make([]string, 0, len(c.Fields))
+
+	for _, field := range c.Fields {
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	params := url.Values{}
+	params.Set("sysparm_fields", strings.Join(fields, ","))
+	params.Set("sysparm_display_value", "true")
+	params.Set("sysparm_exclude_reference_link", "true")
+	params.Set("sysparm_limit", strconv.Itoa(serviceNowPageSize))
+	params.Set("sysparm_offset", strconv.Itoa(offset))
+
+	// the records are ordered, so the pages don't skip or repeat records
+	query := "ORDERBYsys_id"
+	if c.Config.Query != "" {
+		query = c.Config.Query + "^" + query
+	}
+
+	params.Set("sysparm_query", query)
+
+	req, err := http.NewRequest(http.MethodGet,
+		fmt.Sprintf("%s/api/now/table/%s?%s", strings.TrimSuffix(c.Config.URL, "/"), url.PathEscape(table), params.Encode()), nil)
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.Config.Username, c.Config.Password)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Result []map[string]interface{} `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, utils.NewError(err, "CMDB")
+	}
+
+	return body.Result, nil
+}
+
+// serviceNowValue return the value of a field, the references are returned as their display value
+func serviceNowValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}:
+		return serviceNowValue(v["display_value"])
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -28,11 +28,17 @@ import (
 
 type DataControllerInterface interface {
 	InsertHostData(w http.ResponseWriter, r *http.Request)
+	InsertHostDataBatch(w http.ResponseWriter, r *http.Request)
+	GetHostDataUpload(w http.ResponseWriter, r *http.Request)
+	GetHostDataSchemaVersions(w http.ResponseWriter, r *http.Request)
 	CompareCmdbInfo(w http.ResponseWriter, r *http.Request)
 
+	ReplayQuarantinedHostData(w http.ResponseWriter, r *http.Request)
+
 	InsertExadata(w http.ResponseWriter, r *http.Request)
 
 	AuthenticateMiddleware(h http.Handler) http.Handler
+	DecodeBodyMiddleware(h http.Handler) http.Handler
 }
 
 type DataController struct {
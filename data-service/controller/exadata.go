@@ -29,7 +29,7 @@ import (
 func (ctrl *DataController) InsertExadata(w http.ResponseWriter, r *http.Request) {
 	raw, err := io.ReadAll(r.Body)
 	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		ctrl.writeReadBodyError(w, err)
 		return
 	}
 
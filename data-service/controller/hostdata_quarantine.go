@@ -0,0 +1,91 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ReplayQuarantinedHostData validate again a quarantined hostdata, e.g. after an upgrade of ercole,
+// and insert it if it's valid. It's rejected if the host has sent a more recent hostdata in the meantime.
+// The quarantined hostdata are managed by the users through the api-service, which calls this to replay them
+func (ctrl *DataController) ReplayQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	quarantined, err := ctrl.Service.GetQuarantinedHostData(id)
+	if errors.Is(err, utils.ErrQuarantinedHostDataNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	outdated, err := ctrl.Service.IsQuarantinedHostDataOutdated(*quarantined)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	} else if outdated {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, utils.ErrQuarantinedHostDataOutdated)
+		return
+	}
+
+	raw, hostdata, err := ctrl.validateHostData(quarantined.Payload)
+	if errors.Is(err, utils.ErrInvalidHostdata) {
+		if failErr := ctrl.Service.FailQuarantinedHostDataReplay(id, err); failErr != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, failErr)
+			return
+		}
+
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := ctrl.ingestHostData(raw, *hostdata)
+	if res.err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, res.status, res.err)
+		return
+	}
+
+	if err := ctrl.Service.DeleteQuarantinedHostData(id); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if res.upload != nil {
+		w.Header().Set("Location", fmt.Sprintf("/hosts/uploads/%s", res.upload.ID.Hex()))
+		utils.WriteJSONResponse(w, res.status, res.upload)
+
+		return
+	}
+
+	utils.WriteJSONResponse(w, res.status, nil)
+}
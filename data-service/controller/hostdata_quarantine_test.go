@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/mongoutils"
+)
+
+func newQuarantineRequest(t *testing.T, method, id string) *http.Request {
+	req, err := http.NewRequest(method, "/hosts/quarantine/"+id, nil)
+	require.NoError(t, err)
+
+	return mux.SetURLVars(req, map[string]string{"id": id})
+}
+
+func TestReplayQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000001")
+
+	t.Run("valid now", func(t *testing.T) {
+		raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+		require.NoError(t, err)
+
+		var payload bytes.Buffer
+		require.NoError(t, json.Compact(&payload, raw))
+
+		expectedHostDataBE := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
+
+		gomock.InOrder(
+			as.EXPECT().GetQuarantinedHostData(id).Return(&model.QuarantinedHostData{ID: id, Payload: payload.Bytes()}, nil),
+			as.EXPECT().IsQuarantinedHostDataOutdated(model.QuarantinedHostData{ID: id, Payload: payload.Bytes()}).Return(false, nil),
+			as.EXPECT().InsertHostData(expectedHostDataBE).Return(nil),
+			as.EXPECT().DeleteQuarantinedHostData(id).Return(nil),
+		)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("still invalid", func(t *testing.T) {
+		gomock.InOrder(
+			as.EXPECT().GetQuarantinedHostData(id).Return(&model.QuarantinedHostData{ID: id, Payload: []byte("{}")}, nil),
+			as.EXPECT().IsQuarantinedHostDataOutdated(gomock.Any()).Return(false, nil),
+			as.EXPECT().FailQuarantinedHostDataReplay(id, gomock.Any()).
+				DoAndReturn(func(_ interface{}, err error) error {
+					assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+					return nil
+				}),
+		)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		quarantined := &model.QuarantinedHostData{ID: id, Hostname: "foobar", Payload: []byte(`{"hostname":"foobar"}`)}
+
+		gomock.InOrder(
+			as.EXPECT().GetQuarantinedHostData(id).Return(quarantined, nil),
+			as.EXPECT().IsQuarantinedHostDataOutdated(*quarantined).Return(true, nil),
+		)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().GetQuarantinedHostData(id).Return(nil, utils.ErrQuarantinedHostDataNotFound)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ReplayQuarantinedHostData).ServeHTTP(rr, newQuarantineRequest(t, "POST", id.Hex()))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
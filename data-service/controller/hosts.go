@@ -16,12 +16,17 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/schema"
 	"github.com/ercole-io/ercole/v2/utils"
@@ -32,59 +37,183 @@ import (
 func (ctrl *DataController) InsertHostData(w http.ResponseWriter, r *http.Request) {
 	raw, err := io.ReadAll(r.Body)
 	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		ctrl.writeReadBodyError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
+	res := ctrl.insertHostData(raw)
+	if res.err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, res.status, res.err)
+		return
+	}
+
+	if res.upload != nil {
+		w.Header().Set("Location", fmt.Sprintf("/hosts/uploads/%s", res.upload.ID.Hex()))
+		utils.WriteJSONResponse(w, res.status, res.upload)
+
+		return
+	}
+
+	utils.WriteJSONResponse(w, res.status, nil)
+}
+
+// InsertHostDataBatch update the informations about the hosts using the HostData in the request,
+// one for each line of the NDJSON body
+func (ctrl *DataController) InsertHostDataBatch(w http.ResponseWriter, r *http.Request) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		ctrl.writeReadBodyError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	results := make([]dto.HostDataBatchResult, 0)
+
+	for i, line := range bytes.Split(raw, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		res := ctrl.insertHostData(line)
+
+		result := dto.HostDataBatchResult{
+			Line:     i + 1,
+			Hostname: res.hostname,
+			Status:   res.status,
+			Upload:   res.upload,
+		}
+
+		if res.err != nil {
+			result.Error = res.err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+// hostDataInsertion is the outcome of the insertion of an hostdata
+type hostDataInsertion struct {
+	hostname string
+	status   int
+	upload   *model.HostDataUpload
+	err      error
+}
+
+func (ctrl *DataController) insertHostData(raw []byte) hostDataInsertion {
+	var err error
+
 	if raw, err = ctrl.sanitizeJson(raw); err != nil {
 		if errors.Is(err, utils.ErrInvalidJSON) {
-			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 			ctrl.Service.AlertInvalidHostData(err, nil)
 
-			return
+			return hostDataInsertion{status: http.StatusUnprocessableEntity, err: err}
 		}
 
 		ctrl.Log.Error(err)
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 
-		return
+		return hostDataInsertion{status: http.StatusInternalServerError, err: err}
 	}
 
-	var hostdata model.HostDataBE
+	upgraded, hostdata, err := ctrl.validateHostData(raw)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidHostdata) {
+			ctrl.Log.Info(err)
+			ctrl.Service.AlertInvalidHostData(err, hostdata)
 
-	if validationErr := schema.ValidateHostdata(raw); validationErr != nil {
-		if errors.Is(validationErr, utils.ErrInvalidHostdata) {
-			ctrl.Log.Info(validationErr)
-			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, validationErr)
+			if quarantineErr := ctrl.Service.QuarantineHostData(raw, err); quarantineErr != nil {
+				ctrl.Log.Error(quarantineErr)
+			}
 
-			if unmarshalErr := json.Unmarshal(raw, &hostdata); unmarshalErr != nil {
-				ctrl.Service.AlertInvalidHostData(validationErr, nil)
-			} else {
-				ctrl.Service.AlertInvalidHostData(validationErr, &hostdata)
+			res := hostDataInsertion{status: http.StatusUnprocessableEntity, err: err}
+			if hostdata != nil {
+				res.hostname = hostdata.Hostname
 			}
 
-			return
+			return res
 		}
 
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, validationErr)
+		return hostDataInsertion{status: http.StatusInternalServerError, err: err}
+	}
+
+	return ctrl.ingestHostData(upgraded, *hostdata)
+}
 
-		return
+// validateHostData upgrade raw to the current schema version and validate it.
+// When raw isn't valid, the hostdata is returned too if it can be unmarshalled
+func (ctrl *DataController) validateHostData(raw []byte) ([]byte, *model.HostDataBE, error) {
+	var hostdata model.HostDataBE
+
+	upgraded, validationErr := schema.UpgradeHostdata(raw)
+	if validationErr == nil {
+		raw = upgraded
+		validationErr = schema.ValidateHostdata(raw)
+	}
+
+	if validationErr != nil {
+		if errors.Is(validationErr, utils.ErrInvalidHostdata) && json.Unmarshal(raw, &hostdata) == nil {
+			return nil, &hostdata, validationErr
+		}
+
+		return nil, nil, validationErr
+	}
+
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return nil, nil, err
+	}
+
+	return raw, &hostdata, nil
+}
+
+// ingestHostData insert the valid hostdata, or put it in the queue when it's enabled
+func (ctrl *DataController) ingestHostData(raw []byte, hostdata model.HostDataBE) hostDataInsertion {
+	if ctrl.Config.DataService.HostDataQueue.Enabled {
+		upload, err := ctrl.Service.EnqueueHostData(hostdata.Hostname, raw)
+		if err != nil {
+			return hostDataInsertion{hostname: hostdata.Hostname, status: http.StatusInternalServerError, err: err}
+		}
+
+		return hostDataInsertion{hostname: hostdata.Hostname, status: http.StatusAccepted, upload: upload}
+	}
+
+	if err := ctrl.Service.InsertHostData(hostdata); err != nil {
+		return hostDataInsertion{hostname: hostdata.Hostname, status: http.StatusInternalServerError, err: err}
 	}
 
-	err = json.Unmarshal(raw, &hostdata)
+	return hostDataInsertion{hostname: hostdata.Hostname, status: http.StatusOK}
+}
+
+// GetHostDataUpload return the processing status of an hostdata accepted by the queue
+func (ctrl *DataController) GetHostDataUpload(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
 	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
 		return
 	}
 
-	err = ctrl.Service.InsertHostData(hostdata)
-	if err != nil {
+	upload, err := ctrl.Service.GetHostDataUpload(id)
+	if errors.Is(err, utils.ErrHostDataUploadNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.WriteJSONResponse(w, http.StatusOK, nil)
+	utils.WriteJSONResponse(w, http.StatusOK, upload)
+}
+
+// GetHostDataSchemaVersions return the schema versions of the hostdata accepted
+func (ctrl *DataController) GetHostDataSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	versions := dto.HostDataSchemaVersions{
+		Current:   model.SchemaVersion,
+		Supported: schema.SupportedHostdataSchemaVersions(),
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, versions)
 }
 
 func (ctrl *DataController) sanitizeJson(raw []byte) ([]byte, error) {
@@ -17,18 +17,22 @@ package controller
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
 
 	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/ercole-io/ercole/v2/utils/mongoutils"
 )
@@ -126,6 +130,7 @@ func TestUpdateHostInfo_UnprocessableEntity2(t *testing.T) {
 			assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
 			assert.NotNil(t, hd)
 		})
+	as.EXPECT().QuarantineHostData([]byte("{}"), gomock.Any()).Return(nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.InsertHostData)
@@ -191,3 +196,211 @@ func TestUpdateHostInfo_SuccessAndSanitized(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, rr.Code)
 }
+
+func TestUpdateHostInfo_Queued(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{Enabled: true},
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	expectedHostDataBE := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
+
+	upload := &model.HostDataUpload{
+		ID:         utils.Str2oid("000000000000000000000001"),
+		Hostname:   expectedHostDataBE.Hostname,
+		Status:     model.HostDataUploadStatusQueued,
+		ReceivedAt: utils.P("2019-11-05T14:02:03Z"),
+	}
+	as.EXPECT().EnqueueHostData(expectedHostDataBE.Hostname, gomock.Any()).Return(upload, nil)
+
+	handler := http.HandlerFunc(ac.InsertHostData)
+	req, err := http.NewRequest("PUT", "/", bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Equal(t, "/hosts/uploads/000000000000000000000001", rr.Header().Get("Location"))
+	assert.JSONEq(t, utils.ToJSON(upload), rr.Body.String())
+}
+
+func TestInsertHostDataBatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{Enabled: true},
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	var line bytes.Buffer
+	require.NoError(t, json.Compact(&line, raw))
+
+	expectedHostDataBE := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
+
+	upload := &model.HostDataUpload{
+		ID:         utils.Str2oid("000000000000000000000001"),
+		Hostname:   expectedHostDataBE.Hostname,
+		Status:     model.HostDataUploadStatusQueued,
+		ReceivedAt: utils.P("2019-11-05T14:02:03Z"),
+	}
+	as.EXPECT().EnqueueHostData(expectedHostDataBE.Hostname, gomock.Any()).Return(upload, nil)
+	as.EXPECT().AlertInvalidHostData(gomock.Any(), gomock.Any()).
+		Do(func(err error, _ interface{}) {
+			assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+		})
+	as.EXPECT().QuarantineHostData([]byte("{}"), gomock.Any()).Return(errMock)
+
+	body := line.String() + "\n\n{}\n"
+
+	handler := http.HandlerFunc(ac.InsertHostDataBatch)
+	req, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var res struct {
+		Results []dto.HostDataBatchResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+	require.Len(t, res.Results, 2)
+	assert.Equal(t, 1, res.Results[0].Line)
+	assert.Equal(t, expectedHostDataBE.Hostname, res.Results[0].Hostname)
+	assert.Equal(t, http.StatusAccepted, res.Results[0].Status)
+	assert.Equal(t, upload.ID, res.Results[0].Upload.ID)
+	assert.Empty(t, res.Results[0].Error)
+
+	assert.Equal(t, 3, res.Results[1].Line)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Results[1].Status)
+	assert.Nil(t, res.Results[1].Upload)
+	assert.NotEmpty(t, res.Results[1].Error)
+}
+
+func TestInsertHostDataBatch_RequestEntityTooLarge(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				MaxBodySize: 1,
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	body := strings.Repeat("{}\n", 1024*1024)
+
+	handler := ac.DecodeBodyMiddleware(http.HandlerFunc(ac.InsertHostDataBatch))
+	req, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
+
+func TestGetHostDataUpload(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("000000000000000000000001")
+
+	newRequest := func(t *testing.T, id string) *http.Request {
+		req, err := http.NewRequest("GET", "/hosts/uploads/"+id, nil)
+		require.NoError(t, err)
+
+		return mux.SetURLVars(req, map[string]string{"id": id})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		upload := &model.HostDataUpload{
+			ID:       id,
+			Hostname: "foobar",
+			Status:   model.HostDataUploadStatusDone,
+			Attempts: 1,
+		}
+		as.EXPECT().GetHostDataUpload(id).Return(upload, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetHostDataUpload).ServeHTTP(rr, newRequest(t, id.Hex()))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(upload), rr.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		as.EXPECT().GetHostDataUpload(id).Return(nil, utils.ErrHostDataUploadNotFound)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetHostDataUpload).ServeHTTP(rr, newRequest(t, id.Hex()))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetHostDataUpload).ServeHTTP(rr, newRequest(t, "foobar"))
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestGetHostDataSchemaVersions(t *testing.T) {
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	handler := http.HandlerFunc(ac.GetHostDataSchemaVersions)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := dto.HostDataSchemaVersions{
+		Current:   model.SchemaVersion,
+		Supported: []int{model.SchemaVersion},
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
@@ -26,7 +26,7 @@ import (
 func (ctrl *DataController) InsertOracleLicenseTypes(w http.ResponseWriter, r *http.Request) {
 	raw, err := io.ReadAll(r.Body)
 	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		ctrl.writeReadBodyError(w, err)
 		return
 	}
 	defer r.Body.Close()
@@ -0,0 +1,149 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// DecodeBodyMiddleware return the middleware that decompress the body of the requests according to
+// their Content-Encoding and limit its size to the MaxBodySize configured
+func (ctrl *DataController) DecodeBodyMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxSize := ctrl.Config.DataService.MaxBodySize * 1024 * 1024
+		body := limitBody(r.Body, maxSize)
+
+		encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+
+		switch encoding {
+		case "", "identity":
+			r.Body = body
+		case "gzip", "x-gzip":
+			decoder, err := gzip.NewReader(body)
+			if err != nil {
+				ctrl.writeReadBodyError(w, err)
+				return
+			}
+
+			r.Body = limitBody(&decodedBody{Reader: decoder, decoder: decoder, body: body}, maxSize)
+		case "zstd":
+			decoder, err := zstd.NewReader(body)
+			if err != nil {
+				utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+				return
+			}
+
+			readCloser := decoder.IOReadCloser()
+			r.Body = limitBody(&decodedBody{Reader: readCloser, decoder: readCloser, body: body}, maxSize)
+		default:
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnsupportedMediaType,
+				utils.NewError(fmt.Errorf("%w: %q", utils.ErrUnsupportedContentEncoding, encoding), http.StatusText(http.StatusUnsupportedMediaType)))
+
+			return
+		}
+
+		if encoding != "" && encoding != "identity" {
+			r.Header.Del("Content-Encoding")
+			r.ContentLength = -1
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// writeReadBodyError write the error returned reading the body of the request
+func (ctrl *DataController) writeReadBodyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, utils.ErrRequestBodyTooLarge) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusRequestEntityTooLarge, utils.NewError(err, http.StatusText(http.StatusRequestEntityTooLarge)))
+		return
+	}
+
+	utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+}
+
+// decodedBody is the decompressed body of a request
+type decodedBody struct {
+	io.Reader
+	decoder io.Closer
+	body    io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	if err := b.decoder.Close(); err != nil {
+		return err
+	}
+
+	return b.body.Close()
+}
+
+// limitedBody is a body that return ErrRequestBodyTooLarge when it's read over its limit
+type limitedBody struct {
+	body      io.ReadCloser
+	limit     int64
+	remaining int64
+	err       error
+}
+
+// limitBody return body limited to limit bytes, or body itself when limit isn't positive
+func limitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	if limit <= 0 {
+		return body
+	}
+
+	return &limitedBody{body: body, limit: limit, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	// one byte more than the remaining ones is read to know if the limit is exceeded
+	if int64(len(p))-1 > b.remaining {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.body.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		b.err = err
+
+		return n, err
+	}
+
+	n = int(b.remaining)
+	b.remaining = 0
+	b.err = fmt.Errorf("%w: the limit is %d bytes", utils.ErrRequestBodyTooLarge, b.limit)
+
+	return n, b.err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
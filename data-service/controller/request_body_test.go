@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newDecodeBodyTestController(maxBodySize int64) DataController {
+	return DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			DataService: config.DataService{
+				MaxBodySize: maxBodySize,
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+}
+
+// echoHandler write back the body of the request
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusTeapot)
+		return
+	}
+
+	if _, err := w.Write(raw); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+})
+
+func TestDecodeBodyMiddleware_Identity(t *testing.T) {
+	ac := newDecodeBodyTestController(1)
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", strings.NewReader("{\"hostname\":\"foobar\"}"))
+	require.NoError(t, err)
+
+	ac.DecodeBodyMiddleware(echoHandler).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"hostname\":\"foobar\"}", rr.Body.String())
+}
+
+func TestDecodeBodyMiddleware_Gzip(t *testing.T) {
+	ac := newDecodeBodyTestController(1)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte("{\"hostname\":\"foobar\"}"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	ac.DecodeBodyMiddleware(echoHandler).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"hostname\":\"foobar\"}", rr.Body.String())
+}
+
+func TestDecodeBodyMiddleware_Zstd(t *testing.T) {
+	ac := newDecodeBodyTestController(1)
+
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := encoder.EncodeAll([]byte("{\"hostname\":\"foobar\"}"), nil)
+	require.NoError(t, encoder.Close())
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(compressed))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "zstd")
+
+	ac.DecodeBodyMiddleware(echoHandler).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"hostname\":\"foobar\"}", rr.Body.String())
+}
+
+func TestDecodeBodyMiddleware_CorruptedGzip(t *testing.T) {
+	ac := newDecodeBodyTestController(1)
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", strings.NewReader("{\"hostname\":\"foobar\"}"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	ac.DecodeBodyMiddleware(echoHandler).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDecodeBodyMiddleware_UnsupportedMediaType(t *testing.T) {
+	ac := newDecodeBodyTestController(1)
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", strings.NewReader("{\"hostname\":\"foobar\"}"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "br")
+
+	ac.DecodeBodyMiddleware(echoHandler).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+}
+
+func TestDecodeBodyMiddleware_DecompressedBodyTooLarge(t *testing.T) {
+	ac := newDecodeBodyTestController(1)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(bytes.Repeat([]byte(" "), 2*1024*1024))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.Less(t, buf.Len(), 1024*1024)
+
+	handler := http.HandlerFunc(ac.InsertOracleLicenseTypes)
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	ac.DecodeBodyMiddleware(handler).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	assert.Contains(t, rr.Body.String(), utils.ErrRequestBodyTooLarge.Error())
+}
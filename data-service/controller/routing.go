@@ -35,6 +35,7 @@ func (ctrl *DataController) GetDataControllerHandler() http.Handler {
 
 	router.StrictSlash(true)
 	router.Use(ctrl.AuthenticateMiddleware)
+	router.Use(ctrl.DecodeBodyMiddleware)
 
 	ctrl.setupProtectedRoutes(router)
 
@@ -43,6 +44,10 @@ func (ctrl *DataController) GetDataControllerHandler() http.Handler {
 
 func (ctrl *DataController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts", ctrl.InsertHostData).Methods("POST")
+	router.HandleFunc("/hosts/batch", ctrl.InsertHostDataBatch).Methods("POST")
+	router.HandleFunc("/hosts/schema", ctrl.GetHostDataSchemaVersions).Methods("GET")
+	router.HandleFunc("/hosts/uploads/{id}", ctrl.GetHostDataUpload).Methods("GET")
+	router.HandleFunc("/hosts/quarantine/{id}/replay", ctrl.ReplayQuarantinedHostData).Methods("POST")
 	router.HandleFunc("/cmdbs", ctrl.CompareCmdbInfo).Methods("POST")
 	router.HandleFunc("/oracle/license-types", ctrl.InsertOracleLicenseTypes).Methods("POST")
 	router.HandleFunc("/exadatas", ctrl.InsertExadata).Methods("POST")
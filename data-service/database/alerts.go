@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
@@ -53,3 +54,27 @@ func (md *MongoDatabase) DeleteNoDataAlertByHost(hostname string) error {
 
 	return nil
 }
+
+// FindCmdbFieldMismatchAlerts return the CMDB_FIELD_MISMATCH alerts of the CMDB, sorted by date
+func (md *MongoDatabase) FindCmdbFieldMismatchAlerts(cmdb string) ([]model.Alert, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection("alerts").
+		Find(ctx,
+			bson.M{
+				"alertCode":      model.AlertCodeCmdbFieldMismatch,
+				"otherInfo.cmdb": cmdb,
+			},
+			options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	alerts := make([]model.Alert, 0)
+	if err := cur.All(ctx, &alerts); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return alerts, nil
+}
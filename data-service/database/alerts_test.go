@@ -173,3 +173,44 @@ func (m *MongodbSuite) TestDeleteNoDataAlertByHost_Success() {
 		require.Equal(m.T(), 0, len(alerts))
 	})
 }
+
+func (m *MongodbSuite) TestFindCmdbFieldMismatchAlerts_Success() {
+	defer m.db.Client.Database(m.dbname).Collection("alerts").DeleteMany(context.TODO(), bson.M{})
+
+	alerts := []interface{}{
+		model.Alert{
+			ID:            utils.Str2oid("5dd40bfb12f54dfda7b1c291"),
+			AlertCode:     model.AlertCodeCmdbFieldMismatch,
+			AlertCategory: model.AlertCategoryEngine,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusAck,
+			Date:          utils.P("2019-11-05T18:02:03Z"),
+			OtherInfo:     map[string]interface{}{"hostname": "pippo", "cmdb": "servicenow"},
+		},
+		model.Alert{
+			ID:            utils.Str2oid("5dd40bfb12f54dfda7b1c292"),
+			AlertCode:     model.AlertCodeCmdbFieldMismatch,
+			AlertCategory: model.AlertCategoryEngine,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusNew,
+			Date:          utils.P("2019-11-05T18:02:03Z"),
+			OtherInfo:     map[string]interface{}{"hostname": "pippo", "cmdb": "ldap"},
+		},
+		model.Alert{
+			ID:            utils.Str2oid("5dd40bfb12f54dfda7b1c293"),
+			AlertCode:     model.AlertCodeCmdbFieldMismatch,
+			AlertCategory: model.AlertCategoryEngine,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusNew,
+			Date:          utils.P("2019-11-04T18:02:03Z"),
+			OtherInfo:     map[string]interface{}{"hostname": "pluto", "cmdb": "servicenow"},
+		},
+	}
+
+	_, err := m.db.Client.Database(m.dbname).Collection("alerts").InsertMany(context.TODO(), alerts)
+	require.NoError(m.T(), err)
+
+	res, err := m.db.FindCmdbFieldMismatchAlerts("servicenow")
+	require.NoError(m.T(), err)
+	require.Equal(m.T(), []model.Alert{alerts[2].(model.Alert), alerts[0].(model.Alert)}, res)
+}
@@ -37,18 +37,26 @@ type MongoDatabaseInterface interface {
 	DismissHost(hostname string) error
 	InsertHostData(hostData model.HostDataBE) error
 	GetCurrentHostnames() ([]string, error)
-	// FindOldCurrentHostnames return the list of current hosts names that haven't sent hostdata after time t
+	// FindOldCurrentHostnames return the list of current local hosts names that haven't sent hostdata after time t
 	FindOldCurrentHostnames(t time.Time) ([]string, error)
 	FindOldCurrentHostdata(hostName string, t time.Time) (bool, error)
-	// FindOldArchivedHosts return the list of archived hosts older than t
+	// FindOldArchivedHosts return the list of archived hosts older than t and not retained by a snapshot
 	FindOldArchivedHosts(t time.Time) ([]primitive.ObjectID, error)
 	GetActiveHostdata() ([]model.HostDataBE, error)
+	// UpdateHostDataLicenses update the licenses of the databases of the current hostdata
+	UpdateHostDataLicenses(hostdata model.HostDataBE) error
 	DeleteHostData(id primitive.ObjectID) error
 	HistoricizeLicensesCompliance(licenses []dto.LicenseCompliance) error
+	// HistoricizeUsedLicenses replace the snapshot of the used licenses of the current day
+	HistoricizeUsedLicenses(usedLicenses []dto.DatabaseUsedLicense) error
+	// UpdateOracleFeatureUsageLedger record the detections of Oracle database features
+	UpdateOracleFeatureUsageLedger(entries []model.OracleFeatureUsageLedgerEntry) error
 
 	DeleteNoDataAlertByHost(hostname string) error
 	DeleteAllNoDataAlerts() error
-	// FindMostRecentHostDataOlderThan return the most recest hostdata that is older than t
+	// FindCmdbFieldMismatchAlerts return the CMDB_FIELD_MISMATCH alerts of the CMDB, sorted by date
+	FindCmdbFieldMismatchAlerts(cmdb string) ([]model.Alert, error)
+	// FindMostRecentHostDataOlderThan return the most recest hostdata of the local host that is older than t
 	FindMostRecentHostDataOlderThan(hostname string, t time.Time) (*model.HostDataBE, error)
 	GetHostnames() ([]string, error)
 	GetOracleDatabaseLicenseTypes() ([]model.OracleDatabaseLicenseType, error)
@@ -59,6 +67,51 @@ type MongoDatabaseInterface interface {
 	UpdateExadataHostname(rackID, hostname string) error
 	PushComponentToExadataInstance(rackID string, component model.OracleExadataComponent) error
 	SetExadataComponent(rackID string, component model.OracleExadataComponent) error
+
+	InsertHostDataUpload(upload model.HostDataUpload) error
+	GetHostDataUpload(id primitive.ObjectID) (*model.HostDataUpload, error)
+	// ClaimHostDataUpload mark the oldest queued upload of the hosts not excluded as processing and return it
+	ClaimHostDataUpload(excludedHostnames []string) (*model.HostDataUpload, error)
+	UpdateHostDataUploadStatus(id primitive.ObjectID, status string, errMsg string) error
+	// RequeueStaleHostDataUploads queue again the uploads which are processing since before t
+	RequeueStaleHostDataUploads(t time.Time) (int64, error)
+	// AddHostDataUploadThrownAlert save the key of an alert thrown while processing the upload
+	AddHostDataUploadThrownAlert(id primitive.ObjectID, alertKey string) error
+
+	// InsertHostDataChange save the fields of an host changed by an upload
+	InsertHostDataChange(change model.HostDataChange) error
+
+	// RetainCurrentHostData keep the current hostdata at least until the time, return the number of hostdata retained
+	RetainCurrentHostData(until time.Time) (int64, error)
+	InsertHostDataSnapshot(snapshot model.HostDataSnapshot) error
+
+	InsertQuarantinedHostData(quarantined model.QuarantinedHostData) error
+	GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error)
+	UpdateQuarantinedHostDataReplay(id primitive.ObjectID, errors string) error
+	DeleteQuarantinedHostData(id primitive.ObjectID) error
+
+	// IterateHostDataCreatedBetween call fn for each hostdata created between from and to, from the oldest
+	IterateHostDataCreatedBetween(from, to time.Time, fn func(hostdata model.HostDataBE) error) error
+	FindAllExadataInstances() ([]model.OracleExadataInstance, error)
+
+	// FindCurrentFederatedHostData return the current hostdata of the host of the site, nil if there isn't
+	FindCurrentFederatedHostData(site, hostname string) (*model.HostDataBE, error)
+	// DismissFederatedHost dismiss the current hostdata of the host of the site
+	DismissFederatedHost(site, hostname string) error
+	// SaveFederatedHostData save the hostdata pulled from a site, replacing it if it's already stored
+	SaveFederatedHostData(hostdata model.HostDataBE) error
+	// DismissFederatedHostsNotIn dismiss the current hosts of the site whose names aren't in hostnames
+	DismissFederatedHostsNotIn(site string, hostnames []string) error
+	ReplaceFederatedOracleDatabaseContracts(site string, contracts []model.OracleDatabaseContract) error
+	ReplaceFederatedAlerts(site string, alerts []model.Alert) error
+
+	// FindHostIdentityByAlias return the identity of the host with the alias
+	FindHostIdentityByAlias(alias string) (*model.HostIdentity, error)
+	// FindHostIdentitiesByShortName return the identities with an alias with the short name, with or without domain
+	FindHostIdentitiesByShortName(shortName string) ([]model.HostIdentity, error)
+	InsertHostIdentity(identity model.HostIdentity) error
+	// AddHostIdentityAlias add the alias to the identity of the host
+	AddHostIdentityAlias(id string, alias string) error
 }
 
 type MongoDatabase struct {
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -41,6 +42,22 @@ func (md *MongoDatabase) FindExadataByRackID(rackID string) (*model.OracleExadat
 	return result, nil
 }
 
+// FindAllExadataInstances return all the exadata instances
+func (md *MongoDatabase) FindAllExadataInstances() ([]model.OracleExadataInstance, error) {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(exdataCollection).
+		Find(context.TODO(), bson.M{}, options.Find().SetSort(bson.D{{Key: "updateAt", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	exadatas := make([]model.OracleExadataInstance, 0)
+	if err := cur.All(context.TODO(), &exadatas); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return exadatas, nil
+}
+
 func (md *MongoDatabase) AddExadata(exadata model.OracleExadataInstance) error {
 	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(exdataCollection).
 		InsertOne(context.TODO(), exadata)
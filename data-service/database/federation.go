@@ -0,0 +1,145 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// FindCurrentFederatedHostData return the current hostdata of the host of the site, nil if there isn't
+func (md *MongoDatabase) FindCurrentFederatedHostData(site, hostname string) (*model.HostDataBE, error) {
+	var out model.HostDataBE
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		FindOne(context.TODO(), bson.M{
+			"site":        site,
+			"hostname":    hostname,
+			"archived":    false,
+			"dismissedAt": nil,
+		}).Decode(&out)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &out, nil
+}
+
+// DismissFederatedHost dismiss the current hostdata of the host of the site
+func (md *MongoDatabase) DismissFederatedHost(site, hostname string) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").UpdateMany(context.TODO(),
+		bson.M{
+			"site":        site,
+			"hostname":    hostname,
+			"dismissedAt": nil,
+		},
+		bson.M{"$set": bson.M{
+			"dismissedAt": md.TimeNow(),
+			"archived":    true,
+		}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// SaveFederatedHostData save the hostdata pulled from a site as the current one. The hostdata is replaced if
+// it's already stored, e.g. because it had been dismissed
+func (md *MongoDatabase) SaveFederatedHostData(hostdata model.HostDataBE) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		ReplaceOne(context.TODO(), bson.M{"_id": hostdata.ID}, hostdata, options.Replace().SetUpsert(true))
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// DismissFederatedHostsNotIn dismiss the current hosts of the site whose names aren't in hostnames
+func (md *MongoDatabase) DismissFederatedHostsNotIn(site string, hostnames []string) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").UpdateMany(context.TODO(),
+		bson.M{
+			"site":        site,
+			"hostname":    bson.M{"$nin": hostnames},
+			"dismissedAt": nil,
+		},
+		bson.M{"$set": bson.M{
+			"dismissedAt": md.TimeNow(),
+			"archived":    true,
+		}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// ReplaceFederatedOracleDatabaseContracts replace the Oracle/Database contracts of the site
+func (md *MongoDatabase) ReplaceFederatedOracleDatabaseContracts(site string, contracts []model.OracleDatabaseContract) error {
+	docs := make([]interface{}, 0, len(contracts))
+
+	for _, contract := range contracts {
+		contract.Site = site
+		docs = append(docs, contract)
+	}
+
+	return md.replaceFederatedDocuments("oracle_database_contracts", site, docs)
+}
+
+// ReplaceFederatedAlerts replace the alerts of the site
+func (md *MongoDatabase) ReplaceFederatedAlerts(site string, alerts []model.Alert) error {
+	docs := make([]interface{}, 0, len(alerts))
+
+	for _, alert := range alerts {
+		alert.Site = site
+		if alert.ID.IsZero() {
+			alert.ID = primitive.NewObjectIDFromTimestamp(md.TimeNow())
+		}
+
+		docs = append(docs, alert)
+	}
+
+	return md.replaceFederatedDocuments("alerts", site, docs)
+}
+
+func (md *MongoDatabase) replaceFederatedDocuments(collection, site string, docs []interface{}) error {
+	coll := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection)
+
+	if _, err := coll.DeleteMany(context.TODO(), bson.M{"site": site}); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	if _, err := coll.InsertMany(context.TODO(), docs); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestFederation() {
+	defer m.db.Client.Database(m.dbname).Collection("hosts").DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection("alerts").DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection("oracle_database_contracts").DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-06T10:00:00Z") }
+
+	local := model.HostDataBE{
+		ID:       utils.Str2oid("6512f1a5ba2e2a1f4b6b8f41"),
+		Hostname: "local",
+	}
+	remote := model.HostDataBE{
+		ID:       utils.Str2oid("6512f1a5ba2e2a1f4b6b8f42"),
+		Hostname: "foobar",
+		Site:     "milan",
+	}
+	gone := model.HostDataBE{
+		ID:       utils.Str2oid("6512f1a5ba2e2a1f4b6b8f43"),
+		Hostname: "barfoo",
+		Site:     "milan",
+	}
+
+	require.NoError(m.T(), m.db.InsertHostData(local))
+	require.NoError(m.T(), m.db.InsertHostData(remote))
+	require.NoError(m.T(), m.db.InsertHostData(gone))
+
+	m.T().Run("find current hostdata of the site", func(t *testing.T) {
+		actual, err := m.db.FindCurrentFederatedHostData("milan", "foobar")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, remote.ID, actual.ID)
+
+		actual, err = m.db.FindCurrentFederatedHostData("rome", "foobar")
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+
+		actual, err = m.db.FindCurrentFederatedHostData("milan", "local")
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	m.T().Run("local dismission and lookups ignore the sites", func(t *testing.T) {
+		require.NoError(t, m.db.DismissHost("foobar"))
+
+		actual, err := m.db.FindCurrentFederatedHostData("milan", "foobar")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		latest, err := m.db.FindMostRecentHostDataOlderThan("foobar", utils.MAX_TIME)
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+	})
+
+	m.T().Run("dismiss and save again the hostdata of the site", func(t *testing.T) {
+		require.NoError(t, m.db.DismissFederatedHost("milan", "foobar"))
+
+		actual, err := m.db.FindCurrentFederatedHostData("milan", "foobar")
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+
+		require.NoError(t, m.db.SaveFederatedHostData(remote))
+
+		actual, err = m.db.FindCurrentFederatedHostData("milan", "foobar")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, remote.ID, actual.ID)
+	})
+
+	m.T().Run("dismiss hosts not in site", func(t *testing.T) {
+		require.NoError(t, m.db.DismissFederatedHostsNotIn("milan", []string{"foobar"}))
+
+		actual, err := m.db.GetCurrentHostnames()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"local", "foobar"}, actual)
+	})
+
+	m.T().Run("replace contracts and alerts", func(t *testing.T) {
+		_, err := m.db.Client.Database(m.dbname).Collection("alerts").InsertOne(context.TODO(), model.Alert{
+			ID:          utils.Str2oid("6512f1a5ba2e2a1f4b6b8f51"),
+			Description: "local alert",
+		})
+		require.NoError(t, err)
+
+		alerts := []model.Alert{{ID: utils.Str2oid("6512f1a5ba2e2a1f4b6b8f52"), Description: "old"}}
+		require.NoError(t, m.db.ReplaceFederatedAlerts("milan", alerts))
+
+		alerts = []model.Alert{{ID: utils.Str2oid("6512f1a5ba2e2a1f4b6b8f53"), Description: "new"}}
+		require.NoError(t, m.db.ReplaceFederatedAlerts("milan", alerts))
+
+		count, err := m.db.Client.Database(m.dbname).Collection("alerts").CountDocuments(context.TODO(), bson.M{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		var actual model.Alert
+		require.NoError(t, m.db.Client.Database(m.dbname).Collection("alerts").
+			FindOne(context.TODO(), bson.M{"site": "milan"}).Decode(&actual))
+		assert.Equal(t, "new", actual.Description)
+
+		contracts := []model.OracleDatabaseContract{{ID: utils.Str2oid("6512f1a5ba2e2a1f4b6b8f61"), ContractID: "AID001", Hosts: []string{"foobar"}}}
+		require.NoError(t, m.db.ReplaceFederatedOracleDatabaseContracts("milan", contracts))
+		require.NoError(t, m.db.ReplaceFederatedOracleDatabaseContracts("milan", nil))
+
+		count, err = m.db.Client.Database(m.dbname).Collection("oracle_database_contracts").CountDocuments(context.TODO(), bson.M{"site": "milan"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostIdentitiesCollection = "host_identities"
+
+// FindHostIdentityByAlias return the identity of the host with the alias
+func (md *MongoDatabase) FindHostIdentityByAlias(alias string) (*model.HostIdentity, error) {
+	var identity model.HostIdentity
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		FindOne(context.TODO(), bson.M{"aliases": alias}).Decode(&identity)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, utils.ErrHostIdentityNotFound
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &identity, nil
+}
+
+// FindHostIdentitiesByShortName return the identities with an alias with the short name, with or without domain
+func (md *MongoDatabase) FindHostIdentitiesByShortName(shortName string) ([]model.HostIdentity, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		Find(ctx, bson.M{"aliases": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(shortName) + `(\.|$)`}})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	identities := make([]model.HostIdentity, 0)
+	if err := cur.All(ctx, &identities); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return identities, nil
+}
+
+func (md *MongoDatabase) InsertHostIdentity(identity model.HostIdentity) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		InsertOne(context.TODO(), identity)
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrHostAliasConflict
+	} else if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// AddHostIdentityAlias add the alias to the identity of the host
+func (md *MongoDatabase) AddHostIdentityAlias(id string, alias string) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostIdentitiesCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{
+			"$addToSet": bson.M{"aliases": alias},
+			"$set":      bson.M{"updatedAt": md.TimeNow()},
+		})
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrHostAliasConflict
+	} else if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrHostIdentityNotFound
+	}
+
+	return nil
+}
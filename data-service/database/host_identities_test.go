@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHostIdentities() {
+	defer m.db.Client.Database(m.dbname).Collection(hostIdentitiesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-06T10:00:00Z") }
+
+	identity := model.NewHostIdentity("foobar", []string{"foobar"}, utils.P("2020-12-05T10:00:00Z"))
+	require.NoError(m.T(), m.db.InsertHostIdentity(identity))
+
+	_, err := m.db.FindHostIdentityByAlias("foobar.example.com")
+	assert.ErrorIs(m.T(), err, utils.ErrHostIdentityNotFound)
+
+	require.NoError(m.T(), m.db.AddHostIdentityAlias(identity.ID, "foobar.example.com"))
+
+	actual, err := m.db.FindHostIdentityByAlias("foobar.example.com")
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), "foobar", actual.Hostname)
+	assert.Equal(m.T(), []string{"foobar", "foobar.example.com"}, actual.Aliases)
+	assert.Equal(m.T(), utils.P("2020-12-06T10:00:00Z"), actual.UpdatedAt)
+
+	other := model.NewHostIdentity("barfoo", []string{"barfoo", "foobar"}, utils.P("2020-12-05T10:00:00Z"))
+	assert.ErrorIs(m.T(), m.db.InsertHostIdentity(other), utils.ErrHostAliasConflict)
+
+	assert.ErrorIs(m.T(), m.db.AddHostIdentityAlias("missing", "barfoo"), utils.ErrHostIdentityNotFound)
+}
+
+func (m *MongodbSuite) TestFindHostIdentitiesByShortName() {
+	defer m.db.Client.Database(m.dbname).Collection(hostIdentitiesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	prod := model.NewHostIdentity("db01.prod", []string{"db01.prod"}, utils.P("2020-12-05T10:00:00Z"))
+	test := model.NewHostIdentity("db01.test", []string{"db01.test"}, utils.P("2020-12-05T10:00:00Z"))
+	other := model.NewHostIdentity("db011", []string{"db011"}, utils.P("2020-12-05T10:00:00Z"))
+	require.NoError(m.T(), m.db.InsertHostIdentity(prod))
+	require.NoError(m.T(), m.db.InsertHostIdentity(test))
+	require.NoError(m.T(), m.db.InsertHostIdentity(other))
+
+	actual, err := m.db.FindHostIdentitiesByShortName("db01")
+	require.NoError(m.T(), err)
+	assert.ElementsMatch(m.T(), []model.HostIdentity{prod, test}, actual)
+
+	actual, err = m.db.FindHostIdentitiesByShortName("db02")
+	require.NoError(m.T(), err)
+	assert.Empty(m.T(), actual)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostdataQuarantineCollection = "hostdata_quarantine"
+
+// InsertQuarantinedHostData save an hostdata rejected by the validation
+func (md *MongoDatabase) InsertQuarantinedHostData(quarantined model.QuarantinedHostData) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		InsertOne(context.TODO(), quarantined)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// GetQuarantinedHostData return the quarantined hostdata with the id, payload included
+func (md *MongoDatabase) GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error) {
+	var quarantined model.QuarantinedHostData
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		FindOne(context.TODO(), bson.M{"_id": id}).Decode(&quarantined)
+	if err == mongo.ErrNoDocuments {
+		return nil, utils.ErrQuarantinedHostDataNotFound
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &quarantined, nil
+}
+
+// UpdateQuarantinedHostDataReplay save the errors of a replay of a quarantined hostdata
+func (md *MongoDatabase) UpdateQuarantinedHostDataReplay(id primitive.ObjectID, errors string) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		UpdateOne(context.TODO(),
+			bson.M{"_id": id},
+			bson.M{
+				"$set": bson.M{
+					"errors":       errors,
+					"lastReplayAt": md.TimeNow(),
+				},
+				"$inc": bson.M{"replays": 1},
+			},
+		)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrQuarantinedHostDataNotFound
+	}
+
+	return nil
+}
+
+// DeleteQuarantinedHostData remove a quarantined hostdata
+func (md *MongoDatabase) DeleteQuarantinedHostData(id primitive.ObjectID) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.DeletedCount != 1 {
+		return utils.ErrQuarantinedHostDataNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHostDataQuarantine() {
+	defer m.db.Client.Database(m.dbname).Collection(hostdataQuarantineCollection).DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-06T10:00:00Z") }
+
+	first := model.QuarantinedHostData{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f31"),
+		Hostname:      "foobar",
+		Location:      "Italy",
+		Environment:   "PRD",
+		SchemaVersion: 1,
+		Errors:        "invalid hostdata: hostname is required",
+		Payload:       []byte(`{"hostname":"foobar"}`),
+		ReceivedAt:    utils.P("2020-12-05T14:00:00Z"),
+	}
+	second := model.QuarantinedHostData{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f32"),
+		Hostname:      "barfoo",
+		SchemaVersion: 1,
+		Errors:        "invalid hostdata: location is required",
+		Payload:       []byte(`{"hostname":"barfoo"}`),
+		ReceivedAt:    utils.P("2020-12-05T15:00:00Z"),
+	}
+
+	require.NoError(m.T(), m.db.InsertQuarantinedHostData(first))
+	require.NoError(m.T(), m.db.InsertQuarantinedHostData(second))
+
+	m.T().Run("get and replay", func(t *testing.T) {
+		require.NoError(t, m.db.UpdateQuarantinedHostDataReplay(first.ID, "invalid hostdata: tags is required"))
+
+		actual, err := m.db.GetQuarantinedHostData(first.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Hostname, actual.Hostname)
+		assert.Equal(t, first.Payload, actual.Payload)
+		assert.Equal(t, "invalid hostdata: tags is required", actual.Errors)
+		assert.Equal(t, 1, actual.Replays)
+		require.NotNil(t, actual.LastReplayAt)
+		assert.Equal(t, utils.P("2020-12-06T10:00:00Z"), *actual.LastReplayAt)
+	})
+
+	m.T().Run("delete", func(t *testing.T) {
+		require.NoError(t, m.db.DeleteQuarantinedHostData(second.ID))
+
+		_, err := m.db.GetQuarantinedHostData(second.ID)
+		assert.ErrorIs(t, err, utils.ErrQuarantinedHostDataNotFound)
+
+		err = m.db.DeleteQuarantinedHostData(second.ID)
+		assert.ErrorIs(t, err, utils.ErrQuarantinedHostDataNotFound)
+
+		err = m.db.UpdateQuarantinedHostDataReplay(second.ID, "")
+		assert.ErrorIs(t, err, utils.ErrQuarantinedHostDataNotFound)
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostdataSnapshotsCollection = "hostdata_snapshots"
+
+// RetainCurrentHostData keep the current hostdata at least until the time, return the number of hostdata retained
+func (md *MongoDatabase) RetainCurrentHostData(until time.Time) (int64, error) {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		UpdateMany(context.TODO(),
+			bson.M{
+				"archived":    false,
+				"dismissedAt": nil,
+			},
+			bson.M{
+				"$max": bson.M{"retainedUntil": until},
+			})
+	if err != nil {
+		return 0, utils.NewError(err, "DB ERROR")
+	}
+
+	return res.MatchedCount, nil
+}
+
+func (md *MongoDatabase) InsertHostDataSnapshot(snapshot model.HostDataSnapshot) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataSnapshotsCollection).
+		InsertOne(context.TODO(), snapshot)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
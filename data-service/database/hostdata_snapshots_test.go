@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/mongoutils"
+)
+
+func (m *MongodbSuite) TestRetainCurrentHostData() {
+	defer m.db.Client.Database(m.dbname).Collection("hosts").DeleteMany(context.TODO(), bson.M{})
+	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_01.json"))
+	m.InsertHostData(mongoutils.LoadFixtureMongoHostDataMap(m.T(), "../../fixture/test_apiservice_mongohostdata_04.json"))
+
+	retained, err := m.db.RetainCurrentHostData(utils.P("2021-01-01T00:00:00Z"))
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), int64(1), retained)
+
+	err = m.db.DismissHost("test-small2")
+	require.NoError(m.T(), err)
+
+	m.T().Run("retained", func(t *testing.T) {
+		list, err := m.db.FindOldArchivedHosts(utils.P("2020-06-01T00:00:00Z"))
+		require.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{utils.Str2oid("5ea2d15320d55cbdc35022b1")}, list)
+	})
+
+	m.T().Run("retention_expired", func(t *testing.T) {
+		list, err := m.db.FindOldArchivedHosts(utils.P("2021-06-01T00:00:00Z"))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []primitive.ObjectID{utils.Str2oid("5ea2d15320d55cbdc35022b1"), utils.Str2oid("5ea2d3c520d55cbdc35022b5")}, list)
+	})
+}
+
+func (m *MongodbSuite) TestInsertHostDataSnapshot() {
+	defer m.db.Client.Database(m.dbname).Collection(hostdataSnapshotsCollection).DeleteMany(context.TODO(), bson.M{})
+
+	snapshot := model.HostDataSnapshot{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f31"),
+		Kind:          model.HostDataSnapshotKindQuarterly,
+		Date:          utils.P("2020-04-01T00:00:00Z"),
+		Hosts:         2,
+		RetainedUntil: utils.P("2023-04-01T00:00:00Z"),
+	}
+
+	err := m.db.InsertHostDataSnapshot(snapshot)
+	require.NoError(m.T(), err)
+
+	var actual model.HostDataSnapshot
+	err = m.db.Client.Database(m.dbname).Collection(hostdataSnapshotsCollection).
+		FindOne(context.TODO(), bson.M{"_id": snapshot.ID}).Decode(&actual)
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), snapshot, actual)
+}
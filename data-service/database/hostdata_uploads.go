@@ -0,0 +1,207 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	hostdataUploadsCollection      = "hostdata_uploads"
+	hostdataUploadLeasesCollection = "hostdata_upload_leases"
+)
+
+// InsertHostDataUpload insert an upload in the queue
+func (md *MongoDatabase) InsertHostDataUpload(upload model.HostDataUpload) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+		InsertOne(context.TODO(), upload)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// GetHostDataUpload return the upload with the id
+func (md *MongoDatabase) GetHostDataUpload(id primitive.ObjectID) (*model.HostDataUpload, error) {
+	var upload model.HostDataUpload
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+		FindOne(context.TODO(), bson.M{"_id": id}).Decode(&upload)
+	if err == mongo.ErrNoDocuments {
+		return nil, utils.ErrHostDataUploadNotFound
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &upload, nil
+}
+
+// ClaimHostDataUpload mark the oldest queued upload of the hosts not excluded as processing and return it,
+// nil if there isn't any. The upload is claimed only with the lease of its host, so the uploads of a host
+// are processed one at a time also by different instances of the data-service
+func (md *MongoDatabase) ClaimHostDataUpload(excludedHostnames []string) (*model.HostDataUpload, error) {
+	excluded := append([]string{}, excludedHostnames...)
+
+	for {
+		var queued model.HostDataUpload
+
+		filter := bson.M{"status": model.HostDataUploadStatusQueued}
+		if len(excluded) > 0 {
+			filter["hostname"] = bson.M{"$nin": excluded}
+		}
+
+		err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+			FindOne(context.TODO(), filter,
+				options.FindOne().SetSort(bson.D{{Key: "receivedAt", Value: 1}, {Key: "_id", Value: 1}}),
+			).Decode(&queued)
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		} else if err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+
+		_, err = md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadLeasesCollection).
+			InsertOne(context.TODO(), bson.M{
+				"_id":       queued.Hostname,
+				"uploadID":  queued.ID,
+				"startedAt": md.TimeNow(),
+			})
+		if mongo.IsDuplicateKeyError(err) {
+			// an upload of the host is processing
+			excluded = append(excluded, queued.Hostname)
+			continue
+		} else if err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+
+		var upload model.HostDataUpload
+
+		err = md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+			FindOneAndUpdate(context.TODO(),
+				bson.M{"_id": queued.ID, "status": model.HostDataUploadStatusQueued},
+				bson.M{
+					"$set": bson.M{
+						"status":    model.HostDataUploadStatusProcessing,
+						"startedAt": md.TimeNow(),
+					},
+					"$inc": bson.M{"attempts": 1},
+				},
+				options.FindOneAndUpdate().SetReturnDocument(options.After),
+			).Decode(&upload)
+		if err == mongo.ErrNoDocuments {
+			// the upload has been processed between the find and the lease
+			if err := md.releaseHostDataUploadLease(queued.ID); err != nil {
+				return nil, err
+			}
+
+			continue
+		} else if err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+
+		return &upload, nil
+	}
+}
+
+func (md *MongoDatabase) releaseHostDataUploadLease(uploadID primitive.ObjectID) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadLeasesCollection).
+		DeleteOne(context.TODO(), bson.M{"uploadID": uploadID})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// UpdateHostDataUploadStatus set the outcome of the processing of an upload.
+// The payload of the completed uploads is removed
+func (md *MongoDatabase) UpdateHostDataUploadStatus(id primitive.ObjectID, status string, errMsg string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status": status,
+			"error":  errMsg,
+		},
+	}
+
+	if status == model.HostDataUploadStatusDone || status == model.HostDataUploadStatusFailed {
+		update["$set"].(bson.M)["completedAt"] = md.TimeNow()
+	}
+
+	if status == model.HostDataUploadStatusDone {
+		update["$unset"] = bson.M{"payload": ""}
+	}
+
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, update)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrHostDataUploadNotFound
+	}
+
+	return md.releaseHostDataUploadLease(id)
+}
+
+// RequeueStaleHostDataUploads queue again the uploads which are processing since before t
+// and release the leases of their hosts, e.g. because the data-service was stopped while processing them
+func (md *MongoDatabase) RequeueStaleHostDataUploads(t time.Time) (int64, error) {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+		UpdateMany(context.TODO(),
+			bson.M{
+				"status":    model.HostDataUploadStatusProcessing,
+				"startedAt": bson.M{"$lt": t},
+			},
+			bson.M{"$set": bson.M{"status": model.HostDataUploadStatusQueued}},
+		)
+	if err != nil {
+		return 0, utils.NewError(err, "DB ERROR")
+	}
+
+	_, err = md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadLeasesCollection).
+		DeleteMany(context.TODO(), bson.M{"startedAt": bson.M{"$lt": t}})
+	if err != nil {
+		return 0, utils.NewError(err, "DB ERROR")
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// AddHostDataUploadThrownAlert save the key of an alert thrown while processing the upload
+func (md *MongoDatabase) AddHostDataUploadThrownAlert(id primitive.ObjectID, alertKey string) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataUploadsCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$addToSet": bson.M{"thrownAlerts": alertKey}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrHostDataUploadNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHostDataUploads() {
+	defer m.db.Client.Database(m.dbname).Collection(hostdataUploadsCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(hostdataUploadLeasesCollection).DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-05T14:02:03Z") }
+
+	first := model.HostDataUpload{
+		ID:         utils.Str2oid("6512f1a5ba2e2a1f4b6b8f21"),
+		Hostname:   "foobar",
+		Status:     model.HostDataUploadStatusQueued,
+		Payload:    []byte(`{"hostname":"foobar"}`),
+		ReceivedAt: utils.P("2020-12-05T14:00:00Z"),
+	}
+	second := model.HostDataUpload{
+		ID:         utils.Str2oid("6512f1a5ba2e2a1f4b6b8f22"),
+		Hostname:   "barfoo",
+		Status:     model.HostDataUploadStatusQueued,
+		Payload:    []byte(`{"hostname":"barfoo"}`),
+		ReceivedAt: utils.P("2020-12-05T14:01:00Z"),
+	}
+
+	require.NoError(m.T(), m.db.InsertHostDataUpload(second))
+	require.NoError(m.T(), m.db.InsertHostDataUpload(first))
+
+	m.T().Run("claim the oldest", func(t *testing.T) {
+		upload, err := m.db.ClaimHostDataUpload([]string{"barfoo"})
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, upload.ID)
+
+		err = m.db.UpdateHostDataUploadStatus(first.ID, model.HostDataUploadStatusQueued, "")
+		require.NoError(t, err)
+
+		upload, err = m.db.ClaimHostDataUpload(nil)
+		require.NoError(t, err)
+
+		startedAt := utils.P("2020-12-05T14:02:03Z")
+		expected := first
+		expected.Status = model.HostDataUploadStatusProcessing
+		expected.Attempts = 2
+		expected.StartedAt = &startedAt
+		assert.Equal(t, &expected, upload)
+	})
+
+	m.T().Run("done", func(t *testing.T) {
+		err := m.db.UpdateHostDataUploadStatus(first.ID, model.HostDataUploadStatusDone, "")
+		require.NoError(t, err)
+
+		upload, err := m.db.GetHostDataUpload(first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.HostDataUploadStatusDone, upload.Status)
+		assert.Nil(t, upload.Payload)
+		assert.Equal(t, utils.P("2020-12-05T14:02:03Z"), *upload.CompletedAt)
+	})
+
+	m.T().Run("requeue stale", func(t *testing.T) {
+		upload, err := m.db.ClaimHostDataUpload(nil)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, upload.ID)
+
+		upload, err = m.db.ClaimHostDataUpload(nil)
+		require.NoError(t, err)
+		assert.Nil(t, upload)
+
+		count, err := m.db.RequeueStaleHostDataUploads(utils.P("2020-12-05T14:10:00Z"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		upload, err = m.db.ClaimHostDataUpload(nil)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, upload.ID)
+		assert.Equal(t, 2, upload.Attempts)
+
+		upload, err = m.db.ClaimHostDataUpload([]string{"foobar"})
+		require.NoError(t, err)
+		assert.Nil(t, upload)
+	})
+
+	m.T().Run("one upload at a time per host", func(t *testing.T) {
+		third := model.HostDataUpload{
+			ID:         utils.Str2oid("6512f1a5ba2e2a1f4b6b8f23"),
+			Hostname:   "barfoo",
+			Status:     model.HostDataUploadStatusQueued,
+			Payload:    []byte(`{"hostname":"barfoo"}`),
+			ReceivedAt: utils.P("2020-12-05T14:03:00Z"),
+		}
+		require.NoError(t, m.db.InsertHostDataUpload(third))
+
+		// the second upload of barfoo is still processing, e.g. by another instance
+		upload, err := m.db.ClaimHostDataUpload(nil)
+		require.NoError(t, err)
+		assert.Nil(t, upload)
+
+		require.NoError(t, m.db.UpdateHostDataUploadStatus(second.ID, model.HostDataUploadStatusFailed, "error"))
+
+		upload, err = m.db.ClaimHostDataUpload(nil)
+		require.NoError(t, err)
+		assert.Equal(t, third.ID, upload.ID)
+	})
+
+	m.T().Run("thrown alerts", func(t *testing.T) {
+		require.NoError(t, m.db.AddHostDataUploadThrownAlert(second.ID, "NEW_SERVER/foo"))
+		require.NoError(t, m.db.AddHostDataUploadThrownAlert(second.ID, "NEW_SERVER/foo"))
+		require.NoError(t, m.db.AddHostDataUploadThrownAlert(second.ID, "AGENT_ERROR/bar"))
+
+		upload, err := m.db.GetHostDataUpload(second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NEW_SERVER/foo", "AGENT_ERROR/bar"}, upload.ThrownAlerts)
+	})
+
+	m.T().Run("not found", func(t *testing.T) {
+		_, err := m.db.GetHostDataUpload(utils.Str2oid("6512f1a5ba2e2a1f4b6b8f29"))
+		assert.Equal(t, utils.ErrHostDataUploadNotFound, err)
+
+		err = m.db.UpdateHostDataUploadStatus(utils.Str2oid("6512f1a5ba2e2a1f4b6b8f29"), model.HostDataUploadStatusDone, "")
+		assert.Equal(t, utils.ErrHostDataUploadNotFound, err)
+
+		err = m.db.AddHostDataUploadThrownAlert(utils.Str2oid("6512f1a5ba2e2a1f4b6b8f29"), "NEW_SERVER/foo")
+		assert.Equal(t, utils.ErrHostDataUploadNotFound, err)
+	})
+}
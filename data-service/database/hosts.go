@@ -22,14 +22,18 @@ import (
 	"github.com/amreo/mu"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
+// DismissHost dismiss the current hostdata of the local host, the hosts of the federated sites
+// are dismissed only by the pull of their site
 func (md *MongoDatabase) DismissHost(hostname string) error {
 	if _, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").UpdateOne(context.TODO(), bson.M{
 		"hostname":    hostname,
+		"site":        nil,
 		"dismissedAt": nil,
 	}, mu.UOSet(bson.M{
 		"dismissedAt": time.Now(),
@@ -70,12 +74,13 @@ func (md *MongoDatabase) GetCurrentHostnames() ([]string, error) {
 	return hosts, nil
 }
 
-// FindOldCurrentHostnames return the list of current hosts that haven't sent hostdata after time t
+// FindOldCurrentHostnames return the list of current local hosts that haven't sent hostdata after time t
 func (md *MongoDatabase) FindOldCurrentHostnames(t time.Time) ([]string, error) {
 	values, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Distinct(
 		context.TODO(),
 		"hostname",
 		bson.M{
+			"site":        nil,
 			"dismissedAt": nil,
 			"archived":    false,
 			"createdAt":   mu.QOLessThan(t),
@@ -92,10 +97,11 @@ func (md *MongoDatabase) FindOldCurrentHostnames(t time.Time) ([]string, error)
 	return hosts, nil
 }
 
-// FindOldCurrentHosts return the list of current hosts that haven't sent hostdata after time t
+// FindOldCurrentHosts return the list of current local hosts that haven't sent hostdata after time t
 func (md *MongoDatabase) FindOldCurrentHostdata(hostName string, t time.Time) (bool, error) {
 	filter := bson.M{
 		"hostname":    hostName,
+		"site":        nil,
 		"dismissedAt": nil,
 		"archived":    false,
 		"createdAt":   mu.QOLessThan(t),
@@ -144,7 +150,74 @@ func (md *MongoDatabase) GetActiveHostdata() ([]model.HostDataBE, error) {
 	return hosts, nil
 }
 
-// FindOldArchivedHosts return the list of archived hosts older than t
+// UpdateHostDataLicenses update the licenses of the databases of the current hostdata
+func (md *MongoDatabase) UpdateHostDataLicenses(hostdata model.HostDataBE) error {
+	set := bson.M{}
+
+	if hostdata.Features.Oracle != nil && hostdata.Features.Oracle.Database != nil {
+		set["features.oracle.database.databases"] = hostdata.Features.Oracle.Database.Databases
+	}
+
+	if hostdata.Features.Microsoft != nil && hostdata.Features.Microsoft.SQLServer != nil {
+		set["features.microsoft.sqlServer.instances"] = hostdata.Features.Microsoft.SQLServer.Instances
+	}
+
+	if hostdata.Features.MySQL != nil {
+		set["features.mysql.instances"] = hostdata.Features.MySQL.Instances
+	}
+
+	if len(set) == 0 {
+		return nil
+	}
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		UpdateOne(context.TODO(), bson.M{"_id": hostdata.ID, "archived": false}, bson.M{"$set": set})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// IterateHostDataCreatedBetween call fn for each hostdata, current or archived, created between from and to,
+// from the oldest to the most recent
+func (md *MongoDatabase) IterateHostDataCreatedBetween(from, to time.Time, fn func(hostdata model.HostDataBE) error) error {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		Find(context.TODO(),
+			bson.M{
+				"createdAt": bson.M{
+					"$gte": from,
+					"$lt":  to,
+				},
+			},
+			options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}),
+		)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+	defer cur.Close(context.TODO())
+
+	for cur.Next(context.TODO()) {
+		var hostdata model.HostDataBE
+
+		if err := cur.Decode(&hostdata); err != nil {
+			return utils.NewError(err, "Decode ERROR")
+		}
+
+		if err := fn(hostdata); err != nil {
+			return err
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// FindOldArchivedHosts return the list of archived hosts older than t.
+// The hosts retained by a snapshot are returned only when the retention has expired before t
 func (md *MongoDatabase) FindOldArchivedHosts(t time.Time) ([]primitive.ObjectID, error) {
 	values, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Distinct(
 		context.TODO(),
@@ -152,6 +225,10 @@ func (md *MongoDatabase) FindOldArchivedHosts(t time.Time) ([]primitive.ObjectID
 		bson.M{
 			"archived":  true,
 			"createdAt": mu.QOLessThan(t),
+			"$or": bson.A{
+				bson.M{"retainedUntil": nil},
+				bson.M{"retainedUntil": mu.QOLessThan(t)},
+			},
 		})
 	if err != nil {
 		return nil, utils.NewError(err, "DB ERROR")
@@ -178,7 +255,7 @@ func (md *MongoDatabase) DeleteHostData(id primitive.ObjectID) error {
 	return nil
 }
 
-// FindMostRecentHostDataOlderThan return the most recest hostdata that is older than t
+// FindMostRecentHostDataOlderThan return the most recest hostdata of the local host that is older than t
 func (md *MongoDatabase) FindMostRecentHostDataOlderThan(hostname string, t time.Time) (*model.HostDataBE, error) {
 	var out model.HostDataBE
 
@@ -188,6 +265,7 @@ func (md *MongoDatabase) FindMostRecentHostDataOlderThan(hostname string, t time
 		mu.MAPipeline(
 			mu.APMatch(bson.M{
 				"hostname":  hostname,
+				"site":      nil,
 				"createdAt": mu.QOLessThan(t),
 			}),
 			mu.APSort(bson.M{
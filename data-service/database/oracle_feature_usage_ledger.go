@@ -0,0 +1,77 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const oracleFeatureUsageLedgerCollection = "oracle_feature_usage_ledger"
+
+// UpdateOracleFeatureUsageLedger record the detections of the entries in the ledger,
+// keeping the first detection of each host, database and feature and updating the last one
+func (md *MongoDatabase) UpdateOracleFeatureUsageLedger(entries []model.OracleFeatureUsageLedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(entries))
+
+	for _, entry := range entries {
+		filter := bson.M{
+			"hostname": entry.Hostname,
+			"dbName":   entry.DbName,
+			"product":  entry.Product,
+			"feature":  entry.Feature,
+		}
+
+		update := bson.M{
+			"$setOnInsert": bson.M{
+				"firstDetected": entry.FirstDetected,
+				"firstUploadID": entry.FirstUploadID,
+				"firstEvidence": entry.FirstEvidence,
+			},
+			"$set": bson.M{
+				"lastDetected":   entry.LastDetected,
+				"lastUploadID":   entry.LastUploadID,
+				"detectedUsages": entry.DetectedUsages,
+				"currentlyUsed":  entry.CurrentlyUsed,
+				"lastUsageDate":  entry.LastUsageDate,
+				"lastEvidence":   entry.LastEvidence,
+			},
+			"$min": bson.M{
+				"firstUsageDate": entry.FirstUsageDate,
+			},
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleFeatureUsageLedgerCollection).
+		BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestUpdateOracleFeatureUsageLedger() {
+	defer m.db.Client.Database(m.dbname).Collection(oracleFeatureUsageLedgerCollection).DeleteMany(context.TODO(), bson.M{})
+
+	first := model.OracleFeatureUsageLedgerEntry{
+		Hostname:       "foobar",
+		DbName:         "ERCOLE",
+		Product:        "Diagnostics Pack",
+		Feature:        "AWR Report",
+		FirstDetected:  utils.P("2020-12-05T14:02:03Z"),
+		FirstUploadID:  utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		LastDetected:   utils.P("2020-12-05T14:02:03Z"),
+		LastUploadID:   utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		DetectedUsages: 3,
+		CurrentlyUsed:  true,
+		FirstUsageDate: utils.P("2020-11-01T10:00:00Z"),
+		LastUsageDate:  utils.P("2020-12-01T10:00:00Z"),
+		FirstEvidence: model.OracleFeatureUsageEvidence{
+			UploadID:       utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+			UploadedAt:     utils.P("2020-12-05T14:02:03Z"),
+			DetectedUsages: 3,
+		},
+	}
+	first.LastEvidence = first.FirstEvidence
+	require.NoError(m.T(), m.db.UpdateOracleFeatureUsageLedger([]model.OracleFeatureUsageLedgerEntry{first}))
+
+	second := first
+	second.FirstDetected = utils.P("2020-12-06T14:02:03Z")
+	second.FirstUploadID = utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb")
+	second.LastDetected = utils.P("2020-12-06T14:02:03Z")
+	second.LastUploadID = utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb")
+	second.DetectedUsages = 5
+	second.LastUsageDate = utils.P("2020-12-06T10:00:00Z")
+	second.FirstEvidence = model.OracleFeatureUsageEvidence{
+		UploadID:       utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+		UploadedAt:     utils.P("2020-12-06T14:02:03Z"),
+		DetectedUsages: 5,
+	}
+	second.LastEvidence = second.FirstEvidence
+	require.NoError(m.T(), m.db.UpdateOracleFeatureUsageLedger([]model.OracleFeatureUsageLedgerEntry{second}))
+
+	var actual []model.OracleFeatureUsageLedgerEntry
+
+	cur, err := m.db.Client.Database(m.dbname).Collection(oracleFeatureUsageLedgerCollection).Find(context.TODO(), bson.M{})
+	require.NoError(m.T(), err)
+	require.NoError(m.T(), cur.All(context.TODO(), &actual))
+
+	expected := first
+	expected.LastDetected = second.LastDetected
+	expected.LastUploadID = second.LastUploadID
+	expected.DetectedUsages = 5
+	expected.LastUsageDate = second.LastUsageDate
+	expected.LastEvidence = second.LastEvidence
+
+	require.Len(m.T(), actual, 1)
+	assert.Equal(m.T(), expected, actual[0])
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const usedLicensesHistoryCollection = "used_licenses_history"
+
+// HistoricizeUsedLicenses replace the snapshot of the used licenses of the current day,
+// storing a document for each host, database and license type
+func (md *MongoDatabase) HistoricizeUsedLicenses(usedLicenses []dto.DatabaseUsedLicense) error {
+	now := md.TimeNow()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	collection := md.Client.Database(md.Config.Mongodb.DBName).Collection(usedLicensesHistoryCollection)
+
+	if _, err := collection.DeleteMany(context.TODO(), bson.M{"date": today}); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if len(usedLicenses) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(usedLicenses))
+
+	for _, usedLicense := range usedLicenses {
+		documents = append(documents, bson.M{
+			"date":          today,
+			"hostname":      usedLicense.Hostname,
+			"dbName":        usedLicense.DbName,
+			"clusterName":   usedLicense.ClusterName,
+			"licenseTypeID": usedLicense.LicenseTypeID,
+			"description":   usedLicense.Description,
+			"metric":        usedLicense.Metric,
+			"usedLicenses":  usedLicense.UsedLicenses,
+			"ignored":       usedLicense.Ignored,
+		})
+	}
+
+	if _, err := collection.InsertMany(context.TODO(), documents); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
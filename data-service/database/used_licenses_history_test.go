@@ -0,0 +1,55 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHistoricizeUsedLicenses() {
+	defer m.db.Client.Database(m.dbname).Collection(usedLicensesHistoryCollection).DeleteMany(context.TODO(), bson.M{})
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-05T14:02:03Z") }
+
+	usedLicenses := []dto.DatabaseUsedLicense{
+		{Hostname: "foobar", DbName: "ERCOLE", LicenseTypeID: "A90611", Metric: "Processor Perpetual", UsedLicenses: 2},
+		{Hostname: "foobar", DbName: "ERCOLE", LicenseTypeID: "A90620", Metric: "Processor Perpetual", UsedLicenses: 2},
+	}
+
+	require.NoError(m.T(), m.db.HistoricizeUsedLicenses(usedLicenses))
+
+	usedLicenses[0].UsedLicenses = 4
+	require.NoError(m.T(), m.db.HistoricizeUsedLicenses(usedLicenses[:1]))
+
+	var actual []map[string]interface{}
+
+	cur, err := m.db.Client.Database(m.dbname).Collection(usedLicensesHistoryCollection).
+		Find(context.TODO(), bson.M{"date": utils.P("2020-12-05T00:00:00Z")})
+	require.NoError(m.T(), err)
+	require.NoError(m.T(), cur.All(context.TODO(), &actual))
+
+	require.Len(m.T(), actual, 1)
+	assert.Equal(m.T(), "A90611", actual[0]["licenseTypeID"])
+	assert.Equal(m.T(), float64(4), actual[0]["usedLicenses"])
+}
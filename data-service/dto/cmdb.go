@@ -19,3 +19,11 @@ type CmdbInfo struct {
 	Name      string   `json:"name"`
 	Hostnames []string `json:"hostnames"`
 }
+
+// CmdbHost contains the attributes of a host registered in a CMDB
+type CmdbHost struct {
+	Hostname    string `json:"hostname"`
+	Environment string `json:"environment"`
+	Location    string `json:"location"`
+	Owner       string `json:"owner"`
+}
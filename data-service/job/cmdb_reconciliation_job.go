@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/cmdb"
+	"github.com/ercole-io/ercole/v2/data-service/service"
+	"github.com/ercole-io/ercole/v2/logger"
+)
+
+// CmdbReconciliationJob pull the hosts from the CMDBs and reconcile them with the current hosts
+type CmdbReconciliationJob struct {
+	// Service contains the service layer
+	Service service.HostDataServiceInterface
+	// Config contains the dataservice global configuration
+	Config config.Configuration
+	// Log contains logger formatted
+	Log logger.Logger
+	// NewConnector return the connector of the CMDB
+	NewConnector func(conf config.CmdbConnector) (cmdb.Connector, error)
+}
+
+// Run reconcile each CMDB, the errors of a CMDB don't stop the reconciliation of the others
+func (job *CmdbReconciliationJob) Run() {
+	for _, conf := range job.Config.DataService.CmdbReconciliationJob.Connectors {
+		if err := job.reconcile(conf); err != nil {
+			job.Log.Errorf("Can't reconcile the CMDB %s: %s", conf.Name, err)
+			continue
+		}
+
+		job.Log.Infof("Reconciled the CMDB %s", conf.Name)
+	}
+}
+
+func (job *CmdbReconciliationJob) reconcile(conf config.CmdbConnector) error {
+	connector, err := job.NewConnector(conf)
+	if err != nil {
+		return err
+	}
+
+	hosts, err := connector.Hosts()
+	if err != nil {
+		return err
+	}
+
+	// an empty CMDB is more likely a wrong filter than a CMDB without hosts, so all the hosts aren't reported as missing
+	if len(hosts) == 0 {
+		job.Log.Warnf("The CMDB %s returned no hosts, it isn't reconciled", conf.Name)
+		return nil
+	}
+
+	return job.Service.ReconcileCmdb(conf.Name, hosts)
+}
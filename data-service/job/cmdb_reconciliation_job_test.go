@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/cmdb"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+)
+
+func TestCmdbReconciliationJobRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	svc := NewMockHostDataServiceInterface(mockCtrl)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hosts.csv":
+			_, _ = w.Write([]byte("hostname,environment,location\npippo,PRD,Italy\npluto,TST,Germany\n"))
+		case "/empty.csv":
+			_, _ = w.Write([]byte("hostname,environment,location\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	job := CmdbReconciliationJob{
+		Service: svc,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				CmdbReconciliationJob: config.CmdbReconciliationJob{
+					Connectors: []config.CmdbConnector{
+						{Name: "unknown", Type: "excel"},
+						{Name: "missing", Type: cmdb.TypeCSV, URL: server.URL + "/missing.csv"},
+						{Name: "empty", Type: cmdb.TypeCSV, URL: server.URL + "/empty.csv"},
+						{Name: "inventory", Type: cmdb.TypeCSV, URL: server.URL + "/hosts.csv"},
+					},
+				},
+			},
+		},
+		Log:          logger.NewLogger("TEST"),
+		NewConnector: cmdb.NewConnector,
+	}
+
+	svc.EXPECT().ReconcileCmdb("inventory", []dto.CmdbHost{
+		{Hostname: "pippo", Environment: "PRD", Location: "Italy"},
+		{Hostname: "pluto", Environment: "TST", Location: "Germany"},
+	}).Return(nil)
+
+	job.Run()
+}
@@ -23,6 +23,8 @@ import (
 
 //go:generate mockgen -source ../database/database.go -destination=fake_database_test.go -package=job
 //go:generate mockgen -source ../../alert-service/client/client.go -destination=fake_alert_service_client_test.go -package=job
+//go:generate mockgen -source ../../api-service/client/client.go -destination=fake_api_service_client_test.go -package=job
+//go:generate mockgen -source ../service/service.go -destination=fake_service_test.go -package=job
 
 var errMock error = errors.New("MockError")
 var aerrMock error = utils.NewError(errMock, "mock")
@@ -0,0 +1,120 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"fmt"
+	"time"
+
+	apiservice_client "github.com/ercole-io/ercole/v2/api-service/client"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// FederationJob pull the current hostdata, the contracts and the alerts of the federated sites
+type FederationJob struct {
+	// Database contains the database layer
+	Database database.MongoDatabaseInterface
+	// TimeNow contains a function that return the current time
+	TimeNow func() time.Time
+	// Config contains the dataservice global configuration
+	Config config.Configuration
+	// Log contains logger formatted
+	Log logger.Logger
+	// NewClient return the client of the api-service of the site
+	NewClient func(site config.FederatedSite) apiservice_client.ApiSvcClientInterface
+}
+
+// Run pull the data of each site, the errors of a site don't stop the pull of the others
+func (job *FederationJob) Run() {
+	for _, site := range job.Config.Federation.Sites {
+		if err := job.pullSite(site); err != nil {
+			job.Log.Errorf("Can't pull the data of the site %s: %s", site.Name, err)
+			continue
+		}
+
+		job.Log.Infof("Pulled the data of the site %s", site.Name)
+	}
+}
+
+func (job *FederationJob) pullSite(site config.FederatedSite) error {
+	client := job.NewClient(site)
+
+	hostnames, err := client.GetHostnames()
+	if err != nil {
+		return err
+	}
+
+	for _, hostname := range hostnames {
+		if err := job.pullHost(client, site, hostname); err != nil {
+			job.Log.Errorf("Can't pull the host %s of the site %s: %s", hostname, site.Name, err)
+		}
+	}
+
+	if err := job.Database.DismissFederatedHostsNotIn(site.Name, hostnames); err != nil {
+		return err
+	}
+
+	contracts, err := client.GetOracleDatabaseContracts()
+	if err != nil {
+		return err
+	}
+
+	if err := job.Database.ReplaceFederatedOracleDatabaseContracts(site.Name, contracts); err != nil {
+		return err
+	}
+
+	alerts, err := client.GetAlerts(model.AlertStatusNew)
+	if err != nil {
+		return err
+	}
+
+	return job.Database.ReplaceFederatedAlerts(site.Name, alerts)
+}
+
+// pullHost save the current hostdata of the host, if it wasn't already pulled. Only the hosts of the site
+// are looked up and dismissed, so the local hosts and the ones of the other sites with the same name are kept
+func (job *FederationJob) pullHost(client apiservice_client.ApiSvcClientInterface, site config.FederatedSite, hostname string) error {
+	hostdata, err := client.GetMongoHostData(hostname)
+	if err != nil {
+		return err
+	}
+
+	if hostdata.Hostname != hostname {
+		return fmt.Errorf("received the hostdata of %q", hostdata.Hostname)
+	}
+
+	current, err := job.Database.FindCurrentFederatedHostData(site.Name, hostname)
+	if err != nil {
+		return err
+	}
+
+	if current != nil && current.ID == hostdata.ID {
+		return nil
+	}
+
+	hostdata.Site = site.Name
+	hostdata.Archived = false
+	hostdata.DismissedAt = time.Time{}
+
+	if err := job.Database.DismissFederatedHost(site.Name, hostname); err != nil {
+		return err
+	}
+
+	return job.Database.SaveFederatedHostData(*hostdata)
+}
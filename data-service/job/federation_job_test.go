@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+
+	apiservice_client "github.com/ercole-io/ercole/v2/api-service/client"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newFederationJob(db *MockMongoDatabaseInterface, clients map[string]*MockApiSvcClientInterface) FederationJob {
+	sites := make([]config.FederatedSite, 0)
+	for _, name := range []string{"milan", "rome"} {
+		if _, ok := clients[name]; ok {
+			sites = append(sites, config.FederatedSite{Name: name})
+		}
+	}
+
+	return FederationJob{
+		TimeNow:  utils.Btc(utils.P("2020-12-06T10:00:00Z")),
+		Database: db,
+		Config: config.Configuration{
+			Federation: config.Federation{Sites: sites},
+		},
+		Log: logger.NewLogger("TEST"),
+		NewClient: func(site config.FederatedSite) apiservice_client.ApiSvcClientInterface {
+			return clients[site.Name]
+		},
+	}
+}
+
+func TestFederationJobRun_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	client := NewMockApiSvcClientInterface(mockCtrl)
+	job := newFederationJob(db, map[string]*MockApiSvcClientInterface{"milan": client})
+
+	pulled := model.HostDataBE{
+		ID:          utils.Str2oid("6512f1a5ba2e2a1f4b6b8f42"),
+		Hostname:    "foobar",
+		DismissedAt: utils.P("2020-12-05T10:00:00Z"),
+		Archived:    true,
+	}
+	alreadyPulled := model.HostDataBE{
+		ID:       utils.Str2oid("6512f1a5ba2e2a1f4b6b8f43"),
+		Hostname: "barfoo",
+	}
+	contracts := []model.OracleDatabaseContract{{ContractID: "AID001"}}
+	alerts := []model.Alert{{Description: "foobar"}}
+
+	gomock.InOrder(
+		client.EXPECT().GetHostnames().Return([]string{"foobar", "barfoo"}, nil),
+		client.EXPECT().GetMongoHostData("foobar").Return(&pulled, nil),
+		db.EXPECT().FindCurrentFederatedHostData("milan", "foobar").Return(nil, nil),
+		db.EXPECT().DismissFederatedHost("milan", "foobar").Return(nil),
+		db.EXPECT().SaveFederatedHostData(gomock.Any()).
+			Do(func(hostdata model.HostDataBE) {
+				assert.Equal(t, pulled.ID, hostdata.ID)
+				assert.Equal(t, "milan", hostdata.Site)
+				assert.False(t, hostdata.Archived)
+				assert.True(t, hostdata.DismissedAt.IsZero())
+			}).
+			Return(nil),
+		client.EXPECT().GetMongoHostData("barfoo").Return(&alreadyPulled, nil),
+		db.EXPECT().FindCurrentFederatedHostData("milan", "barfoo").Return(&alreadyPulled, nil),
+		db.EXPECT().DismissFederatedHostsNotIn("milan", []string{"foobar", "barfoo"}).Return(nil),
+		client.EXPECT().GetOracleDatabaseContracts().Return(contracts, nil),
+		db.EXPECT().ReplaceFederatedOracleDatabaseContracts("milan", contracts).Return(nil),
+		client.EXPECT().GetAlerts(model.AlertStatusNew).Return(alerts, nil),
+		db.EXPECT().ReplaceFederatedAlerts("milan", alerts).Return(nil),
+	)
+
+	job.Run()
+}
+
+func TestFederationJobRun_HostError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	client := NewMockApiSvcClientInterface(mockCtrl)
+	job := newFederationJob(db, map[string]*MockApiSvcClientInterface{"milan": client})
+
+	gomock.InOrder(
+		client.EXPECT().GetHostnames().Return([]string{"foobar"}, nil),
+		client.EXPECT().GetMongoHostData("foobar").Return(nil, errMock),
+		db.EXPECT().DismissFederatedHostsNotIn("milan", []string{"foobar"}).Return(nil),
+		client.EXPECT().GetOracleDatabaseContracts().Return(nil, nil),
+		db.EXPECT().ReplaceFederatedOracleDatabaseContracts("milan", nil).Return(nil),
+		client.EXPECT().GetAlerts(model.AlertStatusNew).Return(nil, nil),
+		db.EXPECT().ReplaceFederatedAlerts("milan", nil).Return(nil),
+	)
+
+	job.Run()
+}
+
+func TestFederationJobRun_SiteError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	milan := NewMockApiSvcClientInterface(mockCtrl)
+	rome := NewMockApiSvcClientInterface(mockCtrl)
+	job := newFederationJob(db, map[string]*MockApiSvcClientInterface{"milan": milan, "rome": rome})
+
+	gomock.InOrder(
+		milan.EXPECT().GetHostnames().Return(nil, errMock),
+		rome.EXPECT().GetHostnames().Return([]string{}, nil),
+		db.EXPECT().DismissFederatedHostsNotIn("rome", []string{}).Return(nil),
+		rome.EXPECT().GetOracleDatabaseContracts().Return(nil, errMock),
+	)
+
+	job.Run()
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// HostDataSnapshotJob retain the current hostdata, so the inventory can be queried as it was when the job ran
+type HostDataSnapshotJob struct {
+	// Database contains the database layer
+	Database database.MongoDatabaseInterface
+	// TimeNow contains a function that return the current time
+	TimeNow func() time.Time
+	// Config contains the dataservice global configuration
+	Config config.Configuration
+	// Log contains logger formatted
+	Log logger.Logger
+}
+
+// Run take a snapshot of the current hostdata.
+// The snapshots taken in the first month of a quarter are kept for QuarterlyRetention quarters, the others for MonthlyRetention months
+func (job *HostDataSnapshotJob) Run() {
+	now := job.TimeNow()
+
+	kind := model.HostDataSnapshotKindMonthly
+	retainedUntil := now.AddDate(0, job.Config.DataService.HostDataSnapshotJob.MonthlyRetention, 0)
+
+	if now.Month()%3 == 1 {
+		quarterlyRetainedUntil := now.AddDate(0, 3*job.Config.DataService.HostDataSnapshotJob.QuarterlyRetention, 0)
+		if quarterlyRetainedUntil.After(retainedUntil) {
+			kind = model.HostDataSnapshotKindQuarterly
+			retainedUntil = quarterlyRetainedUntil
+		}
+	}
+
+	if !retainedUntil.After(now) {
+		job.Log.Debugf("Skipped the %s snapshot of the hostdata, its retention is disabled", kind)
+		return
+	}
+
+	hosts, err := job.Database.RetainCurrentHostData(retainedUntil)
+	if err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	snapshot := model.HostDataSnapshot{
+		ID:            primitive.NewObjectIDFromTimestamp(now),
+		Kind:          kind,
+		Date:          now,
+		Hosts:         hosts,
+		RetainedUntil: retainedUntil,
+	}
+
+	if err := job.Database.InsertHostDataSnapshot(snapshot); err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	job.Log.Infof("Taken a %s snapshot of %d hosts, retained until %s", kind, hosts, retainedUntil.Format(time.RFC3339))
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newHostDataSnapshotJob(db *MockMongoDatabaseInterface, now string, monthlyRetention, quarterlyRetention int) HostDataSnapshotJob {
+	return HostDataSnapshotJob{
+		TimeNow:  utils.Btc(utils.P(now)),
+		Database: db,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataSnapshotJob: config.HostDataSnapshotJob{
+					MonthlyRetention:   monthlyRetention,
+					QuarterlyRetention: quarterlyRetention,
+				},
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+}
+
+func TestHostDataSnapshotJobRun_Monthly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	job := newHostDataSnapshotJob(db, "2019-11-01T00:00:00Z", 12, 8)
+
+	gomock.InOrder(
+		db.EXPECT().RetainCurrentHostData(utils.P("2020-11-01T00:00:00Z")).Return(int64(42), nil),
+		db.EXPECT().InsertHostDataSnapshot(gomock.Any()).
+			Do(func(snapshot model.HostDataSnapshot) {
+				assert.Equal(t, model.HostDataSnapshotKindMonthly, snapshot.Kind)
+				assert.Equal(t, utils.P("2019-11-01T00:00:00Z"), snapshot.Date)
+				assert.Equal(t, int64(42), snapshot.Hosts)
+				assert.Equal(t, utils.P("2020-11-01T00:00:00Z"), snapshot.RetainedUntil)
+			}).
+			Return(nil),
+	)
+
+	job.Run()
+}
+
+func TestHostDataSnapshotJobRun_Quarterly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	job := newHostDataSnapshotJob(db, "2019-10-01T00:00:00Z", 12, 8)
+
+	gomock.InOrder(
+		db.EXPECT().RetainCurrentHostData(utils.P("2021-10-01T00:00:00Z")).Return(int64(42), nil),
+		db.EXPECT().InsertHostDataSnapshot(gomock.Any()).
+			Do(func(snapshot model.HostDataSnapshot) {
+				assert.Equal(t, model.HostDataSnapshotKindQuarterly, snapshot.Kind)
+				assert.Equal(t, utils.P("2021-10-01T00:00:00Z"), snapshot.RetainedUntil)
+			}).
+			Return(nil),
+	)
+
+	job.Run()
+}
+
+func TestHostDataSnapshotJobRun_Disabled(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	job := newHostDataSnapshotJob(db, "2019-11-01T00:00:00Z", 0, 8)
+
+	job.Run()
+}
+
+func TestHostDataSnapshotJobRun_DatabaseError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	job := newHostDataSnapshotJob(db, "2019-11-01T00:00:00Z", 12, 8)
+
+	db.EXPECT().RetainCurrentHostData(utils.P("2020-11-01T00:00:00Z")).Return(int64(0), aerrMock)
+
+	job.Run()
+}
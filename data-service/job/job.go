@@ -23,8 +23,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	alert_service_client "github.com/ercole-io/ercole/v2/alert-service/client"
+	apiservice_client "github.com/ercole-io/ercole/v2/api-service/client"
 	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/cmdb"
 	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/data-service/service"
 	"github.com/ercole-io/ercole/v2/logger"
 )
 
@@ -36,6 +39,7 @@ type Job struct {
 	Config        config.Configuration
 	ServerVersion string
 	Database      database.MongoDatabaseInterface
+	Service       service.HostDataServiceInterface
 	TimeNow       func() time.Time
 	Log           logger.Logger
 }
@@ -79,6 +83,87 @@ func (j *Job) Init() {
 		jobrunner.Now(freshnessJob)
 	}
 
+	historicizeUsedLicensesJob := &HistoricizeUsedLicensesJob{
+		Database: j.Database,
+		TimeNow:  j.TimeNow,
+		Config:   j.Config,
+		Log:      j.Log,
+	}
+	if err := jobrunner.Schedule(j.Config.DataService.UsedLicensesHistoryJob.Crontab, historicizeUsedLicensesJob); err != nil {
+		j.Log.Errorf("Something went wrong scheduling HistoricizeUsedLicensesJob: %v", err)
+	}
+
+	if j.Config.DataService.UsedLicensesHistoryJob.RunAtStartup {
+		jobrunner.Now(historicizeUsedLicensesJob)
+	}
+
+	hostDataSnapshotJob := &HostDataSnapshotJob{
+		Database: j.Database,
+		TimeNow:  j.TimeNow,
+		Config:   j.Config,
+		Log:      j.Log,
+	}
+	if err := jobrunner.Schedule(j.Config.DataService.HostDataSnapshotJob.Crontab, hostDataSnapshotJob); err != nil {
+		j.Log.Errorf("Something went wrong scheduling HostDataSnapshotJob: %v", err)
+	}
+
+	if j.Config.DataService.HostDataSnapshotJob.RunAtStartup {
+		jobrunner.Now(hostDataSnapshotJob)
+	}
+
+	if len(j.Config.Federation.Sites) > 0 {
+		federationJob := &FederationJob{
+			Database: j.Database,
+			TimeNow:  j.TimeNow,
+			Config:   j.Config,
+			Log:      j.Log,
+			NewClient: func(site config.FederatedSite) apiservice_client.ApiSvcClientInterface {
+				return apiservice_client.NewClient(config.APIService{
+					RemoteEndpoint: site.RemoteEndpoint,
+					AuthenticationProvider: config.AuthenticationProviderConfig{
+						Username: site.Username,
+						Password: site.Password,
+					},
+				})
+			},
+		}
+		if err := jobrunner.Schedule(j.Config.Federation.Crontab, federationJob); err != nil {
+			j.Log.Errorf("Something went wrong scheduling FederationJob: %v", err)
+		}
+
+		if j.Config.Federation.RunAtStartup {
+			jobrunner.Now(federationJob)
+		}
+	}
+
+	if len(j.Config.DataService.CmdbReconciliationJob.Connectors) > 0 {
+		cmdbReconciliationJob := &CmdbReconciliationJob{
+			Service:      j.Service,
+			Config:       j.Config,
+			Log:          j.Log,
+			NewConnector: cmdb.NewConnector,
+		}
+		if err := jobrunner.Schedule(j.Config.DataService.CmdbReconciliationJob.Crontab, cmdbReconciliationJob); err != nil {
+			j.Log.Errorf("Something went wrong scheduling CmdbReconciliationJob: %v", err)
+		}
+
+		if j.Config.DataService.CmdbReconciliationJob.RunAtStartup {
+			jobrunner.Now(cmdbReconciliationJob)
+		}
+	}
+
+	licenseIgnoreRulesJob := &LicenseIgnoreRulesJob{
+		Service: j.Service,
+		Log:     j.Log,
+	}
+	if err := jobrunner.Schedule(j.Config.DataService.LicenseIgnoreRulesJob.Crontab, licenseIgnoreRulesJob); err != nil {
+		j.Log.Errorf("Something went wrong scheduling LicenseIgnoreRulesJob: %v", err)
+	}
+
+	if j.Config.DataService.LicenseIgnoreRulesJob.RunAtStartup {
+		jobrunner.Now(licenseIgnoreRulesJob)
+	}
+
 	historicizeLicensesComplianceJob := &HistoricizeLicensesComplianceJob{
 		Database: j.Database,
 		TimeNow:  j.TimeNow,
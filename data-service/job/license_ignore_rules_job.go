@@ -0,0 +1,37 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"github.com/ercole-io/ercole/v2/data-service/service"
+	"github.com/ercole-io/ercole/v2/logger"
+)
+
+// LicenseIgnoreRulesJob apply the license ignore rules to the current hosts, so the licenses of the expired
+// or deleted rules are counted again without waiting the next upload of the hosts
+type LicenseIgnoreRulesJob struct {
+	// Service contains the service layer
+	Service service.HostDataServiceInterface
+	// Log contains logger formatted
+	Log logger.Logger
+}
+
+// Run apply the license ignore rules
+func (job *LicenseIgnoreRulesJob) Run() {
+	if err := job.Service.ApplyLicenseIgnoreRules(); err != nil {
+		job.Log.Errorf("Can't apply the license ignore rules: %s", err)
+	}
+}
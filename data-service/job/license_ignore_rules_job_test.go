@@ -0,0 +1,41 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"testing"
+
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/logger"
+)
+
+func TestLicenseIgnoreRulesJobRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	svc := NewMockHostDataServiceInterface(mockCtrl)
+
+	job := LicenseIgnoreRulesJob{
+		Service: svc,
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	svc.EXPECT().ApplyLicenseIgnoreRules().Return(nil)
+	job.Run()
+
+	svc.EXPECT().ApplyLicenseIgnoreRules().Return(errMock)
+	job.Run()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// HistoricizeUsedLicensesJob store a daily snapshot of the licenses used by each host and database
+type HistoricizeUsedLicensesJob struct {
+	Database database.MongoDatabaseInterface
+	TimeNow  func() time.Time
+	Config   config.Configuration
+	Log      logger.Logger
+}
+
+func (job *HistoricizeUsedLicensesJob) Run() {
+	url := utils.NewAPIUrlNoParams(
+		job.Config.APIService.RemoteEndpoint,
+		job.Config.APIService.AuthenticationProvider.Username,
+		job.Config.APIService.AuthenticationProvider.Password,
+		"/hosts/technologies/all/databases/licenses-used").String()
+
+	client := http.Client{Timeout: 5 * time.Minute}
+
+	resp, err := client.Get(url)
+	if err != nil || resp == nil {
+		err = fmt.Errorf("Error while retrieving used licenses: [%w], response: [%v]", err, resp)
+		job.Log.Error(err)
+
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err = fmt.Errorf("Error while retrieving used licenses: response status code: response: [%+v]", resp)
+		job.Log.Error(err)
+
+		return
+	}
+
+	response := map[string][]dto.DatabaseUsedLicense{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	if err := job.Database.HistoricizeUsedLicenses(response["usedLicenses"]); err != nil {
+		job.Log.Error("Can't historicize used licenses")
+		return
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestHistoricizeUsedLicensesJobRun_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+
+	usedLicenses := []dto.DatabaseUsedLicense{
+		{Hostname: "foobar", DbName: "ERCOLE", LicenseTypeID: "A90611", UsedLicenses: 2},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hosts/technologies/all/databases/licenses-used", r.URL.Path)
+		utils.WriteJSONResponse(w, http.StatusOK, map[string]interface{}{"usedLicenses": usedLicenses})
+	}))
+	defer server.Close()
+
+	job := HistoricizeUsedLicensesJob{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			APIService: config.APIService{RemoteEndpoint: server.URL},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().HistoricizeUsedLicenses(usedLicenses).Return(nil)
+
+	job.Run()
+}
+
+func TestHistoricizeUsedLicensesJobRun_ApiError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	job := HistoricizeUsedLicensesJob{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			APIService: config.APIService{RemoteEndpoint: server.URL},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	job.Run()
+}
@@ -22,7 +22,9 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
 )
 
 // ThrowNewDatabaseAlert create and insert in the database a new NEW_DATABASE alert
@@ -354,3 +356,113 @@ func (hds *HostDataService) throwMissingDatabasesAlert(hostname string, dbNames
 
 	return hds.AlertSvcClient.ThrowNewAlert(alr)
 }
+
+func (hds *HostDataService) throwSE2SocketLimitAlert(host *model.HostDataBE) error {
+	if !hds.Config.AlertService.Emailer.AlertType.SE2SocketLimit {
+		return nil
+	}
+
+	description := fmt.Sprintf("The host %s runs Standard Edition databases but has %d sockets, more than the %d allowed",
+		host.Hostname, host.Info.CPUSockets, model.OracleDatabaseSE2MaxSockets)
+	if host.IsInAuthorizedCloud() {
+		description = fmt.Sprintf("The host %s runs Standard Edition databases but has %d vCPUs, more than the %d allowed",
+			host.Hostname, host.Info.CPUThreads, model.OracleDatabaseSE2CloudMaxVCPUs)
+	}
+
+	alr := model.Alert{
+		ID:                      primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertCode:               model.AlertCodeSE2SocketLimit,
+		AlertSeverity:           model.AlertSeverityWarning,
+		AlertStatus:             model.AlertStatusNew,
+		Date:                    hds.TimeNow(),
+		Description:             description,
+		OtherInfo: map[string]interface{}{
+			"hostname": host.Hostname,
+		},
+	}
+
+	return hds.AlertSvcClient.ThrowNewAlert(alr)
+}
+
+// throwMultitenantPDBLimitAlert create and insert in the database a new MULTITENANT_PDB_LIMIT_EXCEEDED alert
+// for the database exceeding the limit of user pluggable databases of the rule
+func (hds *HostDataService) throwMultitenantPDBLimitAlert(hostname string, db model.OracleDatabase, rule model.OracleDatabaseLicenseRule) error {
+	alr := model.Alert{
+		ID:                      primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertCode:               model.AlertCodeMultitenantPDBLimit,
+		AlertSeverity:           model.AlertSeverityWarning,
+		AlertStatus:             model.AlertStatusNew,
+		Date:                    hds.TimeNow(),
+		Description: fmt.Sprintf("The database %s on %s has %d user pluggable databases, more than the %d allowed without the %s option",
+			db.Name, hostname, db.UserPDBsCount(), rule.MaxPDBs, rule.License),
+		OtherInfo: map[string]interface{}{
+			"hostname": hostname,
+			"dbname":   db.Name,
+			"ruleID":   rule.ID,
+		},
+	}
+
+	return hds.AlertSvcClient.ThrowNewAlert(alr)
+}
+
+func (hds *HostDataService) throwLicenseIgnoreRuleExpiredAlert(hostname string, licenseTypeIDs []string) error {
+	alr := model.Alert{
+		ID:            primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		AlertCategory: model.AlertCategoryLicense,
+		AlertCode:     model.AlertCodeLicenseIgnoreRuleExpired,
+		AlertSeverity: model.AlertSeverityWarning,
+		AlertStatus:   model.AlertStatusNew,
+		Date:          hds.TimeNow(),
+		Description: fmt.Sprintf("The license ignore rules of host %s are expired, the licenses %s are counted again",
+			hostname, strings.Join(licenseTypeIDs, ", ")),
+		OtherInfo: map[string]interface{}{
+			"hostname":       hostname,
+			"licenseTypeIDs": licenseTypeIDs,
+		},
+	}
+
+	return hds.AlertSvcClient.ThrowNewAlert(alr)
+}
+
+func (hds *HostDataService) ackOldCmdbFieldMismatchAlerts(cmdbName, hostname, field string) error {
+	f := dto.AlertsFilter{
+		AlertCategory: utils.Str2ptr(model.AlertCategoryEngine),
+		AlertCode:     utils.Str2ptr(model.AlertCodeCmdbFieldMismatch),
+		AlertSeverity: utils.Str2ptr(model.AlertSeverityWarning),
+		OtherInfo: map[string]interface{}{
+			"hostname": hostname,
+			"cmdb":     cmdbName,
+			"field":    field,
+		},
+	}
+
+	return hds.ApiSvcClient.AckAlerts(f)
+}
+
+// throwCmdbFieldMismatchAlert create and insert in the database a new CMDB_FIELD_MISMATCH alert
+func (hds *HostDataService) throwCmdbFieldMismatchAlert(cmdbName, hostname, field, ercoleValue, cmdbValue string) error {
+	alr := model.Alert{
+		ID:                      primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		AlertAffectedTechnology: nil,
+		AlertCategory:           model.AlertCategoryEngine,
+		AlertCode:               model.AlertCodeCmdbFieldMismatch,
+		AlertSeverity:           model.AlertSeverityWarning,
+		AlertStatus:             model.AlertStatusNew,
+		Date:                    hds.TimeNow(),
+		Description: fmt.Sprintf("The %s of the host %s is %q in ercole but %q in CMDB %s",
+			field, hostname, ercoleValue, cmdbValue, cmdbName),
+		OtherInfo: map[string]interface{}{
+			"hostname":    hostname,
+			"cmdb":        cmdbName,
+			"field":       field,
+			"ercoleValue": ercoleValue,
+			"cmdbValue":   cmdbValue,
+		},
+	}
+
+	return hds.AlertSvcClient.ThrowNewAlert(alr)
+}
@@ -72,6 +72,141 @@ func (hds *HostDataService) CompareCmdbInfo(cmdbInfo dto.CmdbInfo) error {
 	return nil
 }
 
+// ownerTagPrefix is the prefix of the tag with the owner of the host
+const ownerTagPrefix = "owner:"
+
+// ReconcileCmdb compare the current hosts with the hosts pulled from a CMDB.
+// The hosts of the CMDB are resolved by the aliases of the identities of the hosts.
+// Besides the missing hosts, it throws a CMDB_FIELD_MISMATCH alert for each attribute of a host
+// that is different in the CMDB; the attributes that are empty in the CMDB aren't compared.
+// A mismatch is thrown only if it's different from the last one of the attribute,
+// the alerts of the attributes that aren't different anymore are acked.
+// The owner of a host in ercole is the value of its tag owner:<name>
+func (hds *HostDataService) ReconcileCmdb(cmdbName string, cmdbHosts []dto.CmdbHost) error {
+	cmdbHosts = append([]dto.CmdbHost{}, cmdbHosts...)
+	hostnames := make([]string, 0, len(cmdbHosts))
+
+	for i := range cmdbHosts {
+		hostname, err := hds.canonicalHostname(cmdbHosts[i].Hostname)
+		if err != nil {
+			return err
+		}
+
+		cmdbHosts[i].Hostname = hostname
+		hostnames = append(hostnames, hostname)
+	}
+
+	if err := hds.CompareCmdbInfo(dto.CmdbInfo{Name: cmdbName, Hostnames: hostnames}); err != nil {
+		return err
+	}
+
+	hosts, err := hds.Database.GetActiveHostdata()
+	if err != nil {
+		return err
+	}
+
+	alerts, err := hds.Database.FindCmdbFieldMismatchAlerts(cmdbName)
+	if err != nil {
+		return err
+	}
+
+	lastAlerts := make(map[cmdbFieldKey]model.Alert, len(alerts))
+	for _, alert := range alerts {
+		lastAlerts[newCmdbFieldKey(alert.OtherInfo["hostname"], alert.OtherInfo["field"])] = alert
+	}
+
+	cmdbHostsByName := make(map[string]dto.CmdbHost, len(cmdbHosts))
+
+	for _, h := range cmdbHosts {
+		lch := strings.ToLower(h.Hostname)
+		cmdbHostsByName[lch] = h
+
+		if withoutDomain := hostnameWithoutDomain(lch); withoutDomain != lch {
+			if _, found := cmdbHostsByName[withoutDomain]; !found {
+				cmdbHostsByName[withoutDomain] = h
+			}
+		}
+	}
+
+	mismatches := make(map[cmdbFieldKey]bool)
+
+	for _, host := range hosts {
+		lch := strings.ToLower(host.Hostname)
+
+		cmdbHost, found := cmdbHostsByName[lch]
+		if !found {
+			if cmdbHost, found = cmdbHostsByName[hostnameWithoutDomain(lch)]; !found {
+				continue
+			}
+		}
+
+		fields := []struct {
+			name        string
+			ercoleValue string
+			cmdbValue   string
+		}{
+			{"environment", host.Environment, cmdbHost.Environment},
+			{"location", host.Location, cmdbHost.Location},
+			{"owner", hostOwner(host.Tags), cmdbHost.Owner},
+		}
+
+		for _, f := range fields {
+			if f.cmdbValue == "" || strings.EqualFold(strings.TrimSpace(f.ercoleValue), strings.TrimSpace(f.cmdbValue)) {
+				continue
+			}
+
+			key := newCmdbFieldKey(host.Hostname, f.name)
+			mismatches[key] = true
+
+			if last, found := lastAlerts[key]; found &&
+				last.OtherInfo["ercoleValue"] == f.ercoleValue && last.OtherInfo["cmdbValue"] == f.cmdbValue {
+				continue
+			}
+
+			if err := hds.ackOldCmdbFieldMismatchAlerts(cmdbName, host.Hostname, f.name); err != nil {
+				hds.Log.Errorf("Can't ack CmdbFieldMismatch alerts by filter: %s", err)
+			}
+
+			if err := hds.throwCmdbFieldMismatchAlert(cmdbName, host.Hostname, f.name, f.ercoleValue, f.cmdbValue); err != nil {
+				hds.Log.Errorf("Can't create a new alert: %s", err)
+			}
+		}
+	}
+
+	for key, last := range lastAlerts {
+		if mismatches[key] || last.AlertStatus != model.AlertStatusNew {
+			continue
+		}
+
+		if err := hds.ackOldCmdbFieldMismatchAlerts(cmdbName, key.hostname, key.field); err != nil {
+			hds.Log.Errorf("Can't ack CmdbFieldMismatch alerts by filter: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// cmdbFieldKey identifies an attribute of a host compared with a CMDB
+type cmdbFieldKey struct {
+	hostname string
+	field    string
+}
+
+func newCmdbFieldKey(hostname, field interface{}) cmdbFieldKey {
+	return cmdbFieldKey{hostname: fmt.Sprint(hostname), field: fmt.Sprint(field)}
+}
+
+// hostOwner return the owner of the host from its tags
+func hostOwner(tags []string) string {
+	for _, tag := range tags {
+		if strings.HasPrefix(strings.ToLower(tag), ownerTagPrefix) {
+			return strings.TrimSpace(tag[len(ownerTagPrefix):])
+		}
+	}
+
+	return ""
+}
+
 // differenceHostnames returns hostnames in `a` that aren't in `b`
 // If a has multiple times on item, which is in b even only once, no occurrences will be returned
 func differenceHostnames(a, b []string) []string {
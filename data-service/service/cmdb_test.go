@@ -20,8 +20,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	gomock "go.uber.org/mock/gomock"
 
+	apiservice_dto "github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/logger"
@@ -149,3 +151,111 @@ func TestCompareCmdbInfo_MissingHostInCmdb(t *testing.T) {
 	actualErr := hds.CompareCmdbInfo(cmdbInfo)
 	assert.Nil(t, actualErr)
 }
+
+func TestReconcileCmdb(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	apisc := NewMockApiSvcClientInterface(mockCtrl)
+
+	hds := HostDataService{
+		Config:         config.Configuration{},
+		ServerVersion:  "1.6.6",
+		Database:       db,
+		AlertSvcClient: asc,
+		ApiSvcClient:   apisc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	cmdbHosts := []dto.CmdbHost{
+		{Hostname: "PIPPO.topolinia.top", Environment: "prd", Location: "Italy", Owner: "Topolino"},
+		{Hostname: "pluto-old", Environment: "TST", Location: "Germany"},
+		{Hostname: "paperino", Environment: "PRD", Location: "Italy"},
+	}
+
+	hosts := []model.HostDataBE{
+		{Hostname: "pippo", Environment: "PRD", Location: "Italy", Tags: []string{"owner:Minnie"}},
+		{Hostname: "pluto", Environment: "PRD", Location: "Germany", Tags: []string{"owner:Topolino"}},
+		{Hostname: "paperino", Environment: "PRD", Location: "Italy"},
+	}
+
+	expectedAlert := func(hostname, field, ercoleValue, cmdbValue string) model.Alert {
+		return model.Alert{
+			AlertCategory: model.AlertCategoryEngine,
+			AlertCode:     model.AlertCodeCmdbFieldMismatch,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusNew,
+			Date:          hds.TimeNow(),
+			Description: fmt.Sprintf("The %s of the host %s is %q in ercole but %q in CMDB thisCmdb",
+				field, hostname, ercoleValue, cmdbValue),
+			OtherInfo: map[string]interface{}{
+				"hostname":    hostname,
+				"cmdb":        "thisCmdb",
+				"field":       field,
+				"ercoleValue": ercoleValue,
+				"cmdbValue":   cmdbValue,
+			},
+		}
+	}
+
+	ackedAlert := expectedAlert("pippo", "owner", "Minnie", "Topolino")
+	ackedAlert.AlertStatus = model.AlertStatusAck
+
+	ackFilter := func(hostname, field string) apiservice_dto.AlertsFilter {
+		return apiservice_dto.AlertsFilter{
+			AlertCategory: utils.Str2ptr(model.AlertCategoryEngine),
+			AlertCode:     utils.Str2ptr(model.AlertCodeCmdbFieldMismatch),
+			AlertSeverity: utils.Str2ptr(model.AlertSeverityWarning),
+			OtherInfo: map[string]interface{}{
+				"hostname": hostname,
+				"cmdb":     "thisCmdb",
+				"field":    field,
+			},
+		}
+	}
+
+	gomock.InOrder(
+		db.EXPECT().FindHostIdentityByAlias("pippo.topolinia.top").Return(nil, utils.ErrHostIdentityNotFound),
+		db.EXPECT().FindHostIdentityByAlias("pluto-old").Return(&model.HostIdentity{Hostname: "pluto"}, nil),
+		db.EXPECT().FindHostIdentityByAlias("paperino").Return(nil, utils.ErrHostIdentityNotFound),
+		db.EXPECT().GetCurrentHostnames().Return([]string{"pippo", "pluto", "paperino"}, nil),
+		db.EXPECT().GetActiveHostdata().Return(hosts, nil),
+		db.EXPECT().FindCmdbFieldMismatchAlerts("thisCmdb").Return([]model.Alert{
+			ackedAlert,
+			expectedAlert("paperino", "location", "Italy", "Germany"),
+		}, nil),
+		apisc.EXPECT().AckAlerts(ackFilter("pluto", "environment")).Return(nil),
+		asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
+			assert.Equal(t, hds.TimeNow(), a.ID.Timestamp())
+			a.ID = primitive.NilObjectID
+			assert.Equal(t, expectedAlert("pluto", "environment", "PRD", "TST"), a)
+		}).Return(aerrMock),
+		apisc.EXPECT().AckAlerts(ackFilter("paperino", "location")).Return(nil),
+	)
+
+	err := hds.ReconcileCmdb("thisCmdb", cmdbHosts)
+	assert.NoError(t, err)
+	assert.Equal(t, "pluto-old", cmdbHosts[1].Hostname)
+}
+
+func TestReconcileCmdb_DbError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+
+	hds := HostDataService{
+		Config:   config.Configuration{},
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().FindHostIdentityByAlias("pippo").Return(nil, utils.ErrHostIdentityNotFound)
+	db.EXPECT().GetCurrentHostnames().Return([]string{"pippo"}, nil)
+	db.EXPECT().GetActiveHostdata().Return(nil, aerrMock)
+
+	err := hds.ReconcileCmdb("thisCmdb", []dto.CmdbHost{{Hostname: "pippo"}})
+	assert.Equal(t, aerrMock, err)
+}
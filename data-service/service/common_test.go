@@ -19,6 +19,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
@@ -30,6 +31,15 @@ import (
 var errMock error = errors.New("MockError")
 var aerrMock error = utils.NewError(errMock, "mock")
 
+// hostIdentity return the identity of a host already known with the hostname
+func hostIdentity(hostname string) *model.HostIdentity {
+	return &model.HostIdentity{
+		ID:       model.NewHostIdentityID(hostname),
+		Hostname: hostname,
+		Aliases:  model.HostIdentityKeys(hostname),
+	}
+}
+
 type RoundTripFunc func(req *http.Request) (*http.Response, error)
 
 func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
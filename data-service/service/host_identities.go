@@ -0,0 +1,122 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"errors"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// resolveHostIdentity set the canonical hostname and the identity of the host in the hostdata.
+// The host is resolved only by the hostname sent by the agent and then by its name without domain,
+// if it matches a single identity; the hostname reported by the operating system isn't used, because
+// different hosts can share it (e.g. localhost or cloned VMs).
+// The identity is created when the host isn't resolved
+func (hds *HostDataService) resolveHostIdentity(hostdata *model.HostDataBE) error {
+	alias := model.NormalizeHostAlias(hostdata.Hostname)
+
+	identity, err := hds.Database.FindHostIdentityByAlias(alias)
+	if errors.Is(err, utils.ErrHostIdentityNotFound) {
+		identity, err = hds.findHostIdentityByShortName(alias)
+		if err == nil {
+			err = hds.Database.AddHostIdentityAlias(identity.ID, alias)
+			if errors.Is(err, utils.ErrHostAliasConflict) {
+				// the hostname was taken by another identity in the meantime, the host isn't merged with the matched one
+				hds.Log.Warnf("%v: %s isn't added to the host %s", err, alias, identity.Hostname)
+
+				identity, err = hds.Database.FindHostIdentityByAlias(alias)
+			}
+		}
+	}
+
+	if errors.Is(err, utils.ErrHostIdentityNotFound) {
+		newIdentity := model.NewHostIdentity(hostdata.Hostname, []string{alias}, hds.TimeNow())
+
+		err = hds.Database.InsertHostIdentity(newIdentity)
+		if errors.Is(err, utils.ErrHostAliasConflict) {
+			// it was created by a concurrent upload of the same hostname
+			identity, err = hds.Database.FindHostIdentityByAlias(alias)
+		} else {
+			identity = &newIdentity
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	hostdata.HostID = identity.ID
+	hostdata.Hostname = identity.Hostname
+
+	return nil
+}
+
+// findHostIdentityByShortName return the identity with an alias with the same name without domain of the alias.
+// The names match only if one of them hasn't a domain, the identity isn't resolved if more than one match
+func (hds *HostDataService) findHostIdentityByShortName(alias string) (*model.HostIdentity, error) {
+	shortName := model.HostShortName(alias)
+	if shortName == "" {
+		return nil, utils.ErrHostIdentityNotFound
+	}
+
+	identities, err := hds.Database.FindHostIdentitiesByShortName(shortName)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]model.HostIdentity, 0, 1)
+
+	for _, identity := range identities {
+		if matchesShortName(identity, alias, shortName) {
+			matches = append(matches, identity)
+		}
+	}
+
+	if len(matches) > 1 {
+		hds.Log.Warnf("%v: %s", utils.ErrHostIdentityAmbiguous, alias)
+	}
+
+	if len(matches) != 1 {
+		return nil, utils.ErrHostIdentityNotFound
+	}
+
+	return &matches[0], nil
+}
+
+// matchesShortName return true if every alias of the identity with the short name has no domain or the key hasn't it
+func matchesShortName(identity model.HostIdentity, key, shortName string) bool {
+	for _, alias := range identity.Aliases {
+		if model.HostShortName(alias) == shortName && alias != shortName && key != shortName {
+			return false
+		}
+	}
+
+	return true
+}
+
+// canonicalHostname return the canonical hostname of the host with the name
+func (hds *HostDataService) canonicalHostname(hostname string) (string, error) {
+	identity, err := hds.Database.FindHostIdentityByAlias(model.NormalizeHostAlias(hostname))
+	if errors.Is(err, utils.ErrHostIdentityNotFound) {
+		return hostname, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return identity.Hostname, nil
+}
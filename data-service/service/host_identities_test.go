@@ -0,0 +1,175 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestResolveHostIdentity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	known := &model.HostIdentity{
+		ID:       "6512f1a5ba2e2a1f4b6b8f31",
+		Hostname: "foobar",
+		Aliases:  []string{"foobar", "foobar-old"},
+	}
+
+	t.Run("Resolved by an alias", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "FOOBAR-OLD"}
+
+		db.EXPECT().FindHostIdentityByAlias("foobar-old").Return(known, nil)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "foobar", hostdata.Hostname)
+		assert.Equal(t, known.ID, hostdata.HostID)
+	})
+
+	t.Run("Resolved by the name without domain", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "foobar.example.com"}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("foobar.example.com").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("foobar").Return([]model.HostIdentity{*known}, nil),
+			db.EXPECT().AddHostIdentityAlias(known.ID, "foobar.example.com").Return(nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "foobar", hostdata.Hostname)
+	})
+
+	t.Run("Not resolved by a name with another domain", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "db01.prod"}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("db01.prod").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("db01").
+				Return([]model.HostIdentity{model.NewHostIdentity("db01.test", []string{"db01.test"}, utils.P("2019-11-05T14:02:03Z"))}, nil),
+			db.EXPECT().InsertHostIdentity(model.NewHostIdentity("db01.prod", []string{"db01.prod"}, utils.P("2019-11-05T14:02:03Z"))).Return(nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "db01.prod", hostdata.Hostname)
+		assert.Equal(t, model.NewHostIdentityID("db01.prod"), hostdata.HostID)
+	})
+
+	t.Run("Ambiguous name without domain", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "db01"}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("db01").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("db01").Return([]model.HostIdentity{
+				model.NewHostIdentity("db01.prod", []string{"db01.prod"}, utils.P("2019-11-05T14:02:03Z")),
+				model.NewHostIdentity("db01.test", []string{"db01.test"}, utils.P("2019-11-05T14:02:03Z")),
+			}, nil),
+			db.EXPECT().InsertHostIdentity(model.NewHostIdentity("db01", []string{"db01"}, utils.P("2019-11-05T14:02:03Z"))).Return(nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "db01", hostdata.Hostname)
+		assert.Equal(t, model.NewHostIdentityID("db01"), hostdata.HostID)
+	})
+
+	t.Run("New host", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "barfoo.example.com", Info: model.Host{Hostname: "BARFOO01"}}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("barfoo.example.com").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("barfoo").Return(nil, nil),
+			db.EXPECT().InsertHostIdentity(model.HostIdentity{
+				ID:        model.NewHostIdentityID("barfoo.example.com"),
+				Hostname:  "barfoo.example.com",
+				Aliases:   []string{"barfoo.example.com"},
+				CreatedAt: utils.P("2019-11-05T14:02:03Z"),
+				UpdatedAt: utils.P("2019-11-05T14:02:03Z"),
+			}).Return(nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "barfoo.example.com", hostdata.Hostname)
+		assert.Equal(t, model.NewHostIdentityID("barfoo.example.com"), hostdata.HostID)
+	})
+
+	t.Run("Hosts sharing the operating system hostname", func(t *testing.T) {
+		hostA := model.HostDataBE{Hostname: "a", Info: model.Host{Hostname: "localhost"}}
+		hostB := model.HostDataBE{Hostname: "b", Info: model.Host{Hostname: "localhost"}}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("a").Return(hostIdentity("a"), nil),
+			db.EXPECT().FindHostIdentityByAlias("b").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("b").Return(nil, nil),
+			db.EXPECT().InsertHostIdentity(model.NewHostIdentity("b", []string{"b"}, utils.P("2019-11-05T14:02:03Z"))).Return(nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostA))
+		require.NoError(t, hds.resolveHostIdentity(&hostB))
+		assert.Equal(t, "a", hostA.Hostname)
+		assert.Equal(t, "b", hostB.Hostname)
+		assert.NotEqual(t, hostA.HostID, hostB.HostID)
+	})
+
+	t.Run("Hostname taken by another identity in the meantime", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "foobar.example.com"}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("foobar.example.com").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("foobar").Return([]model.HostIdentity{*known}, nil),
+			db.EXPECT().AddHostIdentityAlias(known.ID, "foobar.example.com").Return(utils.ErrHostAliasConflict),
+			db.EXPECT().FindHostIdentityByAlias("foobar.example.com").Return(hostIdentity("foobar.example.com"), nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "foobar.example.com", hostdata.Hostname)
+		assert.Equal(t, model.NewHostIdentityID("foobar.example.com"), hostdata.HostID)
+	})
+
+	t.Run("Created by a concurrent upload", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "barfoo"}
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("barfoo").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("barfoo").Return(nil, nil),
+			db.EXPECT().InsertHostIdentity(gomock.Any()).Return(utils.ErrHostAliasConflict),
+			db.EXPECT().FindHostIdentityByAlias("barfoo").Return(hostIdentity("barfoo"), nil),
+		)
+
+		require.NoError(t, hds.resolveHostIdentity(&hostdata))
+		assert.Equal(t, "barfoo", hostdata.Hostname)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		hostdata := model.HostDataBE{Hostname: "barfoo"}
+
+		db.EXPECT().FindHostIdentityByAlias("barfoo").Return(nil, aerrMock)
+
+		require.ErrorIs(t, hds.resolveHostIdentity(&hostdata), aerrMock)
+	})
+}
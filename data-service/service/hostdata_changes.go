@@ -0,0 +1,43 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// historicizeHostDataChanges save the fields changed from the previous hostdata, if any
+func (hds *HostDataService) historicizeHostDataChanges(previous, current model.HostDataBE) {
+	changes := model.DiffHostData(previous, current)
+	if len(changes) == 0 {
+		return
+	}
+
+	change := model.HostDataChange{
+		ID:          primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		Hostname:    current.Hostname,
+		Environment: current.Environment,
+		Location:    current.Location,
+		Date:        current.CreatedAt,
+		Changes:     changes,
+	}
+
+	if err := hds.Database.InsertHostDataChange(change); err != nil {
+		hds.Log.Error(err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestHistoricizeHostDataChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	previous := model.HostDataBE{
+		Hostname:    "foobar",
+		Environment: "TST",
+		Location:    "Italy",
+		CreatedAt:   utils.P("2019-11-04T14:02:03Z"),
+	}
+
+	t.Run("nothing changed", func(t *testing.T) {
+		current := previous
+		current.CreatedAt = utils.P("2019-11-05T14:02:03Z")
+
+		hds.historicizeHostDataChanges(previous, current)
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		current := previous
+		current.Environment = "PRD"
+		current.CreatedAt = utils.P("2019-11-05T14:02:03Z")
+
+		expected := model.HostDataChange{
+			Hostname:    "foobar",
+			Environment: "PRD",
+			Location:    "Italy",
+			Date:        utils.P("2019-11-05T14:02:03Z"),
+			Changes: []model.HostDataFieldChange{
+				{
+					Field:    model.HostDataChangeFieldEnvironment,
+					OldValue: "TST",
+					NewValue: "PRD",
+				},
+			},
+		}
+		db.EXPECT().InsertHostDataChange(gomock.Any()).
+			Do(func(change model.HostDataChange) {
+				change.ID = primitive.NilObjectID
+				assert.Equal(t, expected, change)
+			}).
+			Return(nil)
+
+		hds.historicizeHostDataChanges(previous, current)
+	})
+
+	t.Run("insert error is only logged", func(t *testing.T) {
+		current := previous
+		current.Location = "Germany"
+
+		db.EXPECT().InsertHostDataChange(gomock.Any()).Return(aerrMock)
+
+		hds.historicizeHostDataChanges(previous, current)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// QuarantineHostData save an hostdata rejected by the validation, so it can be inspected and replayed
+func (hds *HostDataService) QuarantineHostData(raw []byte, validationErr error) error {
+	quarantined := model.QuarantinedHostData{
+		ID:         primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		Errors:     validationErr.Error(),
+		Payload:    raw,
+		ReceivedAt: hds.TimeNow(),
+	}
+
+	// the fields are read one by one because the whole hostdata could be not unmarshallable
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		quarantined.Hostname, _ = fields["hostname"].(string)
+		quarantined.Location, _ = fields["location"].(string)
+		quarantined.Environment, _ = fields["environment"].(string)
+
+		if schemaVersion, ok := fields["schemaVersion"].(float64); ok {
+			quarantined.SchemaVersion = int(schemaVersion)
+		}
+	}
+
+	return hds.Database.InsertQuarantinedHostData(quarantined)
+}
+
+// GetQuarantinedHostData return a quarantined hostdata with its payload
+func (hds *HostDataService) GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error) {
+	return hds.Database.GetQuarantinedHostData(id)
+}
+
+// IsQuarantinedHostDataOutdated return true if the host has sent an hostdata more recent than the quarantined one,
+// which can't be replayed without overwriting the newer data
+func (hds *HostDataService) IsQuarantinedHostDataOutdated(quarantined model.QuarantinedHostData) (bool, error) {
+	hostname := quarantined.Hostname
+
+	identity, err := hds.Database.FindHostIdentityByAlias(hostname)
+	if err == nil {
+		hostname = identity.Hostname
+	} else if !errors.Is(err, utils.ErrHostIdentityNotFound) {
+		return false, err
+	}
+
+	latest, err := hds.Database.FindMostRecentHostDataOlderThan(hostname, utils.MAX_TIME)
+	if err != nil {
+		return false, err
+	}
+
+	return latest != nil && latest.CreatedAt.After(quarantined.ReceivedAt), nil
+}
+
+// FailQuarantinedHostDataReplay save the errors of a quarantined hostdata which has been rejected again
+func (hds *HostDataService) FailQuarantinedHostDataReplay(id primitive.ObjectID, validationErr error) error {
+	return hds.Database.UpdateQuarantinedHostDataReplay(id, validationErr.Error())
+}
+
+// DeleteQuarantinedHostData remove a quarantined hostdata, e.g. because it has been replayed
+func (hds *HostDataService) DeleteQuarantinedHostData(id primitive.ObjectID) error {
+	return hds.Database.DeleteQuarantinedHostData(id)
+}
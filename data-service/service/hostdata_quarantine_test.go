@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestQuarantineHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	t.Run("Hostdata with the main fields", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar","location":"Italy","environment":"PRD","schemaVersion":1,"info":42}`)
+
+		db.EXPECT().InsertQuarantinedHostData(gomock.Any()).
+			DoAndReturn(func(quarantined model.QuarantinedHostData) error {
+				assert.Equal(t, "foobar", quarantined.Hostname)
+				assert.Equal(t, "Italy", quarantined.Location)
+				assert.Equal(t, "PRD", quarantined.Environment)
+				assert.Equal(t, 1, quarantined.SchemaVersion)
+				assert.Equal(t, utils.ErrInvalidHostdata.Error(), quarantined.Errors)
+				assert.Equal(t, raw, quarantined.Payload)
+				assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), quarantined.ReceivedAt)
+
+				return nil
+			})
+
+		err := hds.QuarantineHostData(raw, utils.ErrInvalidHostdata)
+		require.NoError(t, err)
+	})
+
+	t.Run("Hostdata with wrong fields", func(t *testing.T) {
+		raw := []byte(`{"hostname":42,"schemaVersion":"1"}`)
+
+		db.EXPECT().InsertQuarantinedHostData(gomock.Any()).
+			DoAndReturn(func(quarantined model.QuarantinedHostData) error {
+				assert.Equal(t, "", quarantined.Hostname)
+				assert.Equal(t, 0, quarantined.SchemaVersion)
+				assert.Equal(t, raw, quarantined.Payload)
+
+				return errMock
+			})
+
+		err := hds.QuarantineHostData(raw, utils.ErrInvalidHostdata)
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
+func TestFailQuarantinedHostDataReplay(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("6512f1a5ba2e2a1f4b6b8f31")
+	db.EXPECT().UpdateQuarantinedHostDataReplay(id, utils.ErrInvalidHostdata.Error()).Return(nil)
+
+	err := hds.FailQuarantinedHostDataReplay(id, utils.ErrInvalidHostdata)
+	require.NoError(t, err)
+}
+
+func TestIsQuarantinedHostDataOutdated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	quarantined := model.QuarantinedHostData{
+		Hostname:   "foobar",
+		ReceivedAt: utils.P("2019-11-04T10:00:00Z"),
+	}
+
+	t.Run("more recent hostdata of the canonical host", func(t *testing.T) {
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("foobar").Return(hostIdentity("foobar.example.com"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar.example.com", utils.MAX_TIME).
+				Return(&model.HostDataBE{CreatedAt: utils.P("2019-11-05T10:00:00Z")}, nil),
+		)
+
+		actual, err := hds.IsQuarantinedHostDataOutdated(quarantined)
+		require.NoError(t, err)
+		assert.True(t, actual)
+	})
+
+	t.Run("older hostdata", func(t *testing.T) {
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("foobar").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", utils.MAX_TIME).
+				Return(&model.HostDataBE{CreatedAt: utils.P("2019-11-03T10:00:00Z")}, nil),
+		)
+
+		actual, err := hds.IsQuarantinedHostDataOutdated(quarantined)
+		require.NoError(t, err)
+		assert.False(t, actual)
+	})
+
+	t.Run("new host", func(t *testing.T) {
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("foobar").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", utils.MAX_TIME).Return(nil, nil),
+		)
+
+		actual, err := hds.IsQuarantinedHostDataOutdated(quarantined)
+		require.NoError(t, err)
+		assert.False(t, actual)
+	})
+}
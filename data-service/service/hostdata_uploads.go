@@ -0,0 +1,240 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	alertservice_client "github.com/ercole-io/ercole/v2/alert-service/client"
+	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// EnqueueHostData save the hostdata in the queue, it will be processed by the queue workers
+func (hds *HostDataService) EnqueueHostData(hostname string, raw []byte) (*model.HostDataUpload, error) {
+	upload := model.HostDataUpload{
+		ID:         primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		Hostname:   hostname,
+		Status:     model.HostDataUploadStatusQueued,
+		Payload:    raw,
+		ReceivedAt: hds.TimeNow(),
+	}
+
+	if err := hds.Database.InsertHostDataUpload(upload); err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// GetHostDataUpload return the status of an upload
+func (hds *HostDataService) GetHostDataUpload(id primitive.ObjectID) (*model.HostDataUpload, error) {
+	return hds.Database.GetHostDataUpload(id)
+}
+
+// hostDataQueue contains the hosts whose uploads are processing by the workers of this instance:
+// the uploads of a host are processed one at a time, in the order they were received.
+// The uploads processing by the other instances are excluded by the leases of their hosts in the database
+type hostDataQueue struct {
+	mutex     sync.Mutex
+	hostnames map[string]bool
+}
+
+// StartHostDataQueueWorkers start the workers which process the queued uploads,
+// they stop when the context is done, after the upload they are processing
+func (hds *HostDataService) StartHostDataQueueWorkers(ctx context.Context, wg *sync.WaitGroup) {
+	conf := hds.Config.DataService.HostDataQueue
+
+	workers := conf.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	pollInterval := time.Duration(conf.PollInterval) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	staleThreshold := time.Duration(conf.StaleThreshold) * time.Minute
+	if staleThreshold <= 0 {
+		staleThreshold = 30 * time.Minute
+	}
+
+	// the uploads are requeued periodically, e.g. when another instance was stopped while processing them
+	go func() {
+		ticker := time.NewTicker(staleThreshold)
+		defer ticker.Stop()
+
+		for {
+			hds.requeueStaleHostDataUploads(staleThreshold)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	queue := &hostDataQueue{hostnames: make(map[string]bool)}
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				processed, err := hds.runHostDataQueueWorker(queue)
+				if err != nil {
+					hds.Log.Error(err)
+				}
+
+				if processed && err == nil {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(pollInterval):
+				}
+			}
+		}()
+	}
+
+	hds.Log.Infof("Started %d hostdata queue workers", workers)
+}
+
+func (hds *HostDataService) requeueStaleHostDataUploads(staleThreshold time.Duration) {
+	if count, err := hds.Database.RequeueStaleHostDataUploads(hds.TimeNow().Add(-staleThreshold)); err != nil {
+		hds.Log.Error(err)
+	} else if count > 0 {
+		hds.Log.Infof("%d stale hostdata uploads have been queued again", count)
+	}
+}
+
+// runHostDataQueueWorker process the next upload, a panic doesn't stop the worker:
+// the upload stays in processing and it's queued again when it becomes stale
+func (hds *HostDataService) runHostDataQueueWorker(queue *hostDataQueue) (processed bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			processed, err = false, fmt.Errorf("hostdata queue worker panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return hds.processNextHostDataUpload(queue)
+}
+
+// processNextHostDataUpload process the oldest queued upload, it return false if there wasn't any
+func (hds *HostDataService) processNextHostDataUpload(queue *hostDataQueue) (bool, error) {
+	upload, err := hds.claimHostDataUpload(queue)
+	if err != nil || upload == nil {
+		return false, err
+	}
+
+	defer func() {
+		queue.mutex.Lock()
+		delete(queue.hostnames, upload.Hostname)
+		queue.mutex.Unlock()
+	}()
+
+	status, errMsg := model.HostDataUploadStatusDone, ""
+
+	var hostdata model.HostDataBE
+	if err := json.Unmarshal(upload.Payload, &hostdata); err != nil {
+		status, errMsg = model.HostDataUploadStatusFailed, err.Error()
+	} else if err := hds.insertHostDataUpload(upload, hostdata); err != nil {
+		errMsg = err.Error()
+
+		if upload.Attempts < hds.Config.DataService.HostDataQueue.MaxAttempts {
+			status = model.HostDataUploadStatusQueued
+		} else {
+			status = model.HostDataUploadStatusFailed
+		}
+	}
+
+	return true, hds.Database.UpdateHostDataUploadStatus(upload.ID, status, errMsg)
+}
+
+// insertHostDataUpload insert the hostdata of the upload without throwing again
+// the alerts already thrown by the previous attempts
+func (hds *HostDataService) insertHostDataUpload(upload *model.HostDataUpload, hostdata model.HostDataBE) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while inserting the hostdata: %v", r)
+			hds.Log.Errorf("%s\n%s", err, debug.Stack())
+		}
+	}()
+
+	uploadHds := *hds
+	uploadHds.AlertSvcClient = &hostDataUploadAlertSvcClient{
+		AlertSvcClientInterface: hds.AlertSvcClient,
+		database:                hds.Database,
+		uploadID:                upload.ID,
+		thrownAlerts:            upload.ThrownAlerts,
+	}
+
+	return uploadHds.InsertHostData(hostdata)
+}
+
+// hostDataUploadAlertSvcClient save the alerts thrown while processing an upload
+// and skip the ones already thrown by the previous attempts
+type hostDataUploadAlertSvcClient struct {
+	alertservice_client.AlertSvcClientInterface
+	database     database.MongoDatabaseInterface
+	uploadID     primitive.ObjectID
+	thrownAlerts []string
+}
+
+func (c *hostDataUploadAlertSvcClient) ThrowNewAlert(alert model.Alert) error {
+	key := fmt.Sprintf("%s/%s", alert.AlertCode, alert.Description)
+	if utils.Contains(c.thrownAlerts, key) {
+		return nil
+	}
+
+	if err := c.AlertSvcClientInterface.ThrowNewAlert(alert); err != nil {
+		return err
+	}
+
+	return c.database.AddHostDataUploadThrownAlert(c.uploadID, key)
+}
+
+func (hds *HostDataService) claimHostDataUpload(queue *hostDataQueue) (*model.HostDataUpload, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	excluded := make([]string, 0, len(queue.hostnames))
+	for hostname := range queue.hostnames {
+		excluded = append(excluded, hostname)
+	}
+
+	upload, err := hds.Database.ClaimHostDataUpload(excluded)
+	if err != nil || upload == nil {
+		return nil, err
+	}
+
+	queue.hostnames[upload.Hostname] = true
+
+	return upload, nil
+}
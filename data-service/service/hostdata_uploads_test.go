@@ -0,0 +1,307 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestEnqueueHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	raw := []byte(`{"hostname":"foobar"}`)
+
+	db.EXPECT().InsertHostDataUpload(gomock.Any()).
+		DoAndReturn(func(upload model.HostDataUpload) error {
+			assert.Equal(t, "foobar", upload.Hostname)
+			assert.Equal(t, model.HostDataUploadStatusQueued, upload.Status)
+			assert.Equal(t, raw, upload.Payload)
+			assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), upload.ReceivedAt)
+
+			return nil
+		})
+
+	upload, err := hds.EnqueueHostData("foobar", raw)
+	require.NoError(t, err)
+	assert.Equal(t, model.HostDataUploadStatusQueued, upload.Status)
+}
+
+func TestProcessNextHostDataUpload(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{
+					MaxAttempts: 2,
+				},
+			},
+		},
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	newUpload := func(attempts int, payload string) *model.HostDataUpload {
+		return &model.HostDataUpload{
+			ID:       utils.Str2oid("000000000000000000000001"),
+			Hostname: "foobar",
+			Status:   model.HostDataUploadStatusProcessing,
+			Payload:  []byte(payload),
+			Attempts: attempts,
+		}
+	}
+
+	t.Run("empty queue", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{"barfoo": true}}
+
+		db.EXPECT().ClaimHostDataUpload([]string{"barfoo"}).Return(nil, nil)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.False(t, processed)
+	})
+
+	t.Run("done", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := newUpload(1, `{"hostname":"foobar"}`)
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity("foobar"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", gomock.Any()).Return(&model.HostDataBE{Hostname: "foobar"}, nil),
+			db.EXPECT().DismissHost("foobar").Return(nil),
+			db.EXPECT().InsertHostData(gomock.Any()).Return(nil),
+			db.EXPECT().DeleteNoDataAlertByHost("foobar").Return(nil),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusDone, "").Return(nil),
+		)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		assert.Empty(t, queue.hostnames)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := newUpload(1, `{"hostname":"foobar"}`)
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity("foobar"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", gomock.Any()).Return(nil, aerrMock),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusQueued, aerrMock.Error()).Return(nil),
+		)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("failed after max attempts", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := newUpload(2, `{"hostname":"foobar"}`)
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity("foobar"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", gomock.Any()).Return(nil, aerrMock),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusFailed, aerrMock.Error()).Return(nil),
+		)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := newUpload(1, `{"hostname":`)
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusFailed, "unexpected end of JSON input").Return(nil),
+		)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+}
+
+func TestProcessNextHostDataUpload_Retries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						NewHost: true,
+					},
+				},
+			},
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{
+					MaxAttempts: 3,
+				},
+			},
+		},
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	newServerAlertKey := model.AlertCodeNewServer + "/The host foobar was added to ercole"
+
+	t.Run("save the thrown alerts", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := &model.HostDataUpload{
+			ID:       utils.Str2oid("000000000000000000000001"),
+			Hostname: "foobar",
+			Status:   model.HostDataUploadStatusProcessing,
+			Payload:  []byte(`{"hostname":"foobar"}`),
+			Attempts: 1,
+		}
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity("foobar"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", gomock.Any()).Return(nil, nil),
+			asc.EXPECT().ThrowNewAlert(gomock.Any()).Return(nil),
+			db.EXPECT().AddHostDataUploadThrownAlert(upload.ID, newServerAlertKey).Return(nil),
+			db.EXPECT().DismissHost("foobar").Return(aerrMock),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusQueued, aerrMock.Error()).Return(nil),
+		)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("don't throw again the alerts of the previous attempts", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := &model.HostDataUpload{
+			ID:           utils.Str2oid("000000000000000000000001"),
+			Hostname:     "foobar",
+			Status:       model.HostDataUploadStatusProcessing,
+			Payload:      []byte(`{"hostname":"foobar"}`),
+			Attempts:     2,
+			ThrownAlerts: []string{newServerAlertKey},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity("foobar"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", gomock.Any()).Return(nil, nil),
+			db.EXPECT().DismissHost("foobar").Return(nil),
+			db.EXPECT().InsertHostData(gomock.Any()).Return(nil),
+			db.EXPECT().DeleteNoDataAlertByHost("foobar").Return(nil),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusDone, "").Return(nil),
+		)
+
+		processed, err := hds.processNextHostDataUpload(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("recover a panic", func(t *testing.T) {
+		queue := &hostDataQueue{hostnames: map[string]bool{}}
+		upload := &model.HostDataUpload{
+			ID:           utils.Str2oid("000000000000000000000001"),
+			Hostname:     "foobar",
+			Status:       model.HostDataUploadStatusProcessing,
+			Payload:      []byte(`{"hostname":"foobar"}`),
+			Attempts:     1,
+			ThrownAlerts: []string{newServerAlertKey},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().ClaimHostDataUpload([]string{}).Return(upload, nil),
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity("foobar"), nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", gomock.Any()).Return(nil, nil),
+			db.EXPECT().DismissHost("foobar").DoAndReturn(func(string) error { panic("boom") }),
+			db.EXPECT().UpdateHostDataUploadStatus(upload.ID, model.HostDataUploadStatusQueued, "panic while inserting the hostdata: boom").Return(nil),
+		)
+
+		processed, err := hds.runHostDataQueueWorker(queue)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		assert.Empty(t, queue.hostnames)
+	})
+}
+
+func TestStartHostDataQueueWorkers_Stop(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{
+					Workers:      2,
+					PollInterval: 60,
+				},
+			},
+		},
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	claimed := make(chan struct{}, 2)
+
+	db.EXPECT().RequeueStaleHostDataUploads(gomock.Any()).Return(int64(0), nil).AnyTimes()
+	db.EXPECT().ClaimHostDataUpload(gomock.Any()).
+		DoAndReturn(func([]string) (*model.HostDataUpload, error) {
+			claimed <- struct{}{}
+			return nil, nil
+		}).Times(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+
+	hds.StartHostDataQueueWorkers(ctx, wg)
+
+	<-claimed
+	<-claimed
+	cancel()
+
+	// the workers are waiting for the poll interval, they have to stop without claiming again
+	wg.Wait()
+}
@@ -29,9 +29,15 @@ func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
 	hostdata.ServerVersion = hds.ServerVersion
 	hostdata.Archived = false
 	hostdata.CreatedAt = hds.TimeNow()
-	hostdata.ServerSchemaVersion = model.SchemaVersion
+	hostdata.ServerSchemaVersion = model.ServerSchemaVersion
 	hostdata.ID = primitive.NewObjectIDFromTimestamp(hds.TimeNow())
 
+	reportedHostname := hostdata.Hostname
+	if err := hds.resolveHostIdentity(&hostdata); err != nil {
+		hds.Log.Error(err)
+		return err
+	}
+
 	previousHostdata, err := hds.Database.FindMostRecentHostDataOlderThan(hostdata.Hostname, hostdata.CreatedAt)
 	if err != nil {
 		hds.Log.Error(err)
@@ -62,6 +68,10 @@ func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
 		hds.mySqlDatabasesChecks(previousHostdata, &hostdata)
 	}
 
+	if hostdata.Features.Oracle != nil || hostdata.Features.Microsoft != nil || hostdata.Features.MySQL != nil {
+		hds.licenseIgnoreRulesChecks(&hostdata)
+	}
+
 	if hostdata.Clusters != nil {
 		hds.clusterInfoChecks(hostdata.Clusters)
 	}
@@ -71,6 +81,13 @@ func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
 		return err
 	}
 
+	if reportedHostname != hostdata.Hostname {
+		// the hostdata saved before with the reported name belong now to the canonical host
+		if err := hds.Database.DismissHost(reportedHostname); err != nil {
+			return err
+		}
+	}
+
 	if hds.Config.DataService.LogInsertingHostdata {
 		hds.Log.Info(utils.ToJSON(hostdata))
 	}
@@ -84,6 +101,14 @@ func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
 		hds.Log.Error(err)
 	}
 
+	if previousHostdata != nil {
+		hds.historicizeHostDataChanges(*previousHostdata, hostdata)
+	}
+
+	if hostdata.Features.Oracle != nil {
+		hds.updateOracleFeatureUsageLedger(hostdata)
+	}
+
 	if hostdata.Errors != nil && len(hostdata.Errors) > 0 {
 		if err := hds.throwAgentErrorsAlert(hostdata.Hostname, hostdata.Errors); err != nil {
 			hds.Log.Error(err)
@@ -72,6 +72,7 @@ func TestInsertHostData_Success(t *testing.T) {
 
 	t.Run("New host", func(t *testing.T) {
 		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity(hd.Hostname), nil),
 			db.EXPECT().FindMostRecentHostDataOlderThan(hd.Hostname, utils.P("2019-11-05T14:02:03Z")).Return(nil, nil),
 			asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
 				assert.Equal(t, "The host rac1_x was added to ercole", a.Description)
@@ -100,6 +101,7 @@ func TestInsertHostData_Success(t *testing.T) {
 		previousHostdata := &model.HostDataBE{Archived: true} // it's dismissed!
 
 		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity(hd.Hostname), nil),
 			db.EXPECT().FindMostRecentHostDataOlderThan(hd.Hostname, utils.P("2019-11-05T14:02:03Z")).
 				Return(previousHostdata, nil),
 			asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
@@ -128,6 +130,7 @@ func TestInsertHostData_Success(t *testing.T) {
 		previousHostdata := &model.HostDataBE{Archived: false}
 
 		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity(hd.Hostname), nil),
 			db.EXPECT().FindMostRecentHostDataOlderThan(hd.Hostname, utils.P("2019-11-05T14:02:03Z")).
 				Return(previousHostdata, nil),
 			db.EXPECT().DismissHost("rac1_x").Return(nil),
@@ -144,11 +147,47 @@ func TestInsertHostData_Success(t *testing.T) {
 				}).
 				Return(nil),
 			db.EXPECT().DeleteNoDataAlertByHost(hd.Hostname).Return(nil),
+			db.EXPECT().InsertHostDataChange(gomock.Any()).
+				Do(func(change model.HostDataChange) {
+					assert.Equal(t, "rac1_x", change.Hostname)
+					assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), change.Date)
+					assert.Contains(t, change.Changes, model.HostDataFieldChange{
+						Field:    model.HostDataChangeFieldEnvironment,
+						NewValue: hd.Environment,
+					})
+				}).
+				Return(nil),
 		)
 
 		err := hds.InsertHostData(hd)
 		require.NoError(t, err)
 	})
+	t.Run("Renamed host", func(t *testing.T) {
+		previousHostdata := &model.HostDataBE{Archived: false}
+		renamed := hd
+		renamed.Hostname = "rac1_x.example.com"
+
+		gomock.InOrder(
+			db.EXPECT().FindHostIdentityByAlias("rac1_x.example.com").Return(nil, utils.ErrHostIdentityNotFound),
+			db.EXPECT().FindHostIdentitiesByShortName("rac1_x").Return([]model.HostIdentity{*hostIdentity("rac1_x")}, nil),
+			db.EXPECT().AddHostIdentityAlias(model.NewHostIdentityID("rac1_x"), "rac1_x.example.com").Return(nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("rac1_x", utils.P("2019-11-05T14:02:03Z")).
+				Return(previousHostdata, nil),
+			db.EXPECT().DismissHost("rac1_x").Return(nil),
+			db.EXPECT().DismissHost("rac1_x.example.com").Return(nil),
+			db.EXPECT().InsertHostData(gomock.Any()).
+				Do(func(newHD model.HostDataBE) {
+					assert.Equal(t, "rac1_x", newHD.Hostname)
+					assert.Equal(t, model.NewHostIdentityID("rac1_x"), newHD.HostID)
+				}).
+				Return(nil),
+			db.EXPECT().DeleteNoDataAlertByHost("rac1_x").Return(nil),
+			db.EXPECT().InsertHostDataChange(gomock.Any()).Return(nil),
+		)
+
+		err := hds.InsertHostData(renamed)
+		require.NoError(t, err)
+	})
 }
 
 func TestInsertHostData_DatabaseError1(t *testing.T) {
@@ -185,6 +224,7 @@ func TestInsertHostData_DatabaseError1(t *testing.T) {
 	hd := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
 
 	gomock.InOrder(
+		db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity(hd.Hostname), nil),
 		db.EXPECT().FindMostRecentHostDataOlderThan(hd.Hostname, utils.P("2019-11-05T14:02:03Z")).Return(nil, nil),
 		asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
 			assert.Equal(t, "The host rac1_x was added to ercole", a.Description)
@@ -230,6 +270,7 @@ func TestInsertHostData_DatabaseError2(t *testing.T) {
 	hd := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
 
 	gomock.InOrder(
+		db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity(hd.Hostname), nil),
 		db.EXPECT().FindMostRecentHostDataOlderThan(hd.Hostname, utils.P("2019-11-05T14:02:03Z")).Return(nil, nil),
 		asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
 			assert.Equal(t, "The host rac1_x was added to ercole", a.Description)
@@ -285,6 +326,7 @@ func TestInsertHostData_HttpError(t *testing.T) {
 	hd := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
 
 	gomock.InOrder(
+		db.EXPECT().FindHostIdentityByAlias(gomock.Any()).Return(hostIdentity(hd.Hostname), nil),
 		db.EXPECT().FindMostRecentHostDataOlderThan(hd.Hostname, utils.P("2019-11-05T14:02:03Z")).Return(nil, nil),
 		asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
 			assert.Equal(t, "The host rac1_x was added to ercole", a.Description)
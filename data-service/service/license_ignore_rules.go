@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// licenseIgnoreRulesChecks apply the license ignore rules to the licenses used by hostdata and alert about
+// the licenses which are counted again because their rule is expired
+func (hds *HostDataService) licenseIgnoreRulesChecks(hostdata *model.HostDataBE) {
+	rules, err := hds.ApiSvcClient.GetLicenseIgnoreRules()
+	if err != nil {
+		hds.Log.Error(utils.NewError(err, "Can't retrieve license ignore rules"))
+		return
+	}
+
+	expired, _ := hds.applyLicenseIgnoreRules(hostdata, rules)
+	hds.alertExpiredLicenseIgnoreRules(hostdata.Hostname, expired)
+}
+
+// ApplyLicenseIgnoreRules apply the license ignore rules to the current hosts, so the licenses
+// of the rules expired or deleted after the last upload of a host are counted again
+func (hds *HostDataService) ApplyLicenseIgnoreRules() error {
+	rules, err := hds.ApiSvcClient.GetLicenseIgnoreRules()
+	if err != nil {
+		return utils.NewError(err, "Can't retrieve license ignore rules")
+	}
+
+	hosts, err := hds.Database.GetActiveHostdata()
+	if err != nil {
+		return err
+	}
+
+	for i := range hosts {
+		hostdata := &hosts[i]
+
+		expired, changed := hds.applyLicenseIgnoreRules(hostdata, rules)
+		if !changed {
+			continue
+		}
+
+		if err := hds.Database.UpdateHostDataLicenses(*hostdata); err != nil {
+			hds.Log.Errorf("Can't update the licenses of host %s: %s", hostdata.Hostname, err)
+			continue
+		}
+
+		hds.alertExpiredLicenseIgnoreRules(hostdata.Hostname, expired)
+	}
+
+	return nil
+}
+
+func (hds *HostDataService) alertExpiredLicenseIgnoreRules(hostname string, expired []string) {
+	if len(expired) == 0 || !hds.Config.AlertService.Emailer.AlertType.LicenseIgnoreRuleExpired {
+		return
+	}
+
+	if err := hds.throwLicenseIgnoreRuleExpiredAlert(hostname, expired); err != nil {
+		hds.Log.Error(err)
+	}
+}
+
+// applyLicenseIgnoreRules ignore the licenses matched by an active rule and count again the licenses
+// ignored by a rule which is expired, removed or doesn't match anymore.
+// Licenses ignored manually are left untouched. It return the license types counted again because of expired rules
+// and true if any license has been changed
+func (hds *HostDataService) applyLicenseIgnoreRules(hostdata *model.HostDataBE, rules []model.LicenseIgnoreRule) ([]string, bool) {
+	now := hds.TimeNow()
+
+	rulesByID := make(map[string]model.LicenseIgnoreRule, len(rules))
+	activeRules := make([]model.LicenseIgnoreRule, 0, len(rules))
+
+	for _, rule := range rules {
+		rulesByID[rule.ID.Hex()] = rule
+
+		if !rule.IsExpired(now) && rule.MatchHost(*hostdata) {
+			activeRules = append(activeRules, rule)
+		}
+	}
+
+	expiredLicenseTypes := make(map[string]bool)
+	changed := false
+
+	apply := func(licenseTypeID string, ignored *bool, comment, ruleID *string) {
+		if *ignored && *ruleID == "" {
+			return
+		}
+
+		for _, rule := range activeRules {
+			if rule.MatchLicenseType(licenseTypeID) {
+				changed = changed || !*ignored || *comment != rule.Justification || *ruleID != rule.ID.Hex()
+				*ignored = true
+				*comment = rule.Justification
+				*ruleID = rule.ID.Hex()
+
+				return
+			}
+		}
+
+		if *ruleID == "" {
+			return
+		}
+
+		if rule, ok := rulesByID[*ruleID]; ok && rule.IsExpired(now) {
+			expiredLicenseTypes[licenseTypeID] = true
+		}
+
+		*ignored = false
+		*comment = ""
+		*ruleID = ""
+		changed = true
+	}
+
+	if hostdata.Features.Oracle != nil && hostdata.Features.Oracle.Database != nil {
+		for _, db := range hostdata.Features.Oracle.Database.Databases {
+			for i := range db.Licenses {
+				license := &db.Licenses[i]
+				apply(license.LicenseTypeID, &license.Ignored, &license.IgnoredComment, &license.IgnoreRuleID)
+			}
+		}
+	}
+
+	if hostdata.Features.Microsoft != nil && hostdata.Features.Microsoft.SQLServer != nil {
+		for i := range hostdata.Features.Microsoft.SQLServer.Instances {
+			license := &hostdata.Features.Microsoft.SQLServer.Instances[i].License
+			if license.LicenseTypeID != "" {
+				apply(license.LicenseTypeID, &license.Ignored, &license.IgnoredComment, &license.IgnoreRuleID)
+			}
+		}
+	}
+
+	if hostdata.Features.MySQL != nil {
+		for i := range hostdata.Features.MySQL.Instances {
+			license := &hostdata.Features.MySQL.Instances[i].License
+			if license.LicenseTypeID != "" {
+				apply(license.LicenseTypeID, &license.Ignored, &license.IgnoredComment, &license.IgnoreRuleID)
+			}
+		}
+	}
+
+	res := make([]string, 0, len(expiredLicenseTypes))
+	for licenseTypeID := range expiredLicenseTypes {
+		res = append(res, licenseTypeID)
+	}
+
+	sort.Strings(res)
+
+	return res, changed
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var testLicenseIgnoreRules = []model.LicenseIgnoreRule{
+	{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f01"),
+		HostnameRegex: "^test-",
+		LicenseTypeID: "A90611",
+		Justification: "Test hosts covered by the development agreement",
+		ExpiresAt:     utils.P("2020-01-01T00:00:00Z"),
+	},
+	{
+		ID:            utils.Str2oid("6512f1a5ba2e2a1f4b6b8f02"),
+		Environment:   "TST",
+		LicenseTypeID: "A90649",
+		Justification: "Expired waiver",
+		ExpiresAt:     utils.P("2019-10-01T00:00:00Z"),
+	},
+}
+
+func licenseIgnoreRulesHostdata() model.HostDataBE {
+	return model.HostDataBE{
+		Hostname:    "test-db",
+		Environment: "TST",
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name: "ERCOLE",
+							Licenses: []model.OracleDatabaseLicense{
+								{LicenseTypeID: "A90611", Count: 2},
+								{LicenseTypeID: "A90649", Count: 2, Ignored: true, IgnoredComment: "Expired waiver",
+									IgnoreRuleID: "6512f1a5ba2e2a1f4b6b8f02"},
+								{LicenseTypeID: "A90650", Count: 2, Ignored: true, IgnoredComment: "Ignored by hand"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyLicenseIgnoreRules(t *testing.T) {
+	hds := HostDataService{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	hostdata := licenseIgnoreRulesHostdata()
+
+	expired, changed := hds.applyLicenseIgnoreRules(&hostdata, testLicenseIgnoreRules)
+	assert.Equal(t, []string{"A90649"}, expired)
+	assert.True(t, changed)
+
+	expected := []model.OracleDatabaseLicense{
+		{LicenseTypeID: "A90611", Count: 2, Ignored: true, IgnoredComment: "Test hosts covered by the development agreement",
+			IgnoreRuleID: "6512f1a5ba2e2a1f4b6b8f01"},
+		{LicenseTypeID: "A90649", Count: 2},
+		{LicenseTypeID: "A90650", Count: 2, Ignored: true, IgnoredComment: "Ignored by hand"},
+	}
+	assert.Equal(t, expected, hostdata.Features.Oracle.Database.Databases[0].Licenses)
+
+	t.Run("Rules already applied", func(t *testing.T) {
+		hostdata := hostdata
+
+		_, changed := hds.applyLicenseIgnoreRules(&hostdata, testLicenseIgnoreRules)
+		assert.False(t, changed)
+	})
+
+	t.Run("Rule not matching anymore", func(t *testing.T) {
+		hostdata.Hostname = "prod-db"
+
+		expired, changed := hds.applyLicenseIgnoreRules(&hostdata, testLicenseIgnoreRules)
+		assert.Empty(t, expired)
+		assert.True(t, changed)
+		assert.False(t, hostdata.Features.Oracle.Database.Databases[0].Licenses[0].Ignored)
+	})
+}
+
+func TestLicenseIgnoreRulesChecks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	alertsc := NewMockAlertSvcClientInterface(mockCtrl)
+	apisc := NewMockApiSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						LicenseIgnoreRuleExpired: true,
+					},
+				},
+			},
+		},
+		AlertSvcClient: alertsc,
+		ApiSvcClient:   apisc,
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	hostdata := licenseIgnoreRulesHostdata()
+
+	gomock.InOrder(
+		apisc.EXPECT().GetLicenseIgnoreRules().Return(testLicenseIgnoreRules, nil),
+		alertsc.EXPECT().ThrowNewAlert(&alertSimilarTo{al: model.Alert{
+			AlertCategory: model.AlertCategoryLicense,
+			AlertCode:     model.AlertCodeLicenseIgnoreRuleExpired,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusNew,
+			Date:          utils.P("2019-11-05T14:02:03Z"),
+			Description:   "The license ignore rules of host test-db are expired, the licenses A90649 are counted again",
+			OtherInfo: map[string]interface{}{
+				"hostname":       "test-db",
+				"licenseTypeIDs": []string{"A90649"},
+			},
+		}}).Return(nil),
+	)
+
+	hds.licenseIgnoreRulesChecks(&hostdata)
+
+	t.Run("Error retrieving rules", func(t *testing.T) {
+		hostdata := licenseIgnoreRulesHostdata()
+
+		apisc.EXPECT().GetLicenseIgnoreRules().Return(nil, errMock)
+
+		hds.licenseIgnoreRulesChecks(&hostdata)
+		require.True(t, hostdata.Features.Oracle.Database.Databases[0].Licenses[1].Ignored)
+	})
+}
+
+func TestApplyLicenseIgnoreRulesToCurrentHosts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	alertsc := NewMockAlertSvcClientInterface(mockCtrl)
+	apisc := NewMockApiSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						LicenseIgnoreRuleExpired: true,
+					},
+				},
+			},
+		},
+		Database:       db,
+		AlertSvcClient: alertsc,
+		ApiSvcClient:   apisc,
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	changedHost := licenseIgnoreRulesHostdata()
+	unchangedHost := model.HostDataBE{Hostname: "prod-db"}
+
+	gomock.InOrder(
+		apisc.EXPECT().GetLicenseIgnoreRules().Return(testLicenseIgnoreRules, nil),
+		db.EXPECT().GetActiveHostdata().Return([]model.HostDataBE{changedHost, unchangedHost}, nil),
+		db.EXPECT().UpdateHostDataLicenses(gomock.Any()).Do(func(hostdata model.HostDataBE) {
+			assert.Equal(t, "test-db", hostdata.Hostname)
+			assert.False(t, hostdata.Features.Oracle.Database.Databases[0].Licenses[1].Ignored)
+		}).Return(nil),
+		alertsc.EXPECT().ThrowNewAlert(&alertSimilarTo{al: model.Alert{
+			AlertCategory: model.AlertCategoryLicense,
+			AlertCode:     model.AlertCodeLicenseIgnoreRuleExpired,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusNew,
+			Date:          utils.P("2019-11-05T14:02:03Z"),
+			Description:   "The license ignore rules of host test-db are expired, the licenses A90649 are counted again",
+			OtherInfo: map[string]interface{}{
+				"hostname":       "test-db",
+				"licenseTypeIDs": []string{"A90649"},
+			},
+		}}).Return(nil),
+	)
+
+	require.NoError(t, hds.ApplyLicenseIgnoreRules())
+}
@@ -74,26 +74,18 @@ func (hds *HostDataService) setSqlServerVersion(hostdata *model.HostDataBE) *mod
 }
 
 func (hds *HostDataService) setSqlServerLicenseTypes(hostdata *model.HostDataBE, licenseTypes []model.SqlServerDatabaseLicenseType) {
-	var licenseCount float64
+	var coreLicenses float64
 
 	var listEnt = make(map[string]string)
 
 	info := hostdata.Info
 
-	if info.HardwareAbstraction == "PH" {
-		result := info.CPUCores / info.CPUSockets
-		if result < 4 {
-			licenseCount = float64(info.CPUSockets * 4)
-		} else {
-			licenseCount = float64(info.CPUCores)
-		}
-	} else if info.HardwareAbstraction == "VIRT" {
-		if info.CPUThreads < 4 {
-			licenseCount = 4
-		} else {
-			licenseCount = float64(info.CPUThreads)
-		}
-	} else {
+	switch info.HardwareAbstraction {
+	case "PH":
+		coreLicenses = model.SqlServerPhysicalCoreLicenses(info.CPUCores, info.CPUSockets)
+	case "VIRT":
+		coreLicenses = model.SqlServerVirtualCoreLicenses(info.CPUThreads)
+	default:
 		return
 	}
 
@@ -109,14 +101,15 @@ func (hds *HostDataService) setSqlServerLicenseTypes(hostdata *model.HostDataBE,
 					listEnt[licenseType.ID] = licenseType.ItemDescription
 				}
 
-				if instance.Edition != "ENT" && instance.Edition != "STD" {
+				if !model.IsSqlServerLicensedEdition(instance.Edition) {
 					license.Count = 0
 				} else {
-					license.Count = licenseCount / 2
+					license.Count = model.SqlServerCorePacks(coreLicenses)
 				}
 			}
 		}
 
+		license.Passive = license.Count > 0 && instance.IsPassive()
 		hostdata.Features.Microsoft.SQLServer.Instances[i].License = *license
 	}
 
@@ -144,6 +137,7 @@ func (hds *HostDataService) ignoreSqlServerPreviousLicences(previous, new *model
 		licenseTypeID string
 		ignored       bool
 		comment       string
+		ruleID        string
 	}
 
 	ignoredDbLicenses := make(map[int][]ignoredLicense)
@@ -152,7 +146,7 @@ func (hds *HostDataService) ignoreSqlServerPreviousLicences(previous, new *model
 		licenses := make([]ignoredLicense, 0)
 
 		if db.License.Ignored {
-			ignored := ignoredLicense{ignored: true, licenseTypeID: db.License.LicenseTypeID, comment: db.License.IgnoredComment}
+			ignored := ignoredLicense{ignored: true, licenseTypeID: db.License.LicenseTypeID, comment: db.License.IgnoredComment, ruleID: db.License.IgnoreRuleID}
 			licenses = append(licenses, ignored)
 		}
 
@@ -167,6 +161,7 @@ func (hds *HostDataService) ignoreSqlServerPreviousLicences(previous, new *model
 				if db.License.LicenseTypeID == v.licenseTypeID {
 					new.Features.Microsoft.SQLServer.Instances[i].License.Ignored = v.ignored
 					new.Features.Microsoft.SQLServer.Instances[i].License.IgnoredComment = v.comment
+					new.Features.Microsoft.SQLServer.Instances[i].License.IgnoreRuleID = v.ruleID
 				}
 			}
 		}
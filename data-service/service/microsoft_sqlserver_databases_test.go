@@ -132,3 +132,49 @@ func TestSqlServerVersion_Success(t *testing.T) {
 
 	assert.Contains(t, host.Features.Microsoft.SQLServer.Instances[0].Version, "2019")
 }
+
+func TestSetSqlServerLicenseTypes(t *testing.T) {
+	hds := HostDataService{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	licenseTypes := []model.SqlServerDatabaseLicenseType{
+		{ID: "ENT-2019", ItemDescription: "SQL Server Enterprise", Edition: "ENT", Version: "2019"},
+		{ID: "STD-2019", ItemDescription: "SQL Server Standard", Edition: "STD", Version: "2019"},
+		{ID: "DEV-2019", ItemDescription: "SQL Server Developer", Edition: "DEV", Version: "2019"},
+	}
+
+	hostdata := model.HostDataBE{
+		Hostname: "superhost1",
+		Info: model.Host{
+			HardwareAbstraction: "VIRT",
+			CPUThreads:          5,
+		},
+		Features: model.Features{
+			Microsoft: &model.MicrosoftFeature{
+				SQLServer: &model.MicrosoftSQLServerFeature{
+					Instances: []model.MicrosoftSQLServerInstance{
+						{Name: "primary", Edition: "STD", Version: "2019",
+							Databases: []model.MicrosoftSQLServerDatabase{{Status: "ONLINE"}}},
+						{Name: "secondary", Edition: "STD", Version: "2019",
+							Databases: []model.MicrosoftSQLServerDatabase{{Status: "RESTORING"}}},
+						{Name: "dev", Edition: "DEV", Version: "2019",
+							Databases: []model.MicrosoftSQLServerDatabase{{Status: "RESTORING"}}},
+					},
+				},
+			},
+		},
+	}
+
+	hds.setSqlServerLicenseTypes(&hostdata, licenseTypes)
+
+	expected := []model.MicrosoftSQLServerLicense{
+		{LicenseTypeID: "STD-2019", Name: "SQL Server Standard", Count: 3},
+		{LicenseTypeID: "STD-2019", Name: "SQL Server Standard", Count: 3, Passive: true},
+		{LicenseTypeID: "DEV-2019", Name: "SQL Server Developer", Count: 0},
+	}
+
+	for i, instance := range hostdata.Features.Microsoft.SQLServer.Instances {
+		assert.Equal(t, expected[i], instance.License)
+	}
+}
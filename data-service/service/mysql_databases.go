@@ -71,6 +71,7 @@ func (hds *HostDataService) ignoreMySqlPreviousLicences(previous, new *model.Hos
 		licenseTypeID string
 		ignored       bool
 		comment       string
+		ruleID        string
 	}
 
 	ignoredDbLicenses := make(map[string][]ignoredLicense)
@@ -79,7 +80,7 @@ func (hds *HostDataService) ignoreMySqlPreviousLicences(previous, new *model.Hos
 		licenses := make([]ignoredLicense, 0)
 
 		if db.License.Ignored {
-			ignored := ignoredLicense{ignored: true, licenseTypeID: db.License.LicenseTypeID, comment: db.License.IgnoredComment}
+			ignored := ignoredLicense{ignored: true, licenseTypeID: db.License.LicenseTypeID, comment: db.License.IgnoredComment, ruleID: db.License.IgnoreRuleID}
 			licenses = append(licenses, ignored)
 		}
 
@@ -94,6 +95,7 @@ func (hds *HostDataService) ignoreMySqlPreviousLicences(previous, new *model.Hos
 				if db.License.LicenseTypeID == v.licenseTypeID {
 					new.Features.MySQL.Instances[i].License.Ignored = v.ignored
 					new.Features.MySQL.Instances[i].License.IgnoredComment = v.comment
+					new.Features.MySQL.Instances[i].License.IgnoreRuleID = v.ruleID
 				}
 			}
 		}
@@ -0,0 +1,199 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// oracleDatabaseLicenseRulesFile is the file, relative to the resources directory, with the Oracle database license rules
+const oracleDatabaseLicenseRulesFile = "rules/oracle_database_license_rules.json"
+
+func (hds *HostDataService) loadOracleDatabaseLicenseRules() ([]model.OracleDatabaseLicenseRule, error) {
+	raw, err := os.ReadFile(filepath.Join(hds.Config.ResourceFilePath, oracleDatabaseLicenseRulesFile))
+	if err != nil {
+		return nil, utils.NewError(err, "Can't read Oracle database license rules")
+	}
+
+	rules := make([]model.OracleDatabaseLicenseRule, 0)
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, utils.NewError(err, "Can't parse Oracle database license rules")
+	}
+
+	if err := model.ValidateOracleDatabaseLicenseRules(rules); err != nil {
+		return nil, utils.NewError(err, "Can't load Oracle database license rules")
+	}
+
+	return rules, nil
+}
+
+// multitenantPDBLimitFinding is a database which needs the Multitenant license because of a pdbs rule
+type multitenantPDBLimitFinding struct {
+	db   model.OracleDatabase
+	rule model.OracleDatabaseLicenseRule
+}
+
+// checkLicenseRules add to the databases the licenses implied by the license rules,
+// throw an alert for each database with inconsistent licenses
+// and for each container database with more user pluggable databases than allowed by a pdbs rule
+func (hds *HostDataService) checkLicenseRules(hostdata *model.HostDataBE) {
+	rules := hds.oracleDatabaseLicenseRules
+	if len(rules) == 0 {
+		return
+	}
+
+	rulesByID := make(map[string]model.OracleDatabaseLicenseRule, len(rules))
+	for _, rule := range rules {
+		rulesByID[rule.ID] = rule
+	}
+
+	inconsistentDbs := make(map[string][]model.OracleDatabaseLicenseRuleFinding)
+	exceedingPDBsDbs := make([]multitenantPDBLimitFinding, 0)
+
+	for i := range hostdata.Features.Oracle.Database.Databases {
+		db := &hostdata.Features.Oracle.Database.Databases[i]
+
+		findings := db.ApplyLicenseRules(rules)
+		if len(findings) == 0 {
+			continue
+		}
+
+		db.LicenseRuleFindings = findings
+
+		for _, finding := range findings {
+			rule := rulesByID[finding.RuleID]
+
+			switch {
+			case !finding.Implied:
+				inconsistentDbs[db.Name] = append(inconsistentDbs[db.Name], finding)
+			case rule.Type == model.OracleDatabaseLicenseRulePDBs:
+				exceedingPDBsDbs = append(exceedingPDBsDbs, multitenantPDBLimitFinding{db: *db, rule: rule})
+			}
+		}
+	}
+
+	if hds.Config.AlertService.Emailer.AlertType.InconsistentLicenses {
+		hds.throwInconsistentLicensesAlerts(hostdata.Hostname, inconsistentDbs)
+	}
+
+	if hds.Config.AlertService.Emailer.AlertType.MultitenantPDBLimit {
+		hds.throwMultitenantPDBLimitAlerts(hostdata.Hostname, exceedingPDBsDbs)
+	}
+}
+
+// throwInconsistentLicensesAlerts ack the old INCONSISTENT_LICENSES alerts of the host,
+// so they don't stay open when its licenses become consistent, and throw the new ones
+func (hds *HostDataService) throwInconsistentLicensesAlerts(hostname string, inconsistentDbs map[string][]model.OracleDatabaseLicenseRuleFinding) {
+	if err := hds.ackOldInconsistentLicensesAlerts(hostname); err != nil {
+		hds.Log.Errorf("Can't ack InconsistentLicenses alerts by filter: %s", err)
+	}
+
+	dbnames := make([]string, 0, len(inconsistentDbs))
+	for dbname := range inconsistentDbs {
+		dbnames = append(dbnames, dbname)
+	}
+
+	sort.Strings(dbnames)
+
+	for _, dbname := range dbnames {
+		if err := hds.throwInconsistentLicensesAlert(hostname, dbname, inconsistentDbs[dbname]); err != nil {
+			hds.Log.Error(err)
+		}
+	}
+}
+
+// throwMultitenantPDBLimitAlerts ack the old MULTITENANT_PDB_LIMIT_EXCEEDED alerts of the host,
+// so they don't stay open when its databases are back under the limit, and throw the new ones
+func (hds *HostDataService) throwMultitenantPDBLimitAlerts(hostname string, findings []multitenantPDBLimitFinding) {
+	if err := hds.ackOldMultitenantPDBLimitAlerts(hostname); err != nil {
+		hds.Log.Errorf("Can't ack MultitenantPDBLimit alerts by filter: %s", err)
+	}
+
+	for _, finding := range findings {
+		if err := hds.throwMultitenantPDBLimitAlert(hostname, finding.db, finding.rule); err != nil {
+			hds.Log.Error(err)
+		}
+	}
+}
+
+func (hds *HostDataService) ackOldInconsistentLicensesAlerts(hostname string) error {
+	f := dto.AlertsFilter{
+		AlertCategory:           utils.Str2ptr(model.AlertCategoryLicense),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCode:               utils.Str2ptr(model.AlertCodeInconsistentLicenses),
+		AlertSeverity:           utils.Str2ptr(model.AlertSeverityWarning),
+		OtherInfo: map[string]interface{}{
+			"hostname": hostname,
+		},
+	}
+
+	return hds.ApiSvcClient.AckAlerts(f)
+}
+
+func (hds *HostDataService) ackOldMultitenantPDBLimitAlerts(hostname string) error {
+	f := dto.AlertsFilter{
+		AlertCategory:           utils.Str2ptr(model.AlertCategoryLicense),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCode:               utils.Str2ptr(model.AlertCodeMultitenantPDBLimit),
+		AlertSeverity:           utils.Str2ptr(model.AlertSeverityWarning),
+		OtherInfo: map[string]interface{}{
+			"hostname": hostname,
+		},
+	}
+
+	return hds.ApiSvcClient.AckAlerts(f)
+}
+
+// throwInconsistentLicensesAlert create and insert in the database a new INCONSISTENT_LICENSES alert
+func (hds *HostDataService) throwInconsistentLicensesAlert(hostname, dbname string, findings []model.OracleDatabaseLicenseRuleFinding) error {
+	licenses := make([]string, 0, len(findings))
+	explanations := make([]string, 0, len(findings))
+
+	for _, finding := range findings {
+		licenses = append(licenses, finding.License)
+		explanations = append(explanations, finding.Description)
+	}
+
+	alr := model.Alert{
+		ID:                      primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertCode:               model.AlertCodeInconsistentLicenses,
+		AlertSeverity:           model.AlertSeverityWarning,
+		AlertStatus:             model.AlertStatusNew,
+		Date:                    hds.TimeNow(),
+		Description: fmt.Sprintf("The database %s on %s has inconsistent licenses: %s",
+			dbname, hostname, strings.Join(explanations, "; ")),
+		OtherInfo: map[string]interface{}{
+			"hostname": hostname,
+			"dbname":   dbname,
+			"licenses": licenses,
+		},
+	}
+
+	return hds.AlertSvcClient.ThrowNewAlert(alr)
+}
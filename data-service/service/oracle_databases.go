@@ -35,6 +35,8 @@ func (hds *HostDataService) oracleDatabasesChecks(previousHostdata, hostdata *mo
 
 	hds.checkSecondaryDbs(hostdata)
 
+	hds.checkLicenseRules(hostdata)
+
 	licenseTypes, err := hds.getOracleDatabaseLicenseTypes(hostdata.Environment)
 	if err != nil {
 		hds.Log.Error(err)
@@ -50,6 +52,8 @@ func (hds *HostDataService) oracleDatabasesChecks(previousHostdata, hostdata *mo
 
 	hds.ignoreRacLicenses(hostdata)
 
+	hds.checkStandardEditionSocketLimit(hostdata)
+
 	var unlistedDatabasesAlerts []model.Alert
 
 	for _, dbname := range hostdata.Features.Oracle.Database.UnlistedRunningDatabases {
@@ -403,6 +407,7 @@ func (hds *HostDataService) ignorePreviousLicences(previous, new *model.HostData
 		licenseTypeID string
 		ignored       bool
 		comment       string
+		ruleID        string
 	}
 
 	ignoredDbLicenses := make(map[uint][]ignoredLicense)
@@ -412,7 +417,7 @@ func (hds *HostDataService) ignorePreviousLicences(previous, new *model.HostData
 
 		for _, license := range db.Licenses {
 			if license.Ignored {
-				ignored := ignoredLicense{ignored: true, licenseTypeID: license.LicenseTypeID, comment: license.IgnoredComment}
+				ignored := ignoredLicense{ignored: true, licenseTypeID: license.LicenseTypeID, comment: license.IgnoredComment, ruleID: license.IgnoreRuleID}
 				licenses = append(licenses, ignored)
 			}
 		}
@@ -429,6 +434,7 @@ func (hds *HostDataService) ignorePreviousLicences(previous, new *model.HostData
 					if db.Licenses[i].LicenseTypeID == v.licenseTypeID {
 						db.Licenses[i].Ignored = v.ignored
 						db.Licenses[i].IgnoredComment = v.comment
+						db.Licenses[i].IgnoreRuleID = v.ruleID
 					}
 				}
 			}
@@ -447,6 +453,40 @@ func (hds *HostDataService) ignoreRacLicenses(host *model.HostDataBE) {
 	}
 }
 
+// checkStandardEditionSocketLimit ack the previous SE2 socket limit alerts of the host,
+// so they are closed when it's back under the limit, and throw a new one if it still exceeds it
+func (hds *HostDataService) checkStandardEditionSocketLimit(host *model.HostDataBE) {
+	if !hds.Config.AlertService.Emailer.AlertType.SE2SocketLimit {
+		return
+	}
+
+	if err := hds.ackOldSE2SocketLimitAlerts(host.Hostname); err != nil {
+		hds.Log.Errorf("Can't ack SE2SocketLimit alerts by filter: %s", err)
+	}
+
+	if !host.HasOracleStandardEditionDatabases() || !host.ExceedsSE2SocketLimit() {
+		return
+	}
+
+	if err := hds.throwSE2SocketLimitAlert(host); err != nil {
+		hds.Log.Error(err)
+	}
+}
+
+func (hds *HostDataService) ackOldSE2SocketLimitAlerts(hostname string) error {
+	f := dto.AlertsFilter{
+		AlertCategory:           utils.Str2ptr(model.AlertCategoryLicense),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCode:               utils.Str2ptr(model.AlertCodeSE2SocketLimit),
+		AlertSeverity:           utils.Str2ptr(model.AlertSeverityWarning),
+		OtherInfo: map[string]interface{}{
+			"hostname": hostname,
+		},
+	}
+
+	return hds.ApiSvcClient.AckAlerts(f)
+}
+
 func (hds *HostDataService) checkMissingDatabases(previous, new *model.HostDataBE) {
 	if previous == nil ||
 		previous.Features.Oracle == nil ||
@@ -567,3 +607,59 @@ func (hds *HostDataService) findMissingDatabasesAndThrowAlerts(hostname string,
 
 	return nil
 }
+
+// updateOracleFeatureUsageLedger record in the ledger the features with detected usages of the databases of hostdata.
+// The data reported by the upload is copied in the entry as evidence, since the hostdata isn't retained forever
+func (hds *HostDataService) updateOracleFeatureUsageLedger(hostdata model.HostDataBE) {
+	if hostdata.Features.Oracle.Database == nil {
+		return
+	}
+
+	entries := make([]model.OracleFeatureUsageLedgerEntry, 0)
+
+	for _, db := range hostdata.Features.Oracle.Database.Databases {
+		for _, stat := range db.FeatureUsageStats {
+			if stat.DetectedUsages <= 0 {
+				continue
+			}
+
+			evidence := model.OracleFeatureUsageEvidence{
+				UploadID:         hostdata.ID,
+				UploadedAt:       hostdata.CreatedAt,
+				AgentVersion:     hostdata.AgentVersion,
+				DbVersion:        db.Version,
+				DbEdition:        db.Edition(),
+				DetectedUsages:   stat.DetectedUsages,
+				CurrentlyUsed:    stat.CurrentlyUsed,
+				FirstUsageDate:   stat.FirstUsageDate,
+				LastUsageDate:    stat.LastUsageDate,
+				ExtraFeatureInfo: stat.ExtraFeatureInfo,
+			}
+
+			entries = append(entries, model.OracleFeatureUsageLedgerEntry{
+				Hostname:       hostdata.Hostname,
+				DbName:         db.Name,
+				Product:        stat.Product,
+				Feature:        stat.Feature,
+				FirstDetected:  hostdata.CreatedAt,
+				FirstUploadID:  hostdata.ID,
+				LastDetected:   hostdata.CreatedAt,
+				LastUploadID:   hostdata.ID,
+				DetectedUsages: stat.DetectedUsages,
+				CurrentlyUsed:  stat.CurrentlyUsed,
+				FirstUsageDate: stat.FirstUsageDate,
+				LastUsageDate:  stat.LastUsageDate,
+				FirstEvidence:  evidence,
+				LastEvidence:   evidence,
+			})
+		}
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := hds.Database.UpdateOracleFeatureUsageLedger(entries); err != nil {
+		hds.Log.Error(err)
+	}
+}
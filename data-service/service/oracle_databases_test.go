@@ -17,12 +17,15 @@ package service
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	reflect "reflect"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	gomock "go.uber.org/mock/gomock"
 
@@ -1199,3 +1202,344 @@ func Test_ignoreRacLicenses_success(t *testing.T) {
 
 	assert.NotEqual(t, host, snapHost)
 }
+
+func TestCheckStandardEditionSocketLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	apisc := NewMockApiSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						SE2SocketLimit: true,
+					}}}},
+		AlertSvcClient: asc,
+		ApiSvcClient:   apisc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T16:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	host := model.HostDataBE{
+		Hostname: "foobar",
+		Info: model.Host{
+			CPUSockets: 4,
+		},
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{Name: "std", Version: "19.0.0.0 Standard Edition"},
+					},
+				},
+			},
+		},
+	}
+
+	apisc.EXPECT().AckAlerts(gomock.Any()).Return(nil).Times(2)
+	asc.EXPECT().ThrowNewAlert(&alertSimilarTo{al: model.Alert{
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCode:               model.AlertCodeSE2SocketLimit,
+		AlertSeverity:           model.AlertSeverityWarning,
+		OtherInfo: map[string]interface{}{
+			"hostname": "foobar",
+		},
+	}}).Return(nil)
+
+	hds.checkStandardEditionSocketLimit(&host)
+
+	// back under the limit, the old alert is acked without throwing a new one
+	host.Info.CPUSockets = 2
+	hds.checkStandardEditionSocketLimit(&host)
+}
+
+func TestUpdateOracleFeatureUsageLedger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	hostdata := model.HostDataBE{
+		ID:           utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		Hostname:     "foobar",
+		AgentVersion: "1.6.6",
+		CreatedAt:    utils.P("2019-11-05T14:02:03Z"),
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name:    "ERCOLE",
+							Version: "19.0.0.0.0 Enterprise Edition",
+							FeatureUsageStats: []model.OracleDatabaseFeatureUsageStat{
+								{
+									Product:        "Diagnostics Pack",
+									Feature:        "AWR Report",
+									DetectedUsages: 3,
+									CurrentlyUsed:  true,
+									FirstUsageDate: utils.P("2019-10-01T10:00:00Z"),
+									LastUsageDate:  utils.P("2019-11-01T10:00:00Z"),
+								},
+								{
+									Product: "Partitioning",
+									Feature: "Partitioning (user)",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	evidence := model.OracleFeatureUsageEvidence{
+		UploadID:       utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		UploadedAt:     utils.P("2019-11-05T14:02:03Z"),
+		AgentVersion:   "1.6.6",
+		DbVersion:      "19.0.0.0.0 Enterprise Edition",
+		DbEdition:      model.OracleDatabaseEditionEnterprise,
+		DetectedUsages: 3,
+		CurrentlyUsed:  true,
+		FirstUsageDate: utils.P("2019-10-01T10:00:00Z"),
+		LastUsageDate:  utils.P("2019-11-01T10:00:00Z"),
+	}
+	expected := []model.OracleFeatureUsageLedgerEntry{
+		{
+			Hostname:       "foobar",
+			DbName:         "ERCOLE",
+			Product:        "Diagnostics Pack",
+			Feature:        "AWR Report",
+			FirstDetected:  utils.P("2019-11-05T14:02:03Z"),
+			FirstUploadID:  utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+			LastDetected:   utils.P("2019-11-05T14:02:03Z"),
+			LastUploadID:   utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+			DetectedUsages: 3,
+			CurrentlyUsed:  true,
+			FirstUsageDate: utils.P("2019-10-01T10:00:00Z"),
+			LastUsageDate:  utils.P("2019-11-01T10:00:00Z"),
+			FirstEvidence:  evidence,
+			LastEvidence:   evidence,
+		},
+	}
+	db.EXPECT().UpdateOracleFeatureUsageLedger(expected).Return(nil)
+
+	hds.updateOracleFeatureUsageLedger(hostdata)
+}
+
+func TestInit_OracleDatabaseLicenseRules(t *testing.T) {
+	hds := HostDataService{
+		Config: config.Configuration{
+			ResourceFilePath: t.TempDir(),
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	// the rules file is missing
+	assert.Error(t, hds.Init())
+
+	rulesFile := filepath.Join(hds.Config.ResourceFilePath, oracleDatabaseLicenseRulesFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(rulesFile), 0o755))
+
+	require.NoError(t, os.WriteFile(rulesFile, []byte(`[{"id": "TUNING", "type": "implies", "license": "Tuning Pack"}]`), 0o600))
+	assert.ErrorIs(t, hds.Init(), utils.ErrInvalidOracleDatabaseLicenseRule)
+
+	require.NoError(t, os.WriteFile(rulesFile, []byte(`[{"id": "TUNING", "type": "implies"`), 0o600))
+	assert.Error(t, hds.Init())
+
+	hds.Config.ResourceFilePath = "../../resources"
+	require.NoError(t, hds.Init())
+	assert.NotEmpty(t, hds.oracleDatabaseLicenseRules)
+}
+
+func TestCheckLicenseRules(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	alertsc := NewMockAlertSvcClientInterface(mockCtrl)
+	apisc := NewMockApiSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			ResourceFilePath: "../../resources",
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						InconsistentLicenses: true,
+					},
+				},
+			},
+		},
+		AlertSvcClient: alertsc,
+		ApiSvcClient:   apisc,
+		Log:            logger.NewLogger("TEST"),
+	}
+	require.NoError(t, hds.Init())
+
+	hostdata := model.HostDataBE{
+		Hostname: "foobar",
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name:    "ERCOLE",
+							Version: "19.0.0.0.0 Enterprise Edition",
+							Licenses: []model.OracleDatabaseLicense{
+								{Name: "Oracle ENT", Count: 2},
+								{Name: "Tuning Pack", Count: 2},
+							},
+						},
+						{
+							Name:    "STDDB",
+							Version: "19.0.0.0.0 Standard Edition",
+							Licenses: []model.OracleDatabaseLicense{
+								{Name: "Oracle STD", Count: 1},
+								{Name: "Active Data Guard", Count: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		apisc.EXPECT().AckAlerts(dto.AlertsFilter{
+			AlertCategory:           utils.Str2ptr(model.AlertCategoryLicense),
+			AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+			AlertCode:               utils.Str2ptr(model.AlertCodeInconsistentLicenses),
+			AlertSeverity:           utils.Str2ptr(model.AlertSeverityWarning),
+			OtherInfo: map[string]interface{}{
+				"hostname": "foobar",
+			},
+		}).Return(nil),
+		alertsc.EXPECT().ThrowNewAlert(&alertSimilarTo{
+			al: model.Alert{
+				AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+				AlertCategory:           model.AlertCategoryLicense,
+				AlertCode:               model.AlertCodeInconsistentLicenses,
+				AlertSeverity:           model.AlertSeverityWarning,
+				OtherInfo: map[string]interface{}{
+					"hostname": "foobar",
+					"dbname":   "STDDB",
+					"licenses": []string{"Active Data Guard"},
+				},
+			}}).Return(nil),
+	)
+
+	hds.checkLicenseRules(&hostdata)
+
+	dbs := hostdata.Features.Oracle.Database.Databases
+	assert.Contains(t, dbs[0].Licenses, model.OracleDatabaseLicense{Name: "Diagnostics Pack", Count: 2})
+	assert.Len(t, dbs[0].LicenseRuleFindings, 1)
+	assert.True(t, dbs[0].LicenseRuleFindings[0].Implied)
+	assert.Len(t, dbs[1].LicenseRuleFindings, 1)
+	assert.False(t, dbs[1].LicenseRuleFindings[0].Implied)
+}
+
+func TestCheckMultitenantPDBLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	alertsc := NewMockAlertSvcClientInterface(mockCtrl)
+	apisc := NewMockApiSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			ResourceFilePath: "../../resources",
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						MultitenantPDBLimit: true,
+					},
+				},
+			},
+		},
+		AlertSvcClient: alertsc,
+		ApiSvcClient:   apisc,
+		Log:            logger.NewLogger("TEST"),
+	}
+	require.NoError(t, hds.Init())
+
+	hostdata := model.HostDataBE{
+		Hostname: "foobar",
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name:     "CDB1",
+							Version:  "19.0.0.0.0 Enterprise Edition",
+							Licenses: []model.OracleDatabaseLicense{{Name: "Oracle ENT", Count: 2}},
+							PDBs: []model.OracleDatabasePluggableDatabase{
+								{Name: "PDB$SEED"}, {Name: "PDB1"}, {Name: "PDB2"}, {Name: "PDB3"}, {Name: "PDB4"},
+							},
+						},
+						{
+							Name:     "CDB2",
+							Version:  "19.0.0.0.0 Enterprise Edition",
+							Licenses: []model.OracleDatabaseLicense{{Name: "Oracle ENT", Count: 2}},
+							PDBs: []model.OracleDatabasePluggableDatabase{
+								{Name: "PDB$SEED"}, {Name: "PDB1"}, {Name: "PDB2"}, {Name: "PDB3"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		apisc.EXPECT().AckAlerts(dto.AlertsFilter{
+			AlertCategory:           utils.Str2ptr(model.AlertCategoryLicense),
+			AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+			AlertCode:               utils.Str2ptr(model.AlertCodeMultitenantPDBLimit),
+			AlertSeverity:           utils.Str2ptr(model.AlertSeverityWarning),
+			OtherInfo: map[string]interface{}{
+				"hostname": "foobar",
+			},
+		}).Return(nil),
+		alertsc.EXPECT().ThrowNewAlert(&alertSimilarTo{
+			al: model.Alert{
+				AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+				AlertCategory:           model.AlertCategoryLicense,
+				AlertCode:               model.AlertCodeMultitenantPDBLimit,
+				AlertSeverity:           model.AlertSeverityWarning,
+				OtherInfo: map[string]interface{}{
+					"hostname": "foobar",
+					"dbname":   "CDB1",
+					"ruleID":   "MULTITENANT-19C-PDBS",
+				},
+			}}).Return(nil),
+	)
+
+	hds.checkLicenseRules(&hostdata)
+
+	dbs := hostdata.Features.Oracle.Database.Databases
+	assert.Contains(t, dbs[0].Licenses, model.OracleDatabaseLicense{Name: "Multitenant", Count: 2})
+	assert.Equal(t, []model.OracleDatabaseLicenseRuleFinding{{
+		RuleID:      "MULTITENANT-19C-PDBS",
+		License:     "Multitenant",
+		Implied:     true,
+		Description: "From 19c Multitenant is required by container databases with more than 3 user pluggable databases",
+	}}, dbs[0].LicenseRuleFindings)
+	assert.Len(t, dbs[1].Licenses, 1)
+
+	// back under the limit, the old alert is acked without throwing a new one
+	apisc.EXPECT().AckAlerts(dto.AlertsFilter{
+		AlertCategory:           utils.Str2ptr(model.AlertCategoryLicense),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCode:               utils.Str2ptr(model.AlertCodeMultitenantPDBLimit),
+		AlertSeverity:           utils.Str2ptr(model.AlertSeverityWarning),
+		OtherInfo: map[string]interface{}{
+			"hostname": "foobar",
+		},
+	}).Return(nil)
+
+	hostdata.Features.Oracle.Database.Databases = hostdata.Features.Oracle.Database.Databases[1:]
+	hds.checkLicenseRules(&hostdata)
+}
@@ -19,6 +19,8 @@ package service
 import (
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/logger"
 	"github.com/ercole-io/ercole/v2/model"
@@ -33,9 +35,22 @@ type HostDataServiceInterface interface {
 	InsertHostData(hostdata model.HostDataBE) error
 	AlertInvalidHostData(validationErr error, hostdata *model.HostDataBE)
 	CompareCmdbInfo(cmdbInfo dto.CmdbInfo) error
+	// ReconcileCmdb compare the current hosts and their attributes with the hosts pulled from a CMDB
+	ReconcileCmdb(cmdbName string, cmdbHosts []dto.CmdbHost) error
+	// ApplyLicenseIgnoreRules apply the license ignore rules to the current hosts, so the licenses
+	// of the rules expired or deleted after the last upload of a host are counted again
+	ApplyLicenseIgnoreRules() error
 	InsertOracleLicenseTypes(licenseTypes []model.OracleDatabaseLicenseType) error
 	SanitizeLicenseTypes(raw []byte) ([]model.OracleDatabaseLicenseType, error)
 	SaveExadata(exadata *model.OracleExadataInstance) error
+	EnqueueHostData(hostname string, raw []byte) (*model.HostDataUpload, error)
+	GetHostDataUpload(id primitive.ObjectID) (*model.HostDataUpload, error)
+
+	QuarantineHostData(raw []byte, validationErr error) error
+	GetQuarantinedHostData(id primitive.ObjectID) (*model.QuarantinedHostData, error)
+	IsQuarantinedHostDataOutdated(quarantined model.QuarantinedHostData) (bool, error)
+	FailQuarantinedHostDataReplay(id primitive.ObjectID, validationErr error) error
+	DeleteQuarantinedHostData(id primitive.ObjectID) error
 }
 
 type HostDataService struct {
@@ -46,4 +61,19 @@ type HostDataService struct {
 	ApiSvcClient   apiservice_client.ApiSvcClientInterface
 	TimeNow        func() time.Time
 	Log            logger.Logger
+
+	// oracleDatabaseLicenseRules are loaded by Init
+	oracleDatabaseLicenseRules []model.OracleDatabaseLicenseRule
+}
+
+// Init load the Oracle database license rules, it return an error if they can't be read or are invalid
+func (hds *HostDataService) Init() error {
+	rules, err := hds.loadOracleDatabaseLicenseRules()
+	if err != nil {
+		return err
+	}
+
+	hds.oracleDatabaseLicenseRules = rules
+
+	return nil
 }
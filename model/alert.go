@@ -32,6 +32,7 @@ type Alert struct {
 	Description             string                 `json:"description" bson:"description"`
 	Date                    time.Time              `json:"date" bson:"date"`
 	OtherInfo               map[string]interface{} `json:"otherInfo" bson:"otherInfo"`
+	Site                    string                 `json:"site,omitempty" bson:"site,omitempty"`
 }
 
 const (
@@ -54,6 +55,7 @@ const (
 	AlertCodeMissingHostInCmdb       string = "MISSING_HOST_IN_CMDB"
 	AlertCodeAgentError              string = "AGENT_ERROR"
 	AlertCodeDismissHost             string = "DISMISSED_HOST"
+	AlertCodeCmdbFieldMismatch       string = "CMDB_FIELD_MISMATCH"
 
 	// AGENT
 
@@ -61,18 +63,24 @@ const (
 
 	// LICENSE
 
-	AlertCodeNewDatabase       string = "NEW_DATABASE"
-	AlertCodeNewLicense        string = "NEW_LICENSE"
-	AlertCodeNewOption         string = "NEW_OPTION"
-	AlertCodeIncreasedCPUCores string = "INCREASED_CPU_CORES"
-	AlertCodeMissingDatabase   string = "MISSING_DATABASE"
+	AlertCodeNewDatabase              string = "NEW_DATABASE"
+	AlertCodeNewLicense               string = "NEW_LICENSE"
+	AlertCodeNewOption                string = "NEW_OPTION"
+	AlertCodeIncreasedCPUCores        string = "INCREASED_CPU_CORES"
+	AlertCodeMissingDatabase          string = "MISSING_DATABASE"
+	AlertCodeSE2SocketLimit           string = "SE2_SOCKET_LIMIT_EXCEEDED"
+	AlertCodeInconsistentLicenses     string = "INCONSISTENT_LICENSES"
+	AlertCodeMultitenantPDBLimit      string = "MULTITENANT_PDB_LIMIT_EXCEEDED"
+	AlertCodeLicenseIgnoreRuleExpired string = "LICENSE_IGNORE_RULE_EXPIRED"
 )
 
 func getAlertCodes() []string {
 	return []string{
 		AlertCodeNewServer, AlertCodeUnlistedRunningDatabase, AlertCodeMissingPrimaryDatabase, AlertCodeMissingHostInErcole, AlertCodeMissingHostInCmdb, AlertCodeAgentError,
+		AlertCodeCmdbFieldMismatch,
 		AlertCodeNoData,
 		AlertCodeNewDatabase, AlertCodeNewLicense, AlertCodeNewOption, AlertCodeIncreasedCPUCores, AlertCodeMissingDatabase, AlertCodeDismissHost,
+		AlertCodeSE2SocketLimit, AlertCodeInconsistentLicenses, AlertCodeMultitenantPDBLimit, AlertCodeLicenseIgnoreRuleExpired,
 	}
 }
 
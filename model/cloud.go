@@ -15,6 +15,11 @@
 
 package model
 
+import (
+	"fmt"
+	"math"
+)
+
 type Cloud struct {
 	Membership string `json:"membership" bson:"membership"`
 }
@@ -23,4 +28,70 @@ const (
 	CloudMembershipUnknown string = ""     // It's unknown if host is in a cloud
 	CloudMembershipNone    string = "None" // Host isn't in a known cloud
 	CloudMembershipAws     string = "AWS"
+	CloudMembershipAzure   string = "Azure"
+	CloudMembershipOci     string = "OCI"
 )
+
+// IsAuthorizedCloud returns true if membership is an Oracle authorized cloud environment
+func IsAuthorizedCloud(membership string) bool {
+	switch membership {
+	case CloudMembershipAws, CloudMembershipAzure, CloudMembershipOci:
+		return true
+	}
+
+	return false
+}
+
+// CloudLicensing contains how the processor licenses of a host in an authorized cloud environment are derived
+type CloudLicensing struct {
+	Membership        string  `json:"membership" bson:"membership"`
+	VCPUs             int     `json:"vcpus" bson:"vcpus"`
+	HyperThreading    bool    `json:"hyperThreading" bson:"hyperThreading"`
+	VCPUsPerLicense   int     `json:"vcpusPerLicense" bson:"vcpusPerLicense"`
+	ProcessorLicenses float64 `json:"processorLicenses" bson:"processorLicenses"`
+	Explanation       string  `json:"explanation" bson:"explanation"`
+}
+
+// NewCloudLicensing applies the Oracle authorized cloud environment policy to the host.
+// On AWS and Azure 2 vCPUs are counted as one processor license when hyper-threading is enabled,
+// otherwise every vCPU is a processor license.
+// On OCI every OCPU (a physical core, 2 vCPUs with hyper-threading) is a processor license
+func NewCloudLicensing(cloud Cloud, info Host) (*CloudLicensing, error) {
+	if !IsAuthorizedCloud(cloud.Membership) {
+		return nil, fmt.Errorf("%q isn't an authorized cloud environment", cloud.Membership)
+	}
+
+	vcpus := info.CPUThreads
+	if vcpus <= 0 {
+		vcpus = info.CPUCores
+	}
+
+	hyperThreading := info.ThreadsPerCore > 1 || (info.CPUCores > 0 && vcpus > info.CPUCores)
+
+	vcpusPerLicense := 1
+	if hyperThreading {
+		vcpusPerLicense = 2
+	}
+
+	cl := &CloudLicensing{
+		Membership:        cloud.Membership,
+		VCPUs:             vcpus,
+		HyperThreading:    hyperThreading,
+		VCPUsPerLicense:   vcpusPerLicense,
+		ProcessorLicenses: math.Ceil(float64(vcpus) / float64(vcpusPerLicense)),
+	}
+
+	switch {
+	case cloud.Membership == CloudMembershipOci:
+		cl.Explanation = fmt.Sprintf("%s: %d vCPUs are %v OCPUs, 1 OCPU = 1 processor license",
+			cl.Membership, cl.VCPUs, cl.ProcessorLicenses)
+	case hyperThreading:
+		cl.Explanation = fmt.Sprintf("%s: %d vCPUs with hyper-threading enabled, 2 vCPUs = 1 processor license",
+			cl.Membership, cl.VCPUs)
+	default:
+		cl.Explanation = fmt.Sprintf("%s: %d vCPUs with hyper-threading disabled, 1 vCPU = 1 processor license",
+			cl.Membership, cl.VCPUs)
+	}
+
+	return cl, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCloudLicensing(t *testing.T) {
+	testCases := []struct {
+		membership string
+		info       Host
+		licenses   float64
+		perLicense int
+	}{
+		{
+			membership: CloudMembershipAws,
+			info:       Host{CPUCores: 4, CPUThreads: 8, ThreadsPerCore: 2},
+			licenses:   4,
+			perLicense: 2,
+		},
+		{
+			membership: CloudMembershipAzure,
+			info:       Host{CPUCores: 4, CPUThreads: 4, ThreadsPerCore: 1},
+			licenses:   4,
+			perLicense: 1,
+		},
+		{
+			membership: CloudMembershipAzure,
+			info:       Host{CPUCores: 3, CPUThreads: 5, ThreadsPerCore: 2},
+			licenses:   3,
+			perLicense: 2,
+		},
+		{
+			membership: CloudMembershipOci,
+			info:       Host{CPUCores: 2, CPUThreads: 4, ThreadsPerCore: 2},
+			licenses:   2,
+			perLicense: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		actual, err := NewCloudLicensing(Cloud{Membership: tc.membership}, tc.info)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.licenses, actual.ProcessorLicenses)
+		assert.Equal(t, tc.perLicense, actual.VCPUsPerLicense)
+		assert.NotEmpty(t, actual.Explanation)
+	}
+
+	_, err := NewCloudLicensing(Cloud{Membership: CloudMembershipNone}, Host{})
+	assert.Error(t, err)
+}
+
+func TestCoreFactor(t *testing.T) {
+	host := HostDataBE{Info: Host{CPUCores: 4, CPUThreads: 8, ThreadsPerCore: 2}}
+	assert.Equal(t, 0.5, host.CoreFactor())
+
+	host.Cloud.Membership = CloudMembershipAws
+	assert.Equal(t, 1.0, host.CoreFactor())
+
+	host.Info = Host{CPUCores: 4, CPUThreads: 4, ThreadsPerCore: 1}
+	host.Cloud.Membership = CloudMembershipAzure
+	assert.Equal(t, 1.0, host.CoreFactor())
+}
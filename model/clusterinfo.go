@@ -24,3 +24,23 @@ type ClusterInfo struct {
 	Sockets       int      `json:"sockets" bson:"sockets"`
 	VMs           []VMInfo `json:"vms" bson:"vms"`
 }
+
+// Licensing policies applied to the clusters when computing the licenses used by their VMs
+const (
+	// ClusterLicensingPolicySoftPartitioning license all the cores of the cluster
+	ClusterLicensingPolicySoftPartitioning = "SoftPartitioning"
+	// ClusterLicensingPolicyHardPartitioning license only the vCPUs pinned to the VM
+	ClusterLicensingPolicyHardPartitioning = "HardPartitioning"
+	// ClusterLicensingPolicyVCenter license all the cores of the clusters managed by the same vCenter
+	ClusterLicensingPolicyVCenter = "VCenter"
+)
+
+// IsValidClusterLicensingPolicy return true if policy is a known cluster licensing policy
+func IsValidClusterLicensingPolicy(policy string) bool {
+	switch policy {
+	case ClusterLicensingPolicySoftPartitioning, ClusterLicensingPolicyHardPartitioning, ClusterLicensingPolicyVCenter:
+		return true
+	}
+
+	return false
+}
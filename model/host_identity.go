@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HostIdentity is the canonical identity of a host, shared by all the names the host is reported with
+type HostIdentity struct {
+	// ID is derived from the hostname sent by the agent when the identity is created and doesn't change when it's renamed
+	ID string `json:"id" bson:"_id"`
+	// Hostname is the canonical name, the hostdata of all the aliases are saved with it
+	Hostname string `json:"hostname" bson:"hostname"`
+	// Aliases contains the normalized names resolved to this identity
+	Aliases   []string  `json:"aliases" bson:"aliases"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// NewHostIdentity return a new identity of the host, resolved by the aliases
+func NewHostIdentity(hostname string, aliases []string, now time.Time) HostIdentity {
+	return HostIdentity{
+		ID:        NewHostIdentityID(aliases...),
+		Hostname:  hostname,
+		Aliases:   aliases,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NewHostIdentityID return the stable ID of the identity of the host, derived from its names.
+// The order of the names doesn't change the ID
+func NewHostIdentityID(hostnames ...string) string {
+	keys := HostIdentityKeys(hostnames...)
+	sort.Strings(keys)
+
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+
+	return hex.EncodeToString(sum[:12])
+}
+
+// NormalizeHostAlias return the name as it's saved in the aliases
+func NormalizeHostAlias(hostname string) string {
+	return strings.ToLower(strings.TrimSpace(hostname))
+}
+
+// HostIdentityKeys return the normalized hostnames used to resolve the identity of the host
+func HostIdentityKeys(hostnames ...string) []string {
+	keys := make([]string, 0, len(hostnames))
+	seen := make(map[string]bool)
+
+	for _, hostname := range hostnames {
+		key := NormalizeHostAlias(hostname)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// HostShortName return the normalized hostname without domain, it's empty if the hostname is an IP address
+func HostShortName(hostname string) string {
+	alias := NormalizeHostAlias(hostname)
+	if net.ParseIP(alias) != nil {
+		return ""
+	}
+
+	return strings.Split(alias, ".")[0]
+}
+
+// HasAlias return true if the name is an alias of the identity
+func (identity HostIdentity) HasAlias(hostname string) bool {
+	alias := NormalizeHostAlias(hostname)
+
+	for _, a := range identity.Aliases {
+		if a == alias {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostIdentityKeys(t *testing.T) {
+	assert.Equal(t, []string{"foobar"}, HostIdentityKeys("FooBar"))
+	assert.Equal(t, []string{"foobar.example.com"}, HostIdentityKeys("foobar.example.com"))
+	assert.Equal(t, []string{"foobar", "foobar.example.com"}, HostIdentityKeys("foobar", "FOOBAR.example.com"))
+	assert.Equal(t, []string{"10.0.0.1"}, HostIdentityKeys("10.0.0.1"))
+	assert.Equal(t, []string{"foobar"}, HostIdentityKeys("foobar", ""))
+}
+
+func TestHostShortName(t *testing.T) {
+	assert.Equal(t, "foobar", HostShortName("FooBar.example.com"))
+	assert.Equal(t, "foobar", HostShortName("foobar"))
+	assert.Equal(t, "", HostShortName("10.0.0.1"))
+}
+
+func TestNewHostIdentityID(t *testing.T) {
+	assert.Equal(t, NewHostIdentityID("foobar.example.com"), NewHostIdentityID("FOOBAR.example.com"))
+	assert.NotEqual(t, NewHostIdentityID("foobar.example.com"), NewHostIdentityID("foobar"))
+	assert.Equal(t, NewHostIdentityID("foobar", "foobar.example.com"), NewHostIdentityID("foobar.example.com", "FooBar"))
+	assert.NotEqual(t, NewHostIdentityID("foobar", "foobar.example.com"), NewHostIdentityID("foobar"))
+	assert.Len(t, NewHostIdentityID("foobar"), 24)
+}
+
+func TestHostIdentityHasAlias(t *testing.T) {
+	identity := HostIdentity{Hostname: "foobar", Aliases: []string{"foobar", "foobar.example.com"}}
+
+	assert.True(t, identity.HasAlias("FOOBAR.example.com"))
+	assert.False(t, identity.HasAlias("barfoo"))
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HostLicenseType holds informations about a single operating system or virtualization license type
+type HostLicenseType struct {
+	ID              string `json:"id" bson:"_id"`
+	ItemDescription string `json:"itemDescription" bson:"itemDescription"`
+	Metric          string `json:"metric" bson:"metric"`
+}
+
+// Host license types
+const (
+	// HostLicenseTypeRHELSocketPair is the RHEL subscription of a physical host, counted per pair of sockets
+	HostLicenseTypeRHELSocketPair = "RHEL-SOCKET-PAIR"
+	// HostLicenseTypeRHELVirtual is the RHEL subscription of a virtual host, counted per VM
+	HostLicenseTypeRHELVirtual = "RHEL-VM"
+	// HostLicenseTypeWindowsServerCore is the Windows Server license, counted per core
+	HostLicenseTypeWindowsServerCore = "WINSRV-CORE"
+	// HostLicenseTypeVSphereCPU is the VMware vSphere license, counted per CPU
+	HostLicenseTypeVSphereCPU = "VSPHERE-CPU"
+)
+
+// Metrics of the host licenses
+const (
+	HostLicenseMetricSocketPair = "SOCKET-PAIR"
+	HostLicenseMetricVM         = "VM"
+	HostLicenseMetricCore       = "CORE"
+	HostLicenseMetricCPU        = "CPU"
+)
+
+// Licensing rules of Windows Server and VMware vSphere
+const (
+	// WindowsServerMinCoresPerProcessor is the minimum number of cores licensed for each processor
+	WindowsServerMinCoresPerProcessor = 8
+	// WindowsServerMinCoresPerServer is the minimum number of cores licensed for each physical server
+	WindowsServerMinCoresPerServer = 16
+	// WindowsServerMinCoresPerVM is the minimum number of cores licensed for each virtual machine
+	WindowsServerMinCoresPerVM = 8
+	// WindowsServerCoresPerPack is the number of cores of a Windows Server license pack
+	WindowsServerCoresPerPack = 2
+	// VSphereMaxCoresPerCPU is the maximum number of cores covered by a single vSphere CPU license
+	VSphereMaxCoresPerCPU = 32
+)
+
+// HostLicenseTypes is the list of the license types of operating systems and virtualization platforms
+var HostLicenseTypes = []HostLicenseType{
+	{
+		ID:              HostLicenseTypeRHELSocketPair,
+		ItemDescription: "Red Hat Enterprise Linux Server - physical",
+		Metric:          HostLicenseMetricSocketPair,
+	},
+	{
+		ID:              HostLicenseTypeRHELVirtual,
+		ItemDescription: "Red Hat Enterprise Linux Server - virtual",
+		Metric:          HostLicenseMetricVM,
+	},
+	{
+		ID:              HostLicenseTypeWindowsServerCore,
+		ItemDescription: "Windows Server",
+		Metric:          HostLicenseMetricCore,
+	},
+	{
+		ID:              HostLicenseTypeVSphereCPU,
+		ItemDescription: "VMware vSphere",
+		Metric:          HostLicenseMetricCPU,
+	},
+}
+
+// HostContract holds informations about a single operating system or virtualization contract
+type HostContract struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id"`
+	ContractID        string             `json:"contractID" bson:"contractID"`
+	CSI               string             `json:"csi" bson:"csi"`
+	LicenseTypeID     string             `json:"licenseTypeID" bson:"licenseTypeID"`
+	NumberOfLicenses  uint               `json:"numberOfLicenses" bson:"numberOfLicenses"`
+	SupportExpiration *time.Time         `json:"supportExpiration" bson:"supportExpiration"`
+}
+
+func (contract HostContract) IsValid() bool {
+	if contract.ContractID == "" || contract.NumberOfLicenses == 0 {
+		return false
+	}
+
+	for _, licenseType := range HostLicenseTypes {
+		if licenseType.ID == contract.LicenseTypeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRedHatEnterpriseLinux return true if os is a Red Hat Enterprise Linux distribution
+func IsRedHatEnterpriseLinux(os string) bool {
+	return strings.Contains(os, "Red Hat")
+}
+
+// IsWindowsServer return true if os is a Windows Server edition
+func IsWindowsServer(os string) bool {
+	return strings.Contains(os, "Windows Server")
+}
+
+// RHELSocketPairs return the number of socket pairs subscriptions needed by a physical host
+func RHELSocketPairs(sockets int) float64 {
+	if sockets < 1 {
+		sockets = 1
+	}
+
+	return math.Ceil(float64(sockets) / 2)
+}
+
+// WindowsServerCores return the number of core licenses needed by a Windows Server host,
+// applying the minimums per processor, per server and per VM and rounding up to whole packs
+func WindowsServerCores(cores, sockets int, virtual bool) float64 {
+	var licensed int
+
+	if virtual {
+		licensed = cores
+		if licensed < WindowsServerMinCoresPerVM {
+			licensed = WindowsServerMinCoresPerVM
+		}
+	} else {
+		if sockets < 1 {
+			sockets = 1
+		}
+
+		coresPerProcessor := int(math.Ceil(float64(cores) / float64(sockets)))
+		if coresPerProcessor < WindowsServerMinCoresPerProcessor {
+			coresPerProcessor = WindowsServerMinCoresPerProcessor
+		}
+
+		licensed = coresPerProcessor * sockets
+		if licensed < WindowsServerMinCoresPerServer {
+			licensed = WindowsServerMinCoresPerServer
+		}
+	}
+
+	return math.Ceil(float64(licensed)/WindowsServerCoresPerPack) * WindowsServerCoresPerPack
+}
+
+// VSphereCPUs return the number of CPU licenses needed by a vSphere cluster:
+// every CPU needs a license for each block of up to 32 cores
+func VSphereCPUs(cores, sockets int) float64 {
+	if sockets < 1 {
+		return 0
+	}
+
+	coresPerCPU := math.Ceil(float64(cores) / float64(sockets))
+	licensesPerCPU := math.Ceil(coresPerCPU / VSphereMaxCoresPerCPU)
+
+	if licensesPerCPU < 1 {
+		licensesPerCPU = 1
+	}
+
+	return float64(sockets) * licensesPerCPU
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRHELSocketPairs(t *testing.T) {
+	assert.Equal(t, float64(1), RHELSocketPairs(0))
+	assert.Equal(t, float64(1), RHELSocketPairs(1))
+	assert.Equal(t, float64(1), RHELSocketPairs(2))
+	assert.Equal(t, float64(2), RHELSocketPairs(3))
+	assert.Equal(t, float64(2), RHELSocketPairs(4))
+}
+
+func TestWindowsServerCores(t *testing.T) {
+	testCases := []struct {
+		cores    int
+		sockets  int
+		virtual  bool
+		expected float64
+	}{
+		{cores: 4, sockets: 1, virtual: false, expected: 16},
+		{cores: 12, sockets: 2, virtual: false, expected: 16},
+		{cores: 24, sockets: 2, virtual: false, expected: 24},
+		{cores: 36, sockets: 4, virtual: false, expected: 36},
+		{cores: 42, sockets: 2, virtual: false, expected: 42},
+		{cores: 2, sockets: 1, virtual: true, expected: 8},
+		{cores: 11, sockets: 1, virtual: true, expected: 12},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, WindowsServerCores(tc.cores, tc.sockets, tc.virtual), tc)
+	}
+}
+
+func TestVSphereCPUs(t *testing.T) {
+	assert.Equal(t, float64(0), VSphereCPUs(16, 0))
+	assert.Equal(t, float64(2), VSphereCPUs(32, 2))
+	assert.Equal(t, float64(4), VSphereCPUs(128, 2))
+	assert.Equal(t, float64(4), VSphereCPUs(66, 2))
+}
+
+func TestHostContract_IsValid(t *testing.T) {
+	assert.True(t, HostContract{ContractID: "RH001", LicenseTypeID: HostLicenseTypeRHELVirtual, NumberOfLicenses: 10}.IsValid())
+	assert.False(t, HostContract{ContractID: "RH001", LicenseTypeID: "UNKNOWN", NumberOfLicenses: 10}.IsValid())
+	assert.False(t, HostContract{ContractID: "RH001", LicenseTypeID: HostLicenseTypeRHELVirtual}.IsValid())
+	assert.False(t, HostContract{LicenseTypeID: HostLicenseTypeRHELVirtual, NumberOfLicenses: 10}.IsValid())
+}
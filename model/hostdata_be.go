@@ -16,14 +16,16 @@
 package model
 
 import (
+	"math"
 	"time"
 
 	"github.com/ercole-io/ercole/v2/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// ServerSchemaVersion contains the version of the schema
-const ServerSchemaVersion int = 1
+// ServerSchemaVersion contains the version of the schema of the hostdata stored by the server,
+// the ones received with older versions are upgraded to it
+const ServerSchemaVersion int = SchemaVersion
 
 // HostDataBE holds all informations about a host & services
 type HostDataBE struct {
@@ -36,8 +38,10 @@ type HostDataBE struct {
 	Period              uint               `json:"period" bson:"period"`
 
 	Hostname                string                  `json:"hostname" bson:"hostname"`
+	HostID                  string                  `json:"hostID,omitempty" bson:"hostID,omitempty"`
 	Location                string                  `json:"location" bson:"location"`
 	Environment             string                  `json:"environment" bson:"environment"`
+	Site                    string                  `json:"site,omitempty" bson:"site,omitempty"`
 	AgentVersion            string                  `json:"agentVersion" bson:"agentVersion"`
 	Tags                    []string                `json:"tags" bson:"tags"`
 	Info                    Host                    `json:"info" bson:"info"`
@@ -73,10 +77,63 @@ func (v *HostDataBE) GetClusterCores(hostdatasPerHostname map[string]*HostDataBE
 	return sumClusterCores, nil
 }
 
+// CoreFactor returns the factor to multiply cores by to obtain processor licenses.
+// In authorized cloud environments it's derived from the vCPUs licensing policy
 func (v *HostDataBE) CoreFactor() float64 {
-	if v.Cloud.Membership == CloudMembershipAws {
+	if !v.IsInAuthorizedCloud() {
+		return 0.5
+	}
+
+	cl, err := NewCloudLicensing(v.Cloud, v.Info)
+	if err != nil || v.Info.CPUCores <= 0 {
+		return 1
+	}
+
+	return cl.ProcessorLicenses / float64(v.Info.CPUCores)
+}
+
+// IsInAuthorizedCloud returns true if the host runs in an Oracle authorized cloud environment
+func (v *HostDataBE) IsInAuthorizedCloud() bool {
+	return IsAuthorizedCloud(v.Cloud.Membership)
+}
+
+// SE2Sockets returns the number of sockets to be licensed by Standard Edition 2 databases running on the host.
+// In authorized cloud environments every 4 vCPUs (rounded up) are counted as one occupied socket
+func (v *HostDataBE) SE2Sockets() float64 {
+	if v.IsInAuthorizedCloud() {
+		vcpus := math.Max(float64(v.Info.CPUThreads), 1)
+
+		return math.Ceil(vcpus / OracleDatabaseSE2CloudVCPUsPerSocket)
+	}
+
+	if v.Info.CPUSockets < 1 {
 		return 1
 	}
 
-	return 0.5
+	return float64(v.Info.CPUSockets)
+}
+
+// ExceedsSE2SocketLimit returns true if the host can't run Standard Edition 2 databases
+// because it exceeds the maximum number of sockets (or vCPUs in authorized cloud environments)
+func (v *HostDataBE) ExceedsSE2SocketLimit() bool {
+	if v.IsInAuthorizedCloud() {
+		return v.Info.CPUThreads > OracleDatabaseSE2CloudMaxVCPUs
+	}
+
+	return v.Info.CPUSockets > OracleDatabaseSE2MaxSockets
+}
+
+// HasOracleStandardEditionDatabases returns true if the host runs at least one Standard Edition database
+func (v *HostDataBE) HasOracleStandardEditionDatabases() bool {
+	if v.Features.Oracle == nil || v.Features.Oracle.Database == nil {
+		return false
+	}
+
+	for _, db := range v.Features.Oracle.Database.Databases {
+		if db.Edition() == OracleDatabaseEditionStandard {
+			return true
+		}
+	}
+
+	return false
 }
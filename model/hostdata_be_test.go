@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSE2Sockets(t *testing.T) {
+	testCases := []struct {
+		host     HostDataBE
+		sockets  float64
+		exceeded bool
+	}{
+		{
+			host:     HostDataBE{Info: Host{CPUSockets: 2, CPUThreads: 32}},
+			sockets:  2,
+			exceeded: false,
+		},
+		{
+			host:     HostDataBE{Info: Host{CPUSockets: 0}},
+			sockets:  1,
+			exceeded: false,
+		},
+		{
+			host:     HostDataBE{Info: Host{CPUSockets: 4}},
+			sockets:  4,
+			exceeded: true,
+		},
+		{
+			host:     HostDataBE{Info: Host{CPUSockets: 1, CPUThreads: 6}, Cloud: Cloud{Membership: CloudMembershipAws}},
+			sockets:  2,
+			exceeded: false,
+		},
+		{
+			host:     HostDataBE{Info: Host{CPUSockets: 1, CPUThreads: 16}, Cloud: Cloud{Membership: CloudMembershipAws}},
+			sockets:  4,
+			exceeded: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.sockets, tc.host.SE2Sockets())
+		assert.Equal(t, tc.exceeded, tc.host.ExceedsSE2SocketLimit())
+	}
+}
+
+func TestHasOracleStandardEditionDatabases(t *testing.T) {
+	host := HostDataBE{}
+	assert.False(t, host.HasOracleStandardEditionDatabases())
+
+	host.Features.Oracle = &OracleFeature{
+		Database: &OracleDatabaseFeature{
+			Databases: []OracleDatabase{
+				{Name: "ent", Version: "19.0.0.0 Enterprise Edition"},
+			},
+		},
+	}
+	assert.False(t, host.HasOracleStandardEditionDatabases())
+
+	host.Features.Oracle.Database.Databases = append(host.Features.Oracle.Database.Databases,
+		OracleDatabase{Name: "std", Version: "19.0.0.0 Standard Edition"})
+	assert.True(t, host.HasOracleStandardEditionDatabases())
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fields tracked in the change log of the hosts
+const (
+	HostDataChangeFieldEnvironment                   = "environment"
+	HostDataChangeFieldLocation                      = "location"
+	HostDataChangeFieldCPUModel                      = "info.cpuModel"
+	HostDataChangeFieldCPUSockets                    = "info.cpuSockets"
+	HostDataChangeFieldCPUCores                      = "info.cpuCores"
+	HostDataChangeFieldCPUThreads                    = "info.cpuThreads"
+	HostDataChangeFieldHardwareAbstractionTechnology = "info.hardwareAbstractionTechnology"
+	HostDataChangeFieldOS                            = "info.os"
+	HostDataChangeFieldOSVersion                     = "info.osVersion"
+	HostDataChangeFieldKernelVersion                 = "info.kernelVersion"
+	HostDataChangeFieldMemoryTotal                   = "info.memoryTotal"
+	HostDataChangeFieldOracleDatabase                = "oracle.database"
+	HostDataChangeFieldOracleDatabaseVersion         = "oracle.database.version"
+	HostDataChangeFieldOracleDatabasePatch           = "oracle.database.patch"
+	HostDataChangeFieldOracleDatabasePSU             = "oracle.database.psu"
+	HostDataChangeFieldOracleDatabaseTablespace      = "oracle.database.tablespace"
+	HostDataChangeFieldOracleDatabaseOption          = "oracle.database.option"
+	HostDataChangeFieldSQLServerInstance             = "microsoft.sqlServer.instance"
+	HostDataChangeFieldSQLServerInstanceVersion      = "microsoft.sqlServer.instance.version"
+	HostDataChangeFieldMySQLInstance                 = "mysql.instance"
+	HostDataChangeFieldMySQLInstanceVersion          = "mysql.instance.version"
+)
+
+// HostDataChange contains the fields of an host changed by an upload
+type HostDataChange struct {
+	ID          primitive.ObjectID    `json:"id" bson:"_id"`
+	Hostname    string                `json:"hostname" bson:"hostname"`
+	Environment string                `json:"environment" bson:"environment"`
+	Location    string                `json:"location" bson:"location"`
+	Date        time.Time             `json:"date" bson:"date"`
+	Changes     []HostDataFieldChange `json:"changes" bson:"changes"`
+}
+
+// HostDataFieldChange is a field changed between two uploads of an host.
+// The items added to a list have an empty OldValue, the removed ones an empty NewValue
+type HostDataFieldChange struct {
+	Field    string `json:"field" bson:"field"`
+	Database string `json:"database,omitempty" bson:"database,omitempty"`
+	OldValue string `json:"oldValue" bson:"oldValue"`
+	NewValue string `json:"newValue" bson:"newValue"`
+}
+
+// DiffHostData return the tracked fields changed from previous to current
+func DiffHostData(previous, current HostDataBE) []HostDataFieldChange {
+	changes := make([]HostDataFieldChange, 0)
+
+	appendIfChanged := func(field, database, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, HostDataFieldChange{Field: field, Database: database, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	appendIfChanged(HostDataChangeFieldEnvironment, "", previous.Environment, current.Environment)
+	appendIfChanged(HostDataChangeFieldLocation, "", previous.Location, current.Location)
+	appendIfChanged(HostDataChangeFieldCPUModel, "", previous.Info.CPUModel, current.Info.CPUModel)
+	appendIfChanged(HostDataChangeFieldCPUSockets, "", strconv.Itoa(previous.Info.CPUSockets), strconv.Itoa(current.Info.CPUSockets))
+	appendIfChanged(HostDataChangeFieldCPUCores, "", strconv.Itoa(previous.Info.CPUCores), strconv.Itoa(current.Info.CPUCores))
+	appendIfChanged(HostDataChangeFieldCPUThreads, "", strconv.Itoa(previous.Info.CPUThreads), strconv.Itoa(current.Info.CPUThreads))
+	appendIfChanged(HostDataChangeFieldHardwareAbstractionTechnology, "",
+		previous.Info.HardwareAbstractionTechnology, current.Info.HardwareAbstractionTechnology)
+	appendIfChanged(HostDataChangeFieldOS, "", previous.Info.OS, current.Info.OS)
+	appendIfChanged(HostDataChangeFieldOSVersion, "", previous.Info.OSVersion, current.Info.OSVersion)
+	appendIfChanged(HostDataChangeFieldKernelVersion, "", previous.Info.KernelVersion, current.Info.KernelVersion)
+	appendIfChanged(HostDataChangeFieldMemoryTotal, "",
+		strconv.FormatFloat(previous.Info.MemoryTotal, 'f', -1, 64), strconv.FormatFloat(current.Info.MemoryTotal, 'f', -1, 64))
+
+	appendListChanges := func(field, database string, previousKeys, currentKeys []string) []string {
+		return diffKeys(previousKeys, currentKeys, func(oldValue, newValue string) {
+			appendIfChanged(field, database, oldValue, newValue)
+		})
+	}
+
+	previousDbs, currentDbs := oracleDatabasesByName(previous), oracleDatabasesByName(current)
+	for _, name := range appendListChanges(HostDataChangeFieldOracleDatabase, "", previousDbs.names, currentDbs.names) {
+		previousDb, currentDb := previousDbs.byName[name], currentDbs.byName[name]
+
+		appendIfChanged(HostDataChangeFieldOracleDatabaseVersion, name, previousDb.Version, currentDb.Version)
+		appendListChanges(HostDataChangeFieldOracleDatabasePatch, name, oraclePatchKeys(previousDb), oraclePatchKeys(currentDb))
+		appendListChanges(HostDataChangeFieldOracleDatabasePSU, name, oraclePSUKeys(previousDb), oraclePSUKeys(currentDb))
+		appendListChanges(HostDataChangeFieldOracleDatabaseTablespace, name, oracleTablespaceKeys(previousDb), oracleTablespaceKeys(currentDb))
+		appendListChanges(HostDataChangeFieldOracleDatabaseOption, name, oracleUsedLicenseKeys(previousDb), oracleUsedLicenseKeys(currentDb))
+	}
+
+	previousSQLServer, currentSQLServer := sqlServerInstanceVersions(previous), sqlServerInstanceVersions(current)
+	for _, name := range appendListChanges(HostDataChangeFieldSQLServerInstance, "", previousSQLServer.names, currentSQLServer.names) {
+		appendIfChanged(HostDataChangeFieldSQLServerInstanceVersion, name, previousSQLServer.versions[name], currentSQLServer.versions[name])
+	}
+
+	previousMySQL, currentMySQL := mySQLInstanceVersions(previous), mySQLInstanceVersions(current)
+	for _, name := range appendListChanges(HostDataChangeFieldMySQLInstance, "", previousMySQL.names, currentMySQL.names) {
+		appendIfChanged(HostDataChangeFieldMySQLInstanceVersion, name, previousMySQL.versions[name], currentMySQL.versions[name])
+	}
+
+	return changes
+}
+
+// diffKeys call onChange for each key removed from previous or added to current
+// and return the keys which are in both of them
+func diffKeys(previous, current []string, onChange func(oldValue, newValue string)) []string {
+	previousSet := make(map[string]bool, len(previous))
+	for _, key := range previous {
+		previousSet[key] = true
+	}
+
+	currentSet := make(map[string]bool, len(current))
+	for _, key := range current {
+		currentSet[key] = true
+	}
+
+	common := make([]string, 0)
+
+	for _, key := range previous {
+		if !currentSet[key] {
+			onChange(key, "")
+		}
+	}
+
+	for _, key := range current {
+		if previousSet[key] {
+			common = append(common, key)
+		} else {
+			onChange("", key)
+		}
+	}
+
+	return common
+}
+
+type oracleDatabasesIndex struct {
+	names  []string
+	byName map[string]OracleDatabase
+}
+
+func oracleDatabasesByName(hostdata HostDataBE) oracleDatabasesIndex {
+	index := oracleDatabasesIndex{names: make([]string, 0), byName: make(map[string]OracleDatabase)}
+
+	if hostdata.Features.Oracle == nil || hostdata.Features.Oracle.Database == nil {
+		return index
+	}
+
+	for _, db := range hostdata.Features.Oracle.Database.Databases {
+		index.names = append(index.names, db.Name)
+		index.byName[db.Name] = db
+	}
+
+	return index
+}
+
+func oraclePatchKeys(db OracleDatabase) []string {
+	keys := make([]string, 0, len(db.Patches))
+	for _, patch := range db.Patches {
+		keys = append(keys, fmt.Sprintf("%d %s", patch.PatchID, patch.Description))
+	}
+
+	return keys
+}
+
+func oraclePSUKeys(db OracleDatabase) []string {
+	keys := make([]string, 0, len(db.PSUs))
+	for _, psu := range db.PSUs {
+		keys = append(keys, psu.Description)
+	}
+
+	return keys
+}
+
+func oracleTablespaceKeys(db OracleDatabase) []string {
+	keys := make([]string, 0, len(db.Tablespaces))
+	for _, tablespace := range db.Tablespaces {
+		keys = append(keys, tablespace.Name)
+	}
+
+	return keys
+}
+
+func oracleUsedLicenseKeys(db OracleDatabase) []string {
+	keys := make([]string, 0, len(db.Licenses))
+	for _, license := range db.Licenses {
+		if license.Count > 0 {
+			keys = append(keys, license.Name)
+		}
+	}
+
+	return keys
+}
+
+type instanceVersionsIndex struct {
+	names    []string
+	versions map[string]string
+}
+
+func sqlServerInstanceVersions(hostdata HostDataBE) instanceVersionsIndex {
+	index := instanceVersionsIndex{names: make([]string, 0), versions: make(map[string]string)}
+
+	if hostdata.Features.Microsoft == nil || hostdata.Features.Microsoft.SQLServer == nil {
+		return index
+	}
+
+	for _, instance := range hostdata.Features.Microsoft.SQLServer.Instances {
+		index.names = append(index.names, instance.Name)
+		index.versions[instance.Name] = instance.Version
+	}
+
+	return index
+}
+
+func mySQLInstanceVersions(hostdata HostDataBE) instanceVersionsIndex {
+	index := instanceVersionsIndex{names: make([]string, 0), versions: make(map[string]string)}
+
+	if hostdata.Features.MySQL == nil {
+		return index
+	}
+
+	for _, instance := range hostdata.Features.MySQL.Instances {
+		index.names = append(index.names, instance.Name)
+		index.versions[instance.Name] = instance.Version
+	}
+
+	return index
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffHostData(t *testing.T) {
+	previous := HostDataBE{
+		Environment: "TST",
+		Location:    "Italy",
+		Info: Host{
+			CPUModel:   "Intel(R) Xeon(R) CPU E5630",
+			CPUSockets: 2,
+			CPUCores:   4,
+			CPUThreads: 8,
+			OS:         "Red Hat Enterprise Linux",
+			OSVersion:  "7.6",
+		},
+		Features: Features{
+			Oracle: &OracleFeature{
+				Database: &OracleDatabaseFeature{
+					Databases: []OracleDatabase{
+						{
+							Name:        "ERCOLE",
+							Version:     "12.2.0.1.0 Enterprise Edition",
+							Patches:     []OracleDatabasePatch{{PatchID: 1, Description: "PSU 1"}},
+							Tablespaces: []OracleDatabaseTablespace{{Name: "SYSTEM"}, {Name: "USERS"}},
+							Licenses: []OracleDatabaseLicense{
+								{Name: "Oracle ENT", Count: 2},
+								{Name: "Partitioning", Count: 0},
+							},
+						},
+						{Name: "OLD"},
+					},
+				},
+			},
+			MySQL: &MySQLFeature{
+				Instances: []MySQLInstance{{Name: "mysql", Version: "8.0.23"}},
+			},
+		},
+	}
+
+	current := HostDataBE{
+		Environment: "PRD",
+		Location:    "Italy",
+		Info: Host{
+			CPUModel:   "Intel(R) Xeon(R) CPU E5630",
+			CPUSockets: 2,
+			CPUCores:   8,
+			CPUThreads: 16,
+			OS:         "Red Hat Enterprise Linux",
+			OSVersion:  "7.9",
+		},
+		Features: Features{
+			Oracle: &OracleFeature{
+				Database: &OracleDatabaseFeature{
+					Databases: []OracleDatabase{
+						{
+							Name:        "ERCOLE",
+							Version:     "19.0.0.0.0 Enterprise Edition",
+							Patches:     []OracleDatabasePatch{{PatchID: 1, Description: "PSU 1"}, {PatchID: 2, Description: "PSU 2"}},
+							Tablespaces: []OracleDatabaseTablespace{{Name: "SYSTEM"}, {Name: "USERS"}, {Name: "DATA"}},
+							Licenses: []OracleDatabaseLicense{
+								{Name: "Oracle ENT", Count: 2},
+								{Name: "Partitioning", Count: 2},
+							},
+						},
+						{Name: "NEW"},
+					},
+				},
+			},
+			MySQL: &MySQLFeature{
+				Instances: []MySQLInstance{{Name: "mysql", Version: "8.0.30"}},
+			},
+		},
+	}
+
+	expected := []HostDataFieldChange{
+		{Field: HostDataChangeFieldEnvironment, OldValue: "TST", NewValue: "PRD"},
+		{Field: HostDataChangeFieldCPUCores, OldValue: "4", NewValue: "8"},
+		{Field: HostDataChangeFieldCPUThreads, OldValue: "8", NewValue: "16"},
+		{Field: HostDataChangeFieldOSVersion, OldValue: "7.6", NewValue: "7.9"},
+		{Field: HostDataChangeFieldOracleDatabase, OldValue: "OLD", NewValue: ""},
+		{Field: HostDataChangeFieldOracleDatabase, OldValue: "", NewValue: "NEW"},
+		{Field: HostDataChangeFieldOracleDatabaseVersion, Database: "ERCOLE", OldValue: "12.2.0.1.0 Enterprise Edition", NewValue: "19.0.0.0.0 Enterprise Edition"},
+		{Field: HostDataChangeFieldOracleDatabasePatch, Database: "ERCOLE", OldValue: "", NewValue: "2 PSU 2"},
+		{Field: HostDataChangeFieldOracleDatabaseTablespace, Database: "ERCOLE", OldValue: "", NewValue: "DATA"},
+		{Field: HostDataChangeFieldOracleDatabaseOption, Database: "ERCOLE", OldValue: "", NewValue: "Partitioning"},
+		{Field: HostDataChangeFieldMySQLInstanceVersion, Database: "mysql", OldValue: "8.0.23", NewValue: "8.0.30"},
+	}
+
+	assert.Equal(t, expected, DiffHostData(previous, current))
+	assert.Equal(t, []HostDataFieldChange{}, DiffHostData(current, current))
+}
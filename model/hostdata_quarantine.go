@@ -0,0 +1,36 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QuarantinedHostData is an hostdata rejected by the validation, kept to be inspected and replayed
+type QuarantinedHostData struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Hostname      string             `json:"hostname" bson:"hostname"`
+	Location      string             `json:"location" bson:"location"`
+	Environment   string             `json:"environment" bson:"environment"`
+	SchemaVersion int                `json:"schemaVersion" bson:"schemaVersion"`
+	Errors        string             `json:"errors" bson:"errors"`
+	Payload       []byte             `json:"-" bson:"payload"`
+	ReceivedAt    time.Time          `json:"receivedAt" bson:"receivedAt"`
+	Replays       int                `json:"replays" bson:"replays"`
+	LastReplayAt  *time.Time         `json:"lastReplayAt,omitempty" bson:"lastReplayAt,omitempty"`
+}
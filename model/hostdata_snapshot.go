@@ -0,0 +1,38 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HostDataSnapshot kinds
+const (
+	HostDataSnapshotKindMonthly   = "monthly"
+	HostDataSnapshotKindQuarterly = "quarterly"
+)
+
+// HostDataSnapshot records a snapshot of the current hostdata.
+// The hostdata of a snapshot are kept until RetainedUntil, so the inventory can be queried as it was at Date
+type HostDataSnapshot struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Kind          string             `json:"kind" bson:"kind"`
+	Date          time.Time          `json:"date" bson:"date"`
+	Hosts         int64              `json:"hosts" bson:"hosts"`
+	RetainedUntil time.Time          `json:"retainedUntil" bson:"retainedUntil"`
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Statuses of an hostdata upload
+const (
+	HostDataUploadStatusQueued     = "QUEUED"
+	HostDataUploadStatusProcessing = "PROCESSING"
+	HostDataUploadStatusDone       = "DONE"
+	HostDataUploadStatusFailed     = "FAILED"
+)
+
+// HostDataUpload is an hostdata accepted by the data-service and waiting to be processed
+type HostDataUpload struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Hostname    string             `json:"hostname" bson:"hostname"`
+	Status      string             `json:"status" bson:"status"`
+	Payload     []byte             `json:"-" bson:"payload,omitempty"`
+	Attempts    int                `json:"attempts" bson:"attempts"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+	ReceivedAt  time.Time          `json:"receivedAt" bson:"receivedAt"`
+	StartedAt   *time.Time         `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
+	CompletedAt *time.Time         `json:"completedAt,omitempty" bson:"completedAt,omitempty"`
+	// ThrownAlerts contains the keys of the alerts thrown by the previous attempts, they aren't thrown again by the retries
+	ThrownAlerts []string `json:"-" bson:"thrownAlerts,omitempty"`
+}
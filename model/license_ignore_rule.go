@@ -0,0 +1,100 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LicenseIgnoreRule ignore the licenses used by the hosts it matches until it expires.
+// Empty criteria match every host, environment, tag or license type
+type LicenseIgnoreRule struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	HostnameRegex string             `json:"hostnameRegex" bson:"hostnameRegex"`
+	Environment   string             `json:"environment" bson:"environment"`
+	Tag           string             `json:"tag" bson:"tag"`
+	LicenseTypeID string             `json:"licenseTypeID" bson:"licenseTypeID"`
+	Justification string             `json:"justification" bson:"justification"`
+	ExpiresAt     time.Time          `json:"expiresAt" bson:"expiresAt"`
+	CreatedBy     string             `json:"createdBy" bson:"createdBy"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// Check return an error if the rule isn't valid
+func (rule LicenseIgnoreRule) Check() error {
+	if rule.Justification == "" {
+		return errors.New("The justification is mandatory")
+	}
+
+	if rule.ExpiresAt.IsZero() {
+		return errors.New("The expiry date is mandatory")
+	}
+
+	if rule.HostnameRegex == "" && rule.Environment == "" && rule.Tag == "" && rule.LicenseTypeID == "" {
+		return errors.New("At least one among hostnameRegex, environment, tag and licenseTypeID is mandatory")
+	}
+
+	if _, err := regexp.Compile(rule.HostnameRegex); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsExpired return true if the rule is expired at now
+func (rule LicenseIgnoreRule) IsExpired(now time.Time) bool {
+	return !now.Before(rule.ExpiresAt)
+}
+
+// MatchHost return true if the rule match the hostname, the environment and the tags of the host
+func (rule LicenseIgnoreRule) MatchHost(hostdata HostDataBE) bool {
+	if rule.HostnameRegex != "" {
+		matched, err := regexp.MatchString(rule.HostnameRegex, hostdata.Hostname)
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	if rule.Environment != "" && rule.Environment != hostdata.Environment {
+		return false
+	}
+
+	if rule.Tag != "" {
+		found := false
+
+		for _, tag := range hostdata.Tags {
+			if tag == rule.Tag {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchLicenseType return true if the rule match the license type
+func (rule LicenseIgnoreRule) MatchLicenseType(licenseTypeID string) bool {
+	return rule.LicenseTypeID == "" || rule.LicenseTypeID == licenseTypeID
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseIgnoreRuleCheck(t *testing.T) {
+	expiresAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, LicenseIgnoreRule{HostnameRegex: "^test-", Justification: "test", ExpiresAt: expiresAt}.Check())
+	assert.Error(t, LicenseIgnoreRule{HostnameRegex: "^test-", ExpiresAt: expiresAt}.Check())
+	assert.Error(t, LicenseIgnoreRule{HostnameRegex: "^test-", Justification: "test"}.Check())
+	assert.Error(t, LicenseIgnoreRule{Justification: "test", ExpiresAt: expiresAt}.Check())
+	assert.Error(t, LicenseIgnoreRule{HostnameRegex: "(", Justification: "test", ExpiresAt: expiresAt}.Check())
+}
+
+func TestLicenseIgnoreRuleIsExpired(t *testing.T) {
+	rule := LicenseIgnoreRule{ExpiresAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	assert.False(t, rule.IsExpired(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, rule.IsExpired(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestLicenseIgnoreRuleMatchHost(t *testing.T) {
+	host := HostDataBE{Hostname: "test-db", Environment: "TST", Tags: []string{"dev"}}
+
+	assert.True(t, LicenseIgnoreRule{HostnameRegex: "^test-"}.MatchHost(host))
+	assert.True(t, LicenseIgnoreRule{Environment: "TST", Tag: "dev"}.MatchHost(host))
+	assert.False(t, LicenseIgnoreRule{HostnameRegex: "^prod-"}.MatchHost(host))
+	assert.False(t, LicenseIgnoreRule{Environment: "PRD"}.MatchHost(host))
+	assert.False(t, LicenseIgnoreRule{Tag: "gdpr"}.MatchHost(host))
+}
+
+func TestLicenseIgnoreRuleMatchLicenseType(t *testing.T) {
+	assert.True(t, LicenseIgnoreRule{}.MatchLicenseType("A90611"))
+	assert.True(t, LicenseIgnoreRule{LicenseTypeID: "A90611"}.MatchLicenseType("A90611"))
+	assert.False(t, LicenseIgnoreRule{LicenseTypeID: "A90611"}.MatchLicenseType("A90649"))
+}
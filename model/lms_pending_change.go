@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fields of the hosts that can be reviewed in an imported LMS workbook
+const (
+	LMSPendingChangeFieldIgnored            = "ignored"
+	LMSPendingChangeFieldIgnoredComment     = "ignoredComment"
+	LMSPendingChangeFieldPhysicalServerName = "physicalServerName"
+	LMSPendingChangeFieldProcessorModel     = "processorModel"
+)
+
+// Statuses of an LMS pending change
+const (
+	LMSPendingChangeStatusPending  = "Pending"
+	LMSPendingChangeStatusApproved = "Approved"
+	LMSPendingChangeStatusRejected = "Rejected"
+)
+
+// LMSPendingChange is a value reviewed in an imported LMS workbook which is waiting to be approved or rejected
+type LMSPendingChange struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	ImportID      primitive.ObjectID `json:"importID" bson:"importID"`
+	Row           int                `json:"row" bson:"row"`
+	Hostname      string             `json:"hostname" bson:"hostname"`
+	DatabaseName  string             `json:"databaseName" bson:"databaseName"`
+	LicenseTypeID string             `json:"licenseTypeID,omitempty" bson:"licenseTypeID,omitempty"`
+	Field         string             `json:"field" bson:"field"`
+	CurrentValue  string             `json:"currentValue" bson:"currentValue"`
+	ProposedValue string             `json:"proposedValue" bson:"proposedValue"`
+	Comment       string             `json:"comment" bson:"comment"`
+	Status        string             `json:"status" bson:"status"`
+	CreatedBy     string             `json:"createdBy" bson:"createdBy"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+	ReviewedBy    string             `json:"reviewedBy,omitempty" bson:"reviewedBy,omitempty"`
+	ReviewedAt    *time.Time         `json:"reviewedAt,omitempty" bson:"reviewedAt,omitempty"`
+}
+
+// IsValidLMSPendingChangeStatus return true if status is a valid status of an LMS pending change
+func IsValidLMSPendingChangeStatus(status string) bool {
+	switch status {
+	case LMSPendingChangeStatusPending, LMSPendingChangeStatusApproved, LMSPendingChangeStatusRejected:
+		return true
+	}
+
+	return false
+}
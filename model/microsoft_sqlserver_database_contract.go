@@ -29,10 +29,11 @@ type SqlServerDatabaseContract struct {
 	LicenseTypeID     string             `json:"licenseTypeID" bson:"licenseTypeID" csv:"License Type"`
 	LicensesNumber    int                `json:"licensesNumber" bson:"licensesNumber" csv:"Number of Licenses"`
 	SupportExpiration *time.Time         `json:"supportExpiration" bson:"supportExpiration" csv:"-"`
+	SoftwareAssurance bool               `json:"softwareAssurance" bson:"softwareAssurance" csv:"Software Assurance"`
 	Hosts             []string           `json:"hosts" bson:"hosts" csv:"-"`
 	Clusters          []string           `json:"clusters" bson:"clusters" csv:"-"`
-	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"-"`
-	ClusterLiteral    LiteralStrSlice    `json:"-" bson:"-" csv:"-"`
+	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"Hosts"`
+	ClusterLiteral    LiteralStrSlice    `json:"-" bson:"-" csv:"Clusters"`
 }
 
 const (
@@ -37,6 +37,8 @@ type MicrosoftSQLServerLicense struct {
 	LicenseTypeID  string  `json:"licenseTypeID" bson:"licenseTypeID"`
 	Name           string  `json:"name" bson:"name"`
 	Count          float64 `json:"count" bson:"count"`
+	Passive        bool    `json:"passive" bson:"passive"`
 	Ignored        bool    `json:"ignored" bson:"ignored"`
 	IgnoredComment string  `json:"ignoredComment" bson:"ignoredComment"`
+	IgnoreRuleID   string  `json:"ignoreRuleID,omitempty" bson:"ignoreRuleID,omitempty"`
 }
@@ -0,0 +1,100 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	// SqlServerMinCoreLicenses is the minimum number of core licenses required for each physical processor or VM
+	SqlServerMinCoreLicenses = 4
+	// SqlServerCoresPerPack is the number of core licenses sold in a single pack
+	SqlServerCoresPerPack = 2
+	// SqlServerDatabaseStateRestoring is the state of the databases of a passive secondary replica
+	SqlServerDatabaseStateRestoring = "RESTORING"
+)
+
+// SqlServerPhysicalCoreLicenses return the number of core licenses needed by a physical host:
+// all the cores must be licensed, with a minimum of 4 core licenses for each processor
+func SqlServerPhysicalCoreLicenses(cores, sockets int) float64 {
+	if sockets <= 0 {
+		sockets = 1
+	}
+
+	if cores < sockets*SqlServerMinCoreLicenses {
+		return float64(sockets * SqlServerMinCoreLicenses)
+	}
+
+	return float64(cores)
+}
+
+// SqlServerVirtualCoreLicenses return the number of core licenses needed by a VM:
+// all the virtual cores must be licensed, with a minimum of 4 core licenses for each VM
+func SqlServerVirtualCoreLicenses(vcpus int) float64 {
+	if vcpus < SqlServerMinCoreLicenses {
+		return SqlServerMinCoreLicenses
+	}
+
+	return float64(vcpus)
+}
+
+// SqlServerCorePacks return the number of 2-core packs needed to cover coreLicenses
+func SqlServerCorePacks(coreLicenses float64) float64 {
+	return math.Ceil(coreLicenses / SqlServerCoresPerPack)
+}
+
+// IsSqlServerLicensedEdition return true if the edition is licensed per core (Enterprise and Standard)
+func IsSqlServerLicensedEdition(edition string) bool {
+	return edition == "ENT" || edition == "STD"
+}
+
+// IsPassive return true if the instance is a passive secondary, that is all its databases are restoring
+// as happens with log shipping, database mirroring and not readable availability group replicas
+func (i MicrosoftSQLServerInstance) IsPassive() bool {
+	if len(i.Databases) == 0 {
+		return false
+	}
+
+	for _, db := range i.Databases {
+		if !strings.EqualFold(db.Status, SqlServerDatabaseStateRestoring) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CoversInstance return true if the contract is assigned to the host of the instance or to its cluster
+func (c SqlServerDatabaseContract) CoversInstance(hostname, clustername string) bool {
+	switch c.Type {
+	case SqlServerContractTypeHost:
+		return utils.Contains(c.Hosts, hostname)
+	case SqlServerContractTypeCluster:
+		return clustername != "" && utils.Contains(c.Clusters, clustername)
+	}
+
+	return false
+}
+
+// CoversPassiveSecondaries return true if the contract grants free passive secondaries
+// for licenseTypeID, that is it has Software Assurance
+func (c SqlServerDatabaseContract) CoversPassiveSecondaries(licenseTypeID string) bool {
+	return c.SoftwareAssurance && c.LicenseTypeID == licenseTypeID
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlServerPhysicalCoreLicenses(t *testing.T) {
+	assert.Equal(t, float64(8), SqlServerPhysicalCoreLicenses(4, 2))
+	assert.Equal(t, float64(16), SqlServerPhysicalCoreLicenses(16, 2))
+	assert.Equal(t, float64(4), SqlServerPhysicalCoreLicenses(2, 0))
+}
+
+func TestSqlServerVirtualCoreLicenses(t *testing.T) {
+	assert.Equal(t, float64(4), SqlServerVirtualCoreLicenses(1))
+	assert.Equal(t, float64(6), SqlServerVirtualCoreLicenses(6))
+}
+
+func TestSqlServerCorePacks(t *testing.T) {
+	assert.Equal(t, float64(2), SqlServerCorePacks(4))
+	assert.Equal(t, float64(3), SqlServerCorePacks(5))
+}
+
+func TestMicrosoftSQLServerInstanceIsPassive(t *testing.T) {
+	assert.False(t, MicrosoftSQLServerInstance{}.IsPassive())
+	assert.False(t, MicrosoftSQLServerInstance{Databases: []MicrosoftSQLServerDatabase{
+		{Status: "RESTORING"}, {Status: "ONLINE"},
+	}}.IsPassive())
+	assert.True(t, MicrosoftSQLServerInstance{Databases: []MicrosoftSQLServerDatabase{
+		{Status: "RESTORING"}, {Status: "restoring"},
+	}}.IsPassive())
+}
+
+func TestSqlServerDatabaseContractCoversPassiveSecondaries(t *testing.T) {
+	contract := SqlServerDatabaseContract{LicenseTypeID: "DEF-456", SoftwareAssurance: true}
+
+	assert.True(t, contract.CoversPassiveSecondaries("DEF-456"))
+	assert.False(t, contract.CoversPassiveSecondaries("ABC-123"))
+
+	contract.SoftwareAssurance = false
+	assert.False(t, contract.CoversPassiveSecondaries("DEF-456"))
+}
+
+func TestSqlServerDatabaseContractCoversInstance(t *testing.T) {
+	hostContract := SqlServerDatabaseContract{Type: SqlServerContractTypeHost, Hosts: []string{"host1"}}
+
+	assert.True(t, hostContract.CoversInstance("host1", ""))
+	assert.False(t, hostContract.CoversInstance("host2", ""))
+
+	clusterContract := SqlServerDatabaseContract{Type: SqlServerContractTypeCluster, Clusters: []string{"cluster1"}}
+
+	assert.True(t, clusterContract.CoversInstance("host1", "cluster1"))
+	assert.False(t, clusterContract.CoversInstance("host1", ""))
+}
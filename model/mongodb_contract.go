@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MongoDBLicenseType holds informations about a single MongoDB license type
+type MongoDBLicenseType struct {
+	ID              string `json:"id" bson:"_id"`
+	ItemDescription string `json:"itemDescription" bson:"itemDescription"`
+}
+
+// MongoDBContract holds informations about a single MongoDB subscription
+type MongoDBContract SubscriptionContract
+
+const (
+	// MongoDBContractTypeCore subscription is counted per core of the host
+	MongoDBContractTypeCore = SubscriptionContractTypeCore
+	// MongoDBContractTypeInstance subscription is counted per instance
+	MongoDBContractTypeInstance = SubscriptionContractTypeInstance
+)
+
+const MongoDBEnterprisePartNumber = "MDB-EA"
+
+const MongoDBEnterpriseItemDescription = "MongoDB Enterprise Advanced"
+
+const (
+	// MongoDBEditionEnterprise is the edition reported by the MongoDB Enterprise instances
+	MongoDBEditionEnterprise string = "Enterprise"
+	// MongoDBEditionCommunity is the edition reported by the MongoDB Community instances
+	MongoDBEditionCommunity string = "Community"
+)
+
+// GetID return the ID of the contract
+func (agr MongoDBContract) GetID() primitive.ObjectID {
+	return agr.ID
+}
+
+func (agr MongoDBContract) IsValid() bool {
+	return SubscriptionContract(agr).IsValid()
+}
@@ -18,6 +18,7 @@ package model
 type MongoDBInstance struct {
 	Name             string                 `json:"name" bson:"name"`
 	Version          string                 `json:"version" bson:"version"`
+	Edition          string                 `json:"edition,omitempty" bson:"edition,omitempty"`
 	Dbs              int                    `json:"dbs" bson:"dbs"`
 	ReplicaSet       HelloResult            `json:"replicaSet" bson:"replicaSet"`
 	ShardList        ShardStatus            `json:"shardList" bson:"shardList"`
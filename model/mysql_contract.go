@@ -31,8 +31,8 @@ type MySQLContract struct {
 	SupportExpiration *time.Time         `json:"supportExpiration" bson:"supportExpiration" csv:"-"`
 	Clusters          []string           `json:"clusters" bson:"clusters" csv:"-"`
 	Hosts             []string           `json:"hosts" bson:"hosts" csv:"-"`
-	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"-"`
-	ClusterLiteral    LiteralStrSlice    `json:"-" bson:"-" csv:"-"`
+	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"Hosts"`
+	ClusterLiteral    LiteralStrSlice    `json:"-" bson:"-" csv:"Clusters"`
 }
 
 const (
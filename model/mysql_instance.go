@@ -58,4 +58,5 @@ type MySQLLicense struct {
 	Count          float64 `json:"count" bson:"count"`
 	Ignored        bool    `json:"ignored" bson:"ignored"`
 	IgnoredComment string  `json:"ignoredComment" bson:"ignoredComment"`
+	IgnoreRuleID   string  `json:"ignoreRuleID,omitempty" bson:"ignoreRuleID,omitempty"`
 }
@@ -23,51 +23,52 @@ import (
 
 // OracleDatabase holds information about an Oracle database.
 type OracleDatabase struct {
-	InstanceNumber      int                               `json:"instanceNumber" bson:"instanceNumber"`
-	InstanceName        string                            `json:"instanceName" bson:"instanceName"`
-	Name                string                            `json:"name" bson:"name"`
-	UniqueName          string                            `json:"uniqueName" bson:"uniqueName"`
-	Status              string                            `json:"status" bson:"status"`
-	DbID                uint                              `json:"dbID" bson:"dbID"`
-	Role                string                            `json:"role" bson:"role"`
-	IsCDB               bool                              `json:"isCDB" bson:"isCDB"`
-	Version             string                            `json:"version" bson:"version"`
-	Platform            string                            `json:"platform" bson:"platform"`
-	Archivelog          bool                              `json:"archivelog" bson:"archivelog"`
-	Charset             string                            `json:"charset" bson:"charset"`
-	NCharset            string                            `json:"nCharset" bson:"nCharset"`
-	BlockSize           int                               `json:"blockSize" bson:"blockSize"`
-	CPUCount            int                               `json:"cpuCount" bson:"cpuCount"`
-	SGATarget           float64                           `json:"sgaTarget" bson:"sgaTarget"`
-	PGATarget           float64                           `json:"pgaTarget" bson:"pgaTarget"`
-	MemoryTarget        float64                           `json:"memoryTarget" bson:"memoryTarget"`
-	SGAMaxSize          float64                           `json:"sgaMaxSize" bson:"sgaMaxSize"`
-	SegmentsSize        float64                           `json:"segmentsSize" bson:"segmentsSize"`
-	DatafileSize        float64                           `json:"datafileSize" bson:"datafileSize"`
-	Allocable           float64                           `json:"allocable" bson:"allocable"`
-	Elapsed             *float64                          `json:"elapsed" bson:"elapsed"`
-	DBTime              *float64                          `json:"dbTime" bson:"dbTime"`
-	DailyCPUUsage       *float64                          `json:"dailyCPUUsage" bson:"dailyCPUUsage"`
-	Work                *float64                          `json:"work" bson:"work"`
-	ASM                 bool                              `json:"asm" bson:"asm"`
-	Dataguard           bool                              `json:"dataguard" bson:"dataguard"`
-	IsRAC               bool                              `json:"isRAC" bson:"isRAC"`
-	Patches             []OracleDatabasePatch             `json:"patches" bson:"patches"`
-	Tablespaces         []OracleDatabaseTablespace        `json:"tablespaces" bson:"tablespaces"`
-	Schemas             []OracleDatabaseSchema            `json:"schemas" bson:"schemas"`
-	Licenses            []OracleDatabaseLicense           `json:"licenses" bson:"licenses"`
-	ADDMs               []OracleDatabaseAddm              `json:"addms" bson:"addms"`
-	SegmentAdvisors     []OracleDatabaseSegmentAdvisor    `json:"segmentAdvisors" bson:"segmentAdvisors"`
-	PSUs                []OracleDatabasePSU               `json:"psus" bson:"psus"`
-	Backups             []OracleDatabaseBackup            `json:"backups" bson:"backups"`
-	FeatureUsageStats   []OracleDatabaseFeatureUsageStat  `json:"featureUsageStats" bson:"featureUsageStats"`
-	PDBs                []OracleDatabasePluggableDatabase `json:"pdbs" bson:"pdbs"`
-	Services            []OracleDatabaseService           `json:"services" bson:"services"`
-	Changes             []OracleChanges                   `json:"changes" bson:"changes"`
-	GrantDba            []OracleGrantDba                  `json:"grantDba" bson:"grantDba"`
-	Partitionings       []OracleDatabasePartitioning      `json:"partitionings" bson:"partitionings"`
-	CpuDiskConsumptions []CpuDiskConsumption              `json:"cpuDiskConsumptions" bson:"cpuDiskConsumptions"`
-	PgsqlMigrability    []PgsqlMigrability                `json:"pgsqlMigrability,omitempty" bson:"pgsqlMigrability,omitempty"`
+	InstanceNumber      int                                `json:"instanceNumber" bson:"instanceNumber"`
+	InstanceName        string                             `json:"instanceName" bson:"instanceName"`
+	Name                string                             `json:"name" bson:"name"`
+	UniqueName          string                             `json:"uniqueName" bson:"uniqueName"`
+	Status              string                             `json:"status" bson:"status"`
+	DbID                uint                               `json:"dbID" bson:"dbID"`
+	Role                string                             `json:"role" bson:"role"`
+	IsCDB               bool                               `json:"isCDB" bson:"isCDB"`
+	Version             string                             `json:"version" bson:"version"`
+	Platform            string                             `json:"platform" bson:"platform"`
+	Archivelog          bool                               `json:"archivelog" bson:"archivelog"`
+	Charset             string                             `json:"charset" bson:"charset"`
+	NCharset            string                             `json:"nCharset" bson:"nCharset"`
+	BlockSize           int                                `json:"blockSize" bson:"blockSize"`
+	CPUCount            int                                `json:"cpuCount" bson:"cpuCount"`
+	SGATarget           float64                            `json:"sgaTarget" bson:"sgaTarget"`
+	PGATarget           float64                            `json:"pgaTarget" bson:"pgaTarget"`
+	MemoryTarget        float64                            `json:"memoryTarget" bson:"memoryTarget"`
+	SGAMaxSize          float64                            `json:"sgaMaxSize" bson:"sgaMaxSize"`
+	SegmentsSize        float64                            `json:"segmentsSize" bson:"segmentsSize"`
+	DatafileSize        float64                            `json:"datafileSize" bson:"datafileSize"`
+	Allocable           float64                            `json:"allocable" bson:"allocable"`
+	Elapsed             *float64                           `json:"elapsed" bson:"elapsed"`
+	DBTime              *float64                           `json:"dbTime" bson:"dbTime"`
+	DailyCPUUsage       *float64                           `json:"dailyCPUUsage" bson:"dailyCPUUsage"`
+	Work                *float64                           `json:"work" bson:"work"`
+	ASM                 bool                               `json:"asm" bson:"asm"`
+	Dataguard           bool                               `json:"dataguard" bson:"dataguard"`
+	IsRAC               bool                               `json:"isRAC" bson:"isRAC"`
+	Patches             []OracleDatabasePatch              `json:"patches" bson:"patches"`
+	Tablespaces         []OracleDatabaseTablespace         `json:"tablespaces" bson:"tablespaces"`
+	Schemas             []OracleDatabaseSchema             `json:"schemas" bson:"schemas"`
+	Licenses            []OracleDatabaseLicense            `json:"licenses" bson:"licenses"`
+	ADDMs               []OracleDatabaseAddm               `json:"addms" bson:"addms"`
+	SegmentAdvisors     []OracleDatabaseSegmentAdvisor     `json:"segmentAdvisors" bson:"segmentAdvisors"`
+	PSUs                []OracleDatabasePSU                `json:"psus" bson:"psus"`
+	Backups             []OracleDatabaseBackup             `json:"backups" bson:"backups"`
+	FeatureUsageStats   []OracleDatabaseFeatureUsageStat   `json:"featureUsageStats" bson:"featureUsageStats"`
+	PDBs                []OracleDatabasePluggableDatabase  `json:"pdbs" bson:"pdbs"`
+	Services            []OracleDatabaseService            `json:"services" bson:"services"`
+	Changes             []OracleChanges                    `json:"changes" bson:"changes"`
+	GrantDba            []OracleGrantDba                   `json:"grantDba" bson:"grantDba"`
+	Partitionings       []OracleDatabasePartitioning       `json:"partitionings" bson:"partitionings"`
+	CpuDiskConsumptions []CpuDiskConsumption               `json:"cpuDiskConsumptions" bson:"cpuDiskConsumptions"`
+	PgsqlMigrability    []PgsqlMigrability                 `json:"pgsqlMigrability,omitempty" bson:"pgsqlMigrability,omitempty"`
+	LicenseRuleFindings []OracleDatabaseLicenseRuleFinding `json:"licenseRuleFindings,omitempty" bson:"licenseRuleFindings,omitempty"`
 }
 
 var (
@@ -96,6 +97,18 @@ var (
 	OracleDatabaseEditionExpress    = "XE"
 )
 
+// Standard Edition 2 licensing limits
+const (
+	// OracleDatabaseSE2MaxSockets is the maximum number of sockets of a server running Standard Edition 2
+	OracleDatabaseSE2MaxSockets = 2
+	// OracleDatabaseSE2CloudVCPUsPerSocket is the number of vCPUs counted as one socket in authorized cloud environments
+	OracleDatabaseSE2CloudVCPUsPerSocket = 4
+	// OracleDatabaseSE2CloudMaxVCPUs is the maximum number of vCPUs of an instance running Standard Edition 2 in authorized cloud environments
+	OracleDatabaseSE2CloudMaxVCPUs = 8
+	// OracleDatabaseSE2MinNamedUserPlusPerServer is the minimum number of Named User Plus licenses of a server running Standard Edition 2
+	OracleDatabaseSE2MinNamedUserPlusPerServer = 10
+)
+
 func (v OracleDatabase) Edition() (dbEdition string) {
 	if strings.Contains(strings.ToUpper(v.Version), "ENTERPRISE") {
 		dbEdition = OracleDatabaseEditionEnterprise
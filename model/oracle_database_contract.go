@@ -35,7 +35,8 @@ type OracleDatabaseContract struct {
 	Restricted        bool               `json:"restricted" bson:"restricted" csv:"-"`
 	SupportExpiration *time.Time         `json:"supportExpiration" bson:"supportExpiration" csv:"-"`
 	Hosts             []string           `json:"hosts" bson:"hosts" csv:"-"`
-	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"-"`
+	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"Hosts"`
+	Site              string             `json:"site,omitempty" bson:"site,omitempty" csv:"-"`
 }
 
 func (contract OracleDatabaseContract) Check() error {
@@ -45,3 +46,21 @@ func (contract OracleDatabaseContract) Check() error {
 
 	return nil
 }
+
+// Algorithms used to assign the licenses of the Oracle/Database contracts to the hosts
+const (
+	// OracleDatabaseContractsAssignmentGreedy assign the licenses of each contract, in order, to the hosts with more licenses
+	OracleDatabaseContractsAssignmentGreedy = "Greedy"
+	// OracleDatabaseContractsAssignmentOptimized assign the licenses maximizing the covered licenses and preferring restricted contracts
+	OracleDatabaseContractsAssignmentOptimized = "Optimized"
+)
+
+// IsValidOracleDatabaseContractsAssignmentMode return true if mode is a known contracts assignment algorithm
+func IsValidOracleDatabaseContractsAssignmentMode(mode string) bool {
+	switch mode {
+	case OracleDatabaseContractsAssignmentGreedy, OracleDatabaseContractsAssignmentOptimized:
+		return true
+	}
+
+	return false
+}
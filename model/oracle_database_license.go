@@ -24,6 +24,7 @@ type OracleDatabaseLicense struct {
 	Count          float64 `json:"count" bson:"count"`
 	Ignored        bool    `json:"ignored" bson:"ignored"`
 	IgnoredComment string  `json:"ignoredComment" bson:"ignoredComment"`
+	IgnoreRuleID   string  `json:"ignoreRuleID,omitempty" bson:"ignoreRuleID,omitempty"`
 }
 
 // DiffFeature status of each feature
@@ -0,0 +1,224 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// OracleDatabaseLicenseRule is a dependency between the licenses of an Oracle database
+type OracleDatabaseLicenseRule struct {
+	ID          string   `json:"id" bson:"id"`
+	Type        string   `json:"type" bson:"type"`
+	License     string   `json:"license" bson:"license"`
+	Licenses    []string `json:"licenses,omitempty" bson:"licenses,omitempty"`
+	Editions    []string `json:"editions,omitempty" bson:"editions,omitempty"`
+	MinVersion  int      `json:"minVersion,omitempty" bson:"minVersion,omitempty"`
+	MaxVersion  int      `json:"maxVersion,omitempty" bson:"maxVersion,omitempty"`
+	MaxPDBs     int      `json:"maxPDBs,omitempty" bson:"maxPDBs,omitempty"`
+	Description string   `json:"description" bson:"description"`
+}
+
+// Types of the Oracle database license rules
+const (
+	// OracleDatabaseLicenseRuleImplies means that when License is used, also Licenses are used
+	OracleDatabaseLicenseRuleImplies = "implies"
+	// OracleDatabaseLicenseRuleRequiresEdition means that License can be used only with one of Editions
+	OracleDatabaseLicenseRuleRequiresEdition = "requiresEdition"
+	// OracleDatabaseLicenseRuleExcludes means that License can't be used together with any of Licenses
+	OracleDatabaseLicenseRuleExcludes = "excludes"
+	// OracleDatabaseLicenseRulePDBs means that License is used when the database version is between MinVersion and MaxVersion
+	// and it has more than MaxPDBs user pluggable databases
+	OracleDatabaseLicenseRulePDBs = "pdbs"
+)
+
+// OracleDatabaseSeedPDB is the name of the seed pluggable database, not counted as user pluggable database
+const OracleDatabaseSeedPDB = "PDB$SEED"
+
+// OracleDatabaseLicenseRuleFinding explain a rule applied to the licenses of an Oracle database:
+// an implied license added to the database or an inconsistent license
+type OracleDatabaseLicenseRuleFinding struct {
+	RuleID      string `json:"ruleID" bson:"ruleID"`
+	License     string `json:"license" bson:"license"`
+	Implied     bool   `json:"implied" bson:"implied"`
+	Description string `json:"description" bson:"description"`
+}
+
+// MajorVersion return the major version of the database, 0 if unknown
+func (v OracleDatabase) MajorVersion() int {
+	major, err := strconv.Atoi(strings.SplitN(strings.TrimSpace(v.Version), ".", 2)[0])
+	if err != nil {
+		return 0
+	}
+
+	return major
+}
+
+// UserPDBsCount return the number of the pluggable databases of the database, excluding the seed
+func (v OracleDatabase) UserPDBsCount() int {
+	count := 0
+
+	for _, pdb := range v.PDBs {
+		if !strings.EqualFold(pdb.Name, OracleDatabaseSeedPDB) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ValidateOracleDatabaseLicenseRules return an error if a rule hasn't an unique ID, a known type
+// or the fields required by its type
+func ValidateOracleDatabaseLicenseRules(rules []OracleDatabaseLicenseRule) error {
+	ids := make(map[string]bool, len(rules))
+
+	for _, rule := range rules {
+		if rule.ID == "" || ids[rule.ID] {
+			return fmt.Errorf("%w: missing or duplicated id %q", utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID)
+		}
+
+		ids[rule.ID] = true
+
+		if rule.License == "" {
+			return fmt.Errorf("%w: %s: missing license", utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID)
+		}
+
+		switch rule.Type {
+		case OracleDatabaseLicenseRuleImplies, OracleDatabaseLicenseRuleExcludes:
+			if len(rule.Licenses) == 0 {
+				return fmt.Errorf("%w: %s: missing licenses", utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID)
+			}
+		case OracleDatabaseLicenseRuleRequiresEdition:
+			if len(rule.Editions) == 0 {
+				return fmt.Errorf("%w: %s: missing editions", utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID)
+			}
+		case OracleDatabaseLicenseRulePDBs:
+			if rule.MaxPDBs < 0 || (rule.MaxVersion > 0 && rule.MaxVersion < rule.MinVersion) {
+				return fmt.Errorf("%w: %s: invalid versions or pdbs", utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID)
+			}
+		default:
+			return fmt.Errorf("%w: %s: unknown type %q", utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID, rule.Type)
+		}
+	}
+
+	return nil
+}
+
+// ApplyLicenseRules add to the database the licenses implied by the rules
+// and return the findings explaining every implied and inconsistent license
+func (v *OracleDatabase) ApplyLicenseRules(rules []OracleDatabaseLicenseRule) []OracleDatabaseLicenseRuleFinding {
+	findings := make([]OracleDatabaseLicenseRuleFinding, 0)
+
+	newFinding := func(rule OracleDatabaseLicenseRule, license string, implied bool) OracleDatabaseLicenseRuleFinding {
+		return OracleDatabaseLicenseRuleFinding{
+			RuleID:      rule.ID,
+			License:     license,
+			Implied:     implied,
+			Description: rule.Description,
+		}
+	}
+
+	for _, rule := range rules {
+		count := v.licenseCount(rule.License)
+
+		switch rule.Type {
+		case OracleDatabaseLicenseRuleImplies:
+			if count <= 0 {
+				continue
+			}
+
+			for _, implied := range rule.Licenses {
+				if v.licenseCount(implied) <= 0 {
+					v.setLicenseCount(implied, count)
+					findings = append(findings, newFinding(rule, implied, true))
+				}
+			}
+
+		case OracleDatabaseLicenseRuleRequiresEdition:
+			if count > 0 && !utils.Contains(rule.Editions, v.Edition()) {
+				findings = append(findings, newFinding(rule, rule.License, false))
+			}
+
+		case OracleDatabaseLicenseRuleExcludes:
+			if count <= 0 {
+				continue
+			}
+
+			for _, excluded := range rule.Licenses {
+				if v.licenseCount(excluded) > 0 {
+					findings = append(findings, newFinding(rule, rule.License, false))
+					break
+				}
+			}
+
+		case OracleDatabaseLicenseRulePDBs:
+			version := v.MajorVersion()
+			if version < rule.MinVersion || (rule.MaxVersion > 0 && version > rule.MaxVersion) {
+				continue
+			}
+
+			needed := v.UserPDBsCount() > rule.MaxPDBs
+
+			if needed && count <= 0 {
+				if maxCount := v.maxLicenseCount(); maxCount > 0 {
+					v.setLicenseCount(rule.License, maxCount)
+					findings = append(findings, newFinding(rule, rule.License, true))
+				}
+			} else if !needed && count > 0 {
+				findings = append(findings, newFinding(rule, rule.License, false))
+			}
+		}
+	}
+
+	return findings
+}
+
+func (v OracleDatabase) licenseCount(name string) float64 {
+	for _, license := range v.Licenses {
+		if license.Name == name {
+			return license.Count
+		}
+	}
+
+	return 0
+}
+
+func (v OracleDatabase) maxLicenseCount() float64 {
+	var max float64
+
+	for _, license := range v.Licenses {
+		if license.Count > max {
+			max = license.Count
+		}
+	}
+
+	return max
+}
+
+func (v *OracleDatabase) setLicenseCount(name string, count float64) {
+	for i := range v.Licenses {
+		if v.Licenses[i].Name == name {
+			v.Licenses[i].Count = count
+			return
+		}
+	}
+
+	v.Licenses = append(v.Licenses, OracleDatabaseLicense{Name: name, Count: count})
+}
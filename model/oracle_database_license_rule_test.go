@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var testOracleDatabaseLicenseRules = []OracleDatabaseLicenseRule{
+	{
+		ID:          "TUNING",
+		Type:        OracleDatabaseLicenseRuleImplies,
+		License:     "Tuning Pack",
+		Licenses:    []string{"Diagnostics Pack"},
+		Description: "Tuning Pack requires Diagnostics Pack",
+	},
+	{
+		ID:          "ADG",
+		Type:        OracleDatabaseLicenseRuleRequiresEdition,
+		License:     "Active Data Guard",
+		Editions:    []string{OracleDatabaseEditionEnterprise, OracleDatabaseEditionExtreme},
+		Description: "Active Data Guard requires Enterprise Edition",
+	},
+	{
+		ID:          "RAC",
+		Type:        OracleDatabaseLicenseRuleExcludes,
+		License:     "Real Application Clusters One Node",
+		Licenses:    []string{"Real Application Clusters"},
+		Description: "RAC One Node and RAC are exclusive",
+	},
+	{
+		ID:          "MULTITENANT",
+		Type:        OracleDatabaseLicenseRulePDBs,
+		License:     "Multitenant",
+		MinVersion:  19,
+		MaxPDBs:     3,
+		Description: "Multitenant is required with more than 3 PDBs",
+	},
+}
+
+func TestValidateOracleDatabaseLicenseRules(t *testing.T) {
+	assert.NoError(t, ValidateOracleDatabaseLicenseRules(testOracleDatabaseLicenseRules))
+
+	invalid := []OracleDatabaseLicenseRule{
+		{ID: "TUNING", Type: OracleDatabaseLicenseRuleImplies, License: "Tuning Pack"},
+		{ID: "ADG", Type: "needs", License: "Active Data Guard"},
+		{ID: "MULTITENANT", Type: OracleDatabaseLicenseRulePDBs, License: "Multitenant", MinVersion: 19, MaxVersion: 12},
+		{Type: OracleDatabaseLicenseRuleExcludes, License: "Real Application Clusters", Licenses: []string{"RAC One Node"}},
+	}
+	for _, rule := range invalid {
+		assert.ErrorIs(t, ValidateOracleDatabaseLicenseRules([]OracleDatabaseLicenseRule{rule}), utils.ErrInvalidOracleDatabaseLicenseRule, rule.ID)
+	}
+
+	duplicated := []OracleDatabaseLicenseRule{testOracleDatabaseLicenseRules[0], testOracleDatabaseLicenseRules[0]}
+	assert.ErrorIs(t, ValidateOracleDatabaseLicenseRules(duplicated), utils.ErrInvalidOracleDatabaseLicenseRule)
+}
+
+func TestOracleDatabaseMajorVersion(t *testing.T) {
+	assert.Equal(t, 19, OracleDatabase{Version: "19.0.0.0.0 Enterprise Edition"}.MajorVersion())
+	assert.Equal(t, 11, OracleDatabase{Version: "11.2.0.3.0"}.MajorVersion())
+	assert.Equal(t, 0, OracleDatabase{Version: "unknown"}.MajorVersion())
+}
+
+func TestApplyLicenseRules_Implied(t *testing.T) {
+	db := OracleDatabase{
+		Version: "19.0.0.0.0 Enterprise Edition",
+		Licenses: []OracleDatabaseLicense{
+			{Name: "Oracle ENT", Count: 2},
+			{Name: "Tuning Pack", Count: 2},
+			{Name: "Diagnostics Pack", Count: 0},
+		},
+		PDBs: []OracleDatabasePluggableDatabase{
+			{Name: "PDB$SEED"}, {Name: "PDB1"}, {Name: "PDB2"}, {Name: "PDB3"}, {Name: "PDB4"},
+		},
+	}
+
+	findings := db.ApplyLicenseRules(testOracleDatabaseLicenseRules)
+
+	assert.Equal(t, []OracleDatabaseLicenseRuleFinding{
+		{RuleID: "TUNING", License: "Diagnostics Pack", Implied: true, Description: "Tuning Pack requires Diagnostics Pack"},
+		{RuleID: "MULTITENANT", License: "Multitenant", Implied: true, Description: "Multitenant is required with more than 3 PDBs"},
+	}, findings)
+	assert.Equal(t, []OracleDatabaseLicense{
+		{Name: "Oracle ENT", Count: 2},
+		{Name: "Tuning Pack", Count: 2},
+		{Name: "Diagnostics Pack", Count: 2},
+		{Name: "Multitenant", Count: 2},
+	}, db.Licenses)
+}
+
+func TestApplyLicenseRules_Inconsistent(t *testing.T) {
+	db := OracleDatabase{
+		Version: "19.0.0.0.0 Standard Edition",
+		Licenses: []OracleDatabaseLicense{
+			{Name: "Oracle STD", Count: 1},
+			{Name: "Active Data Guard", Count: 1},
+			{Name: "Real Application Clusters One Node", Count: 1},
+			{Name: "Real Application Clusters", Count: 1},
+			{Name: "Multitenant", Count: 1},
+		},
+		PDBs: []OracleDatabasePluggableDatabase{{Name: "PDB$SEED"}, {Name: "PDB1"}},
+	}
+
+	findings := db.ApplyLicenseRules(testOracleDatabaseLicenseRules)
+
+	assert.Equal(t, []OracleDatabaseLicenseRuleFinding{
+		{RuleID: "ADG", License: "Active Data Guard", Description: "Active Data Guard requires Enterprise Edition"},
+		{RuleID: "RAC", License: "Real Application Clusters One Node", Description: "RAC One Node and RAC are exclusive"},
+		{RuleID: "MULTITENANT", License: "Multitenant", Description: "Multitenant is required with more than 3 PDBs"},
+	}, findings)
+	assert.Len(t, db.Licenses, 5)
+}
+
+func TestApplyLicenseRules_OlderVersion(t *testing.T) {
+	db := OracleDatabase{
+		Version:  "12.2.0.1.0 Enterprise Edition",
+		Licenses: []OracleDatabaseLicense{{Name: "Oracle ENT", Count: 2}},
+		PDBs:     []OracleDatabasePluggableDatabase{{Name: "PDB1"}, {Name: "PDB2"}, {Name: "PDB3"}, {Name: "PDB4"}},
+	}
+
+	assert.Empty(t, db.ApplyLicenseRules(testOracleDatabaseLicenseRules))
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OracleFeatureUsageEvidence is a copy of the data of the upload that reported the usage of a feature.
+// It's kept in the ledger because the uploads are purged from the archived hostdata after some time
+type OracleFeatureUsageEvidence struct {
+	UploadID         primitive.ObjectID `json:"uploadID" bson:"uploadID"`
+	UploadedAt       time.Time          `json:"uploadedAt" bson:"uploadedAt"`
+	AgentVersion     string             `json:"agentVersion" bson:"agentVersion"`
+	DbVersion        string             `json:"dbVersion" bson:"dbVersion"`
+	DbEdition        string             `json:"dbEdition" bson:"dbEdition"`
+	DetectedUsages   int64              `json:"detectedUsages" bson:"detectedUsages"`
+	CurrentlyUsed    bool               `json:"currentlyUsed" bson:"currentlyUsed"`
+	FirstUsageDate   time.Time          `json:"firstUsageDate" bson:"firstUsageDate"`
+	LastUsageDate    time.Time          `json:"lastUsageDate" bson:"lastUsageDate"`
+	ExtraFeatureInfo string             `json:"extraFeatureInfo" bson:"extraFeatureInfo"`
+}
+
+// OracleFeatureUsageLedgerEntry records when a feature of an Oracle database has been detected
+// by the agent for the first and the last time, with the uploads that reported it
+type OracleFeatureUsageLedgerEntry struct {
+	Hostname       string                     `json:"hostname" bson:"hostname"`
+	DbName         string                     `json:"dbName" bson:"dbName"`
+	Product        string                     `json:"product" bson:"product"`
+	Feature        string                     `json:"feature" bson:"feature"`
+	FirstDetected  time.Time                  `json:"firstDetected" bson:"firstDetected"`
+	FirstUploadID  primitive.ObjectID         `json:"firstUploadID" bson:"firstUploadID"`
+	LastDetected   time.Time                  `json:"lastDetected" bson:"lastDetected"`
+	LastUploadID   primitive.ObjectID         `json:"lastUploadID" bson:"lastUploadID"`
+	DetectedUsages int64                      `json:"detectedUsages" bson:"detectedUsages"`
+	CurrentlyUsed  bool                       `json:"currentlyUsed" bson:"currentlyUsed"`
+	FirstUsageDate time.Time                  `json:"firstUsageDate" bson:"firstUsageDate"`
+	LastUsageDate  time.Time                  `json:"lastUsageDate" bson:"lastUsageDate"`
+	FirstEvidence  OracleFeatureUsageEvidence `json:"firstEvidence" bson:"firstEvidence"`
+	LastEvidence   OracleFeatureUsageEvidence `json:"lastEvidence" bson:"lastEvidence"`
+}
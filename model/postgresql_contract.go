@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostgreSQLLicenseType holds informations about a single PostgreSQL license type
+type PostgreSQLLicenseType struct {
+	ID              string `json:"id" bson:"_id"`
+	ItemDescription string `json:"itemDescription" bson:"itemDescription"`
+}
+
+// PostgreSQLContract holds informations about a single PostgreSQL subscription
+type PostgreSQLContract SubscriptionContract
+
+const (
+	// PostgreSQLContractTypeCore subscription is counted per core of the host
+	PostgreSQLContractTypeCore = SubscriptionContractTypeCore
+	// PostgreSQLContractTypeInstance subscription is counted per instance
+	PostgreSQLContractTypeInstance = SubscriptionContractTypeInstance
+)
+
+const PostgreSQLEDBPartNumber = "EDB-EPAS"
+
+const PostgreSQLEDBItemDescription = "EDB Postgres Advanced Server"
+
+// GetID return the ID of the contract
+func (agr PostgreSQLContract) GetID() primitive.ObjectID {
+	return agr.ID
+}
+
+func (agr PostgreSQLContract) IsValid() bool {
+	return SubscriptionContract(agr).IsValid()
+}
+
+// IsEDBPostgresAdvancedServer return true if the version string reported by the instance
+// belongs to EDB Postgres Advanced Server, the only PostgreSQL distribution under subscription
+func IsEDBPostgresAdvancedServer(version string) bool {
+	return strings.Contains(strings.ToLower(version), "enterprisedb")
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SubscriptionContract holds informations about a single subscription
+// of a technology licensed by subscription, like PostgreSQL and MongoDB
+type SubscriptionContract struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id" csv:"-"`
+	Type              string             `json:"type" bson:"type" csv:"Type"`
+	ContractID        string             `json:"contractID" bson:"contractID" csv:"Contract Number"`
+	CSI               string             `json:"csi" bson:"csi" csv:"CSI"`
+	LicenseTypeID     string             `json:"licenseTypeID" bson:"licenseTypeID" csv:"License Type"`
+	NumberOfLicenses  uint               `json:"numberOfLicenses" bson:"numberOfLicenses" csv:"Number of Licenses"`
+	SupportExpiration *time.Time         `json:"supportExpiration" bson:"supportExpiration" csv:"-"`
+	Hosts             []string           `json:"hosts" bson:"hosts" csv:"-"`
+	HostsLiteral      LiteralStrSlice    `json:"-" bson:"-" csv:"Hosts"`
+}
+
+const (
+	// SubscriptionContractTypeCore subscription is counted per core of the host
+	SubscriptionContractTypeCore string = "CORE"
+	// SubscriptionContractTypeInstance subscription is counted per instance
+	SubscriptionContractTypeInstance string = "INSTANCE"
+)
+
+func (agr SubscriptionContract) IsValid() bool {
+	if agr.ContractID == "" || agr.NumberOfLicenses == 0 {
+		return false
+	}
+
+	return agr.Type == SubscriptionContractTypeCore || agr.Type == SubscriptionContractTypeInstance
+}
@@ -20,6 +20,7 @@ type VMInfo struct {
 	Name                    string `json:"name" bson:"name"`
 	Hostname                string `json:"hostname" bson:"hostname"` //Hostname or IP address
 	CappedCPU               bool   `json:"cappedCPU" bson:"cappedCPU"`
+	PinnedCPUs              int    `json:"pinnedCPUs,omitempty" bson:"pinnedCPUs,omitempty"` // vCPUs pinned or capped to the VM, 0 if unknown
 	VirtualizationNode      string `json:"virtualizationNode" bson:"virtualizationNode"`
 	PhysicalServerModelName string `json:"physicalServerModelName" bson:"physicalServerModelName"`
 }
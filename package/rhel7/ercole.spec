@@ -30,11 +30,12 @@ ls
 
 %install
 cd %{_builddir}/%{name}-%{version}
-mkdir -p %{buildroot}/usr/bin/ %{buildroot}/usr/share/ercole/{examples,templates} %{buildroot}/usr/share/ercole/technologies/{Microsoft,Oracle,HP,IBM,RedHat,MariaDBFoundation,PostgreSQL,MongoDB,Unknown,VMWare} %{buildroot}%{_unitdir} %{buildroot}%{_presetdir} %{buildroot}/var/lib/ercole/distributed_files
+mkdir -p %{buildroot}/usr/bin/ %{buildroot}/usr/share/ercole/{examples,templates,rules} %{buildroot}/usr/share/ercole/technologies/{Microsoft,Oracle,HP,IBM,RedHat,MariaDBFoundation,PostgreSQL,MongoDB,Unknown,VMWare} %{buildroot}%{_unitdir} %{buildroot}%{_presetdir} %{buildroot}/var/lib/ercole/distributed_files
 install -m 0755 ercole %{buildroot}/usr/bin/ercole
 install -m 0755 package/ercole-setup %{buildroot}/usr/bin/ercole-setup
 install -m 0644 package/config.toml %{buildroot}/usr/share/ercole/config.toml
 install -m 0644 resources/templates/* %{buildroot}/usr/share/ercole/templates/
+install -m 0644 resources/rules/* %{buildroot}/usr/share/ercole/rules/
 install -m 0644 resources/technologies/list.json %{buildroot}/usr/share/ercole/technologies/list.json
 install -m 0644 resources/technologies/Oracle/* %{buildroot}/usr/share/ercole/technologies/Oracle/
 install -m 0644 resources/technologies/Microsoft/* %{buildroot}/usr/share/ercole/technologies/Microsoft/
@@ -122,6 +123,7 @@ ercole completion bash > /usr/share/bash-completion/completions/ercole
 /usr/share/ercole/templates/template_generic.xlsx
 /usr/share/ercole/templates/template_lms.xlsm
 /usr/share/ercole/templates/template_exadatas.xlsx
+%config(noreplace) /usr/share/ercole/rules/oracle_database_license_rules.json
 /usr/share/ercole/examples/ercole-rhel5-x86_64.repo
 /usr/share/ercole/examples/ercole-rhel6-x86_64.repo
 /usr/share/ercole/examples/ercole-rhel7-x86_64.repo
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// HostdataTransform upgrade an hostdata from its schema version to the next one
+type HostdataTransform func(hostdata map[string]interface{}) error
+
+// hostdataTransforms contains, for each schema version older than model.SchemaVersion still accepted,
+// the transform upgrading it to the next version.
+// The version 1 is the only schema version released so far, so there isn't any transform yet:
+// a new version must increase model.SchemaVersion and the schemaVersion const of hostdata.json,
+// and add here the transform from the previous version
+var hostdataTransforms = map[int]HostdataTransform{}
+
+// SupportedHostdataSchemaVersions return the schema versions of the hostdata that can be upgraded
+// to model.SchemaVersion, sorted in ascending order
+func SupportedHostdataSchemaVersions() []int {
+	versions := []int{model.SchemaVersion}
+
+	for v := model.SchemaVersion - 1; ; v-- {
+		if _, ok := hostdataTransforms[v]; !ok {
+			break
+		}
+
+		versions = append(versions, v)
+	}
+
+	sort.Ints(versions)
+
+	return versions
+}
+
+// UpgradeHostdata upgrade raw from the schema version declared by the agent to model.SchemaVersion,
+// applying in order the transforms of the versions in between
+func UpgradeHostdata(raw []byte) ([]byte, error) {
+	var hostdata map[string]interface{}
+
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
+	}
+
+	declared, ok := hostdata["schemaVersion"].(float64)
+	if !ok || declared != float64(int(declared)) {
+		// the validation will report the missing or wrong schemaVersion
+		return raw, nil
+	}
+
+	version := int(declared)
+	if version == model.SchemaVersion {
+		return raw, nil
+	}
+
+	if !isSupportedHostdataSchemaVersion(version) {
+		return nil, fmt.Errorf("%w: %w: %d, supported versions are %v",
+			utils.ErrInvalidHostdata, utils.ErrUnsupportedHostdataSchemaVersion, version, SupportedHostdataSchemaVersions())
+	}
+
+	for v := version; v < model.SchemaVersion; v++ {
+		if err := hostdataTransforms[v](hostdata); err != nil {
+			return nil, fmt.Errorf("%w: can't upgrade from schema version %d: %s", utils.ErrInvalidHostdata, v, err)
+		}
+
+		hostdata["schemaVersion"] = v + 1
+	}
+
+	upgraded, err := json.Marshal(hostdata)
+	if err != nil {
+		return nil, utils.NewError(err, "Can't marshal the upgraded hostdata")
+	}
+
+	return upgraded, nil
+}
+
+func isSupportedHostdataSchemaVersion(version int) bool {
+	for _, v := range SupportedHostdataSchemaVersions() {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
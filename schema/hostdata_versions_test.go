@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestSupportedHostdataSchemaVersions(t *testing.T) {
+	defer func(transforms map[int]HostdataTransform) { hostdataTransforms = transforms }(hostdataTransforms)
+
+	hostdataTransforms = map[int]HostdataTransform{}
+	assert.Equal(t, []int{model.SchemaVersion}, SupportedHostdataSchemaVersions())
+
+	hostdataTransforms = map[int]HostdataTransform{
+		model.SchemaVersion - 1: func(map[string]interface{}) error { return nil },
+		model.SchemaVersion - 2: func(map[string]interface{}) error { return nil },
+		model.SchemaVersion - 4: func(map[string]interface{}) error { return nil },
+	}
+	assert.Equal(t, []int{model.SchemaVersion - 2, model.SchemaVersion - 1, model.SchemaVersion}, SupportedHostdataSchemaVersions())
+}
+
+func TestUpgradeHostdata(t *testing.T) {
+	defer func(transforms map[int]HostdataTransform) { hostdataTransforms = transforms }(hostdataTransforms)
+
+	hostdataTransforms = map[int]HostdataTransform{
+		model.SchemaVersion - 2: func(hostdata map[string]interface{}) error {
+			hostdata["location"] = hostdata["site"]
+			delete(hostdata, "site")
+
+			return nil
+		},
+		model.SchemaVersion - 1: func(hostdata map[string]interface{}) error {
+			if _, ok := hostdata["environment"]; !ok {
+				return errors.New("missing environment")
+			}
+
+			hostdata["tags"] = []string{}
+
+			return nil
+		},
+	}
+
+	t.Run("Current version", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar","schemaVersion":` + strconv.Itoa(model.SchemaVersion) + `}`)
+
+		actual, err := UpgradeHostdata(raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, actual)
+	})
+
+	t.Run("Older version", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar","site":"Italy","environment":"PRD","schemaVersion":` + strconv.Itoa(model.SchemaVersion-2) + `}`)
+
+		actual, err := UpgradeHostdata(raw)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"hostname":"foobar","location":"Italy","environment":"PRD","tags":[],"schemaVersion":`+strconv.Itoa(model.SchemaVersion)+`}`, string(actual))
+	})
+
+	t.Run("Failing transform", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar","site":"Italy","schemaVersion":` + strconv.Itoa(model.SchemaVersion-2) + `}`)
+
+		_, err := UpgradeHostdata(raw)
+		assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar","schemaVersion":` + strconv.Itoa(model.SchemaVersion-3) + `}`)
+
+		_, err := UpgradeHostdata(raw)
+		assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+		assert.ErrorIs(t, err, utils.ErrUnsupportedHostdataSchemaVersion)
+	})
+
+	t.Run("Missing version", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar"}`)
+
+		actual, err := UpgradeHostdata(raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, actual)
+	})
+}
+
+func TestHostdataSchemaVersion(t *testing.T) {
+	var hostdataJSONSchema struct {
+		Properties struct {
+			SchemaVersion struct {
+				Const int `json:"const"`
+			} `json:"schemaVersion"`
+		} `json:"properties"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(hostdataSchema), &hostdataJSONSchema))
+	assert.Equal(t, model.SchemaVersion, hostdataJSONSchema.Properties.SchemaVersion.Const)
+	assert.Equal(t, []int{1}, SupportedHostdataSchemaVersions())
+}
@@ -92,3 +92,43 @@ var ErrSuperUserCannotBeDeleted = errors.New("Super User cannot be deleted")
 var ErrPermissionDenied = "Permission denied"
 
 var ErrInvalidExadata = errors.New("invalid exadata")
+
+var ErrLicenseIgnoreRuleNotFound = errors.New("License ignore rule not found")
+
+var ErrLMSPendingChangeNotFound = errors.New("LMS pending change not found")
+
+var ErrLMSPendingChangeAlreadyReviewed = errors.New("LMS pending change already reviewed")
+
+var ErrInvalidLMSWorkbook = errors.New("Invalid LMS workbook")
+
+var ErrHostDataUploadNotFound = errors.New("Hostdata upload not found")
+
+var ErrConflictingPointInTimeParams = errors.New("at and older-than params can't be used together")
+
+var ErrRequestBodyTooLarge = errors.New("Request body too large")
+
+var ErrUnsupportedContentEncoding = errors.New("Unsupported content encoding")
+
+var ErrUnsupportedHostdataSchemaVersion = errors.New("Unsupported hostdata schema version")
+
+var ErrQuarantinedHostDataNotFound = errors.New("Quarantined hostdata not found")
+
+var ErrQuarantinedHostDataOutdated = errors.New("The host has sent a more recent hostdata than the quarantined one")
+
+var ErrInvalidBundle = errors.New("Invalid bundle")
+
+var ErrInvalidBundleSignature = errors.New("The signature of the bundle isn't valid")
+
+var ErrHostIdentityNotFound = errors.New("Host identity not found")
+
+var ErrHostAliasConflict = errors.New("The alias belongs to another host identity")
+
+var ErrHostIdentityAmbiguous = errors.New("The name without domain of the host matches more than one host identity")
+
+var ErrInvalidHostAlias = errors.New("Invalid host alias")
+
+var ErrHostIdentityMergedWithItself = errors.New("A host identity can't be merged with itself")
+
+var ErrUnknownCmdbConnectorType = errors.New("Unknown CMDB connector type")
+
+var ErrInvalidOracleDatabaseLicenseRule = errors.New("Invalid Oracle database license rule")
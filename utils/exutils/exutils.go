@@ -16,7 +16,10 @@
 package exutils
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"io"
 
 	"github.com/360EntSecGroup-Skylar/excelize"
 
@@ -112,3 +115,42 @@ func (ah *AxisHelper) NewRowAndFill(xlsx *excelize.File, sheet string, values ..
 	}
 	return xlsx
 }
+
+// NewCSVReaderFromXLSX return a csv reader which reads the rows of the first sheet of the xlsx file
+func NewCSVReaderFromXLSX(r io.Reader) (*csv.Reader, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := file.GetRows(file.GetSheetName(1))
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	for _, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return csv.NewReader(&buf), nil
+}
@@ -168,6 +168,27 @@ func Str2time(in string, defaultValue time.Time) (time.Time, error) {
 	}
 }
 
+// GetOlderThanParam return the point in time requested with the at or the older-than query param, or MAX_TIME.
+// at accepts also a date, which stands for the end of that day
+func GetOlderThanParam(r *http.Request) (time.Time, error) {
+	at := r.URL.Query().Get("at")
+	olderThan := r.URL.Query().Get("older-than")
+
+	if at == "" {
+		return Str2time(olderThan, MAX_TIME)
+	}
+
+	if olderThan != "" {
+		return time.Time{}, NewError(ErrConflictingPointInTimeParams, "Unable to parse the point in time")
+	}
+
+	if day, err := time.Parse("2006-01-02", at); err == nil {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+
+	return Str2time(at, MAX_TIME)
+}
+
 // Str2ptr return a pointer to a copy of s
 // Go pass values by copy, so a copy of the value passed
 // is already made when calling method